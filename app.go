@@ -4,23 +4,33 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"log"
+	"maps"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	goruntime "runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"QMLauncher/internal/bspatch"
 	"QMLauncher/internal/debuglog"
 	"QMLauncher/internal/i18n"
 	"QMLauncher/internal/meta"
@@ -28,6 +38,7 @@ import (
 	env "QMLauncher/pkg"
 	"QMLauncher/pkg/auth"
 	"QMLauncher/pkg/launcher"
+	"QMLauncher/pkg/serverping"
 	"QMLauncher/pkg/serversdat"
 	"QMLauncher/pkg/updater"
 
@@ -215,6 +226,22 @@ func logMessage(message string) {
 	}
 }
 
+// launcherEvents holds the Wails context for events raised by helpers that have no App (set in startup).
+var launcherEvents struct {
+	sync.Mutex
+	ctx context.Context
+}
+
+// emitLauncherEvent emits a frontend event; without a UI it does nothing.
+func emitLauncherEvent(name string, data interface{}) {
+	launcherEvents.Lock()
+	ctx := launcherEvents.ctx
+	launcherEvents.Unlock()
+	if ctx != nil {
+		runtime.EventsEmit(ctx, name, data)
+	}
+}
+
 // NewApp creates a new App application struct
 func NewApp() *App {
 	return &App{}
@@ -224,6 +251,12 @@ func NewApp() *App {
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	launcherEvents.Lock()
+	launcherEvents.ctx = ctx
+	launcherEvents.Unlock()
+	syncConflictPrompts.Lock()
+	syncConflictPrompts.ctx = ctx
+	syncConflictPrompts.Unlock()
 
 	meta.SetCurseForgeKeyChooser(computeLauncherCurseForgeKey)
 	meta.RegisterCurseForgeAPI403Handler(clearCurseForgeCloudKeyCache)
@@ -248,12 +281,23 @@ func (a *App) startup(ctx context.Context) {
 	// Encrypted vault: Microsoft + offline + cloud accounts
 	if err := auth.LoadCredentials(); err != nil {
 		logMessage(fmt.Sprintf("[Auth] LoadCredentials: %v", err))
+		if errors.Is(err, auth.ErrInsecureVault) {
+			runtime.EventsEmit(ctx, "auth-vault-insecure", err.Error())
+		}
 	}
 
 	// Load language and QMServer API target from settings file (default UI language: Russian)
 	langConfigured := false
 	if startupCfg != nil {
 		applyAPITargetFromSettingsMap(startupCfg)
+		applyJavaMirrorsFromSettingsMap(startupCfg)
+		applyQMServerTLSFromSettingsMap(startupCfg)
+		applyBandwidthLimitFromSettingsMap(startupCfg)
+		applyUpdateChannelFromSettingsMap(startupCfg)
+		applyUpdaterNetworkFromSettingsMap(startupCfg)
+		if n, ok := startupCfg["update_backups_keep"].(float64); ok {
+			updater.SetBackupsKept(int(n))
+		}
 		if l, ok := startupCfg["language"].(string); ok && (l == "en" || l == "ru") {
 			langConfigured = true
 			if l == "en" {
@@ -267,13 +311,27 @@ func (a *App) startup(ctx context.Context) {
 		i18n.SetLang(language.Russian)
 	}
 
+	// Token expiry: surface in the status bar instead of failing at launch time
+	if warnings := authExpiryWarnings(); len(warnings) > 0 {
+		for _, w := range warnings {
+			logMessage(fmt.Sprintf("[Auth] %s", w.Message))
+		}
+		runtime.EventsEmit(ctx, "auth-expiry-warning", warnings)
+	}
+
 	// Auto-update: QMServer-hosted release first, then GitHub, then legacy QMWeb /uploads (Windows MD5).
+	updater.CleanupOldBinary()
 	updater.CheckAndApplyQMServerDistributionUpdate(logMessage)
 	updater.CheckAndApplyGitHubBinaryUpdate(logMessage)
 	updater.CheckAndApplyQMWebUpdate(logMessage)
 
 	// Start periodic update check (every 30 min)
 	go startPeriodicUpdateCheck(ctx, logMessage)
+
+	// Opt-in: at most one GitHub check a day, surfaced as a one-line notice
+	if startupCfg != nil && parseBoolish(startupCfg["update_notice"], false) {
+		go a.showUpdateNotice()
+	}
 }
 
 func launcherSettingsPath() (string, error) {
@@ -434,14 +492,252 @@ func startPeriodicUpdateCheck(ctx context.Context, logFn func(string)) {
 // ApplyLauncherUpdate applies available launcher update and restarts. Returns empty string on success;
 // on success the process exits. Returns error message on failure.
 func (a *App) ApplyLauncherUpdate() string {
-	updater.CheckAndApplyQMServerDistributionUpdate(logMessage)
-	updater.CheckAndApplyGitHubBinaryUpdate(logMessage)
+	updater.ApplyQMServerDistributionUpdate(logMessage)
+	updater.ApplyGitHubBinaryUpdate(false, logMessage)
 	if err := updater.ApplyAndRestartQMWebUpdate(logMessage); err != nil {
 		return err.Error()
 	}
 	return ""
 }
 
+// ApplyLauncherDowngrade installs the newest GitHub release on the update channel even when it is older
+// than the running version, e.g. after switching from beta back to stable, and restarts. On success the
+// process exits. Returns error message on failure.
+func (a *App) ApplyLauncherDowngrade() string {
+	updater.ApplyGitHubBinaryUpdate(true, logMessage)
+	return "Error: версия с канала " + updater.Channel() + " не установлена, подробности в журнале"
+}
+
+// UpdateNotice is the one-line notice of a newer launcher release shown by the opt-in daily check.
+type UpdateNotice struct {
+	Version string `json:"version"`
+	Channel string `json:"channel"`
+	Message string `json:"message"`
+}
+
+// showUpdateNotice runs the throttled update check and emits "launcher-update-notice" when a newer
+// release is available.
+func (a *App) showUpdateNotice() {
+	state, err := updater.ThrottledCheck(updater.NoticeCheckInterval)
+	if err != nil {
+		logMessage(fmt.Sprintf("[AutoUpdate] Проверка обновлений: %v", err))
+		return
+	}
+	if !state.Available {
+		return
+	}
+	notice := UpdateNotice{
+		Version: state.LatestVer,
+		Channel: state.Channel,
+		Message: fmt.Sprintf("Доступна версия v%s, установите её через «Обновить лаунчер»", state.LatestVer),
+	}
+	logMessage("[AutoUpdate] " + notice.Message)
+	runtime.EventsEmit(a.ctx, "launcher-update-notice", notice)
+}
+
+// GetUpdateNoticeEnabled reports whether the daily update notice is enabled (off by default).
+func (a *App) GetUpdateNoticeEnabled() bool {
+	return parseBoolish(readLauncherSettingsMap()["update_notice"], false)
+}
+
+// SetUpdateNoticeEnabled turns the daily update notice on or off. The check runs at startup, at most
+// once per day. Returns empty string on success.
+func (a *App) SetUpdateNoticeEnabled(enabled bool) string {
+	var value interface{}
+	if enabled {
+		value = true
+	}
+	if err := setLauncherSetting("update_notice", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	if enabled {
+		go a.showUpdateNotice()
+	}
+	return ""
+}
+
+// applyUpdateChannelFromSettingsMap applies the launcher update channel: QMLAUNCHER_UPDATE_CHANNEL, then
+// settings.json "update_channel".
+func applyUpdateChannelFromSettingsMap(cfg map[string]interface{}) {
+	raw, _ := cfg["update_channel"].(string)
+	if env := strings.TrimSpace(os.Getenv("QMLAUNCHER_UPDATE_CHANNEL")); env != "" {
+		raw = env
+	}
+	name, err := updater.ParseChannel(raw)
+	if err != nil {
+		logMessage(fmt.Sprintf("[AutoUpdate] Канал обновлений не применён: %v", err))
+		return
+	}
+	updater.SetChannel(name)
+	if name != updater.ChannelStable {
+		logMessage(fmt.Sprintf("[AutoUpdate] Канал обновлений: %s", name))
+	}
+}
+
+// GetUpdateChannel returns the launcher update channel: stable, beta or nightly.
+func (a *App) GetUpdateChannel() string {
+	return updater.Channel()
+}
+
+// SetUpdateChannel switches the launcher update channel ("stable", "beta" or "nightly"), so testers get
+// beta or nightly releases through the regular update check. Returns empty string on success.
+func (a *App) SetUpdateChannel(name string) string {
+	name, err := updater.ParseChannel(name)
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	var value interface{}
+	if name != updater.ChannelStable {
+		value = name
+	}
+	if err := setLauncherSetting("update_channel", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	updater.SetChannel(name)
+	logMessage(fmt.Sprintf("[AutoUpdate] Канал обновлений: %s", name))
+	return ""
+}
+
+// applyUpdaterNetworkFromSettingsMap applies settings.json "update_proxy" and "github_token" to update
+// checks (without them the updater uses HTTP(S)_PROXY and QMLAUNCHER_GITHUB_TOKEN / GITHUB_TOKEN), and the
+// self-hosted update source: QMLAUNCHER_UPDATE_URL, then "update_manifest_url".
+func applyUpdaterNetworkFromSettingsMap(cfg map[string]interface{}) {
+	proxy, _ := cfg["update_proxy"].(string)
+	if err := updater.SetProxy(proxy); err != nil {
+		logMessage(fmt.Sprintf("[AutoUpdate] Прокси для обновлений не применён: %v", err))
+	}
+	token, _ := cfg["github_token"].(string)
+	updater.SetGitHubToken(token)
+	manifest, _ := cfg["update_manifest_url"].(string)
+	if env := strings.TrimSpace(os.Getenv("QMLAUNCHER_UPDATE_URL")); env != "" {
+		manifest = env
+	}
+	if err := updater.SetManifestURL(manifest); err != nil {
+		logMessage(fmt.Sprintf("[AutoUpdate] Источник обновлений не применён: %v", err))
+	} else if manifest != "" {
+		logMessage(fmt.Sprintf("[AutoUpdate] Источник обновлений: %s", updater.ManifestURL()))
+	}
+}
+
+// UpdaterNetworkSettings is the proxy and GitHub token used by launcher update checks.
+type UpdaterNetworkSettings struct {
+	Proxy    string `json:"proxy"`    // "" = HTTP(S)_PROXY from the environment
+	TokenSet bool   `json:"tokenSet"` // a GitHub token is configured (settings or environment)
+
+	ManifestURL string `json:"manifestUrl,omitempty"` // effective self-hosted update manifest; "" = GitHub releases
+}
+
+// GetUpdaterNetworkSettings returns the update proxy and whether a GitHub token is set. The token itself
+// is not returned to the UI.
+func (a *App) GetUpdaterNetworkSettings() UpdaterNetworkSettings {
+	return UpdaterNetworkSettings{
+		Proxy:       launcherSettingString("update_proxy"),
+		TokenSet:    updater.GitHubToken() != "",
+		ManifestURL: updater.ManifestURL(),
+	}
+}
+
+// SetUpdateManifestURL switches launcher updates to a self-hosted update manifest (see
+// updater.UpdateManifest), e.g. for a branded build distributed inside an organization; "" restores GitHub
+// releases. Returns empty string on success.
+func (a *App) SetUpdateManifestURL(manifestURL string) string {
+	manifestURL, err := updater.ParseManifestURL(manifestURL)
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	var value interface{}
+	if manifestURL != "" {
+		value = manifestURL
+	}
+	if err := setLauncherSetting("update_manifest_url", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	if err := updater.SetManifestURL(manifestURL); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// SetUpdaterProxy sets the proxy for launcher update checks and downloads, e.g. "http://proxy:3128";
+// "" falls back to HTTP(S)_PROXY. Returns empty string on success.
+func (a *App) SetUpdaterProxy(proxy string) string {
+	proxy = strings.TrimSpace(proxy)
+	if err := updater.SetProxy(proxy); err != nil {
+		return "Error: " + err.Error()
+	}
+	var value interface{}
+	if proxy != "" {
+		value = proxy
+	}
+	if err := setLauncherSetting("update_proxy", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// SetGitHubToken saves a GitHub token for launcher update checks, so many users behind one address don't
+// hit the anonymous API rate limit; "" removes it. Returns empty string on success.
+func (a *App) SetGitHubToken(token string) string {
+	token = strings.TrimSpace(token)
+	var value interface{}
+	if token != "" {
+		value = token
+	}
+	if err := setLauncherSetting("github_token", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	updater.SetGitHubToken(token)
+	return ""
+}
+
+// LauncherBackupsReport lists the previous launcher versions available for rollback.
+type LauncherBackupsReport struct {
+	Current string           `json:"current"`
+	Keep    int              `json:"keep"`
+	Backups []updater.Backup `json:"backups"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// GetLauncherBackups returns the launcher versions kept by updates, newest first.
+func (a *App) GetLauncherBackups() LauncherBackupsReport {
+	report := LauncherBackupsReport{Current: version, Keep: updater.BackupsKept(), Backups: []updater.Backup{}}
+	list, err := updater.Backups()
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Backups = append(report.Backups, list...)
+	return report
+}
+
+// SetLauncherBackupsKept sets how many previous launcher versions updates keep for rollback (0 = the
+// default, updater.DefaultBackupsKept). Returns empty string on success.
+func (a *App) SetLauncherBackupsKept(n int) string {
+	if n < 0 {
+		return "Error: количество копий не может быть отрицательным"
+	}
+	var value interface{}
+	if n > 0 {
+		value = n
+	}
+	if err := setLauncherSetting("update_backups_keep", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	updater.SetBackupsKept(n)
+	return ""
+}
+
+// RollbackLauncherUpdate restores a previous launcher version kept by updates (the newest one when
+// toVersion is "") and restarts the launcher, for when a release breaks launching. On success the process
+// exits. Returns error message on failure.
+func (a *App) RollbackLauncherUpdate(toVersion string) string {
+	if err := updater.Rollback(toVersion, logMessage); err != nil {
+		logMessage(fmt.Sprintf("[AutoUpdate] Откат не выполнен: %v", err))
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
 // GetInstances returns list of available Minecraft instances
 func (a *App) GetInstances() []launcher.Instance {
 	instances, err := launcher.FetchAllInstances()
@@ -476,6 +772,8 @@ type InstanceDetails struct {
 
 	// RemoteInstalls maps "category/path" (e.g. mods/foo.jar) to catalog install metadata.
 	RemoteInstalls map[string]launcher.RemoteInstallMeta `json:"remoteInstalls"`
+	// ModLock is the content of mods.lock (pinned versions and enabled state of managed files).
+	ModLock []launcher.ModLockEntry `json:"modLock"`
 
 	// Catalog flags from QMServer when using cloud (false,false if unavailable). Local instances: both true.
 	CatalogCurseforgeEnabled bool `json:"catalogCurseforgeEnabled"`
@@ -488,12 +786,193 @@ type ResourceStoreLinks struct {
 	ModrinthURL   string `json:"modrinthUrl"`
 }
 
+// GetInstanceResourceModrinthMatches identifies every file of a category (mods, resourcepacks, shaderpacks)
+// on Modrinth by SHA-1. The result is keyed by file name as on disk; unknown files are absent.
+func (a *App) GetInstanceResourceModrinthMatches(instanceName, category string) map[string]meta.ModrinthHashMatch {
+	out := map[string]meta.ModrinthHashMatch{}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return out
+	}
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return out
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	switch category {
+	case "mods", "resourcepacks", "shaderpacks":
+	default:
+		return out
+	}
+	dir := filepath.Join(inst.Dir(), category)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return out
+	}
+	byHash := map[string]string{}
+	var hashes []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if sum, err := meta.FileSHA1(filepath.Join(dir, e.Name())); err == nil {
+			byHash[sum] = e.Name()
+			hashes = append(hashes, sum)
+		}
+	}
+	found, err := meta.IdentifyModrinthHashes(hashes, inst.CachesDir())
+	if err != nil {
+		logMessage(fmt.Sprintf("[Modrinth] version_files: %v", err))
+	}
+	for sum, m := range found {
+		out[byHash[sum]] = m
+	}
+	return out
+}
+
+// ShaderPackInfo is one shader pack with its store links and the shader loaders it supports.
+type ShaderPackInfo struct {
+	File          string `json:"file"`
+	Enabled       bool   `json:"enabled"`
+	Title         string `json:"title"`
+	Version       string `json:"version"`
+	ModrinthURL   string `json:"modrinthUrl"`
+	CurseforgeURL string `json:"curseforgeUrl"`
+	// Loaders are the shader loaders the pack is published for (iris, optifine, canvas); empty when unknown.
+	Loaders []string `json:"loaders"`
+	// Compatible is false when the pack is known to need a shader loader the instance does not have.
+	Compatible bool `json:"compatible"`
+}
+
+// ShaderPacksReport is returned by GetInstanceShaderPacks.
+type ShaderPacksReport struct {
+	Packs []ShaderPackInfo `json:"packs"`
+	// ShaderLoaders are the shader loaders installed as mods (iris, optifine, canvas).
+	ShaderLoaders []string `json:"shaderLoaders"`
+	Error         string   `json:"error"`
+}
+
+// instanceShaderLoaders detects Iris (and its Forge port Oculus), OptiFine and Canvas among enabled mods.
+func instanceShaderLoaders(inst *launcher.Instance) []string {
+	var out []string
+	add := func(l string) {
+		if !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	for _, m := range readInstanceModsMetadata(inst) {
+		if !m.Enabled {
+			continue
+		}
+		switch strings.ToLower(m.ModID) {
+		case "iris", "oculus":
+			add("iris")
+		case "optifine", "optifabric":
+			add("optifine")
+		case "canvas":
+			add("canvas")
+		}
+		if m.ModID == "" && strings.Contains(strings.ToLower(m.File), "optifine") {
+			add("optifine")
+		}
+	}
+	return out
+}
+
+// GetInstanceShaderPacks lists shaderpacks/ with Modrinth/CurseForge links and the shader loaders each pack
+// supports. Packs are identified on Modrinth by hash; unknown packs get search links.
+func (a *App) GetInstanceShaderPacks(instanceName string) ShaderPacksReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ShaderPacksReport{Error: err.Error()}
+	}
+	report := ShaderPacksReport{Packs: []ShaderPackInfo{}, ShaderLoaders: instanceShaderLoaders(&inst)}
+	if report.ShaderLoaders == nil {
+		report.ShaderLoaders = []string{}
+	}
+	entries, err := os.ReadDir(filepath.Join(inst.Dir(), "shaderpacks"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Error = err.Error()
+		}
+		return report
+	}
+	matches := a.GetInstanceResourceModrinthMatches(inst.Name, "shaderpacks")
+	cfOn, mrOn := instanceCatalogFlags(&inst)
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		slug := meta.ExtractResourcePackInfo(resourceStripDisabledSuffix(name)).Slug
+		p := ShaderPackInfo{File: name, Enabled: !resourceHasDisabledSuffix(name), Title: slug, Loaders: []string{}, Compatible: true}
+		if m, ok := matches[name]; ok {
+			p.Title = m.Title
+			p.Version = m.VersionNumber
+			p.ModrinthURL = m.PageURL
+			if m.Loaders != nil {
+				p.Loaders = m.Loaders
+			}
+		} else if mrOn {
+			p.ModrinthURL = "https://modrinth.com/shaders?q=" + url.QueryEscape(slug)
+		}
+		if cfOn {
+			p.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(slug)
+		}
+		if len(p.Loaders) > 0 {
+			p.Compatible = slices.ContainsFunc(p.Loaders, func(l string) bool { return slices.Contains(report.ShaderLoaders, l) })
+		}
+		report.Packs = append(report.Packs, p)
+	}
+	return report
+}
+
 // RemoteStoreSearchResponse is the catalog search result (resource store UI).
 type RemoteStoreSearchResponse struct {
 	Hits  []meta.RemoteStoreHit `json:"hits"`
+	Total int                   `json:"total,omitempty"` // all matching hits (filtered Modrinth search)
 	Error string                `json:"error"`
 }
 
+// SearchModrinthFiltered searches Modrinth with filters and offset pagination.
+// projectType: mod | resourcepack | shader | datapack | modpack (or the plural category names).
+// loader / gameVersion: "instance" uses the instance's values, "" disables the filter.
+// categories: comma-separated Modrinth categories; sort: downloads | updated | newest | relevance | follows.
+func (a *App) SearchModrinthFiltered(instanceName, projectType, query, loader, gameVersion, categories, sort string, limit, offset int) RemoteStoreSearchResponse {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return RemoteStoreSearchResponse{Error: err.Error()}
+	}
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return RemoteStoreSearchResponse{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+	}
+	if strings.EqualFold(strings.TrimSpace(loader), "instance") {
+		loader = string(inst.Loader)
+		if inst.Loader == launcher.LoaderVanilla {
+			loader = ""
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(gameVersion), "instance") {
+		gameVersion = inst.GameVersion
+	}
+	filters := meta.ModrinthSearchFilters{
+		Loader:      loader,
+		GameVersion: gameVersion,
+		Sort:        sort,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	for _, c := range strings.Split(categories, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			filters.Categories = append(filters.Categories, c)
+		}
+	}
+	hits, total, err := meta.SearchModrinthStoreFiltered(projectType, strings.TrimSpace(query), filters, inst.CachesDir())
+	if err != nil {
+		return RemoteStoreSearchResponse{Error: err.Error()}
+	}
+	return RemoteStoreSearchResponse{Hits: hits, Total: total}
+}
+
 // GetInstanceDetails returns extended information about a specific instance,
 // including lists of installed mods, shaderpacks, resourcepacks and other folders.
 func (a *App) GetInstanceDetails(instanceName string) InstanceDetails {
@@ -612,6 +1091,9 @@ func (a *App) GetInstanceDetails(instanceName string) InstanceDetails {
 		remoteInstalls = map[string]launcher.RemoteInstallMeta{}
 	}
 	details.RemoteInstalls = remoteInstalls
+	if lock, err := launcher.LoadModLock(instanceDir); err == nil {
+		details.ModLock = lock.Entries
+	}
 
 	cfCat, mrCat := instanceCatalogFlags(&inst)
 	details.CatalogCurseforgeEnabled = cfCat
@@ -772,6 +1254,9 @@ func (a *App) SetInstanceResourceEnabled(instanceName, category, resourcePath st
 	if err := applyResourceDisabledToggle(absPath, enabled); err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
+	if err := launcher.SetModLockDisabled(inst.Dir(), category, resourcePath, !enabled); err != nil {
+		logMessage(fmt.Sprintf("[Mods] mods.lock: %v", err))
+	}
 	return ""
 }
 
@@ -791,12 +1276,64 @@ func (a *App) DeleteInstanceResource(instanceName, category, resourcePath string
 		return fmt.Sprintf("Error: %v", err)
 	}
 	launcher.RemoveRemoteInstall(inst.Dir(), category, resourcePath)
+	if lock, err := launcher.LoadModLock(inst.Dir()); err == nil && lock.Remove(category, resourcePath) {
+		if err := launcher.SaveModLock(inst.Dir(), lock); err != nil {
+			logMessage(fmt.Sprintf("[Mods] mods.lock: %v", err))
+		}
+	}
 	return ""
 }
 
-// SetInstanceModEnabled is a shortcut for SetInstanceResourceEnabled(..., "mods", ...).
-func (a *App) SetInstanceModEnabled(instanceName, modFileName string, enabled bool) string {
-	return a.SetInstanceResourceEnabled(instanceName, "mods", modFileName, enabled)
+// resolveInstanceModFile maps a mod reference to its file name in mods/ (as on disk, possibly *.disabled).
+// ref is a file name (with or without .disabled) or the slug, project id or title of a mods.lock entry.
+func resolveInstanceModFile(inst *launcher.Instance, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(modsDir, name))
+		return err == nil
+	}
+	if validateModsBasename(ref) == nil {
+		active := resourceStripDisabledSuffix(ref)
+		if exists(active) {
+			return active, nil
+		}
+		if exists(active + ".disabled") {
+			return active + ".disabled", nil
+		}
+	}
+	lock, err := launcher.LoadModLock(inst.Dir())
+	if err != nil {
+		return "", err
+	}
+	for _, e := range lock.Entries {
+		if e.Category != "mods" {
+			continue
+		}
+		if !strings.EqualFold(e.Slug, ref) && e.ProjectID != ref && !strings.EqualFold(e.Title, ref) {
+			continue
+		}
+		if exists(e.Filename) {
+			return e.Filename, nil
+		}
+		if exists(e.Filename + ".disabled") {
+			return e.Filename + ".disabled", nil
+		}
+	}
+	return "", fmt.Errorf("mod not found: %s", ref)
+}
+
+// SetInstanceModEnabled enables or disables a mod by file name or mods.lock slug/project id/title.
+func (a *App) SetInstanceModEnabled(instanceName, mod string, enabled bool) string {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	name, err := resolveInstanceModFile(&inst, mod)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return a.SetInstanceResourceEnabled(instanceName, "mods", name, enabled)
 }
 
 // DeleteInstanceMod is a shortcut for DeleteInstanceResource(..., "mods", ...).
@@ -804,1834 +1341,5145 @@ func (a *App) DeleteInstanceMod(instanceName, modFileName string) string {
 	return a.DeleteInstanceResource(instanceName, "mods", modFileName)
 }
 
-func startDefaultBrowser(target string) error {
-	var cmd *exec.Cmd
-	switch goruntime.GOOS {
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
-	case "darwin":
-		cmd = exec.Command("open", target)
-	default:
-		cmd = exec.Command("xdg-open", target)
-	}
-	return cmd.Start()
+// ModProfilesResponse lists an instance's mod profiles.
+type ModProfilesResponse struct {
+	Active   string                `json:"active"`
+	Profiles []launcher.ModProfile `json:"profiles"`
+	Error    string                `json:"error"`
 }
 
-// OpenBrowserURL opens an http(s) URL in the system default browser.
-func (a *App) OpenBrowserURL(target string) string {
-	target = strings.TrimSpace(target)
-	if target == "" {
-		return "Error: empty URL"
+// GetInstanceModProfiles returns the named mod sets of an instance.
+func (a *App) GetInstanceModProfiles(instanceName string) ModProfilesResponse {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModProfilesResponse{Error: err.Error()}
 	}
-	u, err := url.Parse(target)
-	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
-		return "Error: invalid URL"
+	p, err := launcher.LoadModProfiles(inst.Dir())
+	if err != nil {
+		return ModProfilesResponse{Error: err.Error()}
 	}
-	if err := startDefaultBrowser(target); err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	return ModProfilesResponse{Active: p.Active, Profiles: p.Profiles}
+}
+
+// instanceModFiles lists mods/ as active file name -> enabled.
+func instanceModFiles(inst *launcher.Instance) (map[string]bool, error) {
+	entries, err := os.ReadDir(filepath.Join(inst.Dir(), "mods"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
 	}
-	return ""
+	out := map[string]bool{}
+	for _, e := range entries {
+		if e.IsDir() || validateModsBasename(e.Name()) != nil {
+			continue
+		}
+		active := resourceStripDisabledSuffix(e.Name())
+		out[active] = out[active] || active == e.Name()
+	}
+	return out, nil
 }
 
-// ResolveInstanceResourceStoreLinks resolves CurseForge and Modrinth links for a resource row (best effort).
-// category: mods | resourcepacks | shaderpacks | datapacks — for modpacks returns empty URLs.
-func (a *App) ResolveInstanceResourceStoreLinks(instanceName, category, storagePath string) ResourceStoreLinks {
-	var out ResourceStoreLinks
-	category = strings.ToLower(strings.TrimSpace(category))
-	instanceName = strings.TrimSpace(instanceName)
-	inst, err := launcher.FetchInstance(instanceName)
+// SaveInstanceModProfile creates or replaces a mod profile. When mods is empty the currently enabled
+// mods are used; mod references are resolved like SetInstanceModEnabled. withConfigs stores a
+// snapshot of config/ that replaces config/ when the profile is applied; without it, a snapshot the
+// profile already has is kept.
+func (a *App) SaveInstanceModProfile(instanceName, profile string, mods []string, withConfigs bool) string {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		return out
+		return fmt.Sprintf("Error: %v", err)
 	}
-	absPath, err := instanceResourceAbsPath(&inst, category, storagePath)
-	if err != nil {
-		return out
+	profile = strings.TrimSpace(profile)
+	if err := launcher.ValidateModProfileName(profile); err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
-	if _, err := os.Stat(absPath); err != nil {
-		return out
+	var list []string
+	if len(mods) == 0 {
+		files, err := instanceModFiles(&inst)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		for name, enabled := range files {
+			if enabled {
+				list = append(list, name)
+			}
+		}
+	} else {
+		for _, ref := range mods {
+			name, err := resolveInstanceModFile(&inst, ref)
+			if err != nil {
+				return fmt.Sprintf("Error: %v", err)
+			}
+			list = append(list, resourceStripDisabledSuffix(name))
+		}
 	}
+	sort.Strings(list)
+	list = slices.Compact(list)
 
-	caches := inst.CachesDir()
-	loader := string(inst.Loader)
-	gameVer := inst.GameVersion
-
-	base := filepath.Base(filepath.ToSlash(strings.TrimSpace(storagePath)))
-	base = resourceStripDisabledSuffix(base)
+	profiles, err := launcher.LoadModProfiles(inst.Dir())
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	idx := profiles.Find(profile)
+	entry := launcher.ModProfile{Name: profile, Mods: list, HasConfigs: withConfigs}
+	if withConfigs {
+		if err := launcher.ReplaceTree(filepath.Join(inst.Dir(), "config"), launcher.ModProfileConfigDir(inst.Dir(), profile)); err != nil {
+			return fmt.Sprintf("Error: config snapshot: %v", err)
+		}
+	} else if idx >= 0 {
+		entry.HasConfigs = profiles.Profiles[idx].HasConfigs
+	}
+	if idx >= 0 {
+		profiles.Profiles[idx] = entry
+	} else {
+		profiles.Profiles = append(profiles.Profiles, entry)
+	}
+	if err := launcher.SaveModProfiles(inst.Dir(), profiles); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return ""
+}
 
-	switch category {
-	case "modpacks":
-		return out
-	case "mods":
-		if !strings.HasSuffix(strings.ToLower(base), ".jar") {
-			return out
+// DeleteInstanceModProfile removes a mod profile and its config snapshot. Mods are left as they are.
+func (a *App) DeleteInstanceModProfile(instanceName, profile string) string {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	profiles, err := launcher.LoadModProfiles(inst.Dir())
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	idx := profiles.Find(strings.TrimSpace(profile))
+	if idx < 0 {
+		return fmt.Sprintf("Error: profile not found: %s", profile)
+	}
+	name := profiles.Profiles[idx].Name
+	profiles.Profiles = slices.Delete(profiles.Profiles, idx, idx+1)
+	if strings.EqualFold(profiles.Active, name) {
+		profiles.Active = ""
+	}
+	_ = os.RemoveAll(filepath.Dir(launcher.ModProfileConfigDir(inst.Dir(), name)))
+	if err := launcher.SaveModProfiles(inst.Dir(), profiles); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return ""
+}
+
+// ModProfileApplyReport is the result of switching to a mod profile.
+type ModProfileApplyReport struct {
+	Enabled  []string `json:"enabled"`
+	Disabled []string `json:"disabled"`
+	// Missing are profile mods no longer present in mods/.
+	Missing        []string `json:"missing"`
+	ConfigsSwapped bool     `json:"configsSwapped"`
+	Error          string   `json:"error"`
+}
+
+// ApplyInstanceModProfile enables the profile's mods and disables every other mod. When the profile has a
+// config snapshot, the current config/ is first saved back into the previously active profile (if it keeps
+// one) and then replaced by the snapshot, so configs the snapshot lacks are removed.
+func (a *App) ApplyInstanceModProfile(instanceName, profile string) ModProfileApplyReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModProfileApplyReport{Error: err.Error()}
+	}
+	profiles, err := launcher.LoadModProfiles(inst.Dir())
+	if err != nil {
+		return ModProfileApplyReport{Error: err.Error()}
+	}
+	idx := profiles.Find(strings.TrimSpace(profile))
+	if idx < 0 {
+		return ModProfileApplyReport{Error: fmt.Sprintf("profile not found: %s", profile)}
+	}
+	target := profiles.Profiles[idx]
+	files, err := instanceModFiles(&inst)
+	if err != nil {
+		return ModProfileApplyReport{Error: err.Error()}
+	}
+
+	report := ModProfileApplyReport{Enabled: []string{}, Disabled: []string{}, Missing: []string{}}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	for _, name := range names {
+		want := slices.Contains(target.Mods, name)
+		if files[name] == want {
+			continue
 		}
-		mi := meta.ExtractModInfoFromFilename(base)
-		mi = meta.GetModLinks(mi, caches, loader, gameVer)
-		out.CurseforgeURL = mi.CurseForgeURL
-		out.ModrinthURL = mi.ModrinthURL
-		if out.ModrinthURL == "" {
-			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(mi.Slug)
+		path := filepath.Join(modsDir, name)
+		if want {
+			path += ".disabled"
 		}
-	case "resourcepacks":
-		rp := meta.ExtractResourcePackInfo(base)
-		rp = meta.GetResourcePackLinks(rp, caches, gameVer)
-		out.CurseforgeURL = meta.SearchResourcePackOnCurseForge(rp.Slug, caches, gameVer)
-		out.ModrinthURL = rp.ModrinthURL
-		if out.ModrinthURL == "" {
-			if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "resourcepack", caches); err == nil {
-				out.ModrinthURL = "https://modrinth.com/resourcepack/" + slug
-			} else {
-				out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
-			}
+		if err := applyResourceDisabledToggle(path, want); err != nil {
+			return ModProfileApplyReport{Error: fmt.Sprintf("%s: %v", name, err)}
 		}
-	case "shaderpacks":
-		rp := meta.ExtractResourcePackInfo(base)
-		out.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(rp.Slug)
-		if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "shader", caches); err == nil {
-			out.ModrinthURL = "https://modrinth.com/shader/" + slug
+		if err := launcher.SetModLockDisabled(inst.Dir(), "mods", name, !want); err != nil {
+			logMessage(fmt.Sprintf("[Mods] mods.lock: %v", err))
+		}
+		if want {
+			report.Enabled = append(report.Enabled, name)
 		} else {
-			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
+			report.Disabled = append(report.Disabled, name)
 		}
-	case "datapacks":
-		rp := meta.ExtractResourcePackInfo(base)
-		out.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(rp.Slug)
-		if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "datapack", caches); err == nil {
-			out.ModrinthURL = "https://modrinth.com/datapack/" + slug
+	}
+	for _, name := range target.Mods {
+		if _, ok := files[name]; !ok {
+			report.Missing = append(report.Missing, name)
+		}
+	}
+
+	if target.HasConfigs {
+		configDir := filepath.Join(inst.Dir(), "config")
+		if prev := profiles.Find(profiles.Active); prev >= 0 && prev != idx && profiles.Profiles[prev].HasConfigs {
+			if err := launcher.ReplaceTree(configDir, launcher.ModProfileConfigDir(inst.Dir(), profiles.Profiles[prev].Name)); err != nil {
+				logMessage(fmt.Sprintf("[Mods] profile %s: config snapshot: %v", profiles.Profiles[prev].Name, err))
+			}
+		}
+		if err := launcher.ReplaceTree(launcher.ModProfileConfigDir(inst.Dir(), target.Name), configDir); err != nil {
+			report.Error = fmt.Sprintf("config: %v", err)
 		} else {
-			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
+			report.ConfigsSwapped = true
 		}
 	}
-	cfOn, mrOn := instanceCatalogFlags(&inst)
-	if !cfOn {
-		out.CurseforgeURL = ""
+
+	profiles.Active = target.Name
+	if err := launcher.SaveModProfiles(inst.Dir(), profiles); err != nil && report.Error == "" {
+		report.Error = err.Error()
 	}
-	if !mrOn {
-		out.ModrinthURL = ""
+	logMessage(fmt.Sprintf("[Mods] %s: профиль %s применён (+%d / -%d)", inst.Name, target.Name, len(report.Enabled), len(report.Disabled)))
+	return report
+}
+
+// InstanceModInfo is one mod JAR with the metadata read from inside it.
+type InstanceModInfo struct {
+	File    string `json:"file"`
+	Enabled bool   `json:"enabled"`
+	meta.ModMetadata
+	Error string `json:"error,omitempty"`
+}
+
+// readInstanceModsMetadata parses the descriptor of every JAR in mods/ (active and disabled).
+func readInstanceModsMetadata(inst *launcher.Instance) []InstanceModInfo {
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	entries, err := os.ReadDir(modsDir)
+	if err != nil {
+		return []InstanceModInfo{}
+	}
+	out := make([]InstanceModInfo, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || validateModsBasename(e.Name()) != nil {
+			continue
+		}
+		info := InstanceModInfo{File: e.Name(), Enabled: !resourceHasDisabledSuffix(e.Name())}
+		md, err := meta.ReadModMetadata(filepath.Join(modsDir, e.Name()))
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.ModMetadata = md
+		}
+		out = append(out, info)
 	}
 	return out
 }
 
-// SearchRemoteStore searches CurseForge or Modrinth (or both interleaved) for installable content.
-// source: curseforge | modrinth | both
-// curseSort / modrinthSort: popularity | downloads
-func (a *App) SearchRemoteStore(instanceName, category, source, query, curseSort, modrinthSort string, page int) RemoteStoreSearchResponse {
-	instanceName = strings.TrimSpace(instanceName)
-	inst, err := launcher.FetchInstance(instanceName)
+// GetInstanceModsMetadata returns mod id, name, version and required dependencies of every mod JAR.
+func (a *App) GetInstanceModsMetadata(instanceName string) []InstanceModInfo {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		return RemoteStoreSearchResponse{Error: err.Error()}
-	}
-	caches := inst.CachesDir()
-	query = strings.TrimSpace(query)
-	if query == "" {
-		query = "minecraft"
+		return []InstanceModInfo{}
 	}
-	source = strings.ToLower(strings.TrimSpace(source))
-	if page < 0 {
-		page = 0
+	return readInstanceModsMetadata(&inst)
+}
+
+// InstanceModDetails is the license, authors and description of one mod, from its JAR and Modrinth.
+type InstanceModDetails struct {
+	File     string                       `json:"file"`
+	Enabled  bool                         `json:"enabled"`
+	Jar      meta.ModMetadata             `json:"jar"`
+	Modrinth *meta.ModrinthProjectDetails `json:"modrinth,omitempty"`
+	// License, Authors and Description prefer Modrinth and fall back to the JAR descriptor.
+	License     string   `json:"license"`
+	LicenseURL  string   `json:"licenseUrl,omitempty"`
+	Authors     []string `json:"authors"`
+	Description string   `json:"description"`
+	Error       string   `json:"error"`
+}
+
+// GetInstanceModDetails shows license, authors and description of a mod (file name or mods.lock
+// slug/project id/title), e.g. for pack authors checking redistribution rights.
+func (a *App) GetInstanceModDetails(instanceName, mod string) InstanceModDetails {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return InstanceModDetails{Error: err.Error()}
 	}
-	const pageSize = 20
-	cfOn, mrOn := instanceCatalogFlags(&inst)
-	switch source {
-	case "curseforge":
-		if !cfOn {
-			return RemoteStoreSearchResponse{Error: "Каталог CurseForge отключён в настройках лаунчера"}
-		}
-		hits, err := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
-		if err != nil {
-			return RemoteStoreSearchResponse{Error: err.Error()}
+	name, err := resolveInstanceModFile(&inst, mod)
+	if err != nil {
+		return InstanceModDetails{Error: err.Error()}
+	}
+	path := filepath.Join(inst.Dir(), "mods", name)
+	out := InstanceModDetails{File: name, Enabled: !resourceHasDisabledSuffix(name), Authors: []string{}}
+	if md, err := meta.ReadModMetadata(path); err == nil {
+		out.Jar = md
+	}
+
+	if _, mrOn := instanceCatalogFlags(&inst); mrOn {
+		projectID := ""
+		lock, _ := launcher.LoadModLock(inst.Dir())
+		if idx := lock.Find("mods", resourceStripDisabledSuffix(name)); idx >= 0 && lock.Entries[idx].Provider == "modrinth" {
+			projectID = lock.Entries[idx].ProjectID
+		} else if sum, err := meta.FileSHA1(path); err == nil {
+			if found, err := meta.IdentifyModrinthHashes([]string{sum}, inst.CachesDir()); err == nil {
+				projectID = found[sum].ProjectID
+			}
 		}
-		return RemoteStoreSearchResponse{Hits: hits}
-	case "modrinth":
-		if !mrOn {
-			return RemoteStoreSearchResponse{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+		if projectID != "" {
+			if p, err := meta.FetchModrinthProjectDetails(projectID); err == nil {
+				out.Modrinth = &p
+			} else {
+				logMessage(fmt.Sprintf("[Modrinth] project %s: %v", projectID, err))
+			}
 		}
-		hits, err := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
-		if err != nil {
-			return RemoteStoreSearchResponse{Error: err.Error()}
+	}
+
+	out.License, out.Description = out.Jar.License, out.Jar.Description
+	out.Authors = append(out.Authors, out.Jar.Authors...)
+	if p := out.Modrinth; p != nil {
+		if p.License.ID != "" {
+			out.License = p.License.ID
+			if p.License.Name != "" && p.License.ID == "LicenseRef-Custom" {
+				out.License = p.License.Name
+			}
+			out.LicenseURL = p.License.URL
 		}
-		return RemoteStoreSearchResponse{Hits: hits}
-	case "both":
-		if !cfOn && !mrOn {
-			return RemoteStoreSearchResponse{Error: "Каталоги CurseForge и Modrinth отключены в настройках лаунчера"}
+		if p.Description != "" {
+			out.Description = p.Description
 		}
-		if !cfOn {
-			mr, errMr := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
-			if errMr != nil {
-				return RemoteStoreSearchResponse{Error: errMr.Error()}
+		for _, author := range p.Authors {
+			if !slices.ContainsFunc(out.Authors, func(s string) bool { return strings.EqualFold(s, author) }) {
+				out.Authors = append(out.Authors, author)
 			}
-			return RemoteStoreSearchResponse{Hits: mr}
 		}
-		if !mrOn {
-			cf, errCf := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
-			if errCf != nil {
-				return RemoteStoreSearchResponse{Error: errCf.Error()}
+	}
+	return out
+}
+
+// buildInstanceModList collects names, versions, authors and project links of every mod JAR.
+// Links come from mods.lock / remote-installs first, then from a Modrinth hash lookup.
+func buildInstanceModList(inst *launcher.Instance) launcher.ModList {
+	list := launcher.ModList{
+		Instance:    inst.Name,
+		GameVersion: inst.GameVersion,
+		Loader:      string(inst.Loader),
+		GeneratedAt: time.Now().UTC(),
+		Mods:        []launcher.ModListEntry{},
+	}
+	lock, _ := launcher.LoadModLock(inst.Dir())
+	installs := launcher.LoadRemoteInstalls(inst.Dir())
+	modsDir := filepath.Join(inst.Dir(), "mods")
+
+	unlinked := map[string]int{} // sha1 -> index in list.Mods
+	for _, info := range readInstanceModsMetadata(inst) {
+		active := resourceStripDisabledSuffix(info.File)
+		e := launcher.ModListEntry{
+			Name:     info.Name,
+			ModID:    info.ModID,
+			Version:  info.Version,
+			Authors:  info.Authors,
+			Filename: active,
+			Enabled:  info.Enabled,
+		}
+		if e.Name == "" {
+			e.Name = strings.TrimSuffix(active, ".jar")
+		}
+		if idx := lock.Find("mods", active); idx >= 0 {
+			le := lock.Entries[idx]
+			e.Provider = le.Provider
+			if e.Version == "" {
+				e.Version = le.VersionNumber
+			}
+			e.URL = storeProjectPageURL(le.Provider, le.ProjectID, le.Slug)
+		} else if rec, ok := installs["mods/"+active]; ok {
+			e.Provider = rec.Source
+			e.URL = storeProjectPageURL(rec.Source, rec.ProjectID, rec.Slug)
+		}
+		if e.URL == "" {
+			if sum, err := meta.FileSHA1(filepath.Join(modsDir, info.File)); err == nil {
+				unlinked[sum] = len(list.Mods)
 			}
-			return RemoteStoreSearchResponse{Hits: cf}
 		}
-		cf, errCf := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
-		mr, errMr := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
-		if errMr != nil {
-			return RemoteStoreSearchResponse{Error: errMr.Error()}
+		list.Mods = append(list.Mods, e)
+	}
+
+	if _, mrOn := instanceCatalogFlags(inst); mrOn && len(unlinked) > 0 {
+		hashes := make([]string, 0, len(unlinked))
+		for h := range unlinked {
+			hashes = append(hashes, h)
 		}
-		var cfHits []meta.RemoteStoreHit
-		if errCf == nil {
-			cfHits = cf
+		if found, err := meta.IdentifyModrinthHashes(hashes, inst.CachesDir()); err == nil {
+			for h, m := range found {
+				list.Mods[unlinked[h]].Provider = "modrinth"
+				list.Mods[unlinked[h]].URL = m.PageURL
+			}
 		}
-		return RemoteStoreSearchResponse{Hits: meta.MergeRemoteStoreHits(cfHits, mr)}
-	default:
-		return RemoteStoreSearchResponse{Error: "неизвестный источник каталога"}
 	}
+	sort.SliceStable(list.Mods, func(i, j int) bool {
+		return strings.ToLower(list.Mods[i].Name) < strings.ToLower(list.Mods[j].Name)
+	})
+	return list
 }
 
-func remoteStoreDestDir(inst *launcher.Instance, category string) (string, error) {
-	instanceDir := inst.Dir()
-	switch strings.ToLower(strings.TrimSpace(category)) {
-	case "mods":
-		return filepath.Join(instanceDir, "mods"), nil
-	case "resourcepacks":
-		return filepath.Join(instanceDir, "resourcepacks"), nil
-	case "shaderpacks":
-		return filepath.Join(instanceDir, "shaderpacks"), nil
-	case "datapacks":
-		return filepath.Join(instanceDir, "datapacks"), nil
-	case "modpacks":
-		return instanceDir, nil
-	default:
-		return "", fmt.Errorf("unknown category")
+// storeProjectPageURL returns the project page for a catalog source, or "".
+func storeProjectPageURL(source, projectID, slug string) string {
+	switch strings.ToLower(source) {
+	case "modrinth":
+		if slug == "" {
+			slug = projectID
+		}
+		if slug != "" {
+			return meta.ModrinthPageURL("mod", slug)
+		}
+	case "curseforge":
+		if slug != "" {
+			return "https://www.curseforge.com/minecraft/mc-mods/" + url.PathEscape(slug)
+		}
+		if projectID != "" {
+			return "https://www.curseforge.com/projects/" + url.PathEscape(projectID)
+		}
 	}
+	return ""
 }
 
-// DownloadRemoteStoreProject downloads a file from Modrinth or CurseForge.
-// title is optional display name from the catalog for stored metadata.
-// iconURL is optional HTTPS thumbnail URL from the catalog (shown in instance resource lists).
-func (a *App) DownloadRemoteStoreProject(instanceName, category, storeSource, projectID, slug, title, iconURL string) string {
-	instanceName = strings.TrimSpace(instanceName)
-	inst, err := launcher.FetchInstance(instanceName)
+// RenderInstanceModList returns the instance's mod list as md, json or html text (e.g. for the clipboard).
+func (a *App) RenderInstanceModList(instanceName, format string) (string, string) {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+		return "", err.Error()
 	}
-	destDir, err := remoteStoreDestDir(&inst, category)
+	data, err := launcher.RenderModList(buildInstanceModList(&inst), format)
+	if err != nil {
+		return "", err.Error()
+	}
+	return string(data), ""
+}
+
+// ExportInstanceModList writes the instance's mod list (names, versions, authors, links) as md, json or html.
+// When path is empty, a save dialog is shown.
+func (a *App) ExportInstanceModList(instanceName, format, path string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	ext, ok := launcher.ModListFormats[format]
+	if !ok {
+		return fmt.Sprintf("Error: unknown format %q (md, json, html)", format)
+	}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
-	storeSource = strings.ToLower(strings.TrimSpace(storeSource))
-	cfOn, mrOn := instanceCatalogFlags(&inst)
-	catKey := strings.ToLower(strings.TrimSpace(category))
-	var savedPath string
-	switch storeSource {
-	case "modrinth":
-		if !mrOn {
-			return "Error: Modrinth catalog is disabled in launcher settings"
-		}
-		s := strings.TrimSpace(slug)
-		if s == "" {
-			s = strings.TrimSpace(projectID)
-		}
-		savedPath, err = meta.DownloadModrinthProjectTo(s, inst.GameVersion, string(inst.Loader), catKey, destDir)
+	if strings.TrimSpace(path) == "" {
+		p, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           "Экспорт списка модов",
+			DefaultFilename: inst.Name + "-mods" + ext,
+			Filters:         []runtime.FileFilter{{DisplayName: strings.ToUpper(format) + " (*" + ext + ")", Pattern: "*" + ext}},
+		})
 		if err != nil {
 			return fmt.Sprintf("Error: %v", err)
 		}
-	case "curseforge":
-		if !cfOn {
-			return "Error: CurseForge catalog is disabled in launcher settings"
-		}
-		savedPath, err = meta.DownloadCurseForgeProjectTo(projectID, inst.GameVersion, string(inst.Loader), catKey, meta.CurseForgeAPIKey(), destDir)
-		if err != nil {
-			return fmt.Sprintf("Error: %v", err)
+		if p == "" {
+			return ""
 		}
-	default:
-		return "Error: unknown store"
+		path = p
 	}
-	baseName := filepath.Base(savedPath)
-	launcher.RecordRemoteInstall(inst.Dir(), catKey, baseName, launcher.RemoteInstallMeta{
-		Category:  catKey,
-		Source:    storeSource,
-		ProjectID: strings.TrimSpace(projectID),
-		Slug:      strings.TrimSpace(slug),
-		Title:     strings.TrimSpace(title),
-		IconURL:   strings.TrimSpace(iconURL),
-	})
+	data, err := launcher.RenderModList(buildInstanceModList(&inst), format)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	logMessage(fmt.Sprintf("[Mods] Список модов %s сохранён в %s", inst.Name, path))
 	return ""
 }
 
-// SetInstanceMemory sets min (-Xms) and max (-Xmx) memory for an instance in MB.
-// Both default to 4096. minMemoryMB must be <= maxMemoryMB. Returns error string on failure.
-func (a *App) SetInstanceMemory(instanceName string, minMemoryMB int, maxMemoryMB int) string {
-	if instanceName == "" {
-		return "Error: empty instance name"
+// ModIssue is a problem found among the enabled mods of an instance.
+type ModIssue struct {
+	Kind    string   `json:"kind"` // duplicate | wrong-loader | game-version | missing-api
+	ModID   string   `json:"modId"`
+	Files   []string `json:"files"`
+	Message string   `json:"message"`
+	// Disable are the files FixInstanceModConflicts disables (older duplicates).
+	Disable []string `json:"disable,omitempty"`
+}
+
+// ModConflictReport is returned by CheckInstanceModConflicts and FixInstanceModConflicts.
+type ModConflictReport struct {
+	Issues   []ModIssue `json:"issues"`
+	Disabled []string   `json:"disabled"` // files disabled by the fix
+	Error    string     `json:"error"`
+}
+
+// modCompatIssues checks one mod JAR against the instance's loader and game version (wrong-loader, game-version).
+func modCompatIssues(inst *launcher.Instance, file string, m meta.ModMetadata) []ModIssue {
+	var issues []ModIssue
+	if inst.Loader != launcher.LoaderVanilla && !m.SupportsLoader(string(inst.Loader), inst.GameVersion) {
+		issues = append(issues, ModIssue{
+			Kind:    "wrong-loader",
+			ModID:   m.ModID,
+			Files:   []string{file},
+			Message: fmt.Sprintf("%s собран для %s, а экземпляр использует %s", file, strings.Join(m.Loaders, "/"), inst.Loader),
+		})
 	}
-	if minMemoryMB < 128 || minMemoryMB > 32768 {
-		return "Error: min memory must be between 128 and 32768 MB"
+	if ok, known := m.MinecraftSatisfies(inst.GameVersion); known && !ok {
+		issues = append(issues, ModIssue{
+			Kind:    "game-version",
+			ModID:   m.ModID,
+			Files:   []string{file},
+			Message: fmt.Sprintf("%s требует Minecraft %s, а экземпляр — %s", file, m.Minecraft, inst.GameVersion),
+		})
 	}
-	if maxMemoryMB < 512 || maxMemoryMB > 32768 {
-		return "Error: max memory must be between 512 and 32768 MB"
-	}
-	if minMemoryMB > maxMemoryMB {
-		return "Error: min memory cannot exceed max memory"
-	}
-	inst, err := launcher.FetchInstance(instanceName)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
-	}
-	inst.Config.MinMemory = minMemoryMB
-	inst.Config.MaxMemory = maxMemoryMB
-	if err := inst.WriteConfig(); err != nil {
-		return fmt.Sprintf("Error: failed to save config: %v", err)
-	}
-	return ""
+	return issues
 }
 
-// CreateInstance creates a new Minecraft instance.
-// loader: "vanilla", "fabric", "quilt", "forge", "neoforge"
-// gameVersion: e.g. "1.20.1", "release" for latest
-// loaderVersion: e.g. "latest" for Fabric/Quilt/Forge; empty for vanilla
-// Returns empty string on success, error message on failure.
-func (a *App) CreateInstance(name string, gameVersion string, loader string, loaderVersion string) string {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return "Error: имя инстанса не может быть пустым"
-	}
-	var ldr launcher.Loader
-	switch strings.ToLower(strings.TrimSpace(loader)) {
-	case "fabric":
-		ldr = launcher.LoaderFabric
-	case "quilt":
-		ldr = launcher.LoaderQuilt
-	case "neoforge":
-		ldr = launcher.LoaderNeoForge
-	case "forge":
-		ldr = launcher.LoaderForge
-	default:
-		ldr = launcher.LoaderVanilla
-	}
-	if gameVersion == "" {
-		gameVersion = "release"
-	}
-	if loaderVersion == "" && ldr != launcher.LoaderVanilla {
-		loaderVersion = "latest"
-	}
-	defaultConfig := launcher.InstanceConfig{
-		WindowResolution: struct {
-			Width  int `toml:"width" json:"width"`
-			Height int `toml:"height" json:"height"`
-		}{Width: 1708, Height: 960},
-		MinMemory: 4096,
-		MaxMemory: 4096,
+// findModIssues detects duplicate mod ids, JARs for another loader and game version mismatches.
+func findModIssues(inst *launcher.Instance) []ModIssue {
+	issues := []ModIssue{}
+	byID := map[string][]InstanceModInfo{}
+	var ids []string
+	for _, m := range readInstanceModsMetadata(inst) {
+		if !m.Enabled || m.Error != "" || m.ModID == "" {
+			continue
+		}
+		if _, ok := byID[m.ModID]; !ok {
+			ids = append(ids, m.ModID)
+		}
+		byID[m.ModID] = append(byID[m.ModID], m)
+
+		issues = append(issues, modCompatIssues(inst, m.File, m.ModMetadata)...)
 	}
-	options := launcher.InstanceOptions{
-		Name:          name,
-		GameVersion:   gameVersion,
-		Loader:        ldr,
-		LoaderVersion: loaderVersion,
-		Config:        defaultConfig,
+	for _, m := range findMissingAPIMods(inst) {
+		issues = append(issues, ModIssue{
+			Kind:    "missing-api",
+			ModID:   m.Slug,
+			Files:   m.RequiredBy,
+			Message: fmt.Sprintf("%s не установлен, но нужен для: %s", m.Title, strings.Join(m.RequiredBy, ", ")),
+		})
 	}
-	_, err := launcher.CreateInstance(options)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	sort.Strings(ids)
+	for _, id := range ids {
+		mods := byID[id]
+		if len(mods) < 2 {
+			continue
+		}
+		// Newest first; the rest are the ones to disable.
+		sort.SliceStable(mods, func(i, j int) bool { return meta.CompareModVersions(mods[i].Version, mods[j].Version) > 0 })
+		issue := ModIssue{Kind: "duplicate", ModID: id}
+		for i, m := range mods {
+			issue.Files = append(issue.Files, m.File)
+			if i > 0 {
+				issue.Disable = append(issue.Disable, m.File)
+			}
+		}
+		issue.Message = fmt.Sprintf("%s установлен %d раза: %s", id, len(mods), strings.Join(issue.Files, ", "))
+		issues = append(issues, issue)
 	}
-	return ""
+	return issues
 }
 
-// GetCreateInstanceMinecraftVersions returns Minecraft release version ids (newest first) for the create-instance UI.
-// Prefer QMServer Cloud; on failure or empty list fall back to Mojang manifest via local cache.
-func (a *App) GetCreateInstanceMinecraftVersions() []string {
-	cloudBase := network.EffectiveQMServerAPIBase()
-	if v, err := meta.FetchCreateInstanceMinecraftFromQMServerCloud(cloudBase); err == nil && len(v) > 0 {
-		return v
-	} else if err != nil {
-		logMessage(fmt.Sprintf("[CreateInstance] QMServer Cloud minecraft versions: %v (using fallback)", err))
-	}
-	v, err := meta.ListCreateInstanceMinecraftReleases(env.CachesDir)
-	if err != nil {
-		logMessage(fmt.Sprintf("[CreateInstance] GetCreateInstanceMinecraftVersions: %v", err))
-		return nil
-	}
-	return v
+// MissingAPIMod is a loader API mod (Fabric API, QSL, Architectury) required by installed mods but absent.
+type MissingAPIMod struct {
+	meta.LoaderAPIMod
+	RequiredBy []string `json:"requiredBy"` // mod files declaring the dependency
 }
 
-// GetCreateInstanceLoaderVersions returns loader versions for the given loader type and Minecraft version.
-// loader: fabric | quilt | forge | neoforge
-// Prefer QMServer Cloud; on failure or empty list fall back to Fabric/Quilt/Forge/NeoForge upstream APIs.
-func (a *App) GetCreateInstanceLoaderVersions(loader string, gameVersion string) []string {
-	cloudBase := network.EffectiveQMServerAPIBase()
-	if v, err := meta.FetchCreateInstanceLoaderFromQMServerCloud(cloudBase, loader, gameVersion); err == nil && len(v) > 0 {
-		return v
-	} else if err != nil {
-		logMessage(fmt.Sprintf("[CreateInstance] QMServer Cloud loader versions (%q, %q): %v (using fallback)", loader, gameVersion, err))
+// findMissingAPIMods reads the dependencies of enabled mods and returns the API mods none of them provide.
+func findMissingAPIMods(inst *launcher.Instance) []MissingAPIMod {
+	mods := readInstanceModsMetadata(inst)
+	present := map[string]bool{}
+	for _, m := range mods {
+		if !m.Enabled || m.ModID == "" {
+			continue
+		}
+		present[strings.ToLower(m.ModID)] = true
+		for _, p := range m.Provides {
+			present[strings.ToLower(p)] = true
+		}
 	}
-	v, err := meta.ListCreateInstanceLoaderVersions(loader, gameVersion)
-	if err != nil {
-		logMessage(fmt.Sprintf("[CreateInstance] GetCreateInstanceLoaderVersions(%q, %q): %v", loader, gameVersion, err))
-		return nil
+	var out []MissingAPIMod
+	index := map[string]int{}
+	for _, m := range mods {
+		if !m.Enabled {
+			continue
+		}
+		for _, dep := range m.Dependencies {
+			if present[strings.ToLower(dep)] {
+				continue
+			}
+			api, ok := meta.LoaderAPIForDependency(dep, string(inst.Loader))
+			if !ok || slices.ContainsFunc(api.ModIDs, func(id string) bool { return present[id] }) {
+				continue
+			}
+			i, seen := index[api.Slug]
+			if !seen {
+				i = len(out)
+				index[api.Slug] = i
+				out = append(out, MissingAPIMod{LoaderAPIMod: api})
+			}
+			if !slices.Contains(out[i].RequiredBy, m.File) {
+				out[i].RequiredBy = append(out[i].RequiredBy, m.File)
+			}
+		}
 	}
-	return v
+	return out
 }
 
-// DeleteInstance removes a Minecraft instance by name. Returns empty string on success.
-func (a *App) DeleteInstance(instanceName string) string {
-	instanceName = strings.TrimSpace(instanceName)
-	if instanceName == "" {
-		return "Error: имя инстанса не может быть пустым"
+// MissingAPIModsReport is returned by GetInstanceMissingAPIMods.
+type MissingAPIModsReport struct {
+	Missing []MissingAPIMod `json:"missing"`
+	Error   string          `json:"error"`
+}
+
+// GetInstanceMissingAPIMods lists loader API mods that installed mods require but that are not installed.
+func (a *App) GetInstanceMissingAPIMods(instanceName string) MissingAPIModsReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return MissingAPIModsReport{Error: err.Error()}
 	}
-	if err := launcher.RemoveInstance(instanceName); err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	missing := findMissingAPIMods(&inst)
+	if missing == nil {
+		missing = []MissingAPIMod{}
 	}
-	return ""
+	return MissingAPIModsReport{Missing: missing}
 }
 
-// OpenPath opens a path (file or folder) in the system default application.
-// For directories, opens in file explorer. Returns empty on success, error message on failure.
-func (a *App) OpenPath(path string) string {
-	path = strings.TrimSpace(path)
-	if path == "" {
-		return "Error: path is empty"
-	}
-	absPath, err := filepath.Abs(path)
+// InstallInstanceMissingAPIMods installs every missing loader API mod from Modrinth (with its dependencies).
+func (a *App) InstallInstanceMissingAPIMods(instanceName string) BulkInstallReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+		return BulkInstallReport{Error: err.Error()}
 	}
-	if _, err := os.Stat(absPath); err != nil {
-		return fmt.Sprintf("Error: path not found: %v", err)
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return BulkInstallReport{Error: "Каталог Modrinth отключён в настройках лаунчера"}
 	}
-	var cmd *exec.Cmd
-	switch goruntime.GOOS {
-	case "windows":
-		cmd = exec.Command("explorer", absPath)
-	case "darwin":
-		cmd = exec.Command("open", absPath)
-	default:
-		cmd = exec.Command("xdg-open", absPath)
+	report := BulkInstallReport{Items: []BulkInstallItem{}}
+	seen := map[string]bool{}
+	for _, m := range findMissingAPIMods(&inst) {
+		item := BulkInstallItem{Ref: m.Slug, Title: m.Title}
+		project, err := meta.FetchModrinthProject(m.Slug)
+		var f meta.ModrinthFile
+		if err == nil {
+			seen[project.ID] = true
+			f, err = installModrinthProject(inst, project, "mods")
+		}
+		if err != nil {
+			item.Error = err.Error()
+			report.Failed++
+			report.Items = append(report.Items, item)
+			continue
+		}
+		item.Filename = f.Filename
+		report.Installed++
+		report.Items = append(report.Items, item)
+		logMessage(fmt.Sprintf("[Mods] Установлен %s (%s) в %s", m.Title, f.Filename, inst.Name))
+		installModrinthDependencies(inst, f, seen, &report)
 	}
-	if err := cmd.Start(); err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	return report
+}
+
+// CheckInstanceModConflicts reports duplicate mods, wrong-loader JARs and game version mismatches.
+func (a *App) CheckInstanceModConflicts(instanceName string) ModConflictReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModConflictReport{Error: err.Error()}
 	}
-	return ""
+	return ModConflictReport{Issues: findModIssues(&inst), Disabled: []string{}}
 }
 
-// ServerInfo represents server information for frontend
-type ServerInfo struct {
-	ID               string `json:"id"`
-	Name             string `json:"name"`
-	Address          string `json:"address"`
-	Port             int    `json:"port"`
-	Online           bool   `json:"online"`
-	Enabled          bool   `json:"enabled"`
-	GameServerOnline *bool  `json:"gameServerOnline,omitempty"`
-	Players          int    `json:"players"`
-	MaxPlayers       int    `json:"maxPlayers"`
-	Version          string `json:"version"`
-	ModLoader        string `json:"modLoader,omitempty"`
-	ModLoaderVersion string `json:"modLoaderVersion,omitempty"`
-	IsPremium        bool   `json:"isPremium,omitempty"`
-	ServerID         uint   `json:"serverID,omitempty"`
+// FixInstanceModConflicts disables the older copies of duplicate mods; other issues are only reported.
+func (a *App) FixInstanceModConflicts(instanceName string) ModConflictReport {
+	report := a.CheckInstanceModConflicts(instanceName)
+	if report.Error != "" {
+		return report
+	}
+	for _, issue := range report.Issues {
+		for _, f := range issue.Disable {
+			if msg := a.SetInstanceResourceEnabled(instanceName, "mods", f, false); msg != "" {
+				report.Error = msg
+				return report
+			}
+			report.Disabled = append(report.Disabled, f)
+			logMessage(fmt.Sprintf("[Mods] Отключён старый дубликат %s (%s)", f, issue.ModID))
+		}
+	}
+	return report
 }
 
-// GetQMServersError returns the last error from loading servers (empty if none)
-func (a *App) GetQMServersError() string {
-	return lastQMError
+// ModRemovePlan lists what RemoveInstanceMod deletes; the frontend shows it for confirmation first.
+type ModRemovePlan struct {
+	// Matches are the mod files matching the query; more than one means the query is ambiguous.
+	Matches []string `json:"matches"`
+	Mod     string   `json:"mod"`     // mod file to remove (set when exactly one file matches)
+	Configs []string `json:"configs"` // instance-relative config files/dirs of the mod
+	// Dependencies are mod files that only this mod required (per mods.lock).
+	Dependencies []string `json:"dependencies"`
+	Removed      bool     `json:"removed"`
+	Error        string   `json:"error"`
 }
 
-// InvalidateQMServersCache clears the cached QMServer /servers response so the next GetRecentServers fetches fresh data.
-func (a *App) InvalidateQMServersCache() {
-	network.InvalidateServersCache()
+func normalizeModQuery(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
 }
 
-// GetRecentServers returns list of servers from QMServer Cloud
-func (a *App) GetRecentServers() []ServerInfo {
-	lastQMError = ""
-	serversResponse, err := network.GetQMServersList()
+// matchInstanceMods fuzzy-matches query against mod file names and mods.lock / remote-installs slugs and titles.
+// Only the best-scoring files are returned (exact > prefix > substring).
+func matchInstanceMods(inst *launcher.Instance, query string) []string {
+	q := normalizeModQuery(query)
+	if q == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(filepath.Join(inst.Dir(), "mods"))
 	if err != nil {
-		lastQMError = err.Error()
-		log.Printf("[QMServer] Failed to fetch servers: %v", err)
-		return []ServerInfo{}
+		return nil
 	}
+	lock, _ := launcher.LoadModLock(inst.Dir())
+	installs := launcher.LoadRemoteInstalls(inst.Dir())
 
-	if serversResponse.Error != "" {
-		lastQMError = serversResponse.Error
-		log.Printf("[QMServer] API error: %s", serversResponse.Error)
-		return []ServerInfo{}
+	best := 0
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || validateModsBasename(name) != nil {
+			continue
+		}
+		active := resourceStripDisabledSuffix(name)
+		keys := []string{strings.TrimSuffix(active, ".jar")}
+		if idx := lock.Find("mods", active); idx >= 0 {
+			keys = append(keys, lock.Entries[idx].Slug, lock.Entries[idx].Title, lock.Entries[idx].ProjectID)
+		}
+		if rec, ok := installs["mods/"+active]; ok {
+			keys = append(keys, rec.Slug, rec.Title)
+		}
+		score := 0
+		for _, k := range keys {
+			k = normalizeModQuery(k)
+			switch {
+			case k == "":
+			case k == q:
+				score = max(score, 3)
+			case strings.HasPrefix(k, q):
+				score = max(score, 2)
+			case strings.Contains(k, q):
+				score = max(score, 1)
+			}
+		}
+		if score == 0 || score < best {
+			continue
+		}
+		if score > best {
+			best, out = score, nil
+		}
+		out = append(out, name)
 	}
+	sort.Strings(out)
+	return out
+}
 
-	// Convert QMServerInfo to ServerInfo
-	servers := make([]ServerInfo, 0, len(serversResponse.ServerProfiles))
-	for _, server := range serversResponse.ServerProfiles {
-		enabled := network.QMServerProfileEnabled(server)
-		gameUp := true
-		if server.GameServerOnline != nil {
-			gameUp = *server.GameServerOnline
-		}
-		pl := 0
-		if server.Players != nil {
-			pl = *server.Players
-		}
-		maxPlayers := 0
-		if server.MaxPlayers != nil {
-			maxPlayers = *server.MaxPlayers
-		}
-		servers = append(servers, ServerInfo{
-			ID:               strconv.Itoa(int(server.ID)),
-			Name:             server.Name,
-			Address:          server.Host,
-			ServerID:         server.ID,
-			Port:             server.Port,
-			Online:           enabled && gameUp,
-			Enabled:          enabled,
-			GameServerOnline: server.GameServerOnline,
-			Players:          pl,
-			MaxPlayers:       maxPlayers,
-			Version:          server.Version,
-			ModLoader:        server.ModLoader,
-			ModLoaderVersion: server.ModLoaderVersion,
-			IsPremium:        server.IsPremium,
-		})
+// modConfigSuffixes are the config file stems Forge and NeoForge derive from a mod id (<modid>-client.toml, …).
+var modConfigSuffixes = []string{"", "-client", "-common", "-server"}
+
+// planModRemoval fills configs and now-unneeded dependencies for the mod file in plan.Mod. Only configs
+// named after the JAR's mod id count as the mod's (config/<modid>.json, config/<modid>-client.toml,
+// config/<modid>/), and none when another JAR in mods/ has the same mod id.
+func planModRemoval(inst *launcher.Instance, plan *ModRemovePlan) {
+	active := resourceStripDisabledSuffix(plan.Mod)
+	lock, _ := launcher.LoadModLock(inst.Dir())
+
+	var modID string
+	if md, err := meta.ReadModMetadata(filepath.Join(inst.Dir(), "mods", plan.Mod)); err == nil {
+		modID = strings.ToLower(strings.TrimSpace(md.ModID))
+	}
+	for _, m := range readInstanceModsMetadata(inst) {
+		if m.File != plan.Mod && strings.EqualFold(m.ModID, modID) {
+			modID = ""
+			break
+		}
+	}
+	if cfg, err := os.ReadDir(filepath.Join(inst.Dir(), "config")); err == nil && modID != "" && modID != "minecraft" {
+		for _, e := range cfg {
+			name := strings.ToLower(e.Name())
+			if !e.IsDir() {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			for _, suffix := range modConfigSuffixes {
+				if name == modID+suffix && (suffix == "" || !e.IsDir()) {
+					plan.Configs = append(plan.Configs, filepath.ToSlash(filepath.Join("config", e.Name())))
+					break
+				}
+			}
+		}
 	}
 
-	return servers
+	var removed *launcher.ModLockEntry
+	if idx := lock.Find("mods", active); idx >= 0 {
+		removed = &lock.Entries[idx]
+	}
+	if removed == nil {
+		return
+	}
+	for _, dep := range removed.Dependencies {
+		var depEntry *launcher.ModLockEntry
+		stillNeeded := false
+		for i := range lock.Entries {
+			e := &lock.Entries[i]
+			if e.Category != "mods" || e == removed {
+				continue
+			}
+			if e.ProjectID == dep {
+				depEntry = e
+			}
+			if slices.Contains(e.Dependencies, dep) {
+				stillNeeded = true
+			}
+		}
+		if depEntry == nil || stillNeeded {
+			continue
+		}
+		name := depEntry.Filename
+		if depEntry.Disabled {
+			name += ".disabled"
+		}
+		plan.Dependencies = append(plan.Dependencies, name)
+	}
 }
 
-// LaunchInstance launches an instance with optional server connection - exact copy of TUI launchInstance
-// syncConfigFromServer: when true and serverID > 0, sync config/ and options.txt from QMServer Cloud (overwrite local)
-func (a *App) LaunchInstance(instanceName string, serverAddress string, serverID uint, syncConfigFromServer bool) string {
-	return a.LaunchInstanceWithAccount(instanceName, serverAddress, serverID, syncConfigFromServer, "", "", "", "")
+// PlanInstanceModRemoval resolves a mod by fuzzy name/slug and lists what removing it would delete.
+func (a *App) PlanInstanceModRemoval(instanceName, query string) ModRemovePlan {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModRemovePlan{Error: err.Error()}
+	}
+	plan := ModRemovePlan{Matches: matchInstanceMods(&inst, query), Configs: []string{}, Dependencies: []string{}}
+	switch len(plan.Matches) {
+	case 0:
+		plan.Error = fmt.Sprintf("mod not found: %s", strings.TrimSpace(query))
+	case 1:
+		plan.Mod = plan.Matches[0]
+		planModRemoval(&inst, &plan)
+	default:
+		plan.Error = fmt.Sprintf("%q matches several mods", strings.TrimSpace(query))
+	}
+	return plan
 }
 
-// LaunchInstanceWithAccount launches an instance with a specific account selected
-// selectedAccountUsername: username of the selected account (can be local, cloud_game, or microsoft)
-// disabledModsJSON: optional JSON array of mod paths to exclude from sync (e.g. ["mods/sodium.jar"])
-// enabledResourcepacksOrderJSON: optional JSON array of resourcepack paths in load order (from QMAdmin load_order)
-// serverName: display name for servers.dat when connecting to server (optional)
-func (a *App) LaunchInstanceWithAccount(instanceName string, serverAddress string, serverID uint, syncConfigFromServer bool, selectedAccountUsername string, disabledModsJSON string, enabledResourcepacksOrderJSON string, serverName string) string {
-	// Fetch instance
-	inst, err := launcher.FetchInstance(instanceName)
+// RemoveInstanceMod deletes the mod matched by query (see PlanInstanceModRemoval), optionally with its
+// config files and dependencies no other mod needs. Ambiguous queries remove nothing.
+func (a *App) RemoveInstanceMod(instanceName, query string, withConfigs, withDependencies bool) ModRemovePlan {
+	plan := a.PlanInstanceModRemoval(instanceName, query)
+	if plan.Error != "" {
+		return plan
+	}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		return fmt.Sprintf("Error: Instance '%s' not found: %v", instanceName, err)
+		plan.Error = err.Error()
+		return plan
+	}
+	if !withConfigs {
+		plan.Configs = []string{}
+	}
+	if !withDependencies {
+		plan.Dependencies = []string{}
 	}
 
-	if err := network.CheckServerProfileConnectAllowed(serverID); err != nil {
-		if errors.Is(err, network.ErrServerProfileDisabled) {
-			return "Error: " + i18n.Translate("ui.server.disabled_error")
+	lock, _ := launcher.LoadModLock(inst.Dir())
+	lockChanged := false
+	for _, name := range append([]string{plan.Mod}, plan.Dependencies...) {
+		if err := os.Remove(filepath.Join(inst.Dir(), "mods", name)); err != nil && !os.IsNotExist(err) {
+			plan.Error = err.Error()
+			return plan
+		}
+		launcher.RemoveRemoteInstall(inst.Dir(), "mods", name)
+		if lock.Remove("mods", name) {
+			lockChanged = true
+		}
+		logMessage(fmt.Sprintf("[Mods] Удалён %s", name))
+	}
+	if lockChanged {
+		if err := launcher.SaveModLock(inst.Dir(), lock); err != nil {
+			logMessage(fmt.Sprintf("[Mods] mods.lock: %v", err))
+		}
+	}
+	for _, rel := range plan.Configs {
+		if err := os.RemoveAll(filepath.Join(inst.Dir(), filepath.FromSlash(rel))); err != nil {
+			logMessage(fmt.Sprintf("[Mods] %s: %v", rel, err))
 		}
 	}
+	plan.Removed = true
+	return plan
+}
 
-	// Call internal launchInstance function with selected account
-	err = a.launchInstance(inst, serverAddress, serverID, syncConfigFromServer, selectedAccountUsername, disabledModsJSON, enabledResourcepacksOrderJSON, serverName)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+func startDefaultBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	case "darwin":
+		cmd = exec.Command("open", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
 	}
-
-	return fmt.Sprintf("Success: Launched %s", instanceName)
+	return cmd.Start()
 }
 
-// launchInstance is exact copy of TUI launchInstance function
-// Note: This function needs access to App context for events, so it's now a method
-// syncConfigFromServer: when true and serverID > 0, sync only config/ and options.txt from QMServer (overwrite)
-// selectedAccountUsername: if not empty, use this specific account instead of default
-// disabledModsJSON: JSON array of mod paths to exclude from sync and remove from local instance
-// enabledResourcepacksOrderJSON: optional JSON array of resourcepack paths in load order for options.txt
-func (a *App) launchInstance(inst launcher.Instance, serverAddress string, serverID uint, syncConfigFromServer bool, selectedAccountUsername string, disabledModsJSON string, enabledResourcepacksOrderJSON string, serverName string) error {
-	logMessage(fmt.Sprintf("=== Запуск инстанса: %s (serverID: %d) ===", inst.Name, serverID))
-	if serverAddress != "" {
-		logMessage(fmt.Sprintf("Автоподключение к серверу: %s", serverAddress))
+// OpenBrowserURL opens an http(s) URL in the system default browser.
+func (a *App) OpenBrowserURL(target string) string {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "Error: empty URL"
 	}
-
-	// Initialize logging
-	logMessage("Инициализация логирования")
-	if err := initLogging(inst.Name); err != nil {
-		logMessage(fmt.Sprintf("Не удалось инициализировать логирование: %v", err))
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "Error: invalid URL"
 	}
+	if err := startDefaultBrowser(target); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return ""
+}
 
-	logMessage(fmt.Sprintf("Запуск инстанса: %s", inst.Name))
-
-	// Require specific account selection - no default accounts allowed when connecting to server
-	if serverAddress != "" && selectedAccountUsername == "" {
-		return fmt.Errorf("необходимо выбрать игровой аккаунт для подключения к серверу")
+// ResolveInstanceResourceStoreLinks resolves CurseForge and Modrinth links for a resource row (best effort).
+// category: mods | resourcepacks | shaderpacks | datapacks — for modpacks returns empty URLs.
+func (a *App) ResolveInstanceResourceStoreLinks(instanceName, category, storagePath string) ResourceStoreLinks {
+	var out ResourceStoreLinks
+	category = strings.ToLower(strings.TrimSpace(category))
+	instanceName = strings.TrimSpace(instanceName)
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return out
+	}
+	absPath, err := instanceResourceAbsPath(&inst, category, storagePath)
+	if err != nil {
+		return out
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return out
 	}
 
-	// Resolve session: if selectedAccountUsername is provided, use it; otherwise use default priority
-	var session auth.Session
-	var cloudSkinURL, cloudCapeURL string
+	caches := inst.CachesDir()
+	loader := string(inst.Loader)
+	gameVer := inst.GameVersion
 
-	if selectedAccountUsername != "" {
-		// User selected a specific account
-		logMessage(fmt.Sprintf("Выбран конкретный аккаунт: %s", selectedAccountUsername))
+	base := filepath.Base(filepath.ToSlash(strings.TrimSpace(storagePath)))
+	base = resourceStripDisabledSuffix(base)
 
-		// Check if it's a Microsoft account
-		if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username == selectedAccountUsername {
-			if sess, err := auth.Authenticate(); err == nil {
-				session = sess
-				logMessage(fmt.Sprintf("Использование выбранного аккаунта Microsoft: %s", session.Username))
+	// An exact hash match on Modrinth beats every filename heuristic below.
+	var hashMatch *meta.ModrinthHashMatch
+	if _, mrOn := instanceCatalogFlags(&inst); mrOn && category != "modpacks" {
+		if sum, err := meta.FileSHA1(absPath); err == nil {
+			if found, err := meta.IdentifyModrinthHashes([]string{sum}, caches); err == nil {
+				if m, ok := found[sum]; ok {
+					hashMatch = &m
+				}
 			}
 		}
+	}
 
-		// Check if it's a Cloud game account
-		if session.Username == "" {
-			if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
-				gas, _ := a.GetCloudGameAccounts()
-				for _, ga := range gas {
-					if ga.Username == selectedAccountUsername {
-						// Use ServerUUID for --uuid so Minecraft server sees the correct UUID (inventory sync)
-						launchUUID := ga.ServerUUID
-						if launchUUID == "" {
-							launchUUID = ga.UUID
-						}
-						session = auth.Session{
-							Username:    ga.Username,
-							AccessToken: "",
-							UUID:        launchUUID,
-						}
-						cloudSkinURL = ga.SkinURL
-						cloudCapeURL = ga.CapeURL
-						logMessage(fmt.Sprintf("Использование выбранного аккаунта QMServer Cloud: %s", session.Username))
-						break
-					}
-				}
+	switch category {
+	case "modpacks":
+		return out
+	case "mods":
+		if !strings.HasSuffix(strings.ToLower(base), ".jar") {
+			return out
+		}
+		mi := meta.ExtractModInfoFromJar(absPath)
+		if hashMatch != nil {
+			mi.Slug = hashMatch.Slug
+		}
+		mi = meta.GetModLinks(mi, caches, loader, gameVer)
+		out.CurseforgeURL = mi.CurseForgeURL
+		out.ModrinthURL = mi.ModrinthURL
+		if out.ModrinthURL == "" {
+			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(mi.Slug)
+		}
+	case "resourcepacks":
+		rp := meta.ExtractResourcePackInfo(base)
+		rp = meta.GetResourcePackLinks(rp, caches, gameVer)
+		out.CurseforgeURL = meta.SearchResourcePackOnCurseForge(rp.Slug, caches, gameVer)
+		out.ModrinthURL = rp.ModrinthURL
+		if out.ModrinthURL == "" {
+			if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "resourcepack", caches); err == nil {
+				out.ModrinthURL = "https://modrinth.com/resourcepack/" + slug
+			} else {
+				out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
 			}
 		}
-
-		// Check if it's a local account
-		if session.Username == "" {
-			for _, acc := range auth.LocalStore.Accounts {
-				if acc.Name == selectedAccountUsername {
-					session = auth.Session{
-						Username:    acc.Name,
-						AccessToken: "",
-						UUID:        acc.UUID,
-					}
-					logMessage(fmt.Sprintf("Использование выбранного локального аккаунта: %s", session.Username))
-					break
+	case "shaderpacks":
+		rp := meta.ExtractResourcePackInfo(base)
+		out.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(rp.Slug)
+		if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "shader", caches); err == nil {
+			out.ModrinthURL = "https://modrinth.com/shader/" + slug
+		} else {
+			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
+		}
+	case "datapacks":
+		rp := meta.ExtractResourcePackInfo(base)
+		out.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(rp.Slug)
+		if slug, err := meta.SearchModrinthProjectByType(rp.Slug, "datapack", caches); err == nil {
+			out.ModrinthURL = "https://modrinth.com/datapack/" + slug
+		} else {
+			out.ModrinthURL = "https://modrinth.com/search?q=" + url.QueryEscape(rp.Slug)
+		}
+	}
+	if hashMatch != nil {
+		out.ModrinthURL = hashMatch.PageURL
+	}
+	cfOn, mrOn := instanceCatalogFlags(&inst)
+	if cfOn && meta.CurseForgeAPIKey() != "" {
+		// The whole directory is looked up in one batch, so the other rows are answered from the cache
+		dir := filepath.Dir(absPath)
+		var files []string
+		if entries, err := os.ReadDir(dir); err == nil {
+			for _, e := range entries {
+				if e.Type().IsRegular() {
+					files = append(files, e.Name())
 				}
 			}
 		}
+		if m, ok := curseForgeFileMatches(dir, files, caches, false)[filepath.Base(absPath)]; ok && m.PageURL != "" {
+			out.CurseforgeURL = m.PageURL
+		}
+	}
+	if !cfOn {
+		out.CurseforgeURL = ""
+	}
+	if !mrOn {
+		out.ModrinthURL = ""
+	}
+	return out
+}
 
-		if session.Username == "" {
-			return fmt.Errorf("выбранный аккаунт '%s' не найден", selectedAccountUsername)
+// curseForgeFileMatches identifies files of dir on CurseForge by fingerprint in one batch, keyed by file
+// name. offline uses only cached results.
+func curseForgeFileMatches(dir string, files []string, cachesDir string, offline bool) map[string]meta.CurseForgeFileMatch {
+	byFP := map[uint32][]string{}
+	var fps []uint32
+	for _, f := range files {
+		fp, err := meta.CurseForgeFingerprint(filepath.Join(dir, f))
+		if err != nil {
+			continue
+		}
+		if _, ok := byFP[fp]; !ok {
+			fps = append(fps, fp)
 		}
+		byFP[fp] = append(byFP[fp], f)
+	}
+	var found map[uint32]meta.CurseForgeFileMatch
+	if offline {
+		found = meta.CachedCurseForgeFingerprints(fps, cachesDir)
 	} else {
-		// No specific account selected, use default priority: Microsoft > Cloud > Local
+		var err error
+		if found, err = meta.IdentifyCurseForgeFingerprints(fps, cachesDir); err != nil && !errors.Is(err, meta.ErrCurseForgeNoKey) {
+			logMessage(fmt.Sprintf("[CurseForge] fingerprints: %v", err))
+		}
+	}
+	out := map[string]meta.CurseForgeFileMatch{}
+	for fp, m := range found {
+		for _, f := range byFP[fp] {
+			out[f] = m
+		}
+	}
+	return out
+}
 
-		// 1. Microsoft account (if exists and active)
-		if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username != "" {
-			if sess, err := auth.Authenticate(); err == nil {
-				session = sess
-				logMessage(fmt.Sprintf("Использование аккаунта Microsoft: %s", session.Username))
+const (
+	// modLinkWorkers bounds concurrent per-mod link searches; modLinkInterval spaces their start
+	// to stay well under the Modrinth (300 req/min) and CurseForge rate limits.
+	modLinkWorkers  = 4
+	modLinkInterval = 250 * time.Millisecond
+)
+
+// ModLinksReport is returned by ResolveInstanceModLinks.
+type ModLinksReport struct {
+	Links   map[string]ResourceStoreLinks `json:"links"` // keyed by file name as on disk
+	Total   int                           `json:"total"`
+	Offline bool                          `json:"offline"`
+	Error   string                        `json:"error"`
+}
+
+// ResolveInstanceModLinks resolves store links of every mod JAR at once. Modrinth hashes and CurseForge
+// fingerprints are looked up in one batch each; the remaining mods are searched by name with a bounded,
+// rate-limited worker pool. Progress is emitted as "mods-links-progress" {instance, done, total}.
+// offline uses only cached results and makes no requests.
+func (a *App) ResolveInstanceModLinks(instanceName string, offline bool) ModLinksReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModLinksReport{Error: err.Error()}
+	}
+	report := ModLinksReport{Links: map[string]ResourceStoreLinks{}, Offline: offline}
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	entries, err := os.ReadDir(modsDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Error = err.Error()
+		}
+		return report
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(strings.ToLower(resourceStripDisabledSuffix(e.Name())), ".jar") {
+			files = append(files, e.Name())
+		}
+	}
+	report.Total = len(files)
+	if len(files) == 0 {
+		return report
+	}
+	cfOn, mrOn := instanceCatalogFlags(&inst)
+	caches := inst.CachesDir()
+	loader, gameVer := string(inst.Loader), inst.GameVersion
+
+	hashes := make([]string, len(files))
+	for i, f := range files {
+		hashes[i], _ = meta.FileSHA1(filepath.Join(modsDir, f))
+	}
+	var mrMatches map[string]meta.ModrinthHashMatch
+	if mrOn {
+		if offline {
+			mrMatches = meta.CachedModrinthHashes(hashes, caches)
+		} else if mrMatches, err = meta.IdentifyModrinthHashes(hashes, caches); err != nil {
+			logMessage(fmt.Sprintf("[Modrinth] version_files: %v", err))
+		}
+	}
+	cfURLs := map[string]string{} // file -> project page
+	if cfOn {
+		for f, m := range curseForgeFileMatches(modsDir, files, caches, offline) {
+			if m.PageURL != "" {
+				cfURLs[f] = m.PageURL
 			}
 		}
+	}
 
-		// 2. QMServer Cloud account (if no Microsoft session)
-		if session.Username == "" {
-			if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
-				gas, _ := a.GetCloudGameAccounts()
-				if len(gas) > 0 {
-					ga := gas[0]
-					// Use ServerUUID for --uuid so Minecraft server sees the correct UUID (inventory sync)
-					launchUUID := ga.ServerUUID
-					if launchUUID == "" {
-						launchUUID = ga.UUID
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done int
+	)
+	jobs := make(chan int)
+	tick := time.NewTicker(modLinkInterval)
+	defer tick.Stop()
+	progress := func() {
+		done++
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "mods-links-progress", map[string]interface{}{
+				"instance": inst.Name,
+				"done":     done,
+				"total":    len(files),
+			})
+		}
+	}
+	for w := 0; w < modLinkWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f := files[i]
+				var links ResourceStoreLinks
+				mi := meta.ExtractModInfoFromJar(filepath.Join(modsDir, f))
+				m, hashed := mrMatches[hashes[i]]
+				cfURL, fingerprinted := cfURLs[f]
+				switch {
+				case hashed && (fingerprinted || !cfOn):
+					links = ResourceStoreLinks{ModrinthURL: m.PageURL, CurseforgeURL: cfURL}
+				case offline:
+					links.CurseforgeURL = meta.CurseForgeMinecraftSearchURL(mi.Slug)
+					if id := meta.CachedModrinthModID(mi.Slug, caches, loader, gameVer); id != "" {
+						links.ModrinthURL = "https://modrinth.com/mod/" + id
 					}
-					session = auth.Session{
-						Username:    ga.Username,
-						AccessToken: "",
-						UUID:        launchUUID,
+				default:
+					<-tick.C
+					if hashed {
+						mi.Slug = m.Slug
 					}
-					cloudSkinURL = ga.SkinURL
-					cloudCapeURL = ga.CapeURL
-					logMessage(fmt.Sprintf("Использование аккаунта QMServer Cloud: %s", session.Username))
+					mi = meta.GetModLinks(mi, caches, loader, gameVer)
+					links = ResourceStoreLinks{CurseforgeURL: mi.CurseForgeURL, ModrinthURL: mi.ModrinthURL}
 				}
-			}
-		}
-
-		// 3. Local account (if no Microsoft/Cloud)
-		if session.Username == "" {
-			defaultAccountName := auth.LocalStore.GetDefaultAccount()
-			if defaultAccountName == "" && len(auth.LocalStore.Accounts) > 0 {
-				defaultAccountName = auth.LocalStore.Accounts[0].Name
-				auth.LocalStore.SetDefaultAccount(defaultAccountName)
-			}
-			if defaultAccountName == "" {
-				return fmt.Errorf("нет доступных аккаунтов, создайте аккаунт в разделе 'Аккаунты'")
-			}
-			var account *auth.LocalAccount
-			for _, acc := range auth.LocalStore.Accounts {
-				if acc.Name == defaultAccountName {
-					account = &acc
-					break
+				if hashed {
+					links.ModrinthURL = m.PageURL
 				}
+				if fingerprinted && cfURL != "" {
+					links.CurseforgeURL = cfURL
+				}
+				if !cfOn {
+					links.CurseforgeURL = ""
+				}
+				if !mrOn {
+					links.ModrinthURL = ""
+				}
+				mu.Lock()
+				report.Links[f] = links
+				progress()
+				mu.Unlock()
 			}
-			if account == nil {
-				// DefaultAccount may point to removed/synced account — clear invalid default
-				auth.LocalStore.ClearDefaultIfInvalid()
-				return fmt.Errorf("аккаунт '%s' не найден. Выберите аккаунт в разделе 'Аккаунты'", defaultAccountName)
-			}
-			session = auth.Session{
-				Username:    account.Name,
-				AccessToken: "",
-				UUID:        account.UUID,
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return report
+}
+
+// SearchRemoteStore searches CurseForge or Modrinth (or both interleaved) for installable content.
+// source: curseforge | modrinth | both
+// curseSort / modrinthSort: popularity | downloads
+func (a *App) SearchRemoteStore(instanceName, category, source, query, curseSort, modrinthSort string, page int) RemoteStoreSearchResponse {
+	instanceName = strings.TrimSpace(instanceName)
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return RemoteStoreSearchResponse{Error: err.Error()}
+	}
+	caches := inst.CachesDir()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		query = "minecraft"
+	}
+	source = strings.ToLower(strings.TrimSpace(source))
+	if page < 0 {
+		page = 0
+	}
+	const pageSize = 20
+	cfOn, mrOn := instanceCatalogFlags(&inst)
+	switch source {
+	case "curseforge":
+		if !cfOn {
+			return RemoteStoreSearchResponse{Error: "Каталог CurseForge отключён в настройках лаунчера"}
+		}
+		hits, err := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
+		if err != nil {
+			return RemoteStoreSearchResponse{Error: err.Error()}
+		}
+		return RemoteStoreSearchResponse{Hits: hits}
+	case "modrinth":
+		if !mrOn {
+			return RemoteStoreSearchResponse{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+		}
+		hits, err := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
+		if err != nil {
+			return RemoteStoreSearchResponse{Error: err.Error()}
+		}
+		return RemoteStoreSearchResponse{Hits: hits}
+	case "both":
+		if !cfOn && !mrOn {
+			return RemoteStoreSearchResponse{Error: "Каталоги CurseForge и Modrinth отключены в настройках лаунчера"}
+		}
+		if !cfOn {
+			mr, errMr := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
+			if errMr != nil {
+				return RemoteStoreSearchResponse{Error: errMr.Error()}
 			}
-			logMessage(fmt.Sprintf("Использование локального аккаунта: %s", session.Username))
+			return RemoteStoreSearchResponse{Hits: mr}
+		}
+		if !mrOn {
+			cf, errCf := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
+			if errCf != nil {
+				return RemoteStoreSearchResponse{Error: errCf.Error()}
+			}
+			return RemoteStoreSearchResponse{Hits: cf}
+		}
+		cf, errCf := meta.SearchCurseForgeStore(category, query, curseSort, page, pageSize, caches)
+		mr, errMr := meta.SearchModrinthStore(category, query, modrinthSort, page, pageSize, caches)
+		if errMr != nil {
+			return RemoteStoreSearchResponse{Error: errMr.Error()}
+		}
+		var cfHits []meta.RemoteStoreHit
+		if errCf == nil {
+			cfHits = cf
 		}
+		return RemoteStoreSearchResponse{Hits: meta.MergeRemoteStoreHits(cfHits, mr)}
+	default:
+		return RemoteStoreSearchResponse{Error: "неизвестный источник каталога"}
 	}
+}
 
-	// Prepare launch options - use full instance config like CLI does
-	options := launcher.LaunchOptions{
-		Session:        session,
-		InstanceConfig: inst.Config, // Use full instance config like CLI
-		// Используем javaw на Windows (NoJavaWindow=true), чтобы не открывалось отдельное консольное окно
-		NoJavaWindow:       true,
-		Demo:               false, // Default values like CLI
-		DisableMultiplayer: false,
-		DisableChat:        false,
-		SkinURL:            cloudSkinURL,
-		CapeURL:            cloudCapeURL,
+func remoteStoreDestDir(inst *launcher.Instance, category string) (string, error) {
+	instanceDir := inst.Dir()
+	switch strings.ToLower(strings.TrimSpace(category)) {
+	case "mods":
+		return filepath.Join(instanceDir, "mods"), nil
+	case "resourcepacks":
+		return filepath.Join(instanceDir, "resourcepacks"), nil
+	case "shaderpacks":
+		return filepath.Join(instanceDir, "shaderpacks"), nil
+	case "datapacks":
+		return filepath.Join(instanceDir, "datapacks"), nil
+	case "modpacks":
+		return instanceDir, nil
+	default:
+		return "", fmt.Errorf("unknown category")
 	}
+}
 
-	// Set server for auto-connect if specified
-	if serverAddress != "" {
-		options.QuickPlayServer = serverAddress
-		logMessage(fmt.Sprintf("Автоматическое подключение к серверу: %s", serverAddress))
+// DownloadRemoteStoreProject downloads a file from Modrinth or CurseForge.
+// title is optional display name from the catalog for stored metadata.
+// iconURL is optional HTTPS thumbnail URL from the catalog (shown in instance resource lists).
+func (a *App) DownloadRemoteStoreProject(instanceName, category, storeSource, projectID, slug, title, iconURL string) string {
+	instanceName = strings.TrimSpace(instanceName)
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	destDir, err := remoteStoreDestDir(&inst, category)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	storeSource = strings.ToLower(strings.TrimSpace(storeSource))
+	cfOn, mrOn := instanceCatalogFlags(&inst)
+	catKey := strings.ToLower(strings.TrimSpace(category))
+	var savedPath string
+	var cfFile meta.CurseForgeFile
+	switch storeSource {
+	case "modrinth":
+		if !mrOn {
+			return "Error: Modrinth catalog is disabled in launcher settings"
+		}
+		project := meta.ModrinthProjectInfo{
+			ID:      strings.TrimSpace(projectID),
+			Slug:    strings.TrimSpace(slug),
+			Title:   strings.TrimSpace(title),
+			IconURL: strings.TrimSpace(iconURL),
+		}
+		if _, err := installModrinthProject(inst, project, catKey); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		return ""
+	case "curseforge":
+		if !cfOn {
+			return "Error: CurseForge catalog is disabled in launcher settings"
+		}
+		savedPath, cfFile, err = meta.DownloadCurseForgeProjectTo(projectID, inst.GameVersion, string(inst.Loader), catKey, meta.CurseForgeAPIKey(), destDir)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+	default:
+		return "Error: unknown store"
+	}
+	baseName := filepath.Base(savedPath)
+	launcher.RecordRemoteInstall(inst.Dir(), catKey, baseName, launcher.RemoteInstallMeta{
+		Category:  catKey,
+		Source:    storeSource,
+		ProjectID: strings.TrimSpace(projectID),
+		Slug:      strings.TrimSpace(slug),
+		Title:     strings.TrimSpace(title),
+		IconURL:   strings.TrimSpace(iconURL),
+	})
+	if catKey != "modpacks" {
+		sum, _ := meta.FileSHA1(savedPath)
+		entry := launcher.ModLockEntry{
+			Category:  catKey,
+			Provider:  storeSource,
+			ProjectID: strings.TrimSpace(projectID),
+			Slug:      strings.TrimSpace(slug),
+			Title:     strings.TrimSpace(title),
+			Filename:  baseName,
+			Sha1:      sum,
+		}
+		if cfFile.ID > 0 {
+			// The file id lets RestoreFromModLock ask CurseForge for a fresh download URL
+			entry.VersionID = strconv.FormatInt(cfFile.ID, 10)
+			entry.VersionNumber = cfFile.DisplayName
+			entry.URL = cfFile.DownloadURL
+		}
+		if err := launcher.RecordModLockEntry(inst, entry); err != nil {
+			logMessage(fmt.Sprintf("[Mods] Не удалось обновить %s: %v", launcher.ModLockFileName, err))
+		}
 	}
+	return ""
+}
 
-	logMessage(fmt.Sprintf("Подготовка опций запуска для пользователя: %s", session.Username))
+// ModLockInstallReport is returned by InstallInstanceFromLock.
+type ModLockInstallReport struct {
+	Results   []launcher.ModLockRestore `json:"results"`
+	Installed int                       `json:"installed"`
+	Failed    int                       `json:"failed"`
+	Error     string                    `json:"error"`
+}
 
-	// Sync config and options.txt from QMServer when user requested (checkbox in account picker)
-	// Sync only to the selected account's directory (per-account isolation)
-	if syncConfigFromServer && serverID > 0 {
-		logMessage(fmt.Sprintf("Запрошена синхронизация конфигурации с сервера (serverID=%d) для аккаунта %s", serverID, session.Username))
-		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-			"type":    "sync-start",
-			"message": "Синхронизация конфигурации с QMServer Cloud...",
-		})
-		if err := syncConfigFromQMServer(inst, serverID, session.UUID); err != nil {
-			logMessage(fmt.Sprintf("Ошибка синхронизации конфигурации: %v", err))
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "sync-error",
-				"message": fmt.Sprintf("Ошибка синхронизации конфигурации: %v", err),
-			})
-		} else {
-			logMessage("Синхронизация конфигурации с QMServer Cloud завершена")
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "sync-complete",
-				"message": "Синхронизация конфигурации завершена",
-			})
+// InstallInstanceFromLock reproduces the managed content recorded in mods.lock (missing or changed files
+// are downloaded again and hash-checked).
+func (a *App) InstallInstanceFromLock(instanceName string) ModLockInstallReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModLockInstallReport{Error: err.Error()}
+	}
+	if lock, err := launcher.LoadModLock(inst.Dir()); err == nil && lock.GameVersion != "" &&
+		(lock.GameVersion != inst.GameVersion || !strings.EqualFold(lock.Loader, string(inst.Loader))) {
+		logMessage(fmt.Sprintf("[Mods] %s записан для %s %s, экземпляр: %s %s", launcher.ModLockFileName, lock.Loader, lock.GameVersion, inst.Loader, inst.GameVersion))
+	}
+	results, err := launcher.RestoreFromModLock(inst)
+	if err != nil {
+		return ModLockInstallReport{Error: err.Error()}
+	}
+	report := ModLockInstallReport{Results: results}
+	for _, r := range results {
+		switch r.Status {
+		case "installed":
+			report.Installed++
+		case "failed":
+			report.Failed++
+			logMessage(fmt.Sprintf("[Mods] %s/%s: %s", r.Category, r.Filename, r.Error))
 		}
 	}
+	return report
+}
 
-	// Sync files with QMServer Cloud if this instance uses it (full manifest sync, e.g. mods)
-	logMessage(fmt.Sprintf("Проверка условий синхронизации: IsUsingQMServerCloud=%v, QMServerHost='%s', serverID=%d",
-		inst.Config.IsUsingQMServerCloud, inst.Config.QMServerHost, serverID))
-	logMessage(fmt.Sprintf("Инстанс %s: IsUsingQMServerCloud=%v", inst.Name, inst.Config.IsUsingQMServerCloud))
-	if inst.Config.IsUsingQMServerCloud && inst.Config.QMServerHost != "" && serverID > 0 {
-		logMessage(fmt.Sprintf("Обнаружена QMServer Cloud конфигурация для инстанса %s", inst.Name))
-		logMessage(fmt.Sprintf("QMServer: %s:%d, ServerID: %d", inst.Config.QMServerHost, inst.Config.QMServerPort, serverID))
+// installModrinthProject downloads the newest compatible file of a Modrinth project into the instance,
+// verifies its hash and records it in remote-installs.json and mods.lock. A previously locked file
+// of the same project is replaced.
+func installModrinthProject(inst launcher.Instance, project meta.ModrinthProjectInfo, category string) (meta.ModrinthFile, error) {
+	ref := project.Slug
+	if ref == "" {
+		ref = project.ID
+	}
+	f, err := meta.ResolveModrinthFile(ref, inst.GameVersion, string(inst.Loader), category)
+	if err != nil {
+		return meta.ModrinthFile{}, err
+	}
+	return installModrinthFile(inst, project, category, f)
+}
 
-		// Upload local skins/capes to QMServer before sync (for distribution to other users)
-		if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
-			_ = network.UploadLocalSkinsToQMServer(inst.Dir(), inst.Config.QMServerHost, inst.Config.QMServerPort, cloudAcc.Token, logMessage)
+// installModrinthFile installs an already resolved file of a project (see installModrinthProject).
+func installModrinthFile(inst launcher.Instance, project meta.ModrinthProjectInfo, category string, f meta.ModrinthFile) (meta.ModrinthFile, error) {
+	destDir, err := remoteStoreDestDir(&inst, category)
+	if err != nil {
+		return meta.ModrinthFile{}, err
+	}
+	savedPath, err := meta.DownloadModrinthFile(f, destDir)
+	if err != nil {
+		return meta.ModrinthFile{}, err
+	}
+	baseName := filepath.Base(savedPath)
+	if project.ID == "" {
+		project.ID = f.ProjectID
+	}
+
+	if lock, err := launcher.LoadModLock(inst.Dir()); err == nil {
+		if idx := lock.FindProject(category, "modrinth", project.ID); idx >= 0 {
+			old := lock.Entries[idx].Filename
+			if old != "" && !strings.EqualFold(old, baseName) {
+				_ = os.Remove(filepath.Join(destDir, old))
+				_ = os.Remove(filepath.Join(destDir, old+".disabled"))
+				launcher.RemoveRemoteInstall(inst.Dir(), category, old)
+			}
 		}
+	}
+	launcher.RecordRemoteInstall(inst.Dir(), category, baseName, launcher.RemoteInstallMeta{
+		Category:  category,
+		Source:    "modrinth",
+		ProjectID: project.ID,
+		Slug:      project.Slug,
+		Title:     project.Title,
+		IconURL:   project.IconURL,
+	})
+	if err := launcher.RecordModLockEntry(inst, launcher.ModLockEntry{
+		Category:      category,
+		Provider:      "modrinth",
+		ProjectID:     project.ID,
+		Slug:          project.Slug,
+		Title:         project.Title,
+		VersionID:     f.VersionID,
+		VersionNumber: f.VersionNumber,
+		Filename:      baseName,
+		URL:           f.URL,
+		Sha1:          f.Sha1,
+		Sha512:        f.Sha512,
+		Dependencies:  f.Dependencies,
+	}); err != nil {
+		logMessage(fmt.Sprintf("[Mods] Не удалось обновить %s: %v", launcher.ModLockFileName, err))
+	}
+	return f, nil
+}
 
-		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-			"type":    "sync-start",
-			"message": "Синхронизация с QMServer Cloud...",
-		})
+// ModInstallResult is returned by InstallModrinthProject.
+type ModInstallResult struct {
+	ProjectID     string `json:"projectId"`
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+	VersionNumber string `json:"versionNumber"`
+	Filename      string `json:"filename"`
+	// Category is the instance folder the file went to (mods, resourcepacks, shaderpacks, datapacks).
+	Category string `json:"category,omitempty"`
+	// MissingAPIs are loader API mods the instance now needs but lacks (see InstallInstanceMissingAPIMods).
+	MissingAPIs []MissingAPIMod `json:"missingApis,omitempty"`
+	Cancelled   bool            `json:"cancelled,omitempty"`
+	Error       string          `json:"error"`
+}
 
-		var disabledMods []string
-		if disabledModsJSON != "" {
-			_ = json.Unmarshal([]byte(disabledModsJSON), &disabledMods)
-		}
-		emitSync := func(phase, msg, file string, pct float64) {
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":        "sync-progress",
-				"phase":       phase,
-				"message":     msg,
-				"currentFile": file,
-				"progress":    pct,
-			})
+// InstallModrinthProject installs a mod by Modrinth slug, project id or free-text query (top search hit)
+// into the instance, picking the newest version for its loader and game version.
+func (a *App) InstallModrinthProject(instanceName, queryOrSlug string) ModInstallResult {
+	return a.InstallModrinthContent(instanceName, queryOrSlug, "mod")
+}
+
+// modrinthTypeCategory maps a project type to the instance category it installs into and the Modrinth
+// project_type expected for it ("" when Modrinth does not use a distinct type, e.g. datapacks).
+func modrinthTypeCategory(projectType string) (category, mrType string, err error) {
+	switch strings.ToLower(strings.TrimSpace(projectType)) {
+	case "", "mod", "mods":
+		return "mods", "mod", nil
+	case "resourcepack", "resourcepacks":
+		return "resourcepacks", "resourcepack", nil
+	case "shader", "shaders", "shaderpacks":
+		return "shaderpacks", "shader", nil
+	case "datapack", "datapacks":
+		return "datapacks", "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported project type %q (mod, resourcepack, shader, datapack)", projectType)
+	}
+}
+
+// InstallModrinthContent installs a Modrinth project of the given type (mod, resourcepack, shader, datapack)
+// into mods/, resourcepacks/, shaderpacks/ or datapacks/, recording it in mods.lock like mods.
+// projectType "auto" picks the folder from the project's type and loaders.
+func (a *App) InstallModrinthContent(instanceName, queryOrSlug, projectType string) ModInstallResult {
+	auto := strings.EqualFold(strings.TrimSpace(projectType), "auto")
+	if auto {
+		projectType = "mod"
+	}
+	category, mrType, err := modrinthTypeCategory(projectType)
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return ModInstallResult{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+	}
+	project, err := meta.ResolveModrinthProject(queryOrSlug, category, inst.CachesDir())
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+	if auto && project.ProjectType != "" {
+		category = meta.ModrinthProjectCategory(project, string(inst.Loader))
+		if category == "modpacks" {
+			return ModInstallResult{ProjectID: project.ID, Slug: project.Slug, Title: project.Title,
+				Error: fmt.Sprintf("%s — это сборка (modpack); её нужно импортировать как новый экземпляр", project.Slug)}
 		}
-		if err := syncQMServerFiles(inst, serverID, disabledMods, emitSync); err != nil {
-			logMessage(fmt.Sprintf("Ошибка синхронизации с QMServer Cloud: %v", err))
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "sync-error",
-				"message": fmt.Sprintf("Ошибка синхронизации: %v", err),
-			})
-		} else {
-			logMessage("Синхронизация с QMServer Cloud завершена успешно")
-			// Обновить servers.dat — добавить/обновить сервер для быстрого доступа в Minecraft
-			if serverAddress != "" {
-				name := serverName
-				if name == "" {
-					name = serverAddress
-				}
-				if err := serversdat.UpdateOrAddServer(inst.Dir(), name, serverAddress); err != nil {
-					logMessage(fmt.Sprintf("Не удалось обновить servers.dat: %v", err))
-				} else {
-					logMessage("servers.dat обновлён")
-				}
-			}
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "sync-complete",
-				"message": "Синхронизация завершена",
+		mrType = ""
+	}
+	if mrType != "" && project.ProjectType != "" && project.ProjectType != mrType {
+		return ModInstallResult{ProjectID: project.ID, Slug: project.Slug, Title: project.Title,
+			Error: fmt.Sprintf("%s — это %s, а не %s", project.Slug, project.ProjectType, mrType)}
+	}
+	f, err := installModrinthProject(inst, project, category)
+	if err != nil {
+		logMessage(fmt.Sprintf("[Mods] Установка %s в %s: %v", project.Slug, inst.Name, err))
+		return ModInstallResult{ProjectID: project.ID, Slug: project.Slug, Title: project.Title, Error: err.Error()}
+	}
+	logMessage(fmt.Sprintf("[Mods] Установлен %s %s (%s) в %s", project.Title, f.VersionNumber, f.Filename, inst.Name))
+	res := ModInstallResult{
+		Category:      category,
+		ProjectID:     project.ID,
+		Slug:          project.Slug,
+		Title:         project.Title,
+		VersionNumber: f.VersionNumber,
+		Filename:      f.Filename,
+	}
+	if category == "mods" {
+		res.MissingAPIs = findMissingAPIMods(&inst)
+		if len(res.MissingAPIs) > 0 && a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "mods-missing-api", map[string]interface{}{
+				"instance": inst.Name,
+				"missing":  res.MissingAPIs,
 			})
 		}
 	}
+	return res
+}
 
-	logMessage("Начало загрузки Minecraft и компонентов")
-
-	runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-		"type":    "prepare-start",
-		"message": "Начало загрузки Minecraft и компонентов",
-	})
+// AddInstanceModFromSource adds content from a direct URL or a local path (an open dialog when source is
+// empty); see AddInstanceContentFromSource. The destination folder is detected from the file.
+func (a *App) AddInstanceModFromSource(instanceName, source string) ModInstallResult {
+	return a.AddInstanceContentFromSource(instanceName, source, "")
+}
 
-	// Prepare launch environment
-	// Create progress watcher for GUI - sends events to frontend
-	watcher := func(event any) {
-		switch e := event.(type) {
-		case launcher.DownloadingEvent:
-			if e.Total > 0 {
-				progress := float64(e.Completed) / float64(e.Total) * 100
-				logMessage(fmt.Sprintf("Загрузка: %d/%d (%.1f%%)", e.Completed, e.Total, progress))
-				// Send progress event to frontend
-				runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-					"type":      "downloading",
-					"completed": e.Completed,
-					"total":     e.Total,
-					"progress":  progress,
-					"message":   fmt.Sprintf("Загрузка: %d/%d (%.1f%%)", e.Completed, e.Total, progress),
-				})
-				if e.Completed >= e.Total {
-					logMessage("Загрузка Minecraft завершена")
-					runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-						"type":    "downloading-complete",
-						"message": "Загрузка завершена",
-					})
-				}
-			}
-		case launcher.AssetsResolvedEvent:
-			logMessage(fmt.Sprintf("Ассеты обработаны: %d", e.Total))
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "assets-resolved",
-				"total":   e.Total,
-				"message": fmt.Sprintf("Ассеты обработаны: %d", e.Total),
-			})
-		case launcher.LibrariesResolvedEvent:
-			logMessage(fmt.Sprintf("Библиотеки обработаны: %d", e.Total))
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "libraries-resolved",
-				"total":   e.Total,
-				"message": fmt.Sprintf("Библиотеки обработаны: %d", e.Total),
-			})
-		case launcher.MetadataResolvedEvent:
-			logMessage("Метаданные Minecraft разрешены")
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "metadata-resolved",
-				"message": "Метаданные Minecraft разрешены",
-			})
-		case launcher.PostProcessingEvent:
-			logMessage("Начата пост-обработка (Forge/Minecraft)")
-			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-				"type":    "post-processing",
-				"message": "Пост-обработка (Forge/Minecraft)",
-			})
+// AddInstanceContentFromSource adds a JAR/ZIP from a direct URL or a local path and records its hash and
+// origin in mods.lock, so side-loaded files take part in conflict checks, restores and exports.
+// category: mods | resourcepacks | shaderpacks | datapacks, or "" to detect it from the archive contents.
+// Modrinth and CurseForge project URLs are installed through the catalog instead.
+func (a *App) AddInstanceContentFromSource(instanceName, source, category string) ModInstallResult {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	source = strings.TrimSpace(source)
+	if source == "" {
+		p, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   "Добавить файл",
+			Filters: []runtime.FileFilter{{DisplayName: "Mod / pack (*.jar, *.zip)", Pattern: "*.jar;*.zip"}},
+		})
+		if err != nil {
+			return ModInstallResult{Error: err.Error()}
 		}
+		if p == "" {
+			return ModInstallResult{Cancelled: true}
+		}
+		source = p
 	}
-	launchEnv, err := launcher.Prepare(inst, options, watcher)
-	if err != nil {
-		logMessage(fmt.Sprintf("Ошибка подготовки инстанса: %v", err))
-		return fmt.Errorf("failed to prepare instance: %w", err)
+	if _, ok := meta.ModrinthSlugFromURL(source); ok {
+		projectType := category
+		if projectType == "" {
+			projectType = "auto"
+		}
+		return a.InstallModrinthContent(instanceName, source, projectType)
+	}
+	if project, ok := curseForgeProjectFromURL(source); ok {
+		item := BulkInstallItem{Ref: source}
+		if err := a.installCurseForgeProject(inst, project, &item); err != nil {
+			return ModInstallResult{Slug: project.slug, Error: err.Error()}
+		}
+		return ModInstallResult{Slug: project.slug, Title: item.Title}
 	}
 
-	logMessage("Подготовка завершена успешно")
+	validName := func(name string) error {
+		lower := strings.ToLower(resourceStripDisabledSuffix(name))
+		if err := validateFlatBasename(name); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(lower, ".jar") && !strings.HasSuffix(lower, ".zip") {
+			return fmt.Errorf("only .jar and .zip files can be added")
+		}
+		return nil
+	}
+	provider, origin := "local", ""
+	var name, src string
+	if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		provider, origin = "url", source
+		name = path.Base(u.Path)
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+		if err := validName(name); err != nil {
+			return ModInstallResult{Error: fmt.Sprintf("%s: %v", name, err)}
+		}
+		src = filepath.Join(inst.TmpDir(), "mod-add", name)
+		if err := network.DownloadFile(network.DownloadEntry{URL: source, Path: src}); err != nil {
+			_ = os.Remove(src)
+			return ModInstallResult{Error: err.Error()}
+		}
+		defer os.Remove(src)
+	} else {
+		src = source
+		name = filepath.Base(source)
+		if err := validName(name); err != nil {
+			return ModInstallResult{Error: fmt.Sprintf("%s: %v", name, err)}
+		}
+	}
+	name = resourceStripDisabledSuffix(name)
+	if category == "" {
+		if category, err = meta.DetectArchiveCategory(src); err != nil {
+			return ModInstallResult{Error: fmt.Sprintf("%s is not a valid JAR/ZIP: %v", name, err)}
+		}
+	}
+	if category == "mods" {
+		if err := validateModsBasename(name); err != nil {
+			return ModInstallResult{Error: fmt.Sprintf("%s: %v", name, err)}
+		}
+	}
+	destDir, err := remoteStoreDestDir(&inst, category)
+	if err != nil || category == "modpacks" {
+		return ModInstallResult{Error: fmt.Sprintf("cannot add files to %q", category)}
+	}
+	var md meta.ModMetadata
+	if category == "mods" {
+		md, err = meta.ReadModMetadata(src)
+		if err != nil && !errors.Is(err, meta.ErrNoModMetadata) {
+			return ModInstallResult{Error: fmt.Sprintf("%s is not a valid mod JAR: %v", name, err)}
+		}
+	}
 
-	// Apply selected resource packs to options.txt so they are enabled automatically in-game
-	var rpOrder []string
-	if enabledResourcepacksOrderJSON != "" {
-		_ = json.Unmarshal([]byte(enabledResourcepacksOrderJSON), &rpOrder)
+	dest := filepath.Join(destDir, name)
+	for _, p := range []string{dest, dest + ".disabled"} {
+		if _, err := os.Stat(p); err == nil {
+			return ModInstallResult{Error: fmt.Sprintf("%s already exists in %s", filepath.Base(p), category)}
+		}
 	}
-	if err := launcher.ApplyResourcePacksToOptions(launchEnv.GameDir, rpOrder); err != nil {
-		logMessage(fmt.Sprintf("[ResourcePacks] Не удалось обновить options.txt: %v", err))
+	sum, err := network.AddToContentStore(src)
+	if err == nil {
+		err = network.LinkFromContentStore(sum, dest)
 	}
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+
+	title := md.Name
+	if title == "" {
+		title = strings.TrimSuffix(strings.TrimSuffix(name, ".jar"), ".zip")
+	}
+	if err := launcher.RecordModLockEntry(inst, launcher.ModLockEntry{
+		Category:      category,
+		Provider:      provider,
+		Slug:          md.ModID,
+		Title:         title,
+		VersionNumber: md.Version,
+		Filename:      name,
+		URL:           origin,
+		Sha1:          sum,
+	}); err != nil {
+		logMessage(fmt.Sprintf("[Mods] Не удалось обновить %s: %v", launcher.ModLockFileName, err))
+	}
+	logMessage(fmt.Sprintf("[Mods] Добавлен %s/%s (%s) в %s", category, name, source, inst.Name))
+	res := ModInstallResult{Slug: md.ModID, Title: title, VersionNumber: md.Version, Filename: name, Category: category}
+	if category == "mods" {
+		for _, issue := range modCompatIssues(&inst, name, md) {
+			logMessage(fmt.Sprintf("[Mods] Предупреждение: %s", issue.Message))
+		}
+		res.MissingAPIs = findMissingAPIMods(&inst)
+	}
+	return res
+}
 
-	runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-		"type":    "prepare-complete",
-		"message": "Подготовка завершена, запуск Minecraft...",
-	})
+// BulkInstallItem is the outcome for one entry of InstallInstanceModsFromFile.
+type BulkInstallItem struct {
+	Ref        string `json:"ref"` // line from the list (slug, URL, project id)
+	Title      string `json:"title"`
+	Filename   string `json:"filename"`
+	Dependency bool   `json:"dependency"` // pulled in as a required dependency
+	Skipped    bool   `json:"skipped"`    // already installed
+	// Incompatible is set when the project has no version for the instance (not counted as failed).
+	Incompatible bool   `json:"incompatible,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
 
-	// Launch the instance in background (don't wait for completion)
-	logMessage("Запуск Minecraft...")
-	err = launcher.Launch(launchEnv, func(cmd *exec.Cmd) error {
-		return cmd.Start() // Start in background, don't wait
-	})
+// BulkInstallReport is returned by InstallInstanceModsFromFile.
+type BulkInstallReport struct {
+	Items     []BulkInstallItem `json:"items"`
+	Installed int               `json:"installed"`
+	Failed    int               `json:"failed"`
+	Cancelled bool              `json:"cancelled"`
+	Error     string            `json:"error"`
+}
 
+// parseModListFile reads a list of mods: one slug/URL per line ("#" starts a comment), a JSON array of
+// strings, or a mod list exported by ExportInstanceModList in JSON.
+func parseModListFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		logMessage(fmt.Sprintf("Ошибка запуска: %v", err))
-		runtime.EventsEmit(a.ctx, "launch-error", map[string]interface{}{
-			"error": fmt.Sprintf("Ошибка запуска: %v", err),
-		})
-		return fmt.Errorf("failed to launch instance: %w", err)
+		return nil, err
 	}
-
-	logMessage("Minecraft запущен успешно")
-	logMessage(fmt.Sprintf("=== Завершение запуска инстанса: %s ===", inst.Name))
-	return nil
+	trimmed := bytes.TrimSpace(data)
+	var refs []string
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		if err := json.Unmarshal(trimmed, &refs); err != nil {
+			return nil, fmt.Errorf("parse mod list: %w", err)
+		}
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var list launcher.ModList
+		if err := json.Unmarshal(trimmed, &list); err != nil {
+			return nil, fmt.Errorf("parse mod list: %w", err)
+		}
+		for _, m := range list.Mods {
+			switch {
+			case m.URL != "":
+				refs = append(refs, m.URL)
+			case m.ModID != "":
+				refs = append(refs, m.ModID)
+			}
+		}
+	default:
+		for _, line := range strings.Split(string(data), "\n") {
+			if i := strings.Index(line, "#"); i >= 0 {
+				line = line[:i]
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				refs = append(refs, line)
+			}
+		}
+	}
+	return refs, nil
 }
 
-// EnsureInstanceForServer creates or gets instance for server - exact copy of TUI logic
-func (a *App) EnsureInstanceForServer(serverName string, serverAddress string, serverVersion string, serverModLoader string, serverModLoaderVersion string, serverID uint) string {
-	if err := network.CheckServerProfileConnectAllowed(serverID); err != nil {
-		if errors.Is(err, network.ErrServerProfileDisabled) {
-			return "Error: " + i18n.Translate("ui.server.disabled_error")
+// installModrinthDependencies installs required dependencies of f that are not yet in mods.lock.
+func installModrinthDependencies(inst launcher.Instance, f meta.ModrinthFile, seen map[string]bool, report *BulkInstallReport) {
+	for _, dep := range f.Dependencies {
+		if seen[dep] {
+			continue
 		}
+		seen[dep] = true
+		if lock, err := launcher.LoadModLock(inst.Dir()); err == nil && lock.FindProject("mods", "modrinth", dep) >= 0 {
+			continue
+		}
+		item := BulkInstallItem{Ref: dep, Dependency: true}
+		project, err := meta.FetchModrinthProject(dep)
+		if err == nil {
+			item.Title = project.Title
+			var df meta.ModrinthFile
+			if df, err = installModrinthProject(inst, project, "mods"); err == nil {
+				item.Filename = df.Filename
+				report.Installed++
+				report.Items = append(report.Items, item)
+				installModrinthDependencies(inst, df, seen, report)
+				continue
+			}
+		}
+		item.Error = err.Error()
+		report.Failed++
+		report.Items = append(report.Items, item)
 	}
+}
 
-	// Parse mod loader info (format: "loader version" or just "loader") - exact copy of TUI
-	var loaderType launcher.Loader
-	var loaderVersion string
+// InstallInstanceModsFromFile installs every mod listed in a file (see parseModListFile) with its required
+// dependencies. Modrinth slugs/ids/URLs and CurseForge project URLs are accepted. When path is empty,
+// an open dialog is shown.
+func (a *App) InstallInstanceModsFromFile(instanceName, path string) BulkInstallReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return BulkInstallReport{Error: err.Error()}
+	}
+	if strings.TrimSpace(path) == "" {
+		p, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   "Список модов",
+			Filters: []runtime.FileFilter{{DisplayName: "Mod list (*.txt, *.json)", Pattern: "*.txt;*.json"}},
+		})
+		if err != nil {
+			return BulkInstallReport{Error: err.Error()}
+		}
+		if p == "" {
+			return BulkInstallReport{Cancelled: true}
+		}
+		path = p
+	}
+	refs, err := parseModListFile(path)
+	if err != nil {
+		return BulkInstallReport{Error: err.Error()}
+	}
+	cfOn, mrOn := instanceCatalogFlags(&inst)
 
-	// Combine loader and version like TUI does (modLoaderInfo format)
-	modLoaderInfo := serverModLoader
-	if serverModLoaderVersion != "" {
-		modLoaderInfo = serverModLoader + " " + serverModLoaderVersion
+	report := BulkInstallReport{Items: []BulkInstallItem{}}
+	seen := map[string]bool{}
+	for i, ref := range refs {
+		runtime.EventsEmit(a.ctx, "mods-bulk-progress", map[string]interface{}{
+			"current": i + 1,
+			"total":   len(refs),
+			"ref":     ref,
+		})
+		item := BulkInstallItem{Ref: ref}
+		if project, ok := curseForgeProjectFromURL(ref); ok {
+			if !cfOn {
+				item.Error = "CurseForge catalog is disabled in launcher settings"
+			} else if err := a.installCurseForgeProject(inst, project, &item); err != nil {
+				item.Error = err.Error()
+			}
+		} else if !mrOn {
+			item.Error = "Каталог Modrinth отключён в настройках лаунчера"
+		} else if project, err := meta.ResolveModrinthProject(ref, "mods", inst.CachesDir()); err != nil {
+			item.Error = err.Error()
+		} else if seen[project.ID] {
+			continue
+		} else {
+			seen[project.ID] = true
+			item.Title = project.Title
+			if lock, err := launcher.LoadModLock(inst.Dir()); err == nil {
+				if idx := lock.FindProject("mods", "modrinth", project.ID); idx >= 0 {
+					item.Filename = lock.Entries[idx].Filename
+					item.Skipped = true
+					report.Items = append(report.Items, item)
+					continue
+				}
+			}
+			f, err := installModrinthProject(inst, project, "mods")
+			if err != nil {
+				item.Error = err.Error()
+			} else {
+				item.Filename = f.Filename
+				report.Installed++
+				report.Items = append(report.Items, item)
+				installModrinthDependencies(inst, f, seen, &report)
+				continue
+			}
+		}
+		if item.Error != "" {
+			report.Failed++
+		} else {
+			report.Installed++
+		}
+		report.Items = append(report.Items, item)
 	}
+	logMessage(fmt.Sprintf("[Mods] Установка из %s в %s: установлено %d, ошибок %d", filepath.Base(path), inst.Name, report.Installed, report.Failed))
+	return report
+}
 
-	parts := strings.Fields(modLoaderInfo)
-	if len(parts) >= 1 {
-		loaderStr := strings.ToLower(parts[0])
-		switch loaderStr {
-		case "fabric":
-			loaderType = launcher.LoaderFabric
-		case "forge":
-			loaderType = launcher.LoaderForge
-		case "neoforge":
-			loaderType = launcher.LoaderNeoForge
-		case "quilt":
-			loaderType = launcher.LoaderQuilt
+// InstallModrinthCollection installs every project of a Modrinth collection (id or URL) that has a version
+// for the instance: mods with their dependencies, resource packs, shaders and datapacks. Modpacks, plugins
+// and incompatible projects are skipped. Progress is emitted as "mods-bulk-progress".
+func (a *App) InstallModrinthCollection(instanceName, collection string) BulkInstallReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return BulkInstallReport{Error: err.Error()}
+	}
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return BulkInstallReport{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+	}
+	col, err := meta.FetchModrinthCollection(collection)
+	if err != nil {
+		return BulkInstallReport{Error: err.Error()}
+	}
+	projects, err := meta.FetchModrinthProjects(col.Projects)
+	if err != nil {
+		return BulkInstallReport{Error: err.Error()}
+	}
+	lock, _ := launcher.LoadModLock(inst.Dir())
+
+	report := BulkInstallReport{Items: []BulkInstallItem{}}
+	seen := map[string]bool{}
+	for i, p := range projects {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "mods-bulk-progress", map[string]interface{}{
+				"current": i + 1,
+				"total":   len(projects),
+				"ref":     p.Slug,
+			})
+		}
+		item := BulkInstallItem{Ref: p.Slug, Title: p.Title}
+		category, _, err := modrinthTypeCategory(p.ProjectType)
+		if err != nil {
+			item.Incompatible = true
+			item.Error = err.Error()
+			report.Items = append(report.Items, item)
+			continue
+		}
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		if idx := lock.FindProject(category, "modrinth", p.ID); idx >= 0 {
+			item.Filename = lock.Entries[idx].Filename
+			item.Skipped = true
+			report.Items = append(report.Items, item)
+			continue
+		}
+		f, err := installModrinthProject(inst, p, category)
+		switch {
+		case errors.Is(err, meta.ErrNoCompatibleVersion):
+			item.Incompatible = true
+			item.Error = err.Error()
+		case err != nil:
+			item.Error = err.Error()
+			report.Failed++
 		default:
-			loaderType = launcher.LoaderVanilla
+			item.Filename = f.Filename
+			report.Installed++
 		}
-
-		if len(parts) >= 2 {
-			loaderVersion = parts[1]
+		report.Items = append(report.Items, item)
+		if err == nil && category == "mods" {
+			installModrinthDependencies(inst, f, seen, &report)
 		}
-	} else {
-		loaderType = launcher.LoaderVanilla
 	}
+	logMessage(fmt.Sprintf("[Mods] Коллекция %s (%s) в %s: установлено %d, ошибок %d", col.Name, col.ID, inst.Name, report.Installed, report.Failed))
+	return report
+}
 
-	// Instance name will be sanitized automatically by CreateInstance
-	instanceName := launcher.SanitizeInstanceName(serverName)
+// curseForgeProject identifies a CurseForge project by slug or, for /projects/<id> links, by id.
+type curseForgeProject struct {
+	slug string
+	id   int64
+}
 
-	// Validate input data
-	if serverVersion == "" {
-		return "Error: Empty game version"
+// curseForgeProjectFromURL parses curseforge.com/minecraft/mc-mods/<slug> and curseforge.com/projects/<id>
+// URLs.
+func curseForgeProjectFromURL(s string) (curseForgeProject, bool) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || !strings.HasSuffix(u.Host, "curseforge.com") {
+		return curseForgeProject{}, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "projects" {
+		id, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || id <= 0 {
+			return curseForgeProject{}, false
+		}
+		return curseForgeProject{id: id}, true
+	}
+	if len(parts) < 3 || parts[0] != "minecraft" || parts[1] != "mc-mods" || parts[2] == "" {
+		return curseForgeProject{}, false
 	}
+	return curseForgeProject{slug: parts[2]}, true
+}
 
-	// Create instance automatically with server parameters
-	// Use default config values like CLI does
-	defaultConfig := launcher.InstanceConfig{
-		WindowResolution: struct {
-			Width  int `toml:"width" json:"width"`
-			Height int `toml:"height" json:"height"`
-		}{
-			Width:  1708,
-			Height: 960,
-		},
-		MinMemory: 4096,
-		MaxMemory: 4096,
-		// QMServer Cloud configuration
-		IsUsingQMServerCloud: true,
-		QMServerHost:         defaultQMServerHost,
-		QMServerPort:         defaultQMServerPort,
+// installCurseForgeProject looks the project up by id or slug and installs it like the catalog does.
+func (a *App) installCurseForgeProject(inst launcher.Instance, project curseForgeProject, item *BulkInstallItem) error {
+	c, err := meta.NewCurseForgeClient()
+	if err != nil {
+		return err
+	}
+	var mods []meta.CurseForgeMod
+	if project.id > 0 {
+		mods, err = c.Mods([]int64{project.id})
+	} else {
+		mods, err = c.SearchMods(project.slug, 0, 20)
+	}
+	if err != nil {
+		return err
+	}
+	for _, m := range mods {
+		if project.id > 0 && m.ID != project.id || project.id == 0 && !strings.EqualFold(m.Slug, project.slug) {
+			continue
+		}
+		item.Title = m.Name
+		if msg := a.DownloadRemoteStoreProject(inst.Name, "mods", "curseforge", strconv.FormatInt(m.ID, 10), m.Slug, m.Name, m.Logo.ThumbnailURL); msg != "" {
+			return errors.New(strings.TrimPrefix(msg, "Error: "))
+		}
+		return nil
 	}
+	if project.id > 0 {
+		return fmt.Errorf("проект %d не найден на CurseForge", project.id)
+	}
+	return fmt.Errorf("проект %s не найден на CurseForge", project.slug)
+}
 
-	// For Forge, the version should be in format "gameVersion-loaderVersion" - exact copy of TUI
-	finalLoaderVersion := loaderVersion
-	if loaderType == launcher.LoaderForge && loaderVersion != "" {
-		finalLoaderVersion = serverVersion + "-" + loaderVersion
-		logMessage(fmt.Sprintf("Формируем полную версию Forge: %s", finalLoaderVersion))
+// ModrinthVersionsResponse is returned by GetModrinthProjectVersions.
+type ModrinthVersionsResponse struct {
+	Project  meta.ModrinthProjectInfo   `json:"project"`
+	Versions []meta.ModrinthVersionInfo `json:"versions"`
+	Error    string                     `json:"error"`
+}
+
+// GetModrinthProjectVersions lists the versions of a project for the version picker after a search;
+// versions that fit the instance are flagged compatible.
+func (a *App) GetModrinthProjectVersions(instanceName, projectIDOrSlug, category string) ModrinthVersionsResponse {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModrinthVersionsResponse{Error: err.Error()}
+	}
+	project, err := meta.FetchModrinthProject(projectIDOrSlug)
+	if err != nil {
+		return ModrinthVersionsResponse{Error: err.Error()}
 	}
+	versions, err := meta.ListModrinthVersions(project.ID, inst.GameVersion, string(inst.Loader), category)
+	if err != nil {
+		return ModrinthVersionsResponse{Project: project, Error: err.Error()}
+	}
+	return ModrinthVersionsResponse{Project: project, Versions: versions}
+}
 
-	options := launcher.InstanceOptions{
-		Name:          instanceName,
-		GameVersion:   serverVersion,
-		Loader:        loaderType,
-		LoaderVersion: finalLoaderVersion,
-		Config:        defaultConfig,
+// SearchModrinthVersions lists a project's versions (number, channel, date, downloads) filtered for choosing
+// a version to install or pin. loader / gameVersion: "instance" uses the instance's values, "" disables the
+// filter; channel: release | beta | alpha | "" (all).
+func (a *App) SearchModrinthVersions(instanceName, projectIDOrSlug, loader, gameVersion, channel string) ModrinthVersionsResponse {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModrinthVersionsResponse{Error: err.Error()}
+	}
+	if strings.EqualFold(strings.TrimSpace(loader), "instance") {
+		loader = string(inst.Loader)
+	}
+	if strings.EqualFold(strings.TrimSpace(gameVersion), "instance") {
+		gameVersion = inst.GameVersion
 	}
+	project, err := meta.FetchModrinthProject(projectIDOrSlug)
+	if err != nil {
+		return ModrinthVersionsResponse{Error: err.Error()}
+	}
+	category, _, _ := modrinthTypeCategory(project.ProjectType)
+	versions, err := meta.ListModrinthVersions(project.ID, inst.GameVersion, string(inst.Loader), category)
+	if err != nil {
+		return ModrinthVersionsResponse{Project: project, Error: err.Error()}
+	}
+	return ModrinthVersionsResponse{Project: project, Versions: meta.FilterModrinthVersions(versions, loader, gameVersion, channel)}
+}
 
-	// First, try to find existing instance - exact copy of TUI
-	logMessage("Проверяем существование инстанса")
-	inst, err := launcher.FetchInstance(instanceName)
+// InstallModrinthProjectVersion installs the version picked by the user (versionID "" = newest compatible).
+func (a *App) InstallModrinthProjectVersion(instanceName, projectIDOrSlug, versionID, category string) ModInstallResult {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		logMessage(fmt.Sprintf("Инстанс не найден, создаём новый: %v", err))
-		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-			"type":    "instance-creating",
-			"message": fmt.Sprintf("Создание инстанса '%s'...", instanceName),
-		})
-		// Instance doesn't exist, create it
-		logMessage("Вызов launcher.CreateInstance")
-		inst, err = launcher.CreateInstance(options)
-		if err != nil {
-			logMessage(fmt.Sprintf("Ошибка создания инстанса: %v", err))
-			runtime.EventsEmit(a.ctx, "launch-error", map[string]interface{}{
-				"error": fmt.Sprintf("Ошибка создания инстанса: %v", err),
-			})
-			return fmt.Sprintf("Error creating instance: %v", err)
+		return ModInstallResult{Error: err.Error()}
+	}
+	if _, mrOn := instanceCatalogFlags(&inst); !mrOn {
+		return ModInstallResult{Error: "Каталог Modrinth отключён в настройках лаунчера"}
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		category = "mods"
+	}
+	project, err := meta.FetchModrinthProject(projectIDOrSlug)
+	if err != nil {
+		return ModInstallResult{Error: err.Error()}
+	}
+	var f meta.ModrinthFile
+	if strings.TrimSpace(versionID) == "" {
+		f, err = installModrinthProject(inst, project, category)
+	} else if f, err = meta.FetchModrinthVersionFile(versionID); err == nil {
+		if f.ProjectID != project.ID {
+			err = fmt.Errorf("версия %s не относится к проекту %s", versionID, project.Slug)
+		} else {
+			f, err = installModrinthFile(inst, project, category, f)
 		}
-		logMessage(fmt.Sprintf("Инстанс '%s' создан успешно", instanceName))
-		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-			"type":    "instance-created",
-			"message": fmt.Sprintf("Инстанс '%s' создан успешно", instanceName),
-		})
-	} else {
-		logMessage(fmt.Sprintf("Найден существующий инстанс: %s", instanceName))
-		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
-			"type":    "instance-found",
-			"message": fmt.Sprintf("Найден существующий инстанс '%s'", instanceName),
-		})
-		// Update existing instance config with QMServer settings if needed
-		config := inst.Config
-		configNeedsUpdate := false
+	}
+	result := ModInstallResult{ProjectID: project.ID, Slug: project.Slug, Title: project.Title}
+	if err != nil {
+		logMessage(fmt.Sprintf("[Mods] Установка %s в %s: %v", project.Slug, inst.Name, err))
+		result.Error = err.Error()
+		return result
+	}
+	logMessage(fmt.Sprintf("[Mods] Установлен %s %s (%s) в %s", project.Title, f.VersionNumber, f.Filename, inst.Name))
+	result.VersionNumber = f.VersionNumber
+	result.Filename = f.Filename
+	return result
+}
 
-		if !config.IsUsingQMServerCloud {
-			config.IsUsingQMServerCloud = true
-			configNeedsUpdate = true
-			logMessage("Обновление конфигурации: включена поддержка QMServer Cloud")
-		}
+// ModUpdate is one row of the mod update summary.
+type ModUpdate struct {
+	Filename       string `json:"filename"`
+	ProjectID      string `json:"projectId"`
+	Title          string `json:"title"`
+	CurrentVersion string `json:"currentVersion"`
+	// CurrentVersionID is the Modrinth version of the installed file (used to fetch changelogs).
+	CurrentVersionID string `json:"currentVersionId,omitempty"`
+	LatestVersion    string `json:"latestVersion"`
+	LatestFilename   string `json:"latestFilename"`
+	Applied          bool   `json:"applied"`
+	Error            string `json:"error,omitempty"`
+}
 
-		if config.QMServerHost != defaultQMServerHost {
-			config.QMServerHost = defaultQMServerHost
-			configNeedsUpdate = true
-			logMessage("Обновление конфигурации: установлен QMServerHost")
-		}
+// ModUpdatesReport is returned by CheckInstanceModUpdates and UpdateInstanceMods.
+type ModUpdatesReport struct {
+	Checked int         `json:"checked"` // mod files looked up
+	Updates []ModUpdate `json:"updates"`
+	// Pinned are mod files held back by their mods.lock pin.
+	Pinned []string `json:"pinned"`
+	// Unknown are mod files Modrinth does not know, so their updates cannot be checked.
+	Unknown []string `json:"unknown"`
+	Error   string   `json:"error"`
+}
 
-		if config.QMServerPort != defaultQMServerPort {
-			config.QMServerPort = defaultQMServerPort
-			configNeedsUpdate = true
-			logMessage("Обновление конфигурации: установлен QMServerPort")
-		}
+type modUpdateCandidate struct {
+	row      ModUpdate
+	latest   meta.ModrinthFile
+	disabled bool
+}
 
-		// Update loader, loader version, and game version to match server profile if needed
-		loaderNeedsUpdate := false
+// modUpdateScan is the result of findModUpdates.
+type modUpdateScan struct {
+	candidates []modUpdateCandidate
+	checked    int      // mod files looked up
+	pinned     []string // held back by their mods.lock pin
+	unknown    []string // not on Modrinth
+}
 
-		if inst.GameVersion != serverVersion && serverVersion != "" {
-			logMessage(fmt.Sprintf("Обновление версии игры: %s -> %s", inst.GameVersion, serverVersion))
-			inst.GameVersion = serverVersion
-			loaderNeedsUpdate = true
+// findModUpdates hashes every mod JAR and asks Modrinth for the newest compatible version of each.
+// Mods pinned in mods.lock follow their pin and are listed in pinned when held back; files Modrinth
+// does not know are listed in unknown.
+func findModUpdates(inst launcher.Instance) (modUpdateScan, error) {
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	entries, err := os.ReadDir(modsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return modUpdateScan{}, nil
 		}
-
-		if inst.Loader != loaderType {
-			logMessage(fmt.Sprintf("Обновление типа загрузчика: %s -> %s", inst.Loader, loaderType))
-			inst.Loader = loaderType
-			loaderNeedsUpdate = true
+		return modUpdateScan{}, err
+	}
+	byHash := map[string]string{} // sha1 -> file name on disk
+	var hashes []string
+	for _, e := range entries {
+		if e.IsDir() || validateModsBasename(e.Name()) != nil {
+			continue
 		}
-
-		// Если сервер передал конкретную версию загрузчика – синхронизируем её
-		if finalLoaderVersion != "" && inst.LoaderVersion != finalLoaderVersion {
-			logMessage(fmt.Sprintf("Обновление версии загрузчика: %s -> %s", inst.LoaderVersion, finalLoaderVersion))
-			inst.LoaderVersion = finalLoaderVersion
-			loaderNeedsUpdate = true
+		sum, err := meta.FileSHA1(filepath.Join(modsDir, e.Name()))
+		if err != nil {
+			continue
 		}
-
-		// Apply updates if anything changed
-		if configNeedsUpdate {
-			inst.Config = config
+		byHash[sum] = e.Name()
+		hashes = append(hashes, sum)
+	}
+	latest, err := meta.ModrinthLatestForHashes(hashes, string(inst.Loader), inst.GameVersion)
+	if err != nil {
+		return modUpdateScan{checked: len(hashes)}, err
+	}
+	lock, _ := launcher.LoadModLock(inst.Dir())
+	installs := launcher.LoadRemoteInstalls(inst.Dir())
+
+	// Installed versions of files not in mods.lock come from the (cached) hash lookup.
+	current, _ := meta.IdentifyModrinthHashes(hashes, inst.CachesDir())
+
+	var out []modUpdateCandidate
+	var pinned, unknown []string
+	for _, sum := range hashes {
+		f, ok := latest[sum]
+		if !ok {
+			// version_files/update also skips known files without a compatible version
+			if _, known := current[sum]; !known {
+				unknown = append(unknown, byHash[sum])
+			}
+			continue
 		}
-
-		if configNeedsUpdate || loaderNeedsUpdate {
-			if err := inst.WriteConfig(); err != nil {
-				logMessage(fmt.Sprintf("Ошибка сохранения обновленной конфигурации инстанса: %v", err))
-			} else {
-				logMessage("Конфигурация инстанса успешно обновлена для QMServer Cloud и загрузчика")
+		if strings.EqualFold(f.Sha1, sum) {
+			continue
+		}
+		name := byHash[sum]
+		active := resourceStripDisabledSuffix(name)
+		if idx := lock.Find("mods", active); idx >= 0 && !lock.Entries[idx].Pin.IsZero() {
+			pin := lock.Entries[idx].Pin
+			if pin.VersionID != "" || (pin.Provider != "" && pin.Provider != "modrinth") {
+				pinned = append(pinned, name)
+				continue
+			}
+			if !meta.ModrinthChannelAllows(pin.Channel, f.VersionType) {
+				cf, err := meta.ResolveModrinthFileInChannel(f.ProjectID, inst.GameVersion, string(inst.Loader), "mods", pin.Channel)
+				if err != nil || strings.EqualFold(cf.Sha1, sum) {
+					pinned = append(pinned, name)
+					continue
+				}
+				f = cf
+			}
+		}
+		row := ModUpdate{
+			Filename:       name,
+			ProjectID:      f.ProjectID,
+			Title:          strings.TrimSuffix(active, ".jar"),
+			LatestVersion:  f.VersionNumber,
+			LatestFilename: f.Filename,
+		}
+		if m, ok := current[sum]; ok {
+			row.CurrentVersion = m.VersionNumber
+			row.CurrentVersionID = m.VersionID
+			if m.Title != "" {
+				row.Title = m.Title
+			}
+		}
+		if idx := lock.Find("mods", active); idx >= 0 {
+			if lock.Entries[idx].VersionNumber != "" {
+				row.CurrentVersion = lock.Entries[idx].VersionNumber
+				row.CurrentVersionID = lock.Entries[idx].VersionID
+			}
+			if lock.Entries[idx].Title != "" {
+				row.Title = lock.Entries[idx].Title
 			}
+		} else if rec, ok := installs["mods/"+active]; ok && rec.Title != "" {
+			row.Title = rec.Title
 		}
+		out = append(out, modUpdateCandidate{row: row, latest: f, disabled: resourceHasDisabledSuffix(name)})
 	}
-
-	return instanceName
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].row.Title) < strings.ToLower(out[j].row.Title) })
+	sort.Strings(pinned)
+	sort.Strings(unknown)
+	return modUpdateScan{candidates: out, checked: len(hashes), pinned: pinned, unknown: unknown}, nil
 }
 
-// initLogging initializes logging to a centralized logs directory - exact copy of TUI
-func initLogging(instanceName string) error {
-	logMessage("Определение домашней директории")
-
-	// Use centralized logs directory - cross-platform home directory detection
-	var homeDir string
-	if home := os.Getenv("HOME"); home != "" {
-		homeDir = home
-		logMessage(fmt.Sprintf("Используется HOME: %s", homeDir))
-	} else if home := os.Getenv("USERPROFILE"); home != "" {
-		homeDir = home // Windows
-		logMessage(fmt.Sprintf("Используется USERPROFILE: %s", homeDir))
-	} else {
-		// Fallback - try to get home directory
-		if h, err := os.UserHomeDir(); err == nil {
-			homeDir = h
-			logMessage(fmt.Sprintf("Используется UserHomeDir: %s", homeDir))
-		} else {
-			logMessage(fmt.Sprintf("Не удалось определить домашнюю директорию: %v", err))
-			return fmt.Errorf("cannot determine home directory")
+// applyModUpdate downloads the new file, keeps the enabled/disabled state and replaces the old JAR.
+// When backupDir is set the old JAR is moved there instead of being deleted.
+func applyModUpdate(inst launcher.Instance, c modUpdateCandidate, backupDir string) error {
+	modsDir := filepath.Join(inst.Dir(), "mods")
+	tmpDir := filepath.Join(inst.TmpDir(), "mod-updates")
+	newPath, err := meta.DownloadModrinthFile(c.latest, tmpDir)
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(modsDir, filepath.Base(newPath))
+	if c.disabled {
+		dest += ".disabled"
+	}
+	oldPath := filepath.Join(modsDir, c.row.Filename)
+	if backupDir != "" {
+		if err := os.MkdirAll(backupDir, 0755); err != nil {
+			_ = os.Remove(newPath)
+			return err
+		}
+		if err := os.Rename(oldPath, filepath.Join(backupDir, c.row.Filename)); err != nil && !os.IsNotExist(err) {
+			_ = os.Remove(newPath)
+			return err
 		}
+	} else if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		_ = os.Remove(newPath)
+		return err
 	}
-
-	logsDir := filepath.Join(homeDir, ".qmlauncher", "logs")
-	logMessage(fmt.Sprintf("Директория для логов: %s", logsDir))
-
-	// Create logs directory if it doesn't exist
-	logMessage("Создание директории для логов")
-	if err := os.MkdirAll(logsDir, 0755); err != nil {
-		logMessage(fmt.Sprintf("Ошибка создания директории логов: %v", err))
-		return fmt.Errorf("failed to create logs directory: %w", err)
+	if err := os.Rename(newPath, dest); err != nil {
+		return err
 	}
-	logMessage(fmt.Sprintf("Директория создана: %s", logsDir))
-
-	// Create log filename with instance name and timestamp
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	logFilename := fmt.Sprintf("qmlauncher_%s_%s.log", instanceName, timestamp)
-	logPath := filepath.Join(logsDir, logFilename)
 
-	logMessage(fmt.Sprintf("Создание файла логов: %s", logFilename))
+	oldActive := resourceStripDisabledSuffix(c.row.Filename)
+	rec := launcher.LoadRemoteInstalls(inst.Dir())["mods/"+oldActive]
+	launcher.RemoveRemoteInstall(inst.Dir(), "mods", oldActive)
+	rec.Source = "modrinth"
+	rec.ProjectID = c.latest.ProjectID
+	if rec.Title == "" {
+		rec.Title = c.row.Title
+	}
+	launcher.RecordRemoteInstall(inst.Dir(), "mods", c.latest.Filename, rec)
+
+	entry := launcher.ModLockEntry{
+		Category:      "mods",
+		Provider:      "modrinth",
+		ProjectID:     c.latest.ProjectID,
+		Slug:          rec.Slug,
+		Title:         rec.Title,
+		VersionID:     c.latest.VersionID,
+		VersionNumber: c.latest.VersionNumber,
+		Filename:      c.latest.Filename,
+		URL:           c.latest.URL,
+		Sha1:          c.latest.Sha1,
+		Sha512:        c.latest.Sha512,
+		Disabled:      c.disabled,
+		Dependencies:  c.latest.Dependencies,
+	}
+	if lock, err := launcher.LoadModLock(inst.Dir()); err == nil {
+		if idx := lock.Find("mods", oldActive); idx >= 0 {
+			entry.Pin = lock.Entries[idx].Pin
+		}
+		lock.Remove("mods", oldActive)
+		lock.GameVersion = inst.GameVersion
+		lock.Loader = string(inst.Loader)
+		lock.Upsert(entry)
+		return launcher.SaveModLock(inst.Dir(), lock)
+	}
+	return nil
+}
 
-	var err error
-	logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+// CheckInstanceModUpdates lists mods with a newer Modrinth version for the instance's loader and game version.
+func (a *App) CheckInstanceModUpdates(instanceName string) ModUpdatesReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
 	if err != nil {
-		logMessage(fmt.Sprintf("Ошибка создания файла логов: %v", err))
-		return fmt.Errorf("failed to open log file: %w", err)
+		return ModUpdatesReport{Error: err.Error()}
 	}
-	logMessage("Файл логов создан успешно")
-
-	// Set log output to file
-	log.SetOutput(logFile)
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	scan, err := findModUpdates(inst)
+	if err != nil {
+		return ModUpdatesReport{Checked: scan.checked, Error: err.Error()}
+	}
+	report := ModUpdatesReport{Checked: scan.checked, Updates: make([]ModUpdate, 0, len(scan.candidates)),
+		Pinned: append([]string{}, scan.pinned...), Unknown: append([]string{}, scan.unknown...)}
+	for _, c := range scan.candidates {
+		report.Updates = append(report.Updates, c.row)
+	}
+	return report
+}
 
-	logMessage(fmt.Sprintf("Инстанс: %s", instanceName))
-	return nil
+// ModChangelogPage is one page of changelogs between an installed mod and its newest compatible version.
+type ModChangelogPage struct {
+	Entries  []meta.ModrinthChangelogEntry `json:"entries"`
+	Page     int                           `json:"page"`
+	PageSize int                           `json:"pageSize"`
+	Total    int                           `json:"total"`
+	Error    string                        `json:"error"`
 }
 
-// Translate returns translated string for the given key
-func (a *App) Translate(key string) string {
-	return i18n.Translate(key)
+// GetModUpdateChangelog returns the changelogs of the versions a mod update would pull in, newest first.
+// projectID and currentVersionID come from a ModUpdate row; page starts at 0.
+func (a *App) GetModUpdateChangelog(instanceName, projectID, currentVersionID string, page, pageSize int) ModChangelogPage {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModChangelogPage{Error: err.Error()}
+	}
+	if pageSize <= 0 {
+		pageSize = 5
+	}
+	if page < 0 {
+		page = 0
+	}
+	entries, err := meta.ModrinthChangelogSince(projectID, currentVersionID, inst.GameVersion, string(inst.Loader))
+	if err != nil {
+		return ModChangelogPage{Error: err.Error()}
+	}
+	out := ModChangelogPage{Page: page, PageSize: pageSize, Total: len(entries), Entries: []meta.ModrinthChangelogEntry{}}
+	if start := page * pageSize; start < len(entries) {
+		out.Entries = entries[start:min(start+pageSize, len(entries))]
+	}
+	return out
 }
 
-// SetLang sets the application language (ru, en). Persists to ~/.qmlauncher/settings.json. Call Translate to refresh UI.
-func (a *App) SetLang(langTag string) {
-	switch langTag {
-	case "en", "en-US", "en-GB":
-		i18n.SetLang(language.English)
-	case "ru", "ru-RU":
-		i18n.SetLang(language.Russian)
-	default:
-		i18n.SetLang(language.Russian)
+// UpdateInstanceMods upgrades mods to their newest compatible Modrinth version.
+// mod selects one mod by file name, project id or title; empty updates all.
+func (a *App) UpdateInstanceMods(instanceName, mod string) ModUpdatesReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return ModUpdatesReport{Error: err.Error()}
 	}
-	// Persist language to settings file
-	if homeDir, err := os.UserHomeDir(); err == nil {
-		settingsPath := filepath.Join(homeDir, ".qmlauncher", "settings.json")
-		os.MkdirAll(filepath.Dir(settingsPath), 0755)
-		var existing map[string]interface{}
-		if data, err := os.ReadFile(settingsPath); err == nil {
-			json.Unmarshal(data, &existing)
-		}
-		if existing == nil {
-			existing = make(map[string]interface{})
+	scan, err := findModUpdates(inst)
+	if err != nil {
+		return ModUpdatesReport{Checked: scan.checked, Error: err.Error()}
+	}
+	mod = strings.TrimSpace(mod)
+	report := ModUpdatesReport{Checked: scan.checked, Updates: make([]ModUpdate, 0, len(scan.candidates)),
+		Pinned: append([]string{}, scan.pinned...), Unknown: append([]string{}, scan.unknown...)}
+	for _, c := range scan.candidates {
+		if mod != "" && !strings.EqualFold(mod, c.row.Filename) && !strings.EqualFold(mod, c.row.ProjectID) && !strings.EqualFold(mod, c.row.Title) {
+			continue
 		}
-		existing["language"] = i18n.GetLang()
-		if data, err := json.MarshalIndent(existing, "", "  "); err == nil {
-			os.WriteFile(settingsPath, data, 0644)
+		if err := applyModUpdate(inst, c, ""); err != nil {
+			c.row.Error = err.Error()
+			logMessage(fmt.Sprintf("[Mods] Обновление %s: %v", c.row.Filename, err))
+		} else {
+			c.row.Applied = true
+			logMessage(fmt.Sprintf("[Mods] %s: %s → %s", c.row.Title, c.row.Filename, c.row.LatestFilename))
 		}
+		report.Updates = append(report.Updates, c.row)
 	}
+	if mod != "" && len(report.Updates) == 0 {
+		report.Error = fmt.Sprintf("для %q обновлений нет", mod)
+	}
+	return report
 }
 
-// GetLang returns the current language tag ("ru" or "en").
-func (a *App) GetLang() string {
-	return i18n.GetLang()
-}
-
-// GetLauncherVersion returns semver with a "v" prefix for the window title and header (e.g. v1.0.10).
-func (a *App) GetLauncherVersion() string {
-	return "v" + version
+// OutdatedMod is one row of the installed vs latest-compatible table.
+type OutdatedMod struct {
+	Filename  string `json:"filename"`
+	Title     string `json:"title"`
+	Installed string `json:"installed"`
+	Latest    string `json:"latest"` // newest compatible Modrinth version ("" when unknown)
+	Outdated  bool   `json:"outdated"`
+	Pinned    bool   `json:"pinned"`
+	Unknown   bool   `json:"unknown"` // not on Modrinth
+	Applied   bool   `json:"applied"`
+	Error     string `json:"error,omitempty"`
 }
 
-// LauncherAboutInfo describes the running binary for the About dialog.
-type LauncherAboutInfo struct {
-	Version string `json:"version"`
-	OS      string `json:"os"`
-	Arch    string `json:"arch"`
+// OutdatedModsReport is returned by GetInstanceOutdatedMods.
+type OutdatedModsReport struct {
+	Mods     []OutdatedMod `json:"mods"`
+	Outdated int           `json:"outdated"`
+	// BackupDir holds the replaced JARs when apply was requested.
+	BackupDir string `json:"backupDir,omitempty"`
+	Error     string `json:"error"`
 }
 
-// GetLauncherAboutInfo returns version and platform for the About dialog.
-func (a *App) GetLauncherAboutInfo() LauncherAboutInfo {
-	return LauncherAboutInfo{
-		Version: "v" + version,
-		OS:      goruntime.GOOS,
-		Arch:    goruntime.GOARCH,
+// GetInstanceOutdatedMods lists every mod with its installed and newest compatible version. With apply,
+// all outdated (unpinned) mods are upgraded at once and the replaced JARs are kept in
+// .qmlauncher/mod-backups/<timestamp>/.
+func (a *App) GetInstanceOutdatedMods(instanceName string, apply bool) OutdatedModsReport {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return OutdatedModsReport{Error: err.Error()}
 	}
-}
+	scan, err := findModUpdates(inst)
+	if err != nil {
+		return OutdatedModsReport{Error: err.Error()}
+	}
+	byFile := map[string]modUpdateCandidate{}
+	for _, c := range scan.candidates {
+		byFile[c.row.Filename] = c
+	}
+	lock, _ := launcher.LoadModLock(inst.Dir())
 
-// CheckLauncherUpdateAvailable reports whether an update is available (QMServer distribution, GitHub binary, or QMWeb).
-func (a *App) CheckLauncherUpdateAvailable() bool {
-	return updater.QMServerDistributionUpdateAvailable(nil) ||
-		updater.GitHubBinaryUpdateAvailable() ||
-		updater.CheckForQMWebUpdate(nil)
+	report := OutdatedModsReport{Mods: []OutdatedMod{}}
+	if apply && len(scan.candidates) > 0 {
+		report.BackupDir = filepath.Join(inst.Dir(), ".qmlauncher", "mod-backups", time.Now().Format("20060102-150405"))
+	}
+	for _, m := range readInstanceModsMetadata(&inst) {
+		row := OutdatedMod{Filename: m.File, Title: m.Name, Installed: m.Version, Pinned: slices.Contains(scan.pinned, m.File),
+			Unknown: slices.Contains(scan.unknown, m.File)}
+		if idx := lock.Find("mods", resourceStripDisabledSuffix(m.File)); idx >= 0 {
+			if row.Title == "" {
+				row.Title = lock.Entries[idx].Title
+			}
+			if lock.Entries[idx].VersionNumber != "" {
+				row.Installed = lock.Entries[idx].VersionNumber
+			}
+		}
+		if row.Title == "" {
+			row.Title = strings.TrimSuffix(resourceStripDisabledSuffix(m.File), ".jar")
+		}
+		if c, ok := byFile[m.File]; ok {
+			row.Title = c.row.Title
+			if c.row.CurrentVersion != "" {
+				row.Installed = c.row.CurrentVersion
+			}
+			row.Latest = c.row.LatestVersion
+			row.Outdated = true
+			report.Outdated++
+			if apply {
+				if err := applyModUpdate(inst, c, report.BackupDir); err != nil {
+					row.Error = err.Error()
+					logMessage(fmt.Sprintf("[Mods] Обновление %s: %v", m.File, err))
+				} else {
+					row.Applied = true
+				}
+			}
+		} else if !row.Pinned && !row.Unknown {
+			row.Latest = row.Installed // up to date
+		}
+		report.Mods = append(report.Mods, row)
+	}
+	sort.SliceStable(report.Mods, func(i, j int) bool {
+		return strings.ToLower(report.Mods[i].Title) < strings.ToLower(report.Mods[j].Title)
+	})
+	if apply && report.Outdated > 0 {
+		applied := 0
+		for _, row := range report.Mods {
+			if row.Applied {
+				applied++
+			}
+		}
+		logMessage(fmt.Sprintf("[Mods] %s: обновлено модов %d из %d, старые файлы в %s", inst.Name, applied, report.Outdated, report.BackupDir))
+	}
+	return report
 }
 
-// LauncherAPITargetSettings is read/written via ~/.qmlauncher/settings.json (use_qmserver_cloud, custom_api_base).
-type LauncherAPITargetSettings struct {
-	UseQMServerCloud bool   `json:"use_qmserver_cloud"`
-	CustomAPIBase    string `json:"custom_api_base"`
-	EffectiveAPIBase string `json:"effective_api_base"`
-}
-
-// GetLauncherAPITarget returns persisted API target fields and the effective base URL.
-func (a *App) GetLauncherAPITarget() LauncherAPITargetSettings {
-	cfg := readLauncherSettingsMap()
-	useCloud := true
-	custom := ""
-	if cfg != nil {
-		if v, ok := cfg["use_qmserver_cloud"]; ok {
-			useCloud = parseBoolish(v, true)
+// SetInstanceModPin pins a mod in mods.lock so mod updates never move it unexpectedly.
+// provider: modrinth | curseforge | "" (any); channel: release | beta | alpha | "" (any);
+// versionID: an exact version, "current" for the installed one, or "". All empty removes the pin.
+func (a *App) SetInstanceModPin(instanceName, mod, provider, channel, versionID string) string {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	name, err := resolveInstanceModFile(&inst, mod)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	active := resourceStripDisabledSuffix(name)
+	pin := &launcher.ModPin{
+		Provider:  strings.ToLower(strings.TrimSpace(provider)),
+		Channel:   strings.ToLower(strings.TrimSpace(channel)),
+		VersionID: strings.TrimSpace(versionID),
+	}
+	switch pin.Provider {
+	case "", "modrinth", "curseforge":
+	default:
+		return fmt.Sprintf("Error: unknown provider %q (modrinth, curseforge)", provider)
+	}
+	switch pin.Channel {
+	case "", "release", "beta", "alpha":
+	default:
+		return fmt.Sprintf("Error: unknown channel %q (release, beta, alpha)", channel)
+	}
+	if strings.EqualFold(pin.VersionID, "current") {
+		lock, err := launcher.LoadModLock(inst.Dir())
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err)
 		}
-		if s, ok := cfg["custom_api_base"].(string); ok {
-			custom = s
+		idx := lock.Find("mods", active)
+		if idx < 0 || lock.Entries[idx].VersionID == "" {
+			return fmt.Sprintf("Error: installed version of %s is unknown", active)
 		}
+		pin.VersionID = lock.Entries[idx].VersionID
 	}
-	return LauncherAPITargetSettings{
-		UseQMServerCloud: useCloud,
-		CustomAPIBase:    custom,
-		EffectiveAPIBase: network.EffectiveQMServerAPIBase(),
+	if err := launcher.SetModLockPin(inst.Dir(), "mods", active, pin); err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
+	return ""
 }
 
-// SetLauncherAPITarget persists API target and refreshes in-memory base. Returns empty string on success.
-func (a *App) SetLauncherAPITarget(useCloud bool, customBase string) string {
-	customBase = strings.TrimSpace(customBase)
-	if !useCloud && customBase == "" {
-		return "Укажите базовый URL API (например https://example.com/api/v1) или включите QMServer Cloud."
+// SetInstanceMemory sets min (-Xms) and max (-Xmx) memory for an instance in MB.
+// Both default to 4096. minMemoryMB must be <= maxMemoryMB. Returns error string on failure.
+func (a *App) SetInstanceMemory(instanceName string, minMemoryMB int, maxMemoryMB int) string {
+	if instanceName == "" {
+		return "Error: empty instance name"
 	}
-	path, err := launcherSettingsPath()
-	if err != nil {
-		return err.Error()
+	if minMemoryMB < 128 || minMemoryMB > 32768 {
+		return "Error: min memory must be between 128 and 32768 MB"
 	}
-	_ = os.MkdirAll(filepath.Dir(path), 0755)
-	var existing map[string]interface{}
-	if data, err := os.ReadFile(path); err == nil {
-		_ = json.Unmarshal(data, &existing)
+	if maxMemoryMB < 512 || maxMemoryMB > 32768 {
+		return "Error: max memory must be between 512 and 32768 MB"
 	}
-	if existing == nil {
-		existing = make(map[string]interface{})
+	if minMemoryMB > maxMemoryMB {
+		return "Error: min memory cannot exceed max memory"
 	}
-	existing["use_qmserver_cloud"] = useCloud
-	existing["custom_api_base"] = customBase
-	data, err := json.MarshalIndent(existing, "", "  ")
+	inst, err := launcher.FetchInstance(instanceName)
 	if err != nil {
-		return err.Error()
+		return fmt.Sprintf("Error: %v", err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return err.Error()
+	inst.Config.MinMemory = minMemoryMB
+	inst.Config.MaxMemory = maxMemoryMB
+	if err := inst.WriteConfig(); err != nil {
+		return fmt.Sprintf("Error: failed to save config: %v", err)
 	}
-	network.ApplyLauncherAPITarget(useCloud, customBase)
 	return ""
 }
 
-// CatalogStoreSettings controls CurseForge / Modrinth availability in the resource catalog UI and API.
-type CatalogStoreSettings struct {
-	CurseforgeEnabled bool `json:"curseforge_enabled"`
-	ModrinthEnabled   bool `json:"modrinth_enabled"`
-}
-
-// GetCatalogStoreSettings returns catalog_curseforge_enabled and catalog_modrinth_enabled (default true if absent).
-func (a *App) GetCatalogStoreSettings() CatalogStoreSettings {
-	cfg := readLauncherSettingsMap()
-	cf := true
-	mr := true
-	if cfg != nil {
-		cf = parseBoolish(cfg["catalog_curseforge_enabled"], true)
-		mr = parseBoolish(cfg["catalog_modrinth_enabled"], true)
+// GetInstanceSyncProtect returns the instance's sync.protect patterns.
+func (a *App) GetInstanceSyncProtect(instanceName string) []string {
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil || inst.Config.Sync.Protect == nil {
+		return []string{}
 	}
-	return CatalogStoreSettings{CurseforgeEnabled: cf, ModrinthEnabled: mr}
+	return inst.Config.Sync.Protect
 }
 
-// SetCatalogStoreSettings persists catalog toggles and emits catalog-store-settings-changed.
-func (a *App) SetCatalogStoreSettings(curseforgeEnabled, modrinthEnabled bool) string {
-	path, err := launcherSettingsPath()
-	if err != nil {
-		return err.Error()
-	}
-	_ = os.MkdirAll(filepath.Dir(path), 0755)
-	var existing map[string]interface{}
-	if data, err := os.ReadFile(path); err == nil {
-		_ = json.Unmarshal(data, &existing)
-	}
-	if existing == nil {
-		existing = make(map[string]interface{})
-	}
-	existing["catalog_curseforge_enabled"] = curseforgeEnabled
-	existing["catalog_modrinth_enabled"] = modrinthEnabled
-	data, err := json.MarshalIndent(existing, "", "  ")
+// SetInstanceSyncProtect sets the paths QMServer Cloud sync never overwrites or deletes in an instance,
+// e.g. "config/xaero/**" or "shaderpacks/my-custom.zip" ("**" spans directories; a matching directory
+// protects its contents). Returns error string on failure.
+func (a *App) SetInstanceSyncProtect(instanceName string, patterns []string) string {
+	inst, err := launcher.FetchInstance(instanceName)
 	if err != nil {
-		return err.Error()
+		return fmt.Sprintf("Error: %v", err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return err.Error()
+	var clean []string
+	for _, p := range patterns {
+		p = strings.Trim(filepath.ToSlash(strings.TrimSpace(p)), "/")
+		if p == "" {
+			continue
+		}
+		if err := launcher.ValidateSyncPattern(p); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		if !slices.Contains(clean, p) {
+			clean = append(clean, p)
+		}
 	}
-	if a.ctx != nil {
-		runtime.EventsEmit(a.ctx, "catalog-store-settings-changed", nil)
+	inst.Config.Sync.Protect = clean
+	if err := inst.WriteConfig(); err != nil {
+		return fmt.Sprintf("Error: failed to save config: %v", err)
 	}
 	return ""
 }
 
-// CurseForgeKeySettings describes persisted CurseForge API key state for the UI (no key material exposed).
-type CurseForgeKeySettings struct {
-	HasEffectiveKey bool   `json:"has_effective_key"`
-	KeySavedInFile  bool   `json:"key_saved_in_file"`
-	UseMyKeyDefault bool   `json:"use_my_key_default"`
-	EffectiveSource string `json:"effective_source"`
+// InstanceSyncConflicts is how QMServer Cloud sync treats local files that differ from the server copy.
+type InstanceSyncConflicts struct {
+	OnConflict string            `json:"onConflict"` // overwrite | keep-local | keep-both | ask
+	Conflicts  map[string]string `json:"conflicts"`  // path pattern -> strategy
 }
 
-// GetCurseForgeKeySettings returns whether a key is available for API calls and the source label only (no key preview).
-func (a *App) GetCurseForgeKeySettings() CurseForgeKeySettings {
-	cfg := readLauncherSettingsMap()
-	saved := false
-	useMy := false
-	if cfg != nil {
-		if s, ok := cfg["curseforge_api_key"].(string); ok && meta.NormalizeCurseForgeAPIKey(s) != "" {
-			saved = true
-		}
-		useMy = parseBoolish(cfg["curseforge_use_my_key_default"], false)
+// GetInstanceSyncConflicts returns the instance's conflict strategy and per-path overrides.
+func (a *App) GetInstanceSyncConflicts(instanceName string) InstanceSyncConflicts {
+	res := InstanceSyncConflicts{OnConflict: launcher.SyncOverwrite, Conflicts: map[string]string{}}
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return res
 	}
-	effective := strings.TrimSpace(meta.CurseForgeAPIKey())
-	return CurseForgeKeySettings{
-		HasEffectiveKey: effective != "",
-		KeySavedInFile:  saved,
-		UseMyKeyDefault: useMy,
-		EffectiveSource: curseForgeEffectiveKeySource(),
+	if inst.Config.Sync.OnConflict != "" {
+		res.OnConflict = inst.Config.Sync.OnConflict
 	}
+	for pattern, strategy := range inst.Config.Sync.Conflicts {
+		res.Conflicts[pattern] = strategy
+	}
+	return res
 }
 
-// HasCurseForgeAPIKey reports whether CurseForge Core API requests can be authenticated.
-func (a *App) HasCurseForgeAPIKey() bool {
-	return strings.TrimSpace(meta.CurseForgeAPIKey()) != ""
-}
-
-// SetCurseForgeSettingsKey persists curseforge_api_key and curseforge_use_my_key_default in ~/.qmlauncher/settings.json.
-// If clearKey is true, the stored API key is removed. If clearKey is false and apiKey is empty, the previous key is kept (only the useMyKeyDefault / prefs update).
-func (a *App) SetCurseForgeSettingsKey(apiKey string, useMyKeyDefault bool, clearKey bool) string {
-	apiKey = meta.NormalizeCurseForgeAPIKey(apiKey)
-	path, err := launcherSettingsPath()
+// SetInstanceSyncConflicts sets what sync does with modified local files: overwrite (default),
+// keep-local, keep-both (the local version is kept as <file>.local-<time>) or ask, which asks the player
+// via the "sync-conflict" event. conflicts overrides the strategy per path pattern (same syntax as
+// sync.protect; the most specific pattern wins). Returns error string on failure.
+func (a *App) SetInstanceSyncConflicts(instanceName, onConflict string, conflicts map[string]string) string {
+	inst, err := launcher.FetchInstance(instanceName)
 	if err != nil {
-		return err.Error()
-	}
-	_ = os.MkdirAll(filepath.Dir(path), 0755)
-	var existing map[string]interface{}
-	if data, err := os.ReadFile(path); err == nil {
-		_ = json.Unmarshal(data, &existing)
-	}
-	if existing == nil {
-		existing = make(map[string]interface{})
+		return fmt.Sprintf("Error: %v", err)
 	}
-	if clearKey {
-		delete(existing, "curseforge_api_key")
-	} else if apiKey != "" {
-		existing["curseforge_api_key"] = apiKey
+	onConflict = strings.TrimSpace(onConflict)
+	if onConflict == launcher.SyncOverwrite {
+		onConflict = ""
 	}
-	existing["curseforge_use_my_key_default"] = useMyKeyDefault
-	data, err := json.MarshalIndent(existing, "", "  ")
-	if err != nil {
-		return err.Error()
+	if onConflict != "" {
+		if err := launcher.ValidateConflictStrategy(onConflict); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return err.Error()
+	var clean map[string]string
+	for pattern, strategy := range conflicts {
+		pattern = strings.Trim(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+		strategy = strings.TrimSpace(strategy)
+		if pattern == "" {
+			continue
+		}
+		if err := launcher.ValidateSyncPattern(pattern); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		if err := launcher.ValidateConflictStrategy(strategy); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		if clean == nil {
+			clean = map[string]string{}
+		}
+		clean[pattern] = strategy
 	}
-	clearCurseForgeCloudKeyCache()
-	if a.ctx != nil {
-		runtime.EventsEmit(a.ctx, "curseforge-settings-changed", nil)
+	inst.Config.Sync.OnConflict = onConflict
+	inst.Config.Sync.Conflicts = clean
+	if err := inst.WriteConfig(); err != nil {
+		return fmt.Sprintf("Error: failed to save config: %v", err)
 	}
 	return ""
 }
 
-// AccountInfo represents account information for frontend
-type AccountInfo struct {
-	Type          string `json:"type"` // "microsoft", "local", "cloud", "cloud_game"
-	Username      string `json:"username"`
-	Status        string `json:"status"` // "active", "expired", "none"
-	IsDefault     bool   `json:"isDefault"`
-	SkinModel     string `json:"skinModel"`     // "steve" or "alex" for local; empty for microsoft (use SkinUuid)
-	SkinUuid      string `json:"skinUuid"`      // Mojang UUID for microsoft (to fetch skin); empty for local
-	SkinURL       string `json:"skinUrl"`       // Custom skin URL (Ely.by, etc.) for cloud/local
-	Email         string `json:"email"`         // For cloud accounts - used for logout
-	GameAccountID uint   `json:"gameAccountId"` // ID of cloud_game account (for deletion)
+// GetInstanceSyncTrash lists the files QMServer Cloud sync moved out of an instance, one batch per sync
+// run, newest first.
+func (a *App) GetInstanceSyncTrash(instanceName string) []launcher.SyncTrashBatch {
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return []launcher.SyncTrashBatch{}
+	}
+	batches, err := launcher.ListSyncTrash(inst.Dir())
+	if err != nil {
+		logMessage(fmt.Sprintf("[Sync] Ошибка чтения %s: %v", launcher.SyncTrashDir, err))
+		return []launcher.SyncTrashBatch{}
+	}
+	return batches
 }
 
-// CloudGameAccountInfo represents a game account on QMServer Cloud (for skin editing)
-type CloudGameAccountInfo struct {
-	ID         uint   `json:"id"`
-	Username   string `json:"username"`
-	UUID       string `json:"uuid"`       // Internal game account UUID
-	ServerUUID string `json:"serverUuid"` // UUID that Minecraft server sees (for --uuid at launch)
-	SkinModel  string `json:"skinModel"`
-	SkinURL    string `json:"skinUrl"`
-	CapeURL    string `json:"capeUrl"`
-	MojangUUID string `json:"mojangUuid"` // For mc-heads avatar when skin_url empty
+// SyncRestoreReport is the result of RestoreInstanceSyncTrash.
+type SyncRestoreReport struct {
+	Restored []string `json:"restored"`
+	Skipped  []string `json:"skipped"` // exist in the instance again, left in the trash
+	Error    string   `json:"error,omitempty"`
 }
 
-// shouldShowCloudGameAccountRow filters game_accounts rows that duplicate QMWeb profile or Ely.by
-// identity names. If the row is a real Mojang-linked profile (mojang_uuid set), always show it —
-// otherwise a user whose profile username equals their Minecraft nick would see no game account.
-func shouldShowCloudGameAccountRow(ga CloudGameAccountInfo, exclude map[string]bool) bool {
-	u := strings.TrimSpace(strings.ToLower(ga.Username))
-	if u == "" {
-		return false
+// RestoreInstanceSyncTrash moves files of a sync trash batch back into the instance: the given paths
+// (files or directories relative to the instance) or the whole batch when paths is empty. Restored files
+// that are still missing from the server manifest are quarantined again by the next sync unless they are
+// added to sync.protect.
+func (a *App) RestoreInstanceSyncTrash(instanceName, batch string, paths []string) SyncRestoreReport {
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return SyncRestoreReport{Error: err.Error()}
 	}
-	if strings.TrimSpace(ga.MojangUUID) != "" {
-		return true
+	restored, skipped, err := launcher.RestoreSyncTrash(inst.Dir(), strings.TrimSpace(batch), paths)
+	report := SyncRestoreReport{Restored: restored, Skipped: skipped}
+	if err != nil {
+		report.Error = err.Error()
 	}
-	return !exclude[u]
+	logMessage(fmt.Sprintf("[Sync] Восстановлено из %s/%s: %d, пропущено: %d", launcher.SyncTrashDir, batch, len(restored), len(skipped)))
+	return report
 }
 
-// GetAccounts returns list of all accounts (Microsoft and local)
-func (a *App) GetAccounts() []AccountInfo {
-	accounts := make([]AccountInfo, 0)
-
-	// Check Microsoft account
-	if auth.Store.MSA.RefreshToken != "" {
-		status := "expired"
-		if auth.Store.Minecraft.Username != "" && auth.Store.Minecraft.Expires.After(time.Now()) {
-			status = "active"
-		}
-		accounts = append(accounts, AccountInfo{
-			Type:      "microsoft",
-			Username:  auth.Store.Minecraft.Username,
-			Status:    status,
-			IsDefault: true, // Microsoft account is always default if exists
-			SkinUuid:  auth.Store.Minecraft.UUID,
-		})
+// PruneInstanceSyncTrash deletes sync trash batches past the instance's retention (sync.trash_days,
+// sync.trash_batches). Returns the deleted batch IDs.
+func (a *App) PruneInstanceSyncTrash(instanceName string) []string {
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return []string{}
 	}
+	pruned, err := launcher.PruneSyncTrash(inst.Dir(), inst.Config.Sync)
+	if err != nil {
+		logMessage(fmt.Sprintf("[Sync] Ошибка очистки %s: %v", launcher.SyncTrashDir, err))
+	}
+	if pruned == nil {
+		pruned = []string{}
+	}
+	return pruned
+}
 
-	// Add local accounts
-	for _, localAccount := range auth.LocalStore.Accounts {
-		isDefault := localAccount.Name == auth.LocalStore.DefaultAccount
-		skinModel := localAccount.SkinModel
-		if skinModel != auth.SkinModelAlex {
-			skinModel = auth.SkinModelSteve
-		}
-		accounts = append(accounts, AccountInfo{
-			Type:      "local",
-			Username:  localAccount.Name,
-			Status:    "active",
-			IsDefault: isDefault,
-			SkinModel: skinModel,
-		})
-	}
-
-	// Add QMServer Cloud accounts
-	// Prefer displaying a real Minecraft nick on the Cloud row when it differs from email.
-	// Exclude profile/Ely.by-only duplicate names unless the row is Mojang-linked (see shouldShowCloudGameAccountRow).
-	cloudStore, _ := auth.ReadCloudStore()
-	var gameAccountUsername, gameAccountSkinURL, gameAccountSkinUuid string
-	if defCloud := auth.GetDefaultCloudAccount(); defCloud != nil && defCloud.Token != "" {
-		elyLinked, profileUser, elyUser, elyUserRaw := a.getCloudProfileNonGameUsernames()
-		exclude := map[string]bool{}
-		if profileUser != "" {
-			exclude[profileUser] = true
-		}
-		if elyUser != "" {
-			exclude[elyUser] = true
-		}
-		if gas, _ := a.GetCloudGameAccounts(); len(gas) > 0 {
-			for _, ga := range gas {
-				if !shouldShowCloudGameAccountRow(ga, exclude) {
-					continue
-				}
-				gameAccountUsername = ga.Username
-				gameAccountSkinURL = ga.SkinURL
-				gameAccountSkinUuid = ga.MojangUUID
-				// Fallback: if no skin_url but user has Ely.by linked, use Ely.by skin
-				if gameAccountSkinURL == "" && elyLinked && elyUserRaw != "" {
-					gameAccountSkinURL = "https://skinsystem.ely.by/skins/" + elyUserRaw + ".png"
-				}
-				break
-			}
-		}
+// CreateInstance creates a new Minecraft instance.
+// loader: "vanilla", "fabric", "quilt", "forge", "neoforge"
+// gameVersion: e.g. "1.20.1", "release" for latest
+// loaderVersion: e.g. "latest" for Fabric/Quilt/Forge; empty for vanilla
+// Returns empty string on success, error message on failure.
+func (a *App) CreateInstance(name string, gameVersion string, loader string, loaderVersion string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "Error: имя инстанса не может быть пустым"
 	}
-	for _, cloud := range cloudStore.Accounts {
-		if cloud.Token == "" {
-			continue
-		}
-		isDefault := auth.GetDefaultCloudAccount() != nil && auth.GetDefaultCloudAccount().Email == cloud.Email
-		displayName := cloud.Email // fallback: email, not profile/Ely.by username
-		skinURL := ""
-		skinUuid := ""
-		if isDefault && gameAccountUsername != "" {
-			displayName = gameAccountUsername
-			skinURL = gameAccountSkinURL
-			skinUuid = gameAccountSkinUuid
-		}
-		accounts = append(accounts, AccountInfo{
-			Type:      "cloud",
-			Username:  displayName,
-			Status:    "active",
-			IsDefault: isDefault,
-			SkinModel: auth.SkinModelSteve,
-			SkinURL:   skinURL,
-			SkinUuid:  skinUuid,
-			Email:     cloud.Email,
-		})
+	var ldr launcher.Loader
+	switch strings.ToLower(strings.TrimSpace(loader)) {
+	case "fabric":
+		ldr = launcher.LoaderFabric
+	case "quilt":
+		ldr = launcher.LoaderQuilt
+	case "neoforge":
+		ldr = launcher.LoaderNeoForge
+	case "forge":
+		ldr = launcher.LoaderForge
+	default:
+		ldr = launcher.LoaderVanilla
 	}
-
-	// Add QMServer Cloud game accounts (separate entries for deletion and management)
-	if defCloud := auth.GetDefaultCloudAccount(); defCloud != nil && defCloud.Token != "" {
-		elyLinked, profileUser, elyUser, _ := a.getCloudProfileNonGameUsernames()
-		exclude := map[string]bool{}
-		if profileUser != "" {
-			exclude[profileUser] = true
-		}
-		if elyUser != "" {
-			exclude[elyUser] = true
-		}
-		if gas, _ := a.GetCloudGameAccounts(); len(gas) > 0 {
-			for _, ga := range gas {
-				if !shouldShowCloudGameAccountRow(ga, exclude) {
-					continue
-				}
-				// Use Ely.by skin as fallback
-				skinURL := ga.SkinURL
-				if skinURL == "" && elyLinked && ga.MojangUUID != "" {
-					// Try to get Ely.by username from profile
-					if _, _, elyUsername, elyUsernameRaw := a.getCloudProfileNonGameUsernames(); elyUsername != "" {
-						skinURL = "https://skinsystem.ely.by/skins/" + elyUsernameRaw + ".png"
-					}
-				}
-				// Link UUID for UI: prefer Mojang profile id; else server/Minecraft UUID (matches Microsoft session when mojang_uuid was not stored)
-				linkUUID := strings.TrimSpace(ga.MojangUUID)
-				if linkUUID == "" {
-					linkUUID = strings.TrimSpace(ga.ServerUUID)
-				}
-				accounts = append(accounts, AccountInfo{
-					Type:          "cloud_game",
-					Username:      ga.Username,
-					Status:        "active",
-					IsDefault:     false,
-					SkinModel:     ga.SkinModel,
-					SkinURL:       skinURL,
-					SkinUuid:      linkUUID,
-					GameAccountID: ga.ID,
-				})
-			}
-		}
+	if gameVersion == "" {
+		gameVersion = "release"
 	}
-
-	return accounts
-}
-
-// LoginAccount logs in to Microsoft account
-func (a *App) LoginAccount(noBrowser bool) string {
-	if network.IsMSAAuthDisabledByQMServer() {
-		return "Microsoft-вход отключён в настройках QMAdmin."
+	if loaderVersion == "" && ldr != launcher.LoaderVanilla {
+		loaderVersion = "latest"
 	}
-	if id := network.GetQMLauncherMSAClientID(); id != "" {
-		auth.ClientID = id
-	} else {
-		return "Microsoft/Mojang вход недоступен."
+	defaultConfig := launcher.InstanceConfig{
+		WindowResolution: struct {
+			Width  int `toml:"width" json:"width"`
+			Height int `toml:"height" json:"height"`
+		}{Width: 1708, Height: 960},
+		MinMemory: 4096,
+		MaxMemory: 4096,
 	}
-
-	// Try to authenticate with existing session first
-	session, err := auth.Authenticate()
-	if err == nil {
-		return fmt.Sprintf(i18n.Translate("login.complete"), session.Username)
+	options := launcher.InstanceOptions{
+		Name:          name,
+		GameVersion:   gameVersion,
+		Loader:        ldr,
+		LoaderVersion: loaderVersion,
+		Config:        defaultConfig,
 	}
-
-	// If no existing session, start new authentication
-	if noBrowser {
-		// Device code flow
-		resp, err := auth.FetchDeviceCode()
-		if err != nil {
-			return fmt.Sprintf("Error fetching device code: %v", err)
-		}
-		// Return device code info for user to enter
-		return fmt.Sprintf(i18n.Translate("login.code"), resp.UserCode, resp.VerificationURI)
-	} else {
-		// Browser flow - this will block, so we need to handle it differently
-		// For now, return URL for user to open
-		url := auth.AuthCodeURL()
-		return fmt.Sprintf(i18n.Translate("login.url"), url.String())
+	_, err := launcher.CreateInstance(options)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
+	return ""
 }
 
-// LogoutAccount logs out from Microsoft account
-func (a *App) LogoutAccount() string {
-	err := auth.Store.Clear()
+// GetCreateInstanceMinecraftVersions returns Minecraft release version ids (newest first) for the create-instance UI.
+// Prefer QMServer Cloud; on failure or empty list fall back to Mojang manifest via local cache.
+func (a *App) GetCreateInstanceMinecraftVersions() []string {
+	cloudBase := network.EffectiveQMServerAPIBase()
+	if v, err := meta.FetchCreateInstanceMinecraftFromQMServerCloud(cloudBase); err == nil && len(v) > 0 {
+		return v
+	} else if err != nil {
+		logMessage(fmt.Sprintf("[CreateInstance] QMServer Cloud minecraft versions: %v (using fallback)", err))
+	}
+	v, err := meta.ListCreateInstanceMinecraftReleases(env.CachesDir)
 	if err != nil {
-		return fmt.Sprintf("Error logging out: %v", err)
+		logMessage(fmt.Sprintf("[CreateInstance] GetCreateInstanceMinecraftVersions: %v", err))
+		return nil
 	}
-	return i18n.Translate("logout.complete")
+	return v
 }
 
-// OpenBrowserForMicrosoft opens browser to Microsoft OAuth for login. Starts a local callback server
-// and emits "microsoft-auth-success" or "microsoft-auth-error" when done.
-func (a *App) OpenBrowserForMicrosoft() string {
-	if network.IsMSAAuthDisabledByQMServer() {
-		msg := "Microsoft-вход отключён в настройках QMAdmin (Настройки → Microsoft авторизация)."
-		logMessage(fmt.Sprintf("[MicrosoftAuth] %s", msg))
-		emitMicrosoftAuthError(a.ctx, msg)
-		return "error"
+// GetCreateInstanceLoaderVersions returns loader versions for the given loader type and Minecraft version.
+// loader: fabric | quilt | forge | neoforge
+// Prefer QMServer Cloud; on failure or empty list fall back to Fabric/Quilt/Forge/NeoForge upstream APIs.
+func (a *App) GetCreateInstanceLoaderVersions(loader string, gameVersion string) []string {
+	cloudBase := network.EffectiveQMServerAPIBase()
+	if v, err := meta.FetchCreateInstanceLoaderFromQMServerCloud(cloudBase, loader, gameVersion); err == nil && len(v) > 0 {
+		return v
+	} else if err != nil {
+		logMessage(fmt.Sprintf("[CreateInstance] QMServer Cloud loader versions (%q, %q): %v (using fallback)", loader, gameVersion, err))
 	}
-	// Use fixed redirect URI registered in Azure AD (must match exactly)
-	var err error
-	auth.RedirectURI, err = url.Parse(auth.DefaultRedirectURI)
+	v, err := meta.ListCreateInstanceLoaderVersions(loader, gameVersion)
 	if err != nil {
-		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to parse redirect URI: %v", err))
-		emitMicrosoftAuthError(a.ctx, "Не удалось создать redirect URI")
-		return "error"
+		logMessage(fmt.Sprintf("[CreateInstance] GetCreateInstanceLoaderVersions(%q, %q): %v", loader, gameVersion, err))
+		return nil
 	}
+	return v
+}
 
-	id := network.GetQMLauncherMSAClientID()
-	if id == "" {
-		msg := "Microsoft/Mojang auth is not available (disabled in QMAdmin: Microsoft authorization)."
-		logMessage(fmt.Sprintf("[MicrosoftAuth] %s", msg))
-		emitMicrosoftAuthError(a.ctx, msg)
-		return "error"
+// DeleteInstance removes a Minecraft instance by name. Returns empty string on success.
+func (a *App) DeleteInstance(instanceName string) string {
+	instanceName = strings.TrimSpace(instanceName)
+	if instanceName == "" {
+		return "Error: имя инстанса не может быть пустым"
 	}
-	auth.ClientID = id
-	msa := network.FetchMSAServerSettings()
-	switch {
-	case msa.FetchOK && msa.ClientID != "":
-		logMessage("[MicrosoftAuth] Using MSA Client ID from QMServer")
-	case strings.TrimSpace(os.Getenv("QMLAUNCHER_MSA_CLIENT_ID")) != "":
-		logMessage("[MicrosoftAuth] Using MSA Client ID from QMLAUNCHER_MSA_CLIENT_ID")
-	default:
-		logMessage("[MicrosoftAuth] Using built-in QMLauncher MSA Client ID")
+	if err := launcher.RemoveInstance(instanceName); err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
+	return ""
+}
 
-	// Start listener on port 8000 (matches redirect URI)
-	listener, err := net.Listen("tcp", "127.0.0.1:8000")
+// OpenPath opens a path (file or folder) in the system default application.
+// For directories, opens in file explorer. Returns empty on success, error message on failure.
+func (a *App) OpenPath(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "Error: path is empty"
+	}
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to start callback server (port 8000 may be in use): %v", err))
-		emitMicrosoftAuthError(a.ctx, "Порт 8000 занят. Закройте другие приложения или перезапустите лаунчер.")
-		return "error"
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return fmt.Sprintf("Error: path not found: %v", err)
 	}
-
-	// Get Microsoft OAuth URL
-	authURL := auth.AuthCodeURL()
-
-	// Handle callback in goroutine
-	go func() {
-		defer listener.Close()
-		server := &http.Server{ReadHeaderTimeout: 5 * time.Second}
-		server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path != "/signin" {
-				http.NotFound(w, r)
-				return
-			}
-			code := r.URL.Query().Get("code")
-			errorParam := r.URL.Query().Get("error")
-			errorDesc := r.URL.Query().Get("error_description")
-
-			if errorParam != "" {
-				logMessage(fmt.Sprintf("[MicrosoftAuth] OAuth error: %s - %s", errorParam, errorDesc))
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка авторизации", errorDesc, "Окно можно закрыть.", true)))
-				emitMicrosoftAuthError(a.ctx, errorDesc)
-				go server.Shutdown(context.Background())
-				return
-			}
-
-			if code == "" {
-				logMessage("[MicrosoftAuth] No code in callback")
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка", "Код авторизации не получен.", "Окно можно закрыть.", true)))
-				emitMicrosoftAuthError(a.ctx, "Код авторизации не получен")
-				go server.Shutdown(context.Background())
-				return
-			}
-
-			// Exchange code for tokens
-			resp, err := auth.ExchangeAuthCode(code)
-			if err != nil {
-				logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to authenticate with code: %v", err))
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка", "Не удалось завершить авторизацию.", "Окно можно закрыть.", true)))
-				emitMicrosoftAuthError(a.ctx, fmt.Sprintf("Не удалось завершить авторизацию: %v", err))
-				go server.Shutdown(context.Background())
-				return
-			}
-
-			// Success
-			logMessage(fmt.Sprintf("[MicrosoftAuth] Successfully authenticated as %s", resp.Username))
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Write([]byte(microsoftAuthCallbackHTML("Успешно!", fmt.Sprintf("Вход выполнен как %s", resp.Username), "Окно можно закрыть.", false)))
-			runtime.EventsEmit(a.ctx, "microsoft-auth-success", nil)
-			go server.Shutdown(context.Background())
-		})
-		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
-			logMessage(fmt.Sprintf("[MicrosoftAuth] Server error: %v", err))
-		}
-	}()
-
-	// Open browser (platform-specific). Use Start() to not block.
 	var cmd *exec.Cmd
 	switch goruntime.GOOS {
 	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL.String())
+		cmd = exec.Command("explorer", absPath)
 	case "darwin":
-		cmd = exec.Command("open", authURL.String())
+		cmd = exec.Command("open", absPath)
 	default:
-		cmd = exec.Command("xdg-open", authURL.String())
+		cmd = exec.Command("xdg-open", absPath)
 	}
 	if err := cmd.Start(); err != nil {
-		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to open browser: %v, URL: %s", err, authURL.String()))
-		emitMicrosoftAuthError(a.ctx, "Не удалось открыть браузер. Скопируйте ссылку: "+authURL.String())
-		return authURL.String()
+		return fmt.Sprintf("Error: %v", err)
 	}
-	logMessage(fmt.Sprintf("[MicrosoftAuth] Opened browser with URL: %s", authURL.String()))
-	return authURL.String()
+	return ""
 }
 
-func emitMicrosoftAuthError(ctx context.Context, msg string) {
-	if ctx != nil {
-		runtime.EventsEmit(ctx, "microsoft-auth-error", msg)
-	}
+// ServerInfo represents server information for frontend
+type ServerInfo struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Address          string `json:"address"`
+	Port             int    `json:"port"`
+	Online           bool   `json:"online"`
+	Enabled          bool   `json:"enabled"`
+	GameServerOnline *bool  `json:"gameServerOnline,omitempty"`
+	Players          int    `json:"players"`
+	MaxPlayers       int    `json:"maxPlayers"`
+	Version          string `json:"version"`
+	ModLoader        string `json:"modLoader,omitempty"`
+	ModLoaderVersion string `json:"modLoaderVersion,omitempty"`
+	IsPremium        bool   `json:"isPremium,omitempty"`
+	ServerID         uint   `json:"serverID,omitempty"`
+	Favorite         string `json:"favorite,omitempty"` // favorite short name, for "@name"
+	// Filled by a server list ping (GetServers with Ping)
+	Pinged    bool   `json:"pinged,omitempty"`
+	LatencyMS int64  `json:"latencyMs,omitempty"`
+	MOTD      string `json:"motd,omitempty"`
+	PingError string `json:"pingError,omitempty"`
 }
 
-// microsoftAuthCallbackHTML returns HTML for the Microsoft OAuth callback page
-func microsoftAuthCallbackHTML(title, message, footer string, isError bool) string {
-	accentColor := "#3c8527"
-	if isError {
-		accentColor = "#d4441a"
-	}
-	return fmt.Sprintf(`<!DOCTYPE html>
-<html lang="ru">
-<head>
-  <meta charset="UTF-8">
-  <meta name="viewport" content="width=device-width, initial-scale=1">
-  <link rel="preconnect" href="https://fonts.googleapis.com">
-  <link href="https://fonts.googleapis.com/css2?family=Noto+Sans:wght@400;500;600;700&display=swap" rel="stylesheet">
-  <title>Microsoft Auth</title>
-  <style>
-    * { margin: 0; padding: 0; box-sizing: border-box; }
-    body {
-      font-family: 'Noto Sans', ui-sans-serif, system-ui, sans-serif;
-      background: #1d1e1e;
-      color: #f1edec;
-      min-height: 100vh;
-      display: flex;
-      align-items: center;
-      justify-content: center;
-      padding: 24px;
-    }
-    .card {
-      background: #313131;
-      border: 1px solid rgba(255,255,255,0.1);
-      border-radius: 0;
-      padding: 32px;
-      max-width: 400px;
-      text-align: center;
-    }
-    h1 {
-      font-family: 'Noto Sans', sans-serif;
-      font-size: 1.5rem;
-      font-weight: 700;
-      text-transform: uppercase;
-      letter-spacing: 1px;
-      color: %s;
-      margin-bottom: 16px;
-    }
-    p { font-size: 0.95rem; line-height: 1.6; color: #f1edec; }
-    .message { margin-bottom: 12px; }
-    .footer { font-size: 0.85rem; color: #949494; }
-  </style>
-</head>
-<body>
-  <div class="card">
-    <h1>%s</h1>
-    <p class="message">%s</p>
-    <p class="footer">%s</p>
-  </div>
-</body>
-</html>`, accentColor, title, message, footer)
+// GetQMServersError returns the last error from loading servers (empty if none)
+func (a *App) GetQMServersError() string {
+	return lastQMError
 }
 
-// OpenBrowserForQMServerCloud opens browser to QMWeb for login/register. Starts a local callback server
-// and emits "cloud-auth-success" or "cloud-auth-error" when done.
-func (a *App) OpenBrowserForQMServerCloud() string {
-	// Start listener on random port
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+// InvalidateQMServersCache clears the cached QMServer /servers response so the next GetRecentServers fetches fresh data.
+func (a *App) InvalidateQMServersCache() {
+	network.InvalidateServersCache()
+}
+
+// GetRecentServers returns list of servers from QMServer Cloud
+func (a *App) GetRecentServers() []ServerInfo {
+	lastQMError = ""
+	serversResponse, err := network.GetQMServersList()
 	if err != nil {
-		logMessage(fmt.Sprintf("[CloudAuth] Failed to start callback server: %v", err))
-		emitCloudAuthError(a.ctx, "Не удалось запустить сервер")
-		return "error"
+		lastQMError = err.Error()
+		log.Printf("[QMServer] Failed to fetch servers: %v", err)
+		return []ServerInfo{}
 	}
-	port := listener.Addr().(*net.TCPAddr).Port
-	callbackURL := fmt.Sprintf("http://127.0.0.1:%d/callback", port)
 
-	// QMWeb URL - use production or configurable
-	qmWebBase := "https://web.qx-dev.ru"
-	if base := os.Getenv("QMWEB_URL"); base != "" {
-		qmWebBase = strings.TrimSuffix(base, "/")
+	if serversResponse.Error != "" {
+		lastQMError = serversResponse.Error
+		log.Printf("[QMServer] API error: %s", serversResponse.Error)
+		return []ServerInfo{}
 	}
-	authURL := fmt.Sprintf("%s/auth?callback=%s", qmWebBase, url.QueryEscape(callbackURL))
 
-	// Handle callback in goroutine
-	go func() {
-		defer listener.Close()
-		server := &http.Server{ReadHeaderTimeout: 5 * time.Second}
-		server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path != "/callback" {
-				http.NotFound(w, r)
-				return
+	// Convert QMServerInfo to ServerInfo
+	servers := make([]ServerInfo, 0, len(serversResponse.ServerProfiles))
+	for _, server := range serversResponse.ServerProfiles {
+		servers = append(servers, serverInfoFromQM(server))
+	}
+
+	return servers
+}
+
+// serverInfoFromQM converts a QMServer server profile for the frontend.
+func serverInfoFromQM(server network.QMServerInfo) ServerInfo {
+	enabled := network.QMServerProfileEnabled(server)
+	gameUp := true
+	if server.GameServerOnline != nil {
+		gameUp = *server.GameServerOnline
+	}
+	pl := 0
+	if server.Players != nil {
+		pl = *server.Players
+	}
+	maxPlayers := 0
+	if server.MaxPlayers != nil {
+		maxPlayers = *server.MaxPlayers
+	}
+	return ServerInfo{
+		ID:               strconv.Itoa(int(server.ID)),
+		Name:             server.Name,
+		Address:          server.Host,
+		ServerID:         server.ID,
+		Port:             server.Port,
+		Online:           enabled && gameUp,
+		Enabled:          enabled,
+		GameServerOnline: server.GameServerOnline,
+		Players:          pl,
+		MaxPlayers:       maxPlayers,
+		Version:          server.Version,
+		ModLoader:        server.ModLoader,
+		ModLoaderVersion: server.ModLoaderVersion,
+		IsPremium:        server.IsPremium,
+	}
+}
+
+const (
+	serverPingTimeout = 3 * time.Second
+	serverPingWorkers = 16
+)
+
+// pingServers queries every server with the Server List Ping protocol concurrently. A server that
+// answers is online with the players it reports; one that does not is offline.
+func pingServers(servers []ServerInfo) {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(serverPingWorkers, len(servers)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				s := &servers[i]
+				ctx, cancel := context.WithTimeout(context.Background(), serverPingTimeout)
+				st, err := serverping.Ping(ctx, serverping.JoinAddress(s.Address, s.Port))
+				cancel()
+				s.Pinged = true
+				if err != nil {
+					s.Online = false
+					s.PingError = err.Error()
+					continue
+				}
+				s.Online = s.Enabled
+				s.Players, s.MaxPlayers = st.Online, st.Max
+				s.LatencyMS = max(st.Latency.Milliseconds(), 1)
+				s.MOTD = st.MOTD
 			}
-			token := r.URL.Query().Get("token")
-			email := r.URL.Query().Get("email")
-			username := r.URL.Query().Get("username")
-			if token == "" {
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(cloudAuthCallbackHTML("Ошибка", "Токен не получен.", "Окно можно закрыть.", true)))
-				emitCloudAuthError(a.ctx, "Токен не получен")
-				go server.Shutdown(context.Background())
-				return
+		}()
+	}
+	for i := range servers {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
+
+// ServerListOptions filters and sorts GetServers.
+type ServerListOptions struct {
+	Filter string `json:"filter"` // online | premium | free | text matched against name, address and version
+	Sort   string `json:"sort"`   // name (default) | players | latency
+	Ping   bool   `json:"ping"`   // query each server for its real status, players and latency
+}
+
+// GetServers returns the QMServer Cloud servers, optionally pinged for their live status, then filtered
+// and sorted. With Ping, "online" means the server answered the ping rather than what the API reports.
+func (a *App) GetServers(opts ServerListOptions) []ServerInfo {
+	servers := a.GetRecentServers()
+	if opts.Ping {
+		pingServers(servers)
+	}
+	favorites := serverFavorites()
+	for i := range servers {
+		for _, f := range favorites {
+			if f.ServerID != 0 && f.ServerID == servers[i].ServerID {
+				servers[i].Favorite = f.Name
 			}
-			if err := auth.AddCloudAccount(token, email, username); err != nil {
-				logMessage(fmt.Sprintf("[CloudAuth] Failed to save: %v", err))
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(cloudAuthCallbackHTML("Ошибка сохранения", "Не удалось сохранить аккаунт.", "Окно можно закрыть.", true)))
-				emitCloudAuthError(a.ctx, "Ошибка сохранения")
-			} else {
-				clearCurseForgeCloudKeyCache()
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.Write([]byte(cloudAuthCallbackHTML("Успешно!", "Аккаунт добавлен в QMLauncher.", "Окно можно закрыть.", false)))
-				runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+		}
+	}
+	filter := strings.ToLower(strings.TrimSpace(opts.Filter))
+	out := make([]ServerInfo, 0, len(servers))
+	for _, s := range servers {
+		keep := true
+		switch filter {
+		case "":
+		case "online":
+			keep = s.Online
+		case "offline":
+			keep = !s.Online
+		case "premium":
+			keep = s.IsPremium
+		case "free":
+			keep = !s.IsPremium
+		case "favorite", "favorites":
+			keep = s.Favorite != ""
+		default:
+			keep = strings.Contains(strings.ToLower(s.Name), filter) || strings.Contains(strings.ToLower(s.Address), filter) ||
+				strings.Contains(strings.ToLower(s.Version), filter)
+		}
+		if keep {
+			out = append(out, s)
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(opts.Sort)) {
+	case "players":
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Players > out[j].Players })
+	case "latency":
+		// Servers that did not answer go last
+		sort.SliceStable(out, func(i, j int) bool {
+			li, lj := out[i].LatencyMS, out[j].LatencyMS
+			if li == 0 || lj == 0 {
+				return li != 0
 			}
-			go server.Shutdown(context.Background())
+			return li < lj
 		})
-		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
-			logMessage(fmt.Sprintf("[CloudAuth] Server error: %v", err))
-		}
-	}()
-
-	// Open browser (platform-specific). Use Start() to not block.
-	var cmd *exec.Cmd
-	switch goruntime.GOOS {
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL)
-	case "darwin":
-		cmd = exec.Command("open", authURL)
 	default:
-		cmd = exec.Command("xdg-open", authURL)
-	}
-	if err := cmd.Start(); err != nil {
-		logMessage(fmt.Sprintf("[CloudAuth] Failed to open browser: %v, URL: %s", err, authURL))
-		emitCloudAuthError(a.ctx, "Не удалось открыть браузер. Скопируйте ссылку: "+authURL)
-		return authURL
+		sort.SliceStable(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
 	}
-	return authURL
+	// Favorites first, in the order of the chosen sort
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Favorite != "" && out[j].Favorite == "" })
+	return out
 }
 
-func emitCloudAuthError(ctx context.Context, msg string) {
-	if ctx != nil {
-		runtime.EventsEmit(ctx, "cloud-auth-error", msg)
-	}
-}
+// ServerFavoritePrefix marks a server reference as a favorite's short name (e.g. "@survival").
+const ServerFavoritePrefix = "@"
 
-// cloudAuthCallbackHTML returns HTML for the callback page in QMWeb style (dark theme, Minecraft fonts).
-func cloudAuthCallbackHTML(title, message, footer string, isError bool) string {
+var (
+	serverFavoriteNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+	serverFavoriteSlugRe = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// ServerFavorite is a bookmarked server: a QMServer Cloud server profile or any address.
+type ServerFavorite struct {
+	Name       string `json:"name"` // short name, referenced as "@name"
+	ServerID   uint   `json:"serverId,omitempty"`
+	Address    string `json:"address"` // host:port
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// serverFavorites reads settings.json "server_favorites".
+func serverFavorites() []ServerFavorite {
+	favorites := []ServerFavorite{}
+	raw, ok := readLauncherSettingsMap()["server_favorites"]
+	if !ok {
+		return favorites
+	}
+	data, _ := json.Marshal(raw)
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		logMessage(fmt.Sprintf("[Servers] Некорректный список избранного: %v", err))
+		return []ServerFavorite{}
+	}
+	return favorites
+}
+
+func writeServerFavorites(favorites []ServerFavorite) error {
+	var value interface{}
+	if len(favorites) > 0 {
+		value = favorites
+	}
+	return setLauncherSetting("server_favorites", value)
+}
+
+// findServerFavorite looks a favorite up by short name, with or without "@".
+func findServerFavorite(name string) (ServerFavorite, bool) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), ServerFavoritePrefix))
+	for _, f := range serverFavorites() {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return ServerFavorite{}, false
+}
+
+// GetServerFavorites lists the favorite servers.
+func (a *App) GetServerFavorites() []ServerFavorite {
+	return serverFavorites()
+}
+
+// AddServerFavorite bookmarks a server under a short name (1-32 lowercase letters, digits, '-' or '_';
+// "" = derived from the server name). server is a QMServer Cloud server ID or name, or any host:port.
+// Favorites come first in GetServers and can be launched as "@name". Returns error string on failure.
+func (a *App) AddServerFavorite(server, name string) string {
+	server = strings.TrimSpace(server)
+	fav := ServerFavorite{}
+	if s, err := findQMServer(server); err == nil {
+		fav.ServerID, fav.ServerName = s.ID, s.Name
+		fav.Address = net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	} else if addr, perr := serverping.NormalizeAddress(server); perr == nil && strings.ContainsAny(server, ".:") {
+		fav.Address = addr
+	} else {
+		return "Error: " + err.Error()
+	}
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), ServerFavoritePrefix))
+	if name == "" {
+		base := fav.ServerName
+		if base == "" {
+			base, _, _ = serverping.SplitAddress(fav.Address)
+		}
+		name = strings.Trim(serverFavoriteSlugRe.ReplaceAllString(strings.ToLower(base), "-"), "-")
+		if len(name) > 32 {
+			name = strings.Trim(name[:32], "-")
+		}
+	}
+	if !serverFavoriteNameRe.MatchString(name) {
+		return fmt.Sprintf("Error: invalid favorite name %q: use 1-32 lowercase letters, digits, '-' or '_'", name)
+	}
+	fav.Name = name
+	favorites := serverFavorites()
+	replaced := false
+	for i := range favorites {
+		if favorites[i].Name == name {
+			favorites[i], replaced = fav, true
+		}
+	}
+	if !replaced {
+		favorites = append(favorites, fav)
+	}
+	if err := writeServerFavorites(favorites); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// RemoveServerFavorite deletes a favorite by short name; a missing favorite is not an error.
+func (a *App) RemoveServerFavorite(name string) string {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), ServerFavoritePrefix))
+	favorites := slices.DeleteFunc(serverFavorites(), func(f ServerFavorite) bool { return f.Name == name })
+	if err := writeServerFavorites(favorites); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// ServerListReport is the JSON form of GetServers.
+type ServerListReport struct {
+	Count   int               `json:"count"`
+	Options ServerListOptions `json:"options"`
+	Servers []ServerInfo      `json:"servers"`
+	Error   string            `json:"error,omitempty"` // servers could not be loaded
+}
+
+// GetServersJSON returns GetServers (filtered, sorted, with ping data when requested) as indented JSON
+// for scripts and other frontends.
+func (a *App) GetServersJSON(opts ServerListOptions) string {
+	servers := a.GetServers(opts)
+	data, err := json.MarshalIndent(ServerListReport{Count: len(servers), Options: opts, Servers: servers, Error: lastQMError}, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return string(data)
+}
+
+// LaunchInstance launches an instance with optional server connection - exact copy of TUI launchInstance
+// syncConfigFromServer: when true and serverID > 0, sync config/ and options.txt from QMServer Cloud (overwrite local)
+func (a *App) LaunchInstance(instanceName string, serverAddress string, serverID uint, syncConfigFromServer bool) string {
+	return a.LaunchInstanceWithAccount(instanceName, serverAddress, serverID, syncConfigFromServer, "", "", "", "")
+}
+
+// LaunchInstanceWithAccount launches an instance with a specific account selected
+// selectedAccountUsername: username of the selected account (can be local, cloud_game, or microsoft)
+// disabledModsJSON: optional JSON array of mod paths to exclude from sync (e.g. ["mods/sodium.jar"])
+// enabledResourcepacksOrderJSON: optional JSON array of resourcepack paths in load order (from QMAdmin load_order)
+// serverName: display name for servers.dat when connecting to server (optional)
+func (a *App) LaunchInstanceWithAccount(instanceName string, serverAddress string, serverID uint, syncConfigFromServer bool, selectedAccountUsername string, disabledModsJSON string, enabledResourcepacksOrderJSON string, serverName string) string {
+	// Fetch instance
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		return fmt.Sprintf("Error: Instance '%s' not found: %v", instanceName, err)
+	}
+
+	if err := network.CheckServerProfileConnectAllowed(serverID); err != nil {
+		if errors.Is(err, network.ErrServerProfileDisabled) {
+			return "Error: " + i18n.Translate("ui.server.disabled_error")
+		}
+	}
+
+	// Call internal launchInstance function with selected account
+	err = a.launchInstance(inst, serverAddress, serverID, syncConfigFromServer, selectedAccountUsername, disabledModsJSON, enabledResourcepacksOrderJSON, serverName)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	return fmt.Sprintf("Success: Launched %s", instanceName)
+}
+
+// launchInstance is exact copy of TUI launchInstance function
+// Note: This function needs access to App context for events, so it's now a method
+// syncConfigFromServer: when true and serverID > 0, sync only config/ and options.txt from QMServer (overwrite)
+// selectedAccountUsername: if not empty, use this specific account instead of default
+// disabledModsJSON: JSON array of mod paths to exclude from sync and remove from local instance
+// enabledResourcepacksOrderJSON: optional JSON array of resourcepack paths in load order for options.txt
+func (a *App) launchInstance(inst launcher.Instance, serverAddress string, serverID uint, syncConfigFromServer bool, selectedAccountUsername string, disabledModsJSON string, enabledResourcepacksOrderJSON string, serverName string) error {
+	// "@name" refers to a favorite server
+	if strings.HasPrefix(serverAddress, ServerFavoritePrefix) {
+		fav, ok := findServerFavorite(serverAddress)
+		if !ok {
+			return fmt.Errorf("неизвестный избранный сервер %s", serverAddress)
+		}
+		serverAddress = fav.Address
+		if serverID == 0 {
+			serverID = fav.ServerID
+		}
+		if serverName == "" {
+			serverName = fav.ServerName
+		}
+	}
+	logMessage(fmt.Sprintf("=== Запуск инстанса: %s (serverID: %d) ===", inst.Name, serverID))
+	// Mods of a server on another loader or game version cannot load: refuse instead of syncing them
+	if inst.Config.IsUsingQMServerCloud && serverID > 0 {
+		if m := cloudServerMismatch(inst, serverID); m != nil {
+			logMessage("Несовместимая сборка: " + m.Message)
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":     "server-mismatch",
+				"message":  m.Message,
+				"mismatch": m,
+			})
+			return errors.New(m.Message)
+		}
+	}
+	if serverAddress != "" {
+		addr, err := serverping.NormalizeAddress(serverAddress)
+		if err != nil {
+			return err
+		}
+		serverAddress = addr
+		logMessage(fmt.Sprintf("Автоподключение к серверу: %s", serverAddress))
+	}
+
+	// Initialize logging
+	logMessage("Инициализация логирования")
+	if err := initLogging(inst.Name); err != nil {
+		logMessage(fmt.Sprintf("Не удалось инициализировать логирование: %v", err))
+	}
+
+	logMessage(fmt.Sprintf("Запуск инстанса: %s", inst.Name))
+
+	// Per-instance binding: an "@alias" stored as the instance's last user is used when nothing is selected
+	if selectedAccountUsername == "" && strings.HasPrefix(inst.Config.LastUser, auth.AliasPrefix) {
+		selectedAccountUsername = inst.Config.LastUser
+	}
+	if resolved, err := auth.ResolveAccount(selectedAccountUsername); err != nil {
+		return err
+	} else if resolved != selectedAccountUsername {
+		logMessage(fmt.Sprintf("Алиас %s → %s", selectedAccountUsername, resolved))
+		selectedAccountUsername = resolved
+	}
+
+	// Require specific account selection - no default accounts allowed when connecting to server
+	if serverAddress != "" && selectedAccountUsername == "" {
+		return fmt.Errorf("необходимо выбрать игровой аккаунт для подключения к серверу")
+	}
+
+	for _, w := range authExpiryWarnings() {
+		if selectedAccountUsername != "" && selectedAccountUsername != w.Name {
+			continue
+		}
+		logMessage(fmt.Sprintf("Предупреждение: %s. %s", w.Message, w.Tip))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "warning",
+			"message": w.Message,
+			"tip":     w.Tip,
+		})
+	}
+
+	// Resolve session: if selectedAccountUsername is provided, use it; otherwise use default priority
+	var session auth.Session
+	var cloudSkinURL, cloudCapeURL string
+
+	if selectedAccountUsername != "" {
+		// User selected a specific account
+		logMessage(fmt.Sprintf("Выбран конкретный аккаунт: %s", selectedAccountUsername))
+
+		// Check if it's a Microsoft account
+		if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username == selectedAccountUsername {
+			if sess, err := auth.Authenticate(); err == nil {
+				session = sess
+				logMessage(fmt.Sprintf("Использование выбранного аккаунта Microsoft: %s", session.Username))
+			}
+		}
+
+		// Check if it's a Cloud game account, or the cloud account itself (an alias to its email) whose
+		// first game account is used
+		if session.Username == "" {
+			if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
+				byEmail := strings.EqualFold(strings.TrimSpace(cloudAcc.Email), selectedAccountUsername)
+				gas, _ := a.GetCloudGameAccounts()
+				for _, ga := range gas {
+					if ga.Username == selectedAccountUsername || byEmail {
+						// Use ServerUUID for --uuid so Minecraft server sees the correct UUID (inventory sync)
+						launchUUID := ga.ServerUUID
+						if launchUUID == "" {
+							launchUUID = ga.UUID
+						}
+						session = auth.Session{
+							Username:    ga.Username,
+							AccessToken: "",
+							UUID:        launchUUID,
+						}
+						cloudSkinURL = ga.SkinURL
+						cloudCapeURL = ga.CapeURL
+						logMessage(fmt.Sprintf("Использование выбранного аккаунта QMServer Cloud: %s", session.Username))
+						break
+					}
+				}
+			}
+		}
+
+		// Check if it's a local account
+		if session.Username == "" {
+			for _, acc := range auth.LocalStore.Accounts {
+				if acc.Name == selectedAccountUsername {
+					session = auth.Session{
+						Username:    acc.Name,
+						AccessToken: "",
+						UUID:        acc.UUID,
+					}
+					logMessage(fmt.Sprintf("Использование выбранного локального аккаунта: %s", session.Username))
+					break
+				}
+			}
+		}
+
+		if session.Username == "" {
+			return fmt.Errorf("выбранный аккаунт '%s' не найден", selectedAccountUsername)
+		}
+	} else {
+		// No specific account selected, use default priority: Microsoft > Cloud > Local
+
+		// 1. Microsoft account (if exists and active)
+		if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username != "" {
+			if sess, err := auth.Authenticate(); err == nil {
+				session = sess
+				logMessage(fmt.Sprintf("Использование аккаунта Microsoft: %s", session.Username))
+			}
+		}
+
+		// 2. QMServer Cloud account (if no Microsoft session)
+		if session.Username == "" {
+			if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
+				gas, _ := a.GetCloudGameAccounts()
+				if len(gas) > 0 {
+					ga := gas[0]
+					// Use ServerUUID for --uuid so Minecraft server sees the correct UUID (inventory sync)
+					launchUUID := ga.ServerUUID
+					if launchUUID == "" {
+						launchUUID = ga.UUID
+					}
+					session = auth.Session{
+						Username:    ga.Username,
+						AccessToken: "",
+						UUID:        launchUUID,
+					}
+					cloudSkinURL = ga.SkinURL
+					cloudCapeURL = ga.CapeURL
+					logMessage(fmt.Sprintf("Использование аккаунта QMServer Cloud: %s", session.Username))
+				}
+			}
+		}
+
+		// 3. Local account (if no Microsoft/Cloud)
+		if session.Username == "" {
+			defaultAccountName := auth.LocalStore.GetDefaultAccount()
+			if defaultAccountName == "" && len(auth.LocalStore.Accounts) > 0 {
+				defaultAccountName = auth.LocalStore.Accounts[0].Name
+				auth.LocalStore.SetDefaultAccount(defaultAccountName)
+			}
+			if defaultAccountName == "" {
+				return fmt.Errorf("нет доступных аккаунтов, создайте аккаунт в разделе 'Аккаунты'")
+			}
+			var account *auth.LocalAccount
+			for _, acc := range auth.LocalStore.Accounts {
+				if acc.Name == defaultAccountName {
+					account = &acc
+					break
+				}
+			}
+			if account == nil {
+				// DefaultAccount may point to removed/synced account — clear invalid default
+				auth.LocalStore.ClearDefaultIfInvalid()
+				return fmt.Errorf("аккаунт '%s' не найден. Выберите аккаунт в разделе 'Аккаунты'", defaultAccountName)
+			}
+			session = auth.Session{
+				Username:    account.Name,
+				AccessToken: "",
+				UUID:        account.UUID,
+			}
+			logMessage(fmt.Sprintf("Использование локального аккаунта: %s", session.Username))
+		}
+	}
+
+	// Prepare launch options - use full instance config like CLI does
+	options := launcher.LaunchOptions{
+		Session:        session,
+		InstanceConfig: inst.Config, // Use full instance config like CLI
+		// Используем javaw на Windows (NoJavaWindow=true), чтобы не открывалось отдельное консольное окно
+		NoJavaWindow:       true,
+		Demo:               false, // Default values like CLI
+		DisableMultiplayer: false,
+		DisableChat:        false,
+		SkinURL:            cloudSkinURL,
+		CapeURL:            cloudCapeURL,
+	}
+
+	options.JavaArgTemplates = javaArgTemplatesSetting()
+
+	// Instance without its own Java: use the launcher-wide default runtime, if any
+	if options.Java == "" {
+		if ref := defaultJavaSetting(); ref != "" {
+			if java, err := launcher.ResolveJavaRuntime(ref); err == nil {
+				options.Java = java
+				logMessage(fmt.Sprintf("Java по умолчанию: %s", java))
+			} else {
+				logMessage(fmt.Sprintf("Java по умолчанию недоступна (%v), используется Java от Mojang", err))
+			}
+		}
+	}
+
+	// Set server for auto-connect if specified
+	if serverAddress != "" {
+		options.QuickPlayServer = resolveServerAddress(serverAddress)
+		if options.QuickPlayServer != serverAddress {
+			logMessage(fmt.Sprintf("SRV-запись %s → %s", serverAddress, options.QuickPlayServer))
+		}
+		logMessage(fmt.Sprintf("Автоматическое подключение к серверу: %s", options.QuickPlayServer))
+	}
+
+	logMessage(fmt.Sprintf("Подготовка опций запуска для пользователя: %s", session.Username))
+
+	// Sync config and options.txt from QMServer when user requested (checkbox in account picker)
+	// Sync only to the selected account's directory (per-account isolation)
+	if syncConfigFromServer && serverID > 0 {
+		logMessage(fmt.Sprintf("Запрошена синхронизация конфигурации с сервера (serverID=%d) для аккаунта %s", serverID, session.Username))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "sync-start",
+			"message": "Синхронизация конфигурации с QMServer Cloud...",
+		})
+		if err := syncConfigFromQMServer(inst, serverID, session.UUID); err != nil {
+			logMessage(fmt.Sprintf("Ошибка синхронизации конфигурации: %v", err))
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "sync-error",
+				"message": fmt.Sprintf("Ошибка синхронизации конфигурации: %v", err),
+			})
+		} else {
+			logMessage("Синхронизация конфигурации с QMServer Cloud завершена")
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "sync-complete",
+				"message": "Синхронизация конфигурации завершена",
+			})
+		}
+	}
+
+	// Sync files with QMServer Cloud if this instance uses it (full manifest sync, e.g. mods)
+	qmHost, qmPort := instanceQMServer(inst)
+	logMessage(fmt.Sprintf("Проверка условий синхронизации: IsUsingQMServerCloud=%v, QMServer='%s:%d', serverID=%d",
+		inst.Config.IsUsingQMServerCloud, qmHost, qmPort, serverID))
+	logMessage(fmt.Sprintf("Инстанс %s: IsUsingQMServerCloud=%v", inst.Name, inst.Config.IsUsingQMServerCloud))
+	if inst.Config.IsUsingQMServerCloud && serverID > 0 {
+		logMessage(fmt.Sprintf("Обнаружена QMServer Cloud конфигурация для инстанса %s", inst.Name))
+		logMessage(fmt.Sprintf("QMServer: %s:%d, ServerID: %d", qmHost, qmPort, serverID))
+
+		// Upload local skins/capes to QMServer before sync (for distribution to other users)
+		if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
+			_ = network.UploadLocalSkinsToQMServer(inst.Dir(), qmHost, qmPort, cloudAcc.Token, logMessage)
+		}
+
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "sync-start",
+			"message": "Синхронизация с QMServer Cloud...",
+		})
+
+		var disabledMods []string
+		if disabledModsJSON != "" {
+			_ = json.Unmarshal([]byte(disabledModsJSON), &disabledMods)
+		}
+		emitSync := func(phase, msg, file string, pct float64) {
+			if phase == "warning" {
+				runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+					"type":    "warning",
+					"message": msg,
+				})
+				return
+			}
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":        "sync-progress",
+				"phase":       phase,
+				"message":     msg,
+				"currentFile": file,
+				"progress":    pct,
+			})
+		}
+		if summary, err := syncQMServerFiles(inst, serverID, disabledMods, cloudSyncOptions{}, emitSync); err != nil {
+			logMessage(fmt.Sprintf("Ошибка синхронизации с QMServer Cloud: %v", err))
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "sync-error",
+				"message": fmt.Sprintf("Ошибка синхронизации: %v", err),
+			})
+		} else {
+			logMessage("Синхронизация с QMServer Cloud завершена успешно")
+			// Обновить servers.dat — добавить/обновить сервер для быстрого доступа в Minecraft
+			if serverAddress != "" {
+				name := serverName
+				if name == "" {
+					name = serverAddress
+				}
+				if err := serversdat.UpdateOrAddServer(inst.Dir(), name, serverAddress); err != nil {
+					logMessage(fmt.Sprintf("Не удалось обновить servers.dat: %v", err))
+				} else {
+					logMessage("servers.dat обновлён")
+				}
+			}
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "sync-complete",
+				"message": "Синхронизация завершена",
+				"summary": summary,
+			})
+		}
+	}
+
+	logMessage("Начало загрузки Minecraft и компонентов")
+
+	runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+		"type":    "prepare-start",
+		"message": "Начало загрузки Minecraft и компонентов",
+	})
+
+	// Prepare launch environment
+	// Create progress watcher for GUI - sends events to frontend
+	watcher := func(event any) {
+		switch e := event.(type) {
+		case launcher.DownloadingEvent:
+			if e.Total > 0 {
+				progress := float64(e.Completed) / float64(e.Total) * 100
+				logMessage(fmt.Sprintf("Загрузка: %d/%d (%.1f%%)", e.Completed, e.Total, progress))
+				// Send progress event to frontend
+				runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+					"type":      "downloading",
+					"completed": e.Completed,
+					"total":     e.Total,
+					"progress":  progress,
+					"message":   fmt.Sprintf("Загрузка: %d/%d (%.1f%%)", e.Completed, e.Total, progress),
+				})
+				if e.Completed >= e.Total {
+					logMessage("Загрузка Minecraft завершена")
+					runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+						"type":    "downloading-complete",
+						"message": "Загрузка завершена",
+					})
+				}
+			}
+		case launcher.AssetsResolvedEvent:
+			logMessage(fmt.Sprintf("Ассеты обработаны: %d", e.Total))
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "assets-resolved",
+				"total":   e.Total,
+				"message": fmt.Sprintf("Ассеты обработаны: %d", e.Total),
+			})
+		case launcher.LibrariesResolvedEvent:
+			logMessage(fmt.Sprintf("Библиотеки обработаны: %d", e.Total))
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "libraries-resolved",
+				"total":   e.Total,
+				"message": fmt.Sprintf("Библиотеки обработаны: %d", e.Total),
+			})
+		case launcher.MetadataResolvedEvent:
+			logMessage("Метаданные Minecraft разрешены")
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "metadata-resolved",
+				"message": "Метаданные Minecraft разрешены",
+			})
+		case launcher.JavaMismatchEvent:
+			msg := fmt.Sprintf("Для этой версии Minecraft нужна Java %d, а в сборке указана Java %d (%s). Игра может не запуститься", e.Required, e.Found, e.Path)
+			logMessage(msg)
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":     "warning",
+				"message":  msg,
+				"required": e.Required,
+				"found":    e.Found,
+			})
+		case launcher.JavaRosettaEvent:
+			msg := fmt.Sprintf("Java %s собрана для x86_64 и работает через Rosetta 2 — игра будет медленнее. Установите Java для arm64 (aarch64)", e.Path)
+			logMessage(msg)
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "warning",
+				"message": msg,
+			})
+		case launcher.JavaInstallingEvent:
+			progress := 0.0
+			if e.Total > 0 {
+				progress = float64(e.Done) / float64(e.Total) * 100
+			}
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":     "java-installing",
+				"phase":    e.Phase,
+				"progress": progress,
+				"message":  fmt.Sprintf("Установка Java %d...", e.Major),
+			})
+		case launcher.PostProcessingEvent:
+			logMessage("Начата пост-обработка (Forge/Minecraft)")
+			runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+				"type":    "post-processing",
+				"message": "Пост-обработка (Forge/Minecraft)",
+			})
+		}
+	}
+	launchEnv, err := launcher.Prepare(inst, options, watcher)
+	if err != nil {
+		logMessage(fmt.Sprintf("Ошибка подготовки инстанса: %v", err))
+		return fmt.Errorf("failed to prepare instance: %w", err)
+	}
+
+	logMessage("Подготовка завершена успешно")
+
+	for _, issue := range findModIssues(&inst) {
+		logMessage(fmt.Sprintf("[Mods] Предупреждение: %s", issue.Message))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "warning",
+			"message": issue.Message,
+		})
+	}
+
+	// Apply selected resource packs to options.txt so they are enabled automatically in-game
+	var rpOrder []string
+	if enabledResourcepacksOrderJSON != "" {
+		_ = json.Unmarshal([]byte(enabledResourcepacksOrderJSON), &rpOrder)
+	}
+	if err := launcher.ApplyResourcePacksToOptions(launchEnv.GameDir, rpOrder); err != nil {
+		logMessage(fmt.Sprintf("[ResourcePacks] Не удалось обновить options.txt: %v", err))
+	}
+
+	runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+		"type":    "prepare-complete",
+		"message": "Подготовка завершена, запуск Minecraft...",
+	})
+
+	// Launch the instance in background (don't wait for completion)
+	logMessage("Запуск Minecraft...")
+	var gameCmd *exec.Cmd
+	err = launcher.Launch(launchEnv, func(cmd *exec.Cmd) error {
+		gameCmd = cmd
+		return cmd.Start() // Start in background, don't wait
+	})
+
+	if err != nil {
+		logMessage(fmt.Sprintf("Ошибка запуска: %v", err))
+		runtime.EventsEmit(a.ctx, "launch-error", map[string]interface{}{
+			"error": fmt.Sprintf("Ошибка запуска: %v", err),
+		})
+		return fmt.Errorf("failed to launch instance: %w", err)
+	}
+
+	logMessage("Minecraft запущен успешно")
+	if serverAddress != "" && inst.Config.LastServer != serverAddress {
+		inst.Config.LastServer = serverAddress
+		if err := inst.WriteConfig(); err != nil {
+			logMessage(fmt.Sprintf("Не удалось сохранить last_server: %v", err))
+		}
+	}
+	if serverAddress != "" {
+		go recordRecentConnection(launcher.RecentConnection{
+			Instance:   inst.Name,
+			Server:     serverAddress,
+			ServerID:   serverID,
+			ServerName: serverName,
+			Account:    selectedAccountUsername,
+		})
+	}
+	if inst.Config.IsUsingQMServerCloud && serverID > 0 {
+		go a.watchCloudManifest(inst, serverID, gameCmd)
+	}
+	logMessage(fmt.Sprintf("=== Завершение запуска инстанса: %s ===", inst.Name))
+	return nil
+}
+
+// serverResolveTimeout bounds the SRV lookup of a server address.
+const serverResolveTimeout = 3 * time.Second
+
+// resolveServerAddress returns the host:port the game connects to for addr, following its
+// _minecraft._tcp SRV record. An address that cannot be resolved is returned unchanged.
+func resolveServerAddress(addr string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), serverResolveTimeout)
+	defer cancel()
+	host, port, err := serverping.Resolve(ctx, addr)
+	if err != nil {
+		return addr
+	}
+	if resolved := serverping.JoinAddress(host, port); !serverping.SameAddress(resolved, addr) {
+		return resolved
+	}
+	return addr
+}
+
+// serverAddressResolver remembers SRV resolutions for the duration of one lookup, so matching an address
+// against a list resolves every distinct address once.
+type serverAddressResolver map[string]string
+
+func (r serverAddressResolver) resolve(addr string) string {
+	if resolved, ok := r[addr]; ok {
+		return resolved
+	}
+	resolved := resolveServerAddress(addr)
+	r[addr] = resolved
+	return resolved
+}
+
+// same reports whether two server addresses reach the same server: equal host and port (25565 by
+// default), or the same host:port after SRV resolution. Callers matching a list compare the literal
+// addresses of every entry (serverping.SameAddress) before resolving any.
+func (r serverAddressResolver) same(a, b string) bool {
+	if serverping.SameAddress(a, b) {
+		return true
+	}
+	return serverping.SameAddress(r.resolve(a), r.resolve(b))
+}
+
+// findQMServer finds a server profile by ID or name: an exact (case-insensitive) name first, then a
+// unique name prefix.
+func findQMServer(query string) (network.QMServerInfo, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return network.QMServerInfo{}, fmt.Errorf("server ID or name is required")
+	}
+	resp, err := network.GetQMServersList()
+	if err != nil {
+		return network.QMServerInfo{}, err
+	}
+	if id, err := strconv.ParseUint(query, 10, 32); err == nil {
+		for _, s := range resp.ServerProfiles {
+			if s.ID == uint(id) {
+				return s, nil
+			}
+		}
+	}
+	var prefixed []network.QMServerInfo
+	for _, s := range resp.ServerProfiles {
+		if strings.EqualFold(s.Name, query) {
+			return s, nil
+		}
+		if strings.HasPrefix(strings.ToLower(s.Name), strings.ToLower(query)) {
+			prefixed = append(prefixed, s)
+		}
+	}
+	switch len(prefixed) {
+	case 0:
+		return network.QMServerInfo{}, fmt.Errorf("server %q not found", query)
+	case 1:
+		return prefixed[0], nil
+	}
+	names := make([]string, len(prefixed))
+	for i, s := range prefixed {
+		names[i] = fmt.Sprintf("%s (%d)", s.Name, s.ID)
+	}
+	return network.QMServerInfo{}, fmt.Errorf("server %q is ambiguous: %s", query, strings.Join(names, ", "))
+}
+
+// JoinServer is the one-step path onto a QMServer Cloud server, by ID or name: it creates (or updates) an
+// instance matching the server's game version and mod loader, syncs its files and launches the game
+// connected to the server with accountUsername ("" = default account). Returns the result of
+// LaunchInstanceWithAccount ("Error: ..." on failure).
+func (a *App) JoinServer(server, accountUsername string) string {
+	s, err := findQMServer(server)
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	if !network.QMServerProfileEnabled(s) {
+		return "Error: " + i18n.Translate("ui.server.disabled_error")
+	}
+	if s.GameServerOnline != nil && !*s.GameServerOnline {
+		return "Error: " + i18n.Translate("ui.game_server.process_offline_detail")
+	}
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	version, loader, loaderVersion := s.Version, s.ModLoader, s.ModLoaderVersion
+	if version == "" {
+		version = "release"
+	}
+	if loader == "" {
+		loader = "vanilla"
+	}
+	if loaderVersion == "" {
+		loaderVersion = "latest"
+	}
+	logMessage(fmt.Sprintf("[Join] %s (%d): %s, %s %s", s.Name, s.ID, version, loader, loaderVersion))
+	instanceName := a.EnsureInstanceForServer(s.Name, address, version, loader, loaderVersion, s.ID)
+	if strings.HasPrefix(instanceName, "Error") {
+		return instanceName
+	}
+	return a.LaunchInstanceWithAccount(instanceName, address, s.ID, false, strings.TrimSpace(accountUsername), "", "", s.Name)
+}
+
+// ServerDetails is the full profile of one QMServer Cloud server.
+type ServerDetails struct {
+	Server          ServerInfo `json:"server"`
+	UUID            string     `json:"uuid,omitempty"`
+	GameKind        string     `json:"gameKind,omitempty"`
+	CreatedAt       string     `json:"createdAt,omitempty"`
+	UpdatedAt       string     `json:"updatedAt,omitempty"`
+	ManifestFiles   int        `json:"manifestFiles"`
+	ManifestBytes   int64      `json:"manifestBytes"`
+	ManifestVersion int        `json:"manifestVersion,omitempty"`
+	ManifestUpdated int64      `json:"manifestUpdated,omitempty"` // unix seconds, when the server generated it
+	ManifestError   string     `json:"manifestError,omitempty"`
+	ManifestSigned  bool       `json:"manifestSigned"` // the profile publishes a key and sync requires signed manifests
+	LinkedInstances []string   `json:"linkedInstances"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// GetServerDetails returns one server's full profile by ID or name: address, versions, mod loader,
+// premium status, the size and file count of its cloud files with the time the manifest was generated,
+// and the local instances linked to it (created for it or last connected to it).
+func (a *App) GetServerDetails(server string) ServerDetails {
+	s, err := findQMServer(server)
+	if err != nil {
+		return ServerDetails{LinkedInstances: []string{}, Error: err.Error()}
+	}
+	d := ServerDetails{
+		Server:          serverInfoFromQM(s),
+		UUID:            s.UUID,
+		GameKind:        s.GameKind,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+		ManifestSigned:  s.ManifestKey != "",
+		LinkedInstances: []string{},
+	}
+	for _, f := range serverFavorites() {
+		if f.ServerID == s.ID {
+			d.Server.Favorite = f.Name
+		}
+	}
+
+	host, port := qmServerEndpoint()
+	if manifest, err := downloadDataManifest(s.ID, host, port); err != nil {
+		d.ManifestError = err.Error()
+	} else {
+		d.ManifestFiles = len(manifest.Files)
+		for _, f := range manifest.Files {
+			d.ManifestBytes += f.Size
+		}
+		d.ManifestVersion = manifest.Version
+		d.ManifestUpdated = manifest.Generated
+	}
+
+	address := serverping.JoinAddress(s.Host, s.Port)
+	instances, _ := launcher.FetchAllInstances()
+	resolver := serverAddressResolver{}
+	for _, inst := range instances {
+		if inst.Name == launcher.SanitizeInstanceName(s.Name) || (inst.Config.LastServer != "" && resolver.same(inst.Config.LastServer, address)) {
+			d.LinkedInstances = append(d.LinkedInstances, inst.Name)
+		}
+	}
+	return d
+}
+
+// recordRecentConnection adds a launch to the recent connections, naming the server after its
+// favorite or QMServer Cloud profile when the launch didn't name it.
+func recordRecentConnection(c launcher.RecentConnection) {
+	if c.ServerName == "" {
+		c.ServerName = serverDisplayName(c.Server, c.ServerID)
+	}
+	if err := launcher.RecordRecentConnection(c, recentConnectionsPolicy()); err != nil {
+		logMessage(fmt.Sprintf("Не удалось сохранить недавнее подключение: %v", err))
+	}
+}
+
+// serverDisplayName returns the name of the server at address (or with serverID) from the favorites or
+// the QMServer Cloud server list, or "".
+// Literal matches are looked for in both lists before any address is SRV-resolved.
+func serverDisplayName(address string, serverID uint) string {
+	type candidate struct {
+		name, address string
+		id            uint
+	}
+	var candidates []candidate
+	for _, f := range serverFavorites() {
+		if f.ServerName != "" {
+			candidates = append(candidates, candidate{f.ServerName, f.Address, f.ServerID})
+		}
+	}
+	if resp, err := network.GetQMServersList(); err == nil {
+		for _, s := range resp.ServerProfiles {
+			candidates = append(candidates, candidate{s.Name, serverping.JoinAddress(s.Host, s.Port), s.ID})
+		}
+	}
+	for _, c := range candidates {
+		if (serverID != 0 && c.id == serverID) || serverping.SameAddress(c.address, address) {
+			return c.name
+		}
+	}
+	resolver := serverAddressResolver{}
+	for _, c := range candidates {
+		if resolver.same(c.address, address) {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// recentConnectionsPolicy reads the recent connections rules from settings.json:
+// "recent_connections_max", "recent_connections_dedupe" and "recent_connections_keep_pinned".
+func recentConnectionsPolicy() launcher.RecentConnectionsPolicy {
+	cfg := readLauncherSettingsMap()
+	p := launcher.DefaultRecentConnectionsPolicy()
+	if n, ok := cfg["recent_connections_max"].(float64); ok && n != 0 {
+		p.Max = int(n)
+	}
+	if d, _ := cfg["recent_connections_dedupe"].(string); d != "" && launcher.ValidateRecentDedupe(d) == nil {
+		p.Dedupe = d
+	}
+	p.KeepPinned = parseBoolish(cfg["recent_connections_keep_pinned"], true)
+	return p
+}
+
+// GetRecentConnectionsPolicy returns how the recent connections list is deduped and capped.
+func (a *App) GetRecentConnectionsPolicy() launcher.RecentConnectionsPolicy {
+	return recentConnectionsPolicy()
+}
+
+// SetRecentConnectionsPolicy saves the recent connections rules: max entries (0 = default 20, negative =
+// no limit), dedupe ("instance_server" or "server") and whether pinned entries are kept beyond the cap.
+// The rules apply from the next recorded connection. Returns empty string on success.
+func (a *App) SetRecentConnectionsPolicy(maxEntries int, dedupe string, keepPinned bool) string {
+	dedupe = strings.TrimSpace(dedupe)
+	if err := launcher.ValidateRecentDedupe(dedupe); err != nil {
+		return "Error: " + err.Error()
+	}
+	var maxValue, dedupeValue interface{}
+	if maxEntries != 0 {
+		maxValue = maxEntries
+	}
+	if dedupe != "" {
+		dedupeValue = dedupe
+	}
+	for key, value := range map[string]interface{}{
+		"recent_connections_max":         maxValue,
+		"recent_connections_dedupe":      dedupeValue,
+		"recent_connections_keep_pinned": keepPinned,
+	} {
+		if err := setLauncherSetting(key, value); err != nil {
+			return "Error: " + err.Error()
+		}
+	}
+	return ""
+}
+
+// RecentConnectionsReport is the quick-launch list: entries are numbered from 1 in this order.
+type RecentConnectionsReport struct {
+	Connections []launcher.RecentConnection `json:"connections"`
+	Error       string                      `json:"error,omitempty"`
+}
+
+// GetRecentConnections returns the recent connections, pinned first, then newest first.
+func (a *App) GetRecentConnections() RecentConnectionsReport {
+	list, err := launcher.RecentConnections()
+	if err != nil {
+		return RecentConnectionsReport{Connections: []launcher.RecentConnection{}, Error: err.Error()}
+	}
+	return RecentConnectionsReport{Connections: list}
+}
+
+// RemoveRecentConnection deletes entry n (1-based, as listed by GetRecentConnections).
+func (a *App) RemoveRecentConnection(n int) string {
+	if err := launcher.RemoveRecentConnection(n); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// PinRecentConnection pins or unpins entry n (1-based). Pinned entries are listed first and, unless
+// the policy says otherwise, are never dropped from the list.
+func (a *App) PinRecentConnection(n int, pinned bool) string {
+	if err := launcher.PinRecentConnection(n, pinned); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// ClearRecentConnections empties the list; with keepPinned the pinned entries stay.
+func (a *App) ClearRecentConnections(keepPinned bool) string {
+	if err := launcher.ClearRecentConnections(keepPinned); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
+}
+
+// LaunchRecentConnection launches entry n (1-based) with its instance, server and account.
+func (a *App) LaunchRecentConnection(n int) string {
+	list, err := launcher.RecentConnections()
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	if n < 1 || n > len(list) {
+		return fmt.Sprintf("Error: no recent connection #%d (%d in list)", n, len(list))
+	}
+	c := list[n-1]
+	logMessage(fmt.Sprintf("[Recent] %s -> %s", c.Instance, c.Server))
+	return a.LaunchInstanceWithAccount(c.Instance, c.Server, c.ServerID, false, c.Account, "", "", c.ServerName)
+}
+
+// CloudServerMismatch describes a cloud server profile whose game version or mod loader the instance
+// cannot run, so its mods could not load.
+type CloudServerMismatch struct {
+	ServerID              uint   `json:"serverId"`
+	Server                string `json:"server"`
+	ServerVersion         string `json:"serverVersion"`
+	ServerLoader          string `json:"serverLoader"`
+	ServerLoaderVersion   string `json:"serverLoaderVersion,omitempty"`
+	InstanceVersion       string `json:"instanceVersion"`
+	InstanceLoader        string `json:"instanceLoader"`
+	InstanceLoaderVersion string `json:"instanceLoaderVersion,omitempty"`
+	Message               string `json:"message"`
+}
+
+// qmServerProfile returns a server profile from the cached server list.
+func qmServerProfile(serverID uint) (network.QMServerInfo, bool) {
+	resp, err := network.GetQMServersList()
+	if err != nil {
+		return network.QMServerInfo{}, false
+	}
+	for _, s := range resp.ServerProfiles {
+		if s.ID == serverID {
+			return s, true
+		}
+	}
+	return network.QMServerInfo{}, false
+}
+
+// cloudServerMismatch compares an instance with the game version and mod loader its cloud server
+// declares. It returns nil when they match, when the server is vanilla (any client loader can join)
+// or when the profile is unknown. Loader versions only differ in a logged warning.
+func cloudServerMismatch(inst launcher.Instance, serverID uint) *CloudServerMismatch {
+	s, ok := qmServerProfile(serverID)
+	if !ok {
+		return nil
+	}
+	loader, loaderVersion := parseServerModLoader(s.ModLoader, s.ModLoaderVersion)
+	version := strings.TrimSpace(s.Version)
+	versionDiffers := version != "" && version != "release" && version != "latest" && version != inst.GameVersion
+	loaderDiffers := loader != launcher.LoaderVanilla && loader != inst.Loader
+	if !versionDiffers && !loaderDiffers {
+		if loader != launcher.LoaderVanilla && loaderVersion != "" && loaderVersion != "latest" && !strings.HasSuffix(inst.LoaderVersion, loaderVersion) {
+			logMessage(fmt.Sprintf("[Sync] %s: версия %s на сервере %s, в сборке %s", inst.Name, loader, loaderVersion, inst.LoaderVersion))
+		}
+		return nil
+	}
+	describe := func(loader launcher.Loader, version string) string {
+		if loader == launcher.LoaderVanilla || loader == "" {
+			return version
+		}
+		return fmt.Sprintf("%s %s", loader, version)
+	}
+	return &CloudServerMismatch{
+		ServerID:              s.ID,
+		Server:                s.Name,
+		ServerVersion:         version,
+		ServerLoader:          string(loader),
+		ServerLoaderVersion:   loaderVersion,
+		InstanceVersion:       inst.GameVersion,
+		InstanceLoader:        string(inst.Loader),
+		InstanceLoaderVersion: inst.LoaderVersion,
+		Message: fmt.Sprintf("сервер %s требует %s, а сборка %s — %s; переключите сборку на версию сервера",
+			s.Name, describe(loader, version), inst.Name, describe(inst.Loader, inst.GameVersion)),
+	}
+}
+
+// AlignInstanceWithServer switches an instance to the game version and mod loader its cloud server
+// declares, so it can sync and join after a mismatch. Mods of the old loader are moved to .sync-trash
+// by the next sync. Returns empty string on success.
+func (a *App) AlignInstanceWithServer(instanceName string, serverID uint) string {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	s, ok := qmServerProfile(serverID)
+	if !ok {
+		return fmt.Sprintf("Error: server %d not found", serverID)
+	}
+	loader, loaderVersion := parseServerModLoader(s.ModLoader, s.ModLoaderVersion)
+	version := strings.TrimSpace(s.Version)
+	if version == "" {
+		version = "release"
+	}
+	if loaderVersion == "" {
+		loaderVersion = "latest"
+	} else if loader == launcher.LoaderForge {
+		loaderVersion = version + "-" + loaderVersion // Forge versions are "gameVersion-loaderVersion"
+	}
+	if err := launcher.SwitchInstanceVersion(&inst, version, loader, loaderVersion); err != nil {
+		return "Error: " + err.Error()
+	}
+	logMessage(fmt.Sprintf("[Sync] %s переключена на %s %s %s (сервер %s)", inst.Name, inst.GameVersion, inst.Loader, inst.LoaderVersion, s.Name))
+	return ""
+}
+
+// parseServerModLoader reads the mod loader of a server profile (format: "loader version" or just
+// "loader", like the TUI); unknown loaders are vanilla.
+func parseServerModLoader(modLoader, modLoaderVersion string) (launcher.Loader, string) {
+	// Combine loader and version like TUI does (modLoaderInfo format)
+	modLoaderInfo := modLoader
+	if modLoaderVersion != "" {
+		modLoaderInfo = modLoader + " " + modLoaderVersion
+	}
+	parts := strings.Fields(modLoaderInfo)
+	if len(parts) == 0 {
+		return launcher.LoaderVanilla, ""
+	}
+	var loaderType launcher.Loader
+	switch strings.ToLower(parts[0]) {
+	case "fabric":
+		loaderType = launcher.LoaderFabric
+	case "forge":
+		loaderType = launcher.LoaderForge
+	case "neoforge":
+		loaderType = launcher.LoaderNeoForge
+	case "quilt":
+		loaderType = launcher.LoaderQuilt
+	default:
+		loaderType = launcher.LoaderVanilla
+	}
+	loaderVersion := ""
+	if len(parts) >= 2 {
+		loaderVersion = parts[1]
+	}
+	return loaderType, loaderVersion
+}
+
+// EnsureInstanceForServer creates or gets instance for server - exact copy of TUI logic
+func (a *App) EnsureInstanceForServer(serverName string, serverAddress string, serverVersion string, serverModLoader string, serverModLoaderVersion string, serverID uint) string {
+	if err := network.CheckServerProfileConnectAllowed(serverID); err != nil {
+		if errors.Is(err, network.ErrServerProfileDisabled) {
+			return "Error: " + i18n.Translate("ui.server.disabled_error")
+		}
+	}
+
+	loaderType, loaderVersion := parseServerModLoader(serverModLoader, serverModLoaderVersion)
+
+	// Instance name will be sanitized automatically by CreateInstance
+	instanceName := launcher.SanitizeInstanceName(serverName)
+
+	// Validate input data
+	if serverVersion == "" {
+		return "Error: Empty game version"
+	}
+
+	// Create instance automatically with server parameters
+	// Use default config values like CLI does
+	defaultConfig := launcher.InstanceConfig{
+		WindowResolution: struct {
+			Width  int `toml:"width" json:"width"`
+			Height int `toml:"height" json:"height"`
+		}{
+			Width:  1708,
+			Height: 960,
+		},
+		MinMemory: 4096,
+		MaxMemory: 4096,
+		// QMServer Cloud configuration; the host is left empty so the launcher-wide endpoint applies
+		IsUsingQMServerCloud: true,
+	}
+
+	// For Forge, the version should be in format "gameVersion-loaderVersion" - exact copy of TUI
+	finalLoaderVersion := loaderVersion
+	if loaderType == launcher.LoaderForge && loaderVersion != "" {
+		finalLoaderVersion = serverVersion + "-" + loaderVersion
+		logMessage(fmt.Sprintf("Формируем полную версию Forge: %s", finalLoaderVersion))
+	}
+
+	options := launcher.InstanceOptions{
+		Name:          instanceName,
+		GameVersion:   serverVersion,
+		Loader:        loaderType,
+		LoaderVersion: finalLoaderVersion,
+		Config:        defaultConfig,
+	}
+
+	// First, try to find existing instance - exact copy of TUI
+	logMessage("Проверяем существование инстанса")
+	inst, err := launcher.FetchInstance(instanceName)
+	if err != nil {
+		logMessage(fmt.Sprintf("Инстанс не найден, создаём новый: %v", err))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "instance-creating",
+			"message": fmt.Sprintf("Создание инстанса '%s'...", instanceName),
+		})
+		// Instance doesn't exist, create it
+		logMessage("Вызов launcher.CreateInstance")
+		inst, err = launcher.CreateInstance(options)
+		if err != nil {
+			logMessage(fmt.Sprintf("Ошибка создания инстанса: %v", err))
+			runtime.EventsEmit(a.ctx, "launch-error", map[string]interface{}{
+				"error": fmt.Sprintf("Ошибка создания инстанса: %v", err),
+			})
+			return fmt.Sprintf("Error creating instance: %v", err)
+		}
+		logMessage(fmt.Sprintf("Инстанс '%s' создан успешно", instanceName))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "instance-created",
+			"message": fmt.Sprintf("Инстанс '%s' создан успешно", instanceName),
+		})
+	} else {
+		logMessage(fmt.Sprintf("Найден существующий инстанс: %s", instanceName))
+		runtime.EventsEmit(a.ctx, "launch-progress", map[string]interface{}{
+			"type":    "instance-found",
+			"message": fmt.Sprintf("Найден существующий инстанс '%s'", instanceName),
+		})
+		// Update existing instance config with QMServer settings if needed
+		config := inst.Config
+		configNeedsUpdate := false
+
+		if !config.IsUsingQMServerCloud {
+			config.IsUsingQMServerCloud = true
+			configNeedsUpdate = true
+			logMessage("Обновление конфигурации: включена поддержка QMServer Cloud")
+		}
+
+		// Instances created with the built-in host follow the launcher-wide endpoint; a host set by the
+		// user for this instance is kept
+		if config.QMServerHost == defaultQMServerHost && config.QMServerPort == defaultQMServerPort {
+			if host, port := qmServerEndpoint(); host != defaultQMServerHost || port != defaultQMServerPort {
+				config.QMServerHost = ""
+				config.QMServerPort = 0
+				configNeedsUpdate = true
+				logMessage("Обновление конфигурации: QMServerHost сброшен на общий адрес QMServer Cloud")
+			}
+		}
+
+		// Update loader, loader version, and game version to match server profile if needed
+		loaderNeedsUpdate := false
+
+		if inst.GameVersion != serverVersion && serverVersion != "" {
+			logMessage(fmt.Sprintf("Обновление версии игры: %s -> %s", inst.GameVersion, serverVersion))
+			inst.GameVersion = serverVersion
+			loaderNeedsUpdate = true
+		}
+
+		if inst.Loader != loaderType {
+			logMessage(fmt.Sprintf("Обновление типа загрузчика: %s -> %s", inst.Loader, loaderType))
+			inst.Loader = loaderType
+			loaderNeedsUpdate = true
+		}
+
+		// Если сервер передал конкретную версию загрузчика – синхронизируем её
+		if finalLoaderVersion != "" && inst.LoaderVersion != finalLoaderVersion {
+			logMessage(fmt.Sprintf("Обновление версии загрузчика: %s -> %s", inst.LoaderVersion, finalLoaderVersion))
+			inst.LoaderVersion = finalLoaderVersion
+			loaderNeedsUpdate = true
+		}
+
+		// Apply updates if anything changed
+		if configNeedsUpdate {
+			inst.Config = config
+		}
+
+		if configNeedsUpdate || loaderNeedsUpdate {
+			if err := inst.WriteConfig(); err != nil {
+				logMessage(fmt.Sprintf("Ошибка сохранения обновленной конфигурации инстанса: %v", err))
+			} else {
+				logMessage("Конфигурация инстанса успешно обновлена для QMServer Cloud и загрузчика")
+			}
+		}
+	}
+
+	return instanceName
+}
+
+// initLogging initializes logging to a centralized logs directory - exact copy of TUI
+func initLogging(instanceName string) error {
+	logMessage("Определение домашней директории")
+
+	// Use centralized logs directory - cross-platform home directory detection
+	var homeDir string
+	if home := os.Getenv("HOME"); home != "" {
+		homeDir = home
+		logMessage(fmt.Sprintf("Используется HOME: %s", homeDir))
+	} else if home := os.Getenv("USERPROFILE"); home != "" {
+		homeDir = home // Windows
+		logMessage(fmt.Sprintf("Используется USERPROFILE: %s", homeDir))
+	} else {
+		// Fallback - try to get home directory
+		if h, err := os.UserHomeDir(); err == nil {
+			homeDir = h
+			logMessage(fmt.Sprintf("Используется UserHomeDir: %s", homeDir))
+		} else {
+			logMessage(fmt.Sprintf("Не удалось определить домашнюю директорию: %v", err))
+			return fmt.Errorf("cannot determine home directory")
+		}
+	}
+
+	logsDir := filepath.Join(homeDir, ".qmlauncher", "logs")
+	logMessage(fmt.Sprintf("Директория для логов: %s", logsDir))
+
+	// Create logs directory if it doesn't exist
+	logMessage("Создание директории для логов")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		logMessage(fmt.Sprintf("Ошибка создания директории логов: %v", err))
+		return fmt.Errorf("failed to create logs directory: %w", err)
+	}
+	logMessage(fmt.Sprintf("Директория создана: %s", logsDir))
+
+	// Create log filename with instance name and timestamp
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	logFilename := fmt.Sprintf("qmlauncher_%s_%s.log", instanceName, timestamp)
+	logPath := filepath.Join(logsDir, logFilename)
+
+	logMessage(fmt.Sprintf("Создание файла логов: %s", logFilename))
+
+	var err error
+	logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		logMessage(fmt.Sprintf("Ошибка создания файла логов: %v", err))
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	logMessage("Файл логов создан успешно")
+
+	// Set log output to file
+	log.SetOutput(logFile)
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	logMessage(fmt.Sprintf("Инстанс: %s", instanceName))
+	return nil
+}
+
+// Translate returns translated string for the given key
+func (a *App) Translate(key string) string {
+	return i18n.Translate(key)
+}
+
+// SetLang sets the application language (ru, en). Persists to ~/.qmlauncher/settings.json. Call Translate to refresh UI.
+func (a *App) SetLang(langTag string) {
+	switch langTag {
+	case "en", "en-US", "en-GB":
+		i18n.SetLang(language.English)
+	case "ru", "ru-RU":
+		i18n.SetLang(language.Russian)
+	default:
+		i18n.SetLang(language.Russian)
+	}
+	// Persist language to settings file
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		settingsPath := filepath.Join(homeDir, ".qmlauncher", "settings.json")
+		os.MkdirAll(filepath.Dir(settingsPath), 0755)
+		var existing map[string]interface{}
+		if data, err := os.ReadFile(settingsPath); err == nil {
+			json.Unmarshal(data, &existing)
+		}
+		if existing == nil {
+			existing = make(map[string]interface{})
+		}
+		existing["language"] = i18n.GetLang()
+		if data, err := json.MarshalIndent(existing, "", "  "); err == nil {
+			os.WriteFile(settingsPath, data, 0644)
+		}
+	}
+}
+
+// GetLang returns the current language tag ("ru" or "en").
+func (a *App) GetLang() string {
+	return i18n.GetLang()
+}
+
+// GetLauncherVersion returns semver with a "v" prefix for the window title and header (e.g. v1.0.10).
+func (a *App) GetLauncherVersion() string {
+	return "v" + version
+}
+
+// LauncherAboutInfo describes the running binary for the About dialog.
+type LauncherAboutInfo struct {
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+}
+
+// GetLauncherAboutInfo returns version and platform for the About dialog.
+func (a *App) GetLauncherAboutInfo() LauncherAboutInfo {
+	return LauncherAboutInfo{
+		Version: "v" + version,
+		OS:      goruntime.GOOS,
+		Arch:    goruntime.GOARCH,
+	}
+}
+
+// CheckLauncherUpdateAvailable reports whether an update is available (QMServer distribution, GitHub binary, or QMWeb).
+func (a *App) CheckLauncherUpdateAvailable() bool {
+	return updater.QMServerDistributionUpdateAvailable(nil) ||
+		updater.GitHubBinaryUpdateAvailable() ||
+		updater.CheckForQMWebUpdate(nil)
+}
+
+// LauncherUpdateInfo is the newest GitHub release on the update channel, with the release notes of every
+// release since the running version.
+type LauncherUpdateInfo struct {
+	Available  bool   `json:"available"`
+	Current    string `json:"current"`
+	Version    string `json:"version,omitempty"`
+	Channel    string `json:"channel"`
+	ReleaseURL string `json:"releaseUrl,omitempty"`
+	Changelog  string `json:"changelog,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// GetLauncherUpdateInfo checks GitHub for a launcher update and returns its version and the combined
+// changelog of all releases that would be skipped.
+func (a *App) GetLauncherUpdateInfo() LauncherUpdateInfo {
+	report := LauncherUpdateInfo{Current: version, Channel: updater.Channel()}
+	info, err := updater.CheckGitHubRelease()
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Available = info.Available
+	report.Version = info.LatestVer
+	report.ReleaseURL = info.ReleaseURL
+	report.Changelog = info.Changelog
+	return report
+}
+
+// LauncherAPITargetSettings is read/written via ~/.qmlauncher/settings.json (use_qmserver_cloud, custom_api_base).
+type LauncherAPITargetSettings struct {
+	UseQMServerCloud bool   `json:"use_qmserver_cloud"`
+	CustomAPIBase    string `json:"custom_api_base"`
+	EffectiveAPIBase string `json:"effective_api_base"`
+}
+
+// GetLauncherAPITarget returns persisted API target fields and the effective base URL.
+func (a *App) GetLauncherAPITarget() LauncherAPITargetSettings {
+	cfg := readLauncherSettingsMap()
+	useCloud := true
+	custom := ""
+	if cfg != nil {
+		if v, ok := cfg["use_qmserver_cloud"]; ok {
+			useCloud = parseBoolish(v, true)
+		}
+		if s, ok := cfg["custom_api_base"].(string); ok {
+			custom = s
+		}
+	}
+	return LauncherAPITargetSettings{
+		UseQMServerCloud: useCloud,
+		CustomAPIBase:    custom,
+		EffectiveAPIBase: network.EffectiveQMServerAPIBase(),
+	}
+}
+
+// SetLauncherAPITarget persists API target and refreshes in-memory base. Returns empty string on success.
+func (a *App) SetLauncherAPITarget(useCloud bool, customBase string) string {
+	customBase = strings.TrimSpace(customBase)
+	if !useCloud && customBase == "" {
+		return "Укажите базовый URL API (например https://example.com/api/v1) или включите QMServer Cloud."
+	}
+	path, err := launcherSettingsPath()
+	if err != nil {
+		return err.Error()
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
+	var existing map[string]interface{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &existing)
+	}
+	if existing == nil {
+		existing = make(map[string]interface{})
+	}
+	existing["use_qmserver_cloud"] = useCloud
+	existing["custom_api_base"] = customBase
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err.Error()
+	}
+	network.ApplyLauncherAPITarget(useCloud, customBase)
+	return ""
+}
+
+// CatalogStoreSettings controls CurseForge / Modrinth availability in the resource catalog UI and API.
+type CatalogStoreSettings struct {
+	CurseforgeEnabled bool `json:"curseforge_enabled"`
+	ModrinthEnabled   bool `json:"modrinth_enabled"`
+}
+
+// setLauncherSetting writes one key of settings.json, removing it when value is nil.
+func setLauncherSetting(key string, value interface{}) error {
+	path, err := launcherSettingsPath()
+	if err != nil {
+		return err
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
+	var existing map[string]interface{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &existing)
+	}
+	if existing == nil {
+		existing = make(map[string]interface{})
+	}
+	if value == nil {
+		delete(existing, key)
+	} else {
+		existing[key] = value
+	}
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// GetCatalogStoreSettings returns catalog_curseforge_enabled and catalog_modrinth_enabled (default true if absent).
+func (a *App) GetCatalogStoreSettings() CatalogStoreSettings {
+	cfg := readLauncherSettingsMap()
+	cf := true
+	mr := true
+	if cfg != nil {
+		cf = parseBoolish(cfg["catalog_curseforge_enabled"], true)
+		mr = parseBoolish(cfg["catalog_modrinth_enabled"], true)
+	}
+	return CatalogStoreSettings{CurseforgeEnabled: cf, ModrinthEnabled: mr}
+}
+
+// SetCatalogStoreSettings persists catalog toggles and emits catalog-store-settings-changed.
+func (a *App) SetCatalogStoreSettings(curseforgeEnabled, modrinthEnabled bool) string {
+	path, err := launcherSettingsPath()
+	if err != nil {
+		return err.Error()
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
+	var existing map[string]interface{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &existing)
+	}
+	if existing == nil {
+		existing = make(map[string]interface{})
+	}
+	existing["catalog_curseforge_enabled"] = curseforgeEnabled
+	existing["catalog_modrinth_enabled"] = modrinthEnabled
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err.Error()
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "catalog-store-settings-changed", nil)
+	}
+	return ""
+}
+
+// CurseForgeKeySettings describes persisted CurseForge API key state for the UI (no key material exposed).
+type CurseForgeKeySettings struct {
+	HasEffectiveKey bool   `json:"has_effective_key"`
+	KeySavedInFile  bool   `json:"key_saved_in_file"`
+	UseMyKeyDefault bool   `json:"use_my_key_default"`
+	EffectiveSource string `json:"effective_source"`
+}
+
+// GetCurseForgeKeySettings returns whether a key is available for API calls and the source label only (no key preview).
+func (a *App) GetCurseForgeKeySettings() CurseForgeKeySettings {
+	cfg := readLauncherSettingsMap()
+	saved := false
+	useMy := false
+	if cfg != nil {
+		if s, ok := cfg["curseforge_api_key"].(string); ok && meta.NormalizeCurseForgeAPIKey(s) != "" {
+			saved = true
+		}
+		useMy = parseBoolish(cfg["curseforge_use_my_key_default"], false)
+	}
+	effective := strings.TrimSpace(meta.CurseForgeAPIKey())
+	return CurseForgeKeySettings{
+		HasEffectiveKey: effective != "",
+		KeySavedInFile:  saved,
+		UseMyKeyDefault: useMy,
+		EffectiveSource: curseForgeEffectiveKeySource(),
+	}
+}
+
+// HasCurseForgeAPIKey reports whether CurseForge Core API requests can be authenticated.
+func (a *App) HasCurseForgeAPIKey() bool {
+	return strings.TrimSpace(meta.CurseForgeAPIKey()) != ""
+}
+
+// SetCurseForgeSettingsKey persists curseforge_api_key and curseforge_use_my_key_default in ~/.qmlauncher/settings.json.
+// If clearKey is true, the stored API key is removed. If clearKey is false and apiKey is empty, the previous key is kept (only the useMyKeyDefault / prefs update).
+func (a *App) SetCurseForgeSettingsKey(apiKey string, useMyKeyDefault bool, clearKey bool) string {
+	apiKey = meta.NormalizeCurseForgeAPIKey(apiKey)
+	path, err := launcherSettingsPath()
+	if err != nil {
+		return err.Error()
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
+	var existing map[string]interface{}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &existing)
+	}
+	if existing == nil {
+		existing = make(map[string]interface{})
+	}
+	if clearKey {
+		delete(existing, "curseforge_api_key")
+	} else if apiKey != "" {
+		existing["curseforge_api_key"] = apiKey
+	}
+	existing["curseforge_use_my_key_default"] = useMyKeyDefault
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err.Error()
+	}
+	clearCurseForgeCloudKeyCache()
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "curseforge-settings-changed", nil)
+	}
+	return ""
+}
+
+// AccountInfo represents account information for frontend
+type AccountInfo struct {
+	Type          string `json:"type"` // "microsoft", "local", "cloud", "cloud_game"
+	Username      string `json:"username"`
+	Status        string `json:"status"` // "active", "expired", "none"
+	IsDefault     bool   `json:"isDefault"`
+	SkinModel     string `json:"skinModel"`     // "steve" or "alex" for local; empty for microsoft (use SkinUuid)
+	SkinUuid      string `json:"skinUuid"`      // Mojang UUID for microsoft (to fetch skin); empty for local
+	SkinURL       string `json:"skinUrl"`       // Custom skin URL (Ely.by, etc.) for cloud/local
+	Email         string `json:"email"`         // For cloud accounts - used for logout
+	GameAccountID uint   `json:"gameAccountId"` // ID of cloud_game account (for deletion)
+}
+
+// CloudGameAccountInfo represents a game account on QMServer Cloud (for skin editing)
+type CloudGameAccountInfo struct {
+	ID         uint   `json:"id"`
+	Username   string `json:"username"`
+	UUID       string `json:"uuid"`       // Internal game account UUID
+	ServerUUID string `json:"serverUuid"` // UUID that Minecraft server sees (for --uuid at launch)
+	SkinModel  string `json:"skinModel"`
+	SkinURL    string `json:"skinUrl"`
+	CapeURL    string `json:"capeUrl"`
+	MojangUUID string `json:"mojangUuid"` // For mc-heads avatar when skin_url empty
+}
+
+// shouldShowCloudGameAccountRow filters game_accounts rows that duplicate QMWeb profile or Ely.by
+// identity names. If the row is a real Mojang-linked profile (mojang_uuid set), always show it —
+// otherwise a user whose profile username equals their Minecraft nick would see no game account.
+func shouldShowCloudGameAccountRow(ga CloudGameAccountInfo, exclude map[string]bool) bool {
+	u := strings.TrimSpace(strings.ToLower(ga.Username))
+	if u == "" {
+		return false
+	}
+	if strings.TrimSpace(ga.MojangUUID) != "" {
+		return true
+	}
+	return !exclude[u]
+}
+
+// GetAccounts returns list of all accounts (Microsoft and local)
+func (a *App) GetAccounts() []AccountInfo {
+	accounts := make([]AccountInfo, 0)
+
+	// Check Microsoft account
+	if auth.Store.MSA.RefreshToken != "" {
+		status := "expired"
+		if auth.Store.Minecraft.Username != "" && auth.Store.Minecraft.Expires.After(time.Now()) {
+			status = "active"
+		}
+		accounts = append(accounts, AccountInfo{
+			Type:      "microsoft",
+			Username:  auth.Store.Minecraft.Username,
+			Status:    status,
+			IsDefault: true, // Microsoft account is always default if exists
+			SkinUuid:  auth.Store.Minecraft.UUID,
+		})
+	}
+
+	// Add local accounts
+	for _, localAccount := range auth.LocalStore.Accounts {
+		isDefault := localAccount.Name == auth.LocalStore.DefaultAccount
+		skinModel := localAccount.SkinModel
+		if skinModel != auth.SkinModelAlex {
+			skinModel = auth.SkinModelSteve
+		}
+		accounts = append(accounts, AccountInfo{
+			Type:      "local",
+			Username:  localAccount.Name,
+			Status:    "active",
+			IsDefault: isDefault,
+			SkinModel: skinModel,
+		})
+	}
+
+	// Add QMServer Cloud accounts
+	// Prefer displaying a real Minecraft nick on the Cloud row when it differs from email.
+	// Exclude profile/Ely.by-only duplicate names unless the row is Mojang-linked (see shouldShowCloudGameAccountRow).
+	cloudStore, _ := auth.ReadCloudStore()
+	var gameAccountUsername, gameAccountSkinURL, gameAccountSkinUuid string
+	if defCloud := auth.GetDefaultCloudAccount(); defCloud != nil && defCloud.Token != "" {
+		elyLinked, profileUser, elyUser, elyUserRaw := a.getCloudProfileNonGameUsernames()
+		exclude := map[string]bool{}
+		if profileUser != "" {
+			exclude[profileUser] = true
+		}
+		if elyUser != "" {
+			exclude[elyUser] = true
+		}
+		if gas, _ := a.GetCloudGameAccounts(); len(gas) > 0 {
+			for _, ga := range gas {
+				if !shouldShowCloudGameAccountRow(ga, exclude) {
+					continue
+				}
+				gameAccountUsername = ga.Username
+				gameAccountSkinURL = ga.SkinURL
+				gameAccountSkinUuid = ga.MojangUUID
+				// Fallback: if no skin_url but user has Ely.by linked, use Ely.by skin
+				if gameAccountSkinURL == "" && elyLinked && elyUserRaw != "" {
+					gameAccountSkinURL = "https://skinsystem.ely.by/skins/" + elyUserRaw + ".png"
+				}
+				break
+			}
+		}
+	}
+	for _, cloud := range cloudStore.Accounts {
+		if cloud.Token == "" {
+			continue
+		}
+		isDefault := auth.GetDefaultCloudAccount() != nil && auth.GetDefaultCloudAccount().Email == cloud.Email
+		displayName := cloud.Email // fallback: email, not profile/Ely.by username
+		skinURL := ""
+		skinUuid := ""
+		if isDefault && gameAccountUsername != "" {
+			displayName = gameAccountUsername
+			skinURL = gameAccountSkinURL
+			skinUuid = gameAccountSkinUuid
+		}
+		accounts = append(accounts, AccountInfo{
+			Type:      "cloud",
+			Username:  displayName,
+			Status:    "active",
+			IsDefault: isDefault,
+			SkinModel: auth.SkinModelSteve,
+			SkinURL:   skinURL,
+			SkinUuid:  skinUuid,
+			Email:     cloud.Email,
+		})
+	}
+
+	// Add QMServer Cloud game accounts (separate entries for deletion and management)
+	if defCloud := auth.GetDefaultCloudAccount(); defCloud != nil && defCloud.Token != "" {
+		elyLinked, profileUser, elyUser, _ := a.getCloudProfileNonGameUsernames()
+		exclude := map[string]bool{}
+		if profileUser != "" {
+			exclude[profileUser] = true
+		}
+		if elyUser != "" {
+			exclude[elyUser] = true
+		}
+		if gas, _ := a.GetCloudGameAccounts(); len(gas) > 0 {
+			for _, ga := range gas {
+				if !shouldShowCloudGameAccountRow(ga, exclude) {
+					continue
+				}
+				// Use Ely.by skin as fallback
+				skinURL := ga.SkinURL
+				if skinURL == "" && elyLinked && ga.MojangUUID != "" {
+					// Try to get Ely.by username from profile
+					if _, _, elyUsername, elyUsernameRaw := a.getCloudProfileNonGameUsernames(); elyUsername != "" {
+						skinURL = "https://skinsystem.ely.by/skins/" + elyUsernameRaw + ".png"
+					}
+				}
+				// Link UUID for UI: prefer Mojang profile id; else server/Minecraft UUID (matches Microsoft session when mojang_uuid was not stored)
+				linkUUID := strings.TrimSpace(ga.MojangUUID)
+				if linkUUID == "" {
+					linkUUID = strings.TrimSpace(ga.ServerUUID)
+				}
+				accounts = append(accounts, AccountInfo{
+					Type:          "cloud_game",
+					Username:      ga.Username,
+					Status:        "active",
+					IsDefault:     false,
+					SkinModel:     ga.SkinModel,
+					SkinURL:       skinURL,
+					SkinUuid:      linkUUID,
+					GameAccountID: ga.ID,
+				})
+			}
+		}
+	}
+
+	return accounts
+}
+
+// LoginAccount logs in to Microsoft account
+func (a *App) LoginAccount(noBrowser bool) string {
+	if network.IsMSAAuthDisabledByQMServer() {
+		return "Microsoft-вход отключён в настройках QMAdmin."
+	}
+	if id := network.GetQMLauncherMSAClientID(); id != "" {
+		auth.ClientID = id
+	} else {
+		return "Microsoft/Mojang вход недоступен."
+	}
+
+	// Try to authenticate with existing session first
+	session, err := auth.Authenticate()
+	if err == nil {
+		return fmt.Sprintf(i18n.Translate("login.complete"), session.Username)
+	}
+
+	// If no existing session, start new authentication
+	if noBrowser {
+		// Device code flow
+		resp, err := auth.FetchDeviceCode()
+		if err != nil {
+			return fmt.Sprintf("Error fetching device code: %v", err)
+		}
+		// Return device code info for user to enter
+		return fmt.Sprintf(i18n.Translate("login.code"), resp.UserCode, resp.VerificationURI)
+	} else {
+		// Browser flow: local callback server completes login and emits microsoft-auth-success/error
+		authURL := a.OpenBrowserForMicrosoft()
+		if authURL == "error" {
+			return "Error: " + i18n.Translate("login.redirectfail")
+		}
+		return i18n.Translate("login.browser") + "\n" + fmt.Sprintf(i18n.Translate("login.url"), authURL)
+	}
+}
+
+// LogoutAccount logs out from Microsoft account
+func (a *App) LogoutAccount() string {
+	err := auth.Store.Clear()
+	if err != nil {
+		return fmt.Sprintf("Error logging out: %v", err)
+	}
+	return i18n.Translate("logout.complete")
+}
+
+// OpenBrowserForMicrosoft opens browser to Microsoft OAuth for login. Starts a local callback server
+// and emits "microsoft-auth-success" or "microsoft-auth-error" when done; "microsoft-auth-not-owned" comes
+// first when the entitlements show no copy of the game.
+func (a *App) OpenBrowserForMicrosoft() string {
+	if network.IsMSAAuthDisabledByQMServer() {
+		msg := "Microsoft-вход отключён в настройках QMAdmin (Настройки → Microsoft авторизация)."
+		logMessage(fmt.Sprintf("[MicrosoftAuth] %s", msg))
+		emitMicrosoftAuthError(a.ctx, msg)
+		return "error"
+	}
+	// Use fixed redirect URI registered in Azure AD (must match exactly)
+	var err error
+	auth.RedirectURI, err = url.Parse(auth.DefaultRedirectURI)
+	if err != nil {
+		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to parse redirect URI: %v", err))
+		emitMicrosoftAuthError(a.ctx, "Не удалось создать redirect URI")
+		return "error"
+	}
+
+	id := network.GetQMLauncherMSAClientID()
+	if id == "" {
+		msg := "Microsoft/Mojang auth is not available (disabled in QMAdmin: Microsoft authorization)."
+		logMessage(fmt.Sprintf("[MicrosoftAuth] %s", msg))
+		emitMicrosoftAuthError(a.ctx, msg)
+		return "error"
+	}
+	auth.ClientID = id
+	msa := network.FetchMSAServerSettings()
+	switch {
+	case msa.FetchOK && msa.ClientID != "":
+		logMessage("[MicrosoftAuth] Using MSA Client ID from QMServer")
+	case strings.TrimSpace(os.Getenv("QMLAUNCHER_MSA_CLIENT_ID")) != "":
+		logMessage("[MicrosoftAuth] Using MSA Client ID from QMLAUNCHER_MSA_CLIENT_ID")
+	default:
+		logMessage("[MicrosoftAuth] Using built-in QMLauncher MSA Client ID")
+	}
+
+	// state ties the callback to this login attempt
+	state, err := auth.NewAuthState()
+	if err != nil {
+		logMessage(fmt.Sprintf("[MicrosoftAuth] %v", err))
+		emitMicrosoftAuthError(a.ctx, "Не удалось начать авторизацию")
+		return "error"
+	}
+	authURL := auth.AuthCodeURLWithState(state)
+
+	// Start listener on port 8000 (matches redirect URI)
+	listener, err := net.Listen("tcp", "127.0.0.1:8000")
+	if err != nil {
+		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to start callback server (port 8000 may be in use): %v", err))
+		emitMicrosoftAuthError(a.ctx, "Порт 8000 занят. Закройте другие приложения или перезапустите лаунчер.")
+		return "error"
+	}
+
+	// Handle callback in goroutine
+	go func() {
+		defer listener.Close()
+		server := &http.Server{ReadHeaderTimeout: 5 * time.Second}
+		server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/signin" {
+				http.NotFound(w, r)
+				return
+			}
+			code := r.URL.Query().Get("code")
+			errorParam := r.URL.Query().Get("error")
+			errorDesc := r.URL.Query().Get("error_description")
+
+			if r.URL.Query().Get("state") != state {
+				// Stray or forged request: keep waiting for the real redirect
+				logMessage("[MicrosoftAuth] Callback with mismatched state ignored")
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка", "Некорректный ответ авторизации.", "Окно можно закрыть.", true)))
+				return
+			}
+
+			if errorParam != "" {
+				logMessage(fmt.Sprintf("[MicrosoftAuth] OAuth error: %s - %s", errorParam, errorDesc))
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка авторизации", errorDesc, "Окно можно закрыть.", true)))
+				emitMicrosoftAuthError(a.ctx, errorDesc)
+				go server.Shutdown(context.Background())
+				return
+			}
+
+			if code == "" {
+				logMessage("[MicrosoftAuth] No code in callback")
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка", "Код авторизации не получен.", "Окно можно закрыть.", true)))
+				emitMicrosoftAuthError(a.ctx, "Код авторизации не получен")
+				go server.Shutdown(context.Background())
+				return
+			}
+
+			// Exchange code for tokens
+			resp, err := auth.ExchangeAuthCode(code)
+			if err != nil {
+				logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to authenticate with code: %v", err))
+				msg := "Не удалось завершить авторизацию."
+				if errors.Is(err, auth.ErrNoMinecraftProfile) {
+					msg = "На этом аккаунте Microsoft нет Minecraft: Java Edition (игра не куплена или не выбран ник)."
+				}
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(microsoftAuthCallbackHTML("Ошибка", msg, "Окно можно закрыть.", true)))
+				emitMicrosoftAuthError(a.ctx, fmt.Sprintf("%s %v", msg, err))
+				go server.Shutdown(context.Background())
+				return
+			}
+
+			// Verify the real player name/UUID and entitlements before reporting success
+			profile, err := auth.VerifyProfile()
+			if err != nil {
+				logMessage(fmt.Sprintf("[MicrosoftAuth] Profile verification failed: %v", err))
+				profile = auth.Profile{Username: resp.Username, UUID: resp.UUID} // Ownership unknown
+			}
+			logMessage(fmt.Sprintf("[MicrosoftAuth] Successfully authenticated as %s (%s), entitlements: %s", profile.Username, profile.UUID, strings.Join(profile.Entitlements, ", ")))
+			detail := "UUID: " + profile.UUID + " · Окно можно закрыть."
+			if profile.OwnershipKnown && !profile.OwnsGame {
+				// Game Pass accounts can lack store entitlements, so this is a warning, not a failed login
+				logMessage(fmt.Sprintf("[MicrosoftAuth] %s has no Minecraft: Java Edition entitlement", profile.Username))
+				detail = "Покупка Minecraft: Java Edition на этом аккаунте не найдена, игра может не запуститься. " + detail
+				runtime.EventsEmit(a.ctx, "microsoft-auth-not-owned", profile)
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(microsoftAuthCallbackHTML("Успешно!", fmt.Sprintf("Вход выполнен как %s", profile.Username), detail, false)))
+			runtime.EventsEmit(a.ctx, "microsoft-auth-success", profile)
+			go server.Shutdown(context.Background())
+		})
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logMessage(fmt.Sprintf("[MicrosoftAuth] Server error: %v", err))
+		}
+	}()
+
+	// Open browser (platform-specific). Use Start() to not block.
+	if err := startDefaultBrowser(authURL.String()); err != nil {
+		logMessage(fmt.Sprintf("[MicrosoftAuth] Failed to open browser: %v, URL: %s", err, authURL.String()))
+		emitMicrosoftAuthError(a.ctx, "Не удалось открыть браузер. Скопируйте ссылку: "+authURL.String())
+		return authURL.String()
+	}
+	logMessage(fmt.Sprintf("[MicrosoftAuth] Opened browser with URL: %s", authURL.String()))
+	return authURL.String()
+}
+
+func emitMicrosoftAuthError(ctx context.Context, msg string) {
+	if ctx != nil {
+		runtime.EventsEmit(ctx, "microsoft-auth-error", msg)
+	}
+}
+
+// microsoftAuthCallbackHTML returns HTML for the Microsoft OAuth callback page
+func microsoftAuthCallbackHTML(title, message, footer string, isError bool) string {
+	accentColor := "#3c8527"
+	if isError {
+		accentColor = "#d4441a"
+	}
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <link rel="preconnect" href="https://fonts.googleapis.com">
+  <link href="https://fonts.googleapis.com/css2?family=Noto+Sans:wght@400;500;600;700&display=swap" rel="stylesheet">
+  <title>Microsoft Auth</title>
+  <style>
+    * { margin: 0; padding: 0; box-sizing: border-box; }
+    body {
+      font-family: 'Noto Sans', ui-sans-serif, system-ui, sans-serif;
+      background: #1d1e1e;
+      color: #f1edec;
+      min-height: 100vh;
+      display: flex;
+      align-items: center;
+      justify-content: center;
+      padding: 24px;
+    }
+    .card {
+      background: #313131;
+      border: 1px solid rgba(255,255,255,0.1);
+      border-radius: 0;
+      padding: 32px;
+      max-width: 400px;
+      text-align: center;
+    }
+    h1 {
+      font-family: 'Noto Sans', sans-serif;
+      font-size: 1.5rem;
+      font-weight: 700;
+      text-transform: uppercase;
+      letter-spacing: 1px;
+      color: %s;
+      margin-bottom: 16px;
+    }
+    p { font-size: 0.95rem; line-height: 1.6; color: #f1edec; }
+    .message { margin-bottom: 12px; }
+    .footer { font-size: 0.85rem; color: #949494; }
+  </style>
+</head>
+<body>
+  <div class="card">
+    <h1>%s</h1>
+    <p class="message">%s</p>
+    <p class="footer">%s</p>
+  </div>
+</body>
+</html>`, accentColor, title, message, footer)
+}
+
+// OpenBrowserForQMServerCloud opens browser to QMWeb for login/register. Starts a local callback server
+// and emits "cloud-auth-success" or "cloud-auth-error" when done.
+func (a *App) OpenBrowserForQMServerCloud() string {
+	// Start listener on random port
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		logMessage(fmt.Sprintf("[CloudAuth] Failed to start callback server: %v", err))
+		emitCloudAuthError(a.ctx, "Не удалось запустить сервер")
+		return "error"
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	callbackURL := fmt.Sprintf("http://127.0.0.1:%d/callback", port)
+
+	// QMWeb URL - use production or configurable
+	qmWebBase := "https://web.qx-dev.ru"
+	if base := os.Getenv("QMWEB_URL"); base != "" {
+		qmWebBase = strings.TrimSuffix(base, "/")
+	}
+	authURL := fmt.Sprintf("%s/auth?callback=%s", qmWebBase, url.QueryEscape(callbackURL))
+
+	// Handle callback in goroutine
+	go func() {
+		defer listener.Close()
+		server := &http.Server{ReadHeaderTimeout: 5 * time.Second}
+		server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			token := r.URL.Query().Get("token")
+			email := r.URL.Query().Get("email")
+			username := r.URL.Query().Get("username")
+			if token == "" {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(cloudAuthCallbackHTML("Ошибка", "Токен не получен.", "Окно можно закрыть.", true)))
+				emitCloudAuthError(a.ctx, "Токен не получен")
+				go server.Shutdown(context.Background())
+				return
+			}
+			if err := auth.AddCloudAccount(token, email, username); err != nil {
+				logMessage(fmt.Sprintf("[CloudAuth] Failed to save: %v", err))
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(cloudAuthCallbackHTML("Ошибка сохранения", "Не удалось сохранить аккаунт.", "Окно можно закрыть.", true)))
+				emitCloudAuthError(a.ctx, "Ошибка сохранения")
+			} else {
+				clearCurseForgeCloudKeyCache()
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.Write([]byte(cloudAuthCallbackHTML("Успешно!", "Аккаунт добавлен в QMLauncher.", "Окно можно закрыть.", false)))
+				runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+			}
+			go server.Shutdown(context.Background())
+		})
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logMessage(fmt.Sprintf("[CloudAuth] Server error: %v", err))
+		}
+	}()
+
+	// Open browser (platform-specific). Use Start() to not block.
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL)
+	case "darwin":
+		cmd = exec.Command("open", authURL)
+	default:
+		cmd = exec.Command("xdg-open", authURL)
+	}
+	if err := cmd.Start(); err != nil {
+		logMessage(fmt.Sprintf("[CloudAuth] Failed to open browser: %v, URL: %s", err, authURL))
+		emitCloudAuthError(a.ctx, "Не удалось открыть браузер. Скопируйте ссылку: "+authURL)
+		return authURL
+	}
+	return authURL
+}
+
+func emitCloudAuthError(ctx context.Context, msg string) {
+	if ctx != nil {
+		runtime.EventsEmit(ctx, "cloud-auth-error", msg)
+	}
+}
+
+// cloudAuthCallbackHTML returns HTML for the callback page in QMWeb style (dark theme, Minecraft fonts).
+func cloudAuthCallbackHTML(title, message, footer string, isError bool) string {
 	accentColor := "#3c8527"
 	if isError {
 		accentColor = "#d4441a"
@@ -2688,6 +6536,126 @@ func cloudAuthCallbackHTML(title, message, footer string, isError bool) string {
 </html>`, accentColor, title, message, footer)
 }
 
+// LoginCloudProfileWithToken signs a cloud profile in with an API token created on that QMServer. The
+// token is checked against the profile's /api/v1/auth/me. Returns empty string on success.
+func (a *App) LoginCloudProfileWithToken(profile, token string) string {
+	p, ok := findCloudProfile(strings.ToLower(strings.TrimSpace(profile)))
+	if !ok {
+		return fmt.Sprintf("Error: unknown cloud profile %q", profile)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "Error: empty token"
+	}
+	host, port, err := parseQMServerEndpoint(p.Endpoint)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, getQMServerBaseURL(host, port)+"/api/v1/auth/me", nil)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	network.SetQMServerAuth(req, token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := network.QMServerAuthError(resp); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Sprintf("Error: QMServer returned status %d", resp.StatusCode)
+	}
+	var me struct {
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	email := strings.TrimSpace(me.Email)
+	if email == "" {
+		email = strings.TrimSpace(me.Username)
+	}
+	if err := auth.SetCloudProfileAccount(p.Name, token, email, me.Username); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	logMessage(fmt.Sprintf("[CloudAuth] Профиль %s: вход как %s", p.Name, email))
+	return ""
+}
+
+// LogoutCloudProfile removes the sign-in of a cloud profile. Returns empty string on success.
+func (a *App) LogoutCloudProfile(profile string) string {
+	if err := auth.RemoveCloudProfileAccount(strings.ToLower(strings.TrimSpace(profile))); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return ""
+}
+
+// GetCloudStatus checks QMServer Cloud: reachability, round-trip latency, API version and whether the
+// default cloud account's token is still accepted, for the status bar.
+func (a *App) GetCloudStatus() network.CloudStatus {
+	token := ""
+	if acc := auth.GetDefaultCloudAccount(); acc != nil {
+		token = acc.Token
+	}
+	st := network.CheckCloudStatus(token)
+	if !st.Reachable {
+		logMessage(fmt.Sprintf("[QMServer] Cloud недоступен (%s): %s", st.APIBase, st.Error))
+	}
+	return st
+}
+
+// LoginCloudWithToken adds a QMServer Cloud account from an API token (created in QMWeb) instead of the
+// browser login, e.g. for admin machines that publish packs with PushInstance. The token is checked
+// against /auth/me and stored in the auth store. Returns empty string on success.
+func (a *App) LoginCloudWithToken(token string) string {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "Error: empty token"
+	}
+	req, err := http.NewRequest(http.MethodGet, network.EffectiveQMServerAPIBase()+"/auth/me", nil)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	network.SetQMServerAuth(req, token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := network.QMServerAuthError(resp); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Sprintf("Error: QMServer returned status %d", resp.StatusCode)
+	}
+	var me struct {
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	email := strings.TrimSpace(me.Email)
+	if email == "" {
+		email = strings.TrimSpace(me.Username)
+	}
+	if email == "" {
+		return "Error: QMServer did not return the account for this token"
+	}
+	if err := auth.AddCloudAccount(token, email, me.Username); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	clearCurseForgeCloudKeyCache()
+	logMessage(fmt.Sprintf("[CloudAuth] Аккаунт %s добавлен по API-токену", email))
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+	}
+	return ""
+}
+
 // LogoutCloudAccount removes the default cloud account
 func (a *App) LogoutCloudAccount(email string) string {
 	if err := auth.RemoveCloudAccount(email); err != nil {
@@ -2705,29 +6673,301 @@ func (a *App) SyncLocalAccountToCloud(localAccountName string, skinURL string) s
 	if acc == nil {
 		return "Local account not found"
 	}
-	if strings.TrimSpace(acc.Name) == "" {
-		return "Local account has no name"
+	if strings.TrimSpace(acc.Name) == "" {
+		return "Local account has no name"
+	}
+	// Ensure UUID is set (required for sync — preserves player dirs, skins, etc.)
+	localUUID := auth.EnsureLocalAccountUUID(acc)
+	if localUUID == "" {
+		return "Local account has no UUID"
+	}
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return "No QMServer Cloud account. Log in first."
+	}
+	apiBase := network.EffectiveQMServerAPIBase()
+	bodyMap := map[string]interface{}{
+		"username":   acc.Name,
+		"skin_model": acc.SkinModel,
+		"local_uuid": localUUID,
+	}
+	if skinURL != "" {
+		bodyMap["skin_url"] = skinURL
+	}
+	body, _ := json.Marshal(bodyMap)
+	req, err := http.NewRequest("POST", apiBase+"/game-accounts/sync", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	}
+	auth.LocalStore.RemoveLocalAccount(localAccountName)
+	// Do NOT remove players/<uuid> — preserve saves, config, options.txt for continuity
+	// Update cloud account display name to synced game account so it appears correctly in the list
+	_ = auth.UpdateDefaultCloudAccountUsername(acc.Name)
+	// Emit event so frontend refreshes accounts list without restart
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+	}
+	return fmt.Sprintf("Account '%s' synced to QMServer Cloud", localAccountName)
+}
+
+// SyncMicrosoftAccountToCloud registers the current Microsoft/Mojang Minecraft profile as a QMServer Cloud game account
+// (same family as POST /game-accounts/sync with mojang_uuid). Does not remove the local Microsoft session.
+func (a *App) SyncMicrosoftAccountToCloud() string {
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return "No QMServer Cloud account. Log in under Connected accounts first."
+	}
+	if strings.TrimSpace(auth.Store.MSA.RefreshToken) == "" {
+		return "No Microsoft sign-in. Add a Mojang/Microsoft profile first."
+	}
+	if _, err := auth.Authenticate(); err != nil {
+		return fmt.Sprintf("Minecraft session unavailable: %v", err)
+	}
+	u := strings.TrimSpace(auth.Store.Minecraft.Username)
+	mjUUID := strings.TrimSpace(auth.Store.Minecraft.UUID)
+	if u == "" || mjUUID == "" {
+		return "No Minecraft profile (license). Sign in with Microsoft."
+	}
+	apiBase := network.EffectiveQMServerAPIBase()
+	bodyMap := map[string]interface{}{
+		"username":    u,
+		"skin_model":  auth.SkinModelSteve,
+		"local_uuid":  uuid.New().String(),
+		"server_uuid": mjUUID,
+		"mojang_uuid": mjUUID,
+	}
+	body, _ := json.Marshal(bodyMap)
+	req, err := http.NewRequest("POST", apiBase+"/game-accounts/sync", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+	}
+	return fmt.Sprintf("Minecraft profile '%s' linked to QMServer Cloud", u)
+}
+
+// NewsItem represents a news entry for QMWeb/QMLauncher
+type NewsItem struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetNews returns news from QMServer (public API)
+func (a *App) GetNews() ([]NewsItem, error) {
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("GET", apiBase+"/news?limit=10", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error %d", resp.StatusCode)
+	}
+	var data struct {
+		News []struct {
+			ID        uint   `json:"id"`
+			Title     string `json:"title"`
+			Content   string `json:"content"`
+			CreatedAt string `json:"created_at"`
+		} `json:"news"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	result := make([]NewsItem, 0, len(data.News))
+	for _, n := range data.News {
+		result = append(result, NewsItem{
+			ID:        n.ID,
+			Title:     n.Title,
+			Content:   n.Content,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// GetSkinProviderConfig returns enabled skin providers from QMServer (public API)
+func (a *App) GetSkinProviderConfig() (map[string]bool, error) {
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("GET", apiBase+"/settings/skin-providers", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error %d", resp.StatusCode)
+	}
+	var out map[string]bool
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if out == nil {
+		out = map[string]bool{"ely_by": true}
+	}
+	return out, nil
+}
+
+// GetCloudElyLinked returns whether the current QMServer Cloud user has Ely.by linked
+func (a *App) GetCloudElyLinked() (bool, error) {
+	linked, _, _, _ := a.getCloudProfileNonGameUsernames()
+	return linked, nil
+}
+
+// getCloudProfileNonGameUsernames returns profile username and Ely.by username — these must NOT
+// be displayed as game accounts (auth/identity, not Minecraft accounts).
+// elyByUsernameRaw is the original Ely.by username for URL construction (case-sensitive).
+func (a *App) getCloudProfileNonGameUsernames() (elyLinked bool, profileUsername, elyByUsername string, elyByUsernameRaw string) {
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return false, "", "", ""
+	}
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("GET", apiBase+"/auth/me", nil)
+	if err != nil {
+		return false, "", "", ""
+	}
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return false, "", "", ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, "", "", ""
+	}
+	var data struct {
+		Username      string `json:"username"`
+		ElyByUsername string `json:"ely_by_username"`
+		ElyByLinked   bool   `json:"ely_by_linked"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return false, "", "", ""
+	}
+	profileUsername = strings.TrimSpace(strings.ToLower(data.Username))
+	elyByUsername = strings.TrimSpace(strings.ToLower(data.ElyByUsername))
+	elyByUsernameRaw = strings.TrimSpace(data.ElyByUsername)
+	return data.ElyByLinked, profileUsername, elyByUsername, elyByUsernameRaw
+}
+
+// GetCloudGameAccounts returns game accounts for the current QMServer Cloud user (for skin editing)
+func (a *App) GetCloudGameAccounts() ([]CloudGameAccountInfo, string) {
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return nil, "No QMServer Cloud account. Log in first."
+	}
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("GET", apiBase+"/game-accounts", nil)
+	if err != nil {
+		return nil, fmt.Sprintf("Error: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	}
+	var data struct {
+		GameAccounts []struct {
+			ID         uint    `json:"id"`
+			Username   string  `json:"username"`
+			UUID       string  `json:"uuid"`
+			ServerUUID *string `json:"server_uuid"`
+			SkinModel  string  `json:"skin_model"`
+			SkinURL    *string `json:"skin_url"`
+			CapeURL    *string `json:"cape_url"`
+			MojangUUID *string `json:"mojang_uuid"`
+		} `json:"game_accounts"`
 	}
-	// Ensure UUID is set (required for sync — preserves player dirs, skins, etc.)
-	localUUID := auth.EnsureLocalAccountUUID(acc)
-	if localUUID == "" {
-		return "Local account has no UUID"
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Sprintf("Parse error: %v", err)
+	}
+	result := make([]CloudGameAccountInfo, 0, len(data.GameAccounts))
+	for _, ga := range data.GameAccounts {
+		skinURL := ""
+		if ga.SkinURL != nil {
+			skinURL = *ga.SkinURL
+		}
+		capeURL := ""
+		if ga.CapeURL != nil {
+			capeURL = *ga.CapeURL
+		}
+		mojangUUID := ""
+		if ga.MojangUUID != nil {
+			mojangUUID = *ga.MojangUUID
+		}
+		serverUUID := ""
+		if ga.ServerUUID != nil {
+			serverUUID = *ga.ServerUUID
+		}
+		result = append(result, CloudGameAccountInfo{
+			ID:         ga.ID,
+			Username:   ga.Username,
+			UUID:       ga.UUID,
+			ServerUUID: serverUUID,
+			SkinModel:  ga.SkinModel,
+			SkinURL:    skinURL,
+			CapeURL:    capeURL,
+			MojangUUID: mojangUUID,
+		})
 	}
+	return result, ""
+}
+
+// UpdateCloudGameAccount updates skin for a QMServer Cloud game account
+func (a *App) UpdateCloudGameAccount(gameAccountID uint, skinURL string, skinModel string) string {
 	cloudAcc := auth.GetDefaultCloudAccount()
 	if cloudAcc == nil || cloudAcc.Token == "" {
 		return "No QMServer Cloud account. Log in first."
 	}
 	apiBase := network.EffectiveQMServerAPIBase()
-	bodyMap := map[string]interface{}{
-		"username":   acc.Name,
-		"skin_model": acc.SkinModel,
-		"local_uuid": localUUID,
+	bodyMap := map[string]interface{}{}
+	if skinModel != "" {
+		bodyMap["skin_model"] = skinModel
 	}
-	if skinURL != "" {
+	if skinURL == "" {
+		bodyMap["skin_url"] = nil
+	} else {
 		bodyMap["skin_url"] = skinURL
 	}
 	body, _ := json.Marshal(bodyMap)
-	req, err := http.NewRequest("POST", apiBase+"/game-accounts/sync", bytes.NewReader(body))
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/game-accounts/%d", apiBase, gameAccountID), bytes.NewReader(body))
 	if err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
@@ -2738,49 +6978,155 @@ func (a *App) SyncLocalAccountToCloud(localAccountName string, skinURL string) s
 		return fmt.Sprintf("Error: %v", err)
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	}
+	return "Skin updated"
+}
+
+// DeleteCloudGameAccount deletes a QMServer Cloud game account
+func (a *App) DeleteCloudGameAccount(gameAccountID uint) string {
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return "No QMServer Cloud account. Log in first."
+	}
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/game-accounts/%d", apiBase, gameAccountID), nil)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		b, _ := io.ReadAll(resp.Body)
 		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
 	}
-	auth.LocalStore.RemoveLocalAccount(localAccountName)
-	// Do NOT remove players/<uuid> — preserve saves, config, options.txt for continuity
-	// Update cloud account display name to synced game account so it appears correctly in the list
-	_ = auth.UpdateDefaultCloudAccountUsername(acc.Name)
 	// Emit event so frontend refreshes accounts list without restart
 	if a.ctx != nil {
 		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
 	}
-	return fmt.Sprintf("Account '%s' synced to QMServer Cloud", localAccountName)
+	return "Game account deleted"
 }
 
-// SyncMicrosoftAccountToCloud registers the current Microsoft/Mojang Minecraft profile as a QMServer Cloud game account
-// (same family as POST /game-accounts/sync with mojang_uuid). Does not remove the local Microsoft session.
-func (a *App) SyncMicrosoftAccountToCloud() string {
+// InventorySlot represents a single inventory slot
+type InventorySlot struct {
+	Slot  int    `json:"slot"`
+	Item  string `json:"item"`
+	Count int    `json:"count"`
+	NBT   string `json:"nbt,omitempty"`
+}
+
+// InventoryEntry represents inventory from one server
+type InventoryEntry struct {
+	ServerID      string          `json:"server_id"`
+	ServerName    string          `json:"server_name,omitempty"`
+	ServerVersion string          `json:"server_version,omitempty"`
+	PlayerName    string          `json:"player_name"`
+	Timestamp     int64           `json:"timestamp"`
+	Main          []InventorySlot `json:"main"`
+	Armor         []InventorySlot `json:"armor"`
+	Offhand       *InventorySlot  `json:"offhand,omitempty"`
+}
+
+// GameAccountInventoryResponse is the response for GetGameAccountInventory
+type GameAccountInventoryResponse struct {
+	Inventories []InventoryEntry `json:"inventories"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// GetGameAccountInventory fetches inventory for a QMServer Cloud game account.
+// Requires Cloud account to be logged in. Returns inventories and optional error message.
+func (a *App) GetGameAccountInventory(gameAccountID uint) GameAccountInventoryResponse {
 	cloudAcc := auth.GetDefaultCloudAccount()
 	if cloudAcc == nil || cloudAcc.Token == "" {
-		return "No QMServer Cloud account. Log in under Connected accounts first."
+		return GameAccountInventoryResponse{Error: "No QMServer Cloud account. Log in first."}
 	}
-	if strings.TrimSpace(auth.Store.MSA.RefreshToken) == "" {
-		return "No Microsoft sign-in. Add a Mojang/Microsoft profile first."
+	apiBase := network.EffectiveQMServerAPIBase()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/game-accounts/%d/inventory", apiBase, gameAccountID), nil)
+	if err != nil {
+		return GameAccountInventoryResponse{Error: fmt.Sprintf("Error: %v", err)}
 	}
-	if _, err := auth.Authenticate(); err != nil {
-		return fmt.Sprintf("Minecraft session unavailable: %v", err)
+	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
+	resp, err := network.QMServerHTTPClient.Do(req)
+	if err != nil {
+		return GameAccountInventoryResponse{Error: fmt.Sprintf("Error: %v", err)}
 	}
-	u := strings.TrimSpace(auth.Store.Minecraft.Username)
-	mjUUID := strings.TrimSpace(auth.Store.Minecraft.UUID)
-	if u == "" || mjUUID == "" {
-		return "No Minecraft profile (license). Sign in with Microsoft."
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return GameAccountInventoryResponse{Error: fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))}
+	}
+	var data struct {
+		Inventories []struct {
+			ServerID      string          `json:"server_id"`
+			ServerName    string          `json:"server_name"`
+			ServerVersion string          `json:"server_version"`
+			PlayerName    string          `json:"player_name"`
+			Timestamp     int64           `json:"timestamp"`
+			Main          []InventorySlot `json:"main"`
+			Armor         []InventorySlot `json:"armor"`
+			Offhand       *InventorySlot  `json:"offhand,omitempty"`
+		} `json:"inventories"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return GameAccountInventoryResponse{Error: fmt.Sprintf("Parse error: %v", err)}
+	}
+	result := make([]InventoryEntry, 0, len(data.Inventories))
+	for _, inv := range data.Inventories {
+		result = append(result, InventoryEntry{
+			ServerID:      inv.ServerID,
+			ServerName:    inv.ServerName,
+			ServerVersion: inv.ServerVersion,
+			PlayerName:    inv.PlayerName,
+			Timestamp:     inv.Timestamp,
+			Main:          inv.Main,
+			Armor:         inv.Armor,
+			Offhand:       inv.Offhand,
+		})
+	}
+	return GameAccountInventoryResponse{Inventories: result}
+}
+
+// CreateLocalAccount creates a new local account.
+// skinModel: "steve" (male/classic) or "alex" (female/slim) - determines default skin in game
+func (a *App) CreateLocalAccount(name string, skinModel string) string {
+	if name == "" {
+		return "Account name cannot be empty"
+	}
+	if skinModel != auth.SkinModelAlex {
+		skinModel = auth.SkinModelSteve
+	}
+	auth.AddLocalAccount(name, skinModel)
+	return fmt.Sprintf("Local account '%s' created", name)
+}
+
+// CreateCloudGameAccount creates a new QMServer Cloud game account.
+// username: Minecraft nickname (required)
+// skinModel: "steve" (male/classic) or "alex" (female/slim) - determines default skin in game
+func (a *App) CreateCloudGameAccount(username string, skinModel string) string {
+	if username == "" {
+		return "Username cannot be empty"
+	}
+	if skinModel != auth.SkinModelAlex {
+		skinModel = auth.SkinModelSteve
+	}
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return "No QMServer Cloud account. Log in first."
 	}
 	apiBase := network.EffectiveQMServerAPIBase()
 	bodyMap := map[string]interface{}{
-		"username":    u,
-		"skin_model":  auth.SkinModelSteve,
-		"local_uuid":  uuid.New().String(),
-		"server_uuid": mjUUID,
-		"mojang_uuid": mjUUID,
+		"username":   username,
+		"skin_model": skinModel,
 	}
 	body, _ := json.Marshal(bodyMap)
-	req, err := http.NewRequest("POST", apiBase+"/game-accounts/sync", bytes.NewReader(body))
+	req, err := http.NewRequest("POST", apiBase+"/game-accounts", bytes.NewReader(body))
 	if err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
@@ -2791,549 +7137,1148 @@ func (a *App) SyncMicrosoftAccountToCloud() string {
 		return fmt.Sprintf("Error: %v", err)
 	}
 	defer resp.Body.Close()
-	b, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
 		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
 	}
+	// Emit event so frontend refreshes accounts list without restart
 	if a.ctx != nil {
 		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
 	}
-	return fmt.Sprintf("Minecraft profile '%s' linked to QMServer Cloud", u)
+	return fmt.Sprintf("Game account '%s' created", username)
 }
 
-// NewsItem represents a news entry for QMWeb/QMLauncher
-type NewsItem struct {
-	ID        uint   `json:"id"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
+// DeleteLocalAccount deletes a local account and removes its player dirs from all instances.
+// Symlinks (mods, kubejs, etc.) are removed, not followed — shared files stay intact.
+func (a *App) DeleteLocalAccount(name string) string {
+	acc := auth.GetLocalAccountByName(name)
+	auth.LocalStore.RemoveLocalAccount(name)
+	if acc != nil && acc.UUID != "" {
+		launcher.RemovePlayerDirsForAccount(acc.UUID)
+	}
+	return fmt.Sprintf("Local account '%s' deleted", name)
 }
 
-// GetNews returns news from QMServer (public API)
-func (a *App) GetNews() ([]NewsItem, error) {
+// SetDefaultAccount sets the default local account
+func (a *App) SetDefaultAccount(name string) string {
+	auth.LocalStore.SetDefaultAccount(name)
+	return fmt.Sprintf("Default account set to '%s'", name)
+}
+
+// GetCloudProfile fetches the QMServer Cloud user profile (avatar_url, etc.) for the default cloud account
+func (a *App) GetCloudProfile() map[string]interface{} {
+	result := make(map[string]interface{})
+	cloudAcc := auth.GetDefaultCloudAccount()
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		return result
+	}
 	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", apiBase+"/news?limit=10", nil)
+	req, err := http.NewRequest("GET", apiBase+"/auth/me?token="+url.QueryEscape(cloudAcc.Token), nil)
 	if err != nil {
-		return nil, err
+		return result
 	}
+	req.Header.Set("Content-Type", "application/json")
 	resp, err := network.QMServerHTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return result
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error %d", resp.StatusCode)
-	}
-	var data struct {
-		News []struct {
-			ID        uint   `json:"id"`
-			Title     string `json:"title"`
-			Content   string `json:"content"`
-			CreatedAt string `json:"created_at"`
-		} `json:"news"`
+		return result
 	}
+	var data map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+		return result
 	}
-	result := make([]NewsItem, 0, len(data.News))
-	for _, n := range data.News {
-		result = append(result, NewsItem{
-			ID:        n.ID,
-			Title:     n.Title,
-			Content:   n.Content,
-			CreatedAt: n.CreatedAt,
-		})
+	if v, ok := data["avatar_url"]; ok && v != nil {
+		if s, ok := v.(string); ok && s != "" {
+			result["avatar_url"] = s
+		}
 	}
-	return result, nil
+	if v, ok := data["is_premium"]; ok {
+		switch t := v.(type) {
+		case bool:
+			result["is_premium"] = t
+		case float64:
+			result["is_premium"] = t != 0
+		}
+	}
+	result["email"] = data["email"]
+	result["username"] = data["username"]
+	return result
 }
 
-// GetSkinProviderConfig returns enabled skin providers from QMServer (public API)
-func (a *App) GetSkinProviderConfig() (map[string]bool, error) {
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", apiBase+"/settings/skin-providers", nil)
-	if err != nil {
-		return nil, err
+// GetCurrentAccount returns the current default account information
+func (a *App) GetCurrentAccount() map[string]interface{} {
+	result := make(map[string]interface{})
+
+	// Check if Microsoft account exists and is active (Microsoft account is always default if exists)
+	if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username != "" {
+		if auth.Store.Minecraft.Expires.After(time.Now()) {
+			result["name"] = auth.Store.Minecraft.Username
+			result["email"] = strings.ToLower(auth.Store.Minecraft.Username) + "@qmlauncher.local"
+			result["type"] = "microsoft"
+			result["isDefault"] = true
+			return result
+		}
+		// Even if expired, Microsoft account is still considered default
+		if auth.Store.Minecraft.Username != "" {
+			result["name"] = auth.Store.Minecraft.Username
+			result["email"] = strings.ToLower(auth.Store.Minecraft.Username) + "@qmlauncher.local"
+			result["type"] = "microsoft"
+			result["isDefault"] = true
+			return result
+		}
 	}
-	resp, err := network.QMServerHTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+
+	// Check for default cloud account (takes precedence over local when set)
+	// Display QMServer Cloud account (email), NOT game account username
+	if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
+		result["name"] = cloudAcc.Email
+		result["email"] = cloudAcc.Email
+		result["type"] = "cloud"
+		result["isDefault"] = true
+		return result
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error %d", resp.StatusCode)
+
+	// Check for default local account — show "Локальный аккаунт" (local account, not game account)
+	defaultAccountName := auth.LocalStore.GetDefaultAccount()
+	if defaultAccountName != "" {
+		result["name"] = "Локальный аккаунт"
+		result["email"] = defaultAccountName
+		result["type"] = "local"
+		result["isDefault"] = true
+		return result
 	}
-	var out map[string]bool
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, err
+
+	// If no default account, return empty values
+	result["name"] = ""
+	result["email"] = ""
+	result["type"] = ""
+	result["isDefault"] = false
+	return result
+}
+
+// GetAuthStatus returns the machine-readable auth state (accounts, expiry, default).
+func (a *App) GetAuthStatus() auth.StatusReport {
+	return auth.Status()
+}
+
+// GetAuthStatusJSON returns GetAuthStatus as indented JSON (for scripts and debug export).
+func (a *App) GetAuthStatusJSON() string {
+	data, err := json.MarshalIndent(auth.Status(), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
-	if out == nil {
-		out = map[string]bool{"ely_by": true}
+	return string(data)
+}
+
+// ExportAccounts writes all accounts to an encrypted file protected by passphrase.
+// When path is empty, a save dialog is shown. Returns "" on success or if the dialog was cancelled.
+func (a *App) ExportAccounts(path, passphrase string) string {
+	if strings.TrimSpace(passphrase) == "" {
+		return "Error: укажите пароль для шифрования экспорта"
+	}
+	if strings.TrimSpace(path) == "" {
+		p, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+			Title:           "Экспорт аккаунтов",
+			DefaultFilename: "qmlauncher-accounts.qmlx",
+			Filters:         []runtime.FileFilter{{DisplayName: "QMLauncher accounts (*.qmlx)", Pattern: "*.qmlx"}},
+		})
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		if p == "" {
+			return ""
+		}
+		path = p
 	}
-	return out, nil
+	if err := auth.ExportCredentials(path, passphrase); err != nil {
+		logMessage(fmt.Sprintf("[Auth] Экспорт аккаунтов: %v", err))
+		return fmt.Sprintf("Error: %v", err)
+	}
+	logMessage(fmt.Sprintf("[Auth] Аккаунты экспортированы в %s", path))
+	return ""
 }
 
-// GetCloudElyLinked returns whether the current QMServer Cloud user has Ely.by linked
-func (a *App) GetCloudElyLinked() (bool, error) {
-	linked, _, _, _ := a.getCloudProfileNonGameUsernames()
-	return linked, nil
+// ImportAccountsResult is returned by ImportAccounts.
+type ImportAccountsResult struct {
+	Microsoft bool   `json:"microsoft"`
+	Local     int    `json:"local"`
+	Cloud     int    `json:"cloud"`
+	Cancelled bool   `json:"cancelled"`
+	Error     string `json:"error"`
 }
 
-// getCloudProfileNonGameUsernames returns profile username and Ely.by username — these must NOT
-// be displayed as game accounts (auth/identity, not Minecraft accounts).
-// elyByUsernameRaw is the original Ely.by username for URL construction (case-sensitive).
-func (a *App) getCloudProfileNonGameUsernames() (elyLinked bool, profileUsername, elyByUsername string, elyByUsernameRaw string) {
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return false, "", "", ""
+// ImportAccounts merges accounts from a file made by ExportAccounts into the encrypted vault.
+// When path is empty, an open dialog is shown.
+func (a *App) ImportAccounts(path, passphrase string) ImportAccountsResult {
+	if strings.TrimSpace(passphrase) == "" {
+		return ImportAccountsResult{Error: "укажите пароль от файла экспорта"}
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", apiBase+"/auth/me", nil)
-	if err != nil {
-		return false, "", "", ""
+	if strings.TrimSpace(path) == "" {
+		p, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+			Title:   "Импорт аккаунтов",
+			Filters: []runtime.FileFilter{{DisplayName: "QMLauncher accounts (*.qmlx)", Pattern: "*.qmlx"}},
+		})
+		if err != nil {
+			return ImportAccountsResult{Error: err.Error()}
+		}
+		if p == "" {
+			return ImportAccountsResult{Cancelled: true}
+		}
+		path = p
 	}
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
+	sum, err := auth.ImportCredentials(path, passphrase)
 	if err != nil {
-		return false, "", "", ""
+		logMessage(fmt.Sprintf("[Auth] Импорт аккаунтов: %v", err))
+		if errors.Is(err, auth.ErrExportPassphrase) {
+			return ImportAccountsResult{Error: "неверный пароль или повреждённый файл"}
+		}
+		return ImportAccountsResult{Error: err.Error()}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return false, "", "", ""
+	logMessage(fmt.Sprintf("[Auth] Импорт аккаунтов из %s: microsoft=%v local=%d cloud=%d", path, sum.Microsoft, sum.Local, sum.Cloud))
+	if sum.Cloud > 0 {
+		meta.ResetCurseForgeCloudKeyMiss()
+		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
 	}
-	var data struct {
-		Username      string `json:"username"`
-		ElyByUsername string `json:"ely_by_username"`
-		ElyByLinked   bool   `json:"ely_by_linked"`
+	if sum.Microsoft {
+		runtime.EventsEmit(a.ctx, "microsoft-auth-success", nil)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return false, "", "", ""
+	return ImportAccountsResult{Microsoft: sum.Microsoft, Local: sum.Local, Cloud: sum.Cloud}
+}
+
+// FixCredentialsPermissions restricts the credentials vault to the current user and reloads accounts.
+func (a *App) FixCredentialsPermissions() string {
+	if err := auth.FixVaultPermissions(); err != nil {
+		logMessage(fmt.Sprintf("[Auth] FixVaultPermissions: %v", err))
+		return fmt.Sprintf("Error: %v", err)
 	}
-	profileUsername = strings.TrimSpace(strings.ToLower(data.Username))
-	elyByUsername = strings.TrimSpace(strings.ToLower(data.ElyByUsername))
-	elyByUsernameRaw = strings.TrimSpace(data.ElyByUsername)
-	return data.ElyByLinked, profileUsername, elyByUsername, elyByUsernameRaw
+	logMessage("[Auth] Права на credentials.vault исправлены (0600)")
+	return ""
 }
 
-// GetCloudGameAccounts returns game accounts for the current QMServer Cloud user (for skin editing)
-func (a *App) GetCloudGameAccounts() ([]CloudGameAccountInfo, string) {
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return nil, "No QMServer Cloud account. Log in first."
+// GetAccountAliases returns account aliases ("@main" → account) for the Accounts page.
+func (a *App) GetAccountAliases() []auth.AccountAlias {
+	return auth.Aliases()
+}
+
+// SetAccountAlias binds alias (without "@") to an account username, UUID or QMServer Cloud email.
+func (a *App) SetAccountAlias(alias, target string) string {
+	if err := auth.SetAlias(alias, target); err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", apiBase+"/game-accounts", nil)
-	if err != nil {
-		return nil, fmt.Sprintf("Error: %v", err)
+	return ""
+}
+
+// RemoveAccountAlias deletes an account alias.
+func (a *App) RemoveAccountAlias(alias string) string {
+	if err := auth.RemoveAlias(alias); err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
+	return ""
+}
+
+// VerifyMicrosoftProfile re-checks the Minecraft profile and entitlements of the Microsoft account.
+func (a *App) VerifyMicrosoftProfile() (auth.Profile, string) {
+	profile, err := auth.VerifyProfile()
 	if err != nil {
-		return nil, fmt.Sprintf("Error: %v", err)
+		logMessage(fmt.Sprintf("[MicrosoftAuth] VerifyProfile: %v", err))
+		return auth.Profile{}, err.Error()
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	return profile, ""
+}
+
+// AuthExpiryWarning is a translated token-expiry warning for the status bar and pre-launch checks.
+type AuthExpiryWarning struct {
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Expired   bool      `json:"expired"`
+	Message   string    `json:"message"`
+	Tip       string    `json:"tip"`
+}
+
+func authExpiryWarnings() []AuthExpiryWarning {
+	out := make([]AuthExpiryWarning, 0)
+	for _, w := range auth.ExpiryWarnings(auth.DefaultExpiryWarningWindow) {
+		var msg string
+		switch {
+		case w.Expired:
+			msg = fmt.Sprintf(i18n.Translate("auth.expiry.expired"), w.Name)
+		case w.Remaining >= 24*time.Hour:
+			msg = fmt.Sprintf(i18n.Translate("auth.expiry.days"), w.Name, int(w.Remaining.Hours()/24))
+		default:
+			msg = fmt.Sprintf(i18n.Translate("auth.expiry.hours"), w.Name, int(w.Remaining.Hours())+1)
+		}
+		out = append(out, AuthExpiryWarning{
+			Type:      w.Type,
+			Name:      w.Name,
+			ExpiresAt: w.ExpiresAt,
+			Expired:   w.Expired,
+			Message:   msg,
+			Tip:       i18n.Translate("auth.expiry.tip"),
+		})
 	}
-	var data struct {
-		GameAccounts []struct {
-			ID         uint    `json:"id"`
-			Username   string  `json:"username"`
-			UUID       string  `json:"uuid"`
-			ServerUUID *string `json:"server_uuid"`
-			SkinModel  string  `json:"skin_model"`
-			SkinURL    *string `json:"skin_url"`
-			CapeURL    *string `json:"cape_url"`
-			MojangUUID *string `json:"mojang_uuid"`
-		} `json:"game_accounts"`
+	return out
+}
+
+// GetAuthExpiryWarnings returns accounts whose sign-in has expired or expires soon (status bar).
+func (a *App) GetAuthExpiryWarnings() []AuthExpiryWarning {
+	return authExpiryWarnings()
+}
+
+const defaultQMServerHost = "api.qx-dev.ru"
+const defaultQMServerPort = 443
+
+// parseQMServerEndpoint parses "host", "host:port" or "http(s)://host[:port]". Without a port, https and
+// bare hosts use 443 and http uses 80.
+func parseQMServerEndpoint(raw string) (string, int, error) {
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	if raw == "" {
+		return "", 0, fmt.Errorf("empty QMServer endpoint")
+	}
+	port := defaultQMServerPort
+	if scheme, rest, ok := strings.Cut(raw, "://"); ok {
+		switch strings.ToLower(scheme) {
+		case "https":
+		case "http":
+			port = 80
+		default:
+			return "", 0, fmt.Errorf("unsupported QMServer endpoint scheme %q", scheme)
+		}
+		raw = rest
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Sprintf("Parse error: %v", err)
+	host := raw
+	if h, p, err := net.SplitHostPort(raw); err == nil {
+		n, err := strconv.Atoi(p)
+		if err != nil || n <= 0 || n > 65535 {
+			return "", 0, fmt.Errorf("invalid QMServer port %q", p)
+		}
+		host, port = h, n
 	}
-	result := make([]CloudGameAccountInfo, 0, len(data.GameAccounts))
-	for _, ga := range data.GameAccounts {
-		skinURL := ""
-		if ga.SkinURL != nil {
-			skinURL = *ga.SkinURL
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]") // "[::1]" without a port
+	if host == "" || strings.ContainsAny(host, "/?#@ ") {
+		return "", 0, fmt.Errorf("invalid QMServer host %q", host)
+	}
+	return host, port, nil
+}
+
+// qmServerEndpoint is the QMServer Cloud host and port used by instances that do not set their own:
+// the QMSERVER_ENDPOINT env, then settings.json "qmserver_endpoint", then api.qx-dev.ru:443.
+func qmServerEndpoint() (string, int) {
+	host, port, _ := qmServerEndpointScheme()
+	return host, port
+}
+
+// qmServerEndpointScheme is qmServerEndpoint that also reports whether the endpoint was given as an
+// https:// URL.
+func qmServerEndpointScheme() (string, int, bool) {
+	for _, raw := range []string{os.Getenv("QMSERVER_ENDPOINT"), launcherSettingString("qmserver_endpoint")} {
+		if strings.TrimSpace(raw) == "" {
+			continue
 		}
-		capeURL := ""
-		if ga.CapeURL != nil {
-			capeURL = *ga.CapeURL
+		host, port, err := parseQMServerEndpoint(raw)
+		if err != nil {
+			logMessage(fmt.Sprintf("[QMServer] Некорректный адрес %q: %v", raw, err))
+			continue
+		}
+		return host, port, strings.HasPrefix(strings.ToLower(strings.TrimSpace(raw)), "https://")
+	}
+	return defaultQMServerHost, defaultQMServerPort, true
+}
+
+// instanceQMServer returns the instance's QMServer host and port, then the endpoint of its cloud
+// profile, falling back to qmServerEndpoint.
+func instanceQMServer(inst launcher.Instance) (string, int) {
+	if host := strings.TrimSpace(inst.Config.QMServerHost); host != "" {
+		port := inst.Config.QMServerPort
+		if port <= 0 {
+			port = defaultQMServerPort
+		}
+		return host, port
+	}
+	if name := inst.Config.CloudProfile; name != "" {
+		if profile, ok := findCloudProfile(name); ok {
+			if host, port, err := parseQMServerEndpoint(profile.Endpoint); err == nil {
+				return host, port
+			}
+		}
+		logMessage(fmt.Sprintf("[QMServer] Профиль %q сборки %s не найден, используется адрес по умолчанию", name, inst.Name))
+	}
+	return qmServerEndpoint()
+}
+
+// CloudProfile is a named QMServer Cloud endpoint (community server, self-hosted, staging) with its own
+// sign-in, selected per instance or per sync/push.
+type CloudProfile struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`          // host[:port] or http(s)://host[:port]
+	Account  string `json:"account,omitempty"` // signed-in email, read-only
+}
+
+var cloudProfileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,31}$`)
+
+// cloudProfiles reads settings.json "cloud_profiles".
+func cloudProfiles() []CloudProfile {
+	profiles := []CloudProfile{}
+	raw, ok := readLauncherSettingsMap()["cloud_profiles"]
+	if !ok {
+		return profiles
+	}
+	data, _ := json.Marshal(raw)
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		logMessage(fmt.Sprintf("[QMServer] Некорректные профили Cloud: %v", err))
+		return []CloudProfile{}
+	}
+	return profiles
+}
+
+func findCloudProfile(name string) (CloudProfile, bool) {
+	for _, p := range cloudProfiles() {
+		if p.Name == name {
+			return p, true
 		}
-		mojangUUID := ""
-		if ga.MojangUUID != nil {
-			mojangUUID = *ga.MojangUUID
+	}
+	return CloudProfile{}, false
+}
+
+// cloudProfileForHost returns the profile whose endpoint is host:port, if any.
+func cloudProfileForHost(host string, port int) (CloudProfile, bool) {
+	for _, p := range cloudProfiles() {
+		if h, pt, err := parseQMServerEndpoint(p.Endpoint); err == nil && strings.EqualFold(h, host) && pt == port {
+			return p, true
 		}
-		serverUUID := ""
-		if ga.ServerUUID != nil {
-			serverUUID = *ga.ServerUUID
+	}
+	return CloudProfile{}, false
+}
+
+// GetCloudProfiles returns the configured cloud profiles and the account signed in to each.
+func (a *App) GetCloudProfiles() []CloudProfile {
+	profiles := cloudProfiles()
+	for i := range profiles {
+		if acc := auth.GetCloudProfileAccount(profiles[i].Name); acc != nil {
+			profiles[i].Account = acc.Email
 		}
-		result = append(result, CloudGameAccountInfo{
-			ID:         ga.ID,
-			Username:   ga.Username,
-			UUID:       ga.UUID,
-			ServerUUID: serverUUID,
-			SkinModel:  ga.SkinModel,
-			SkinURL:    skinURL,
-			CapeURL:    capeURL,
-			MojangUUID: mojangUUID,
-		})
 	}
-	return result, ""
+	return profiles
 }
 
-// UpdateCloudGameAccount updates skin for a QMServer Cloud game account
-func (a *App) UpdateCloudGameAccount(gameAccountID uint, skinURL string, skinModel string) string {
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return "No QMServer Cloud account. Log in first."
+// SetCloudProfiles replaces the cloud profiles. Names are lowercase ([a-z0-9._-]); endpoints take the
+// same forms as SetQMServerEndpoint. Sign-ins of removed profiles are dropped. Returns empty string on
+// success.
+func (a *App) SetCloudProfiles(profiles []CloudProfile) string {
+	clean := make([]CloudProfile, 0, len(profiles))
+	seen := map[string]bool{}
+	for _, p := range profiles {
+		p.Name = strings.ToLower(strings.TrimSpace(p.Name))
+		p.Endpoint = strings.TrimSpace(p.Endpoint)
+		p.Account = ""
+		if !cloudProfileNameRe.MatchString(p.Name) {
+			return fmt.Sprintf("Error: invalid profile name %q", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Sprintf("Error: duplicate profile %q", p.Name)
+		}
+		if _, _, err := parseQMServerEndpoint(p.Endpoint); err != nil {
+			return fmt.Sprintf("Error: profile %s: %v", p.Name, err)
+		}
+		seen[p.Name] = true
+		clean = append(clean, p)
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	bodyMap := map[string]interface{}{}
-	if skinModel != "" {
-		bodyMap["skin_model"] = skinModel
+	for _, old := range cloudProfiles() {
+		if !seen[old.Name] {
+			_ = auth.RemoveCloudProfileAccount(old.Name)
+		}
 	}
-	if skinURL == "" {
-		bodyMap["skin_url"] = nil
-	} else {
-		bodyMap["skin_url"] = skinURL
+	var value interface{}
+	if len(clean) > 0 {
+		value = clean
 	}
-	body, _ := json.Marshal(bodyMap)
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/game-accounts/%d", apiBase, gameAccountID), bytes.NewReader(body))
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	if err := setLauncherSetting("cloud_profiles", value); err != nil {
+		return "Error: " + err.Error()
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
+	return ""
+}
+
+// SetInstanceCloudProfile selects the cloud profile an instance syncs with ("" = default endpoint).
+// An instance's own QMServer host still takes precedence. Returns error string on failure.
+func (a *App) SetInstanceCloudProfile(instanceName, profile string) string {
+	inst, err := launcher.FetchInstance(instanceName)
 	if err != nil {
 		return fmt.Sprintf("Error: %v", err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	if profile != "" {
+		if _, ok := findCloudProfile(profile); !ok {
+			return fmt.Sprintf("Error: unknown cloud profile %q", profile)
+		}
 	}
-	return "Skin updated"
+	inst.Config.CloudProfile = profile
+	if err := inst.WriteConfig(); err != nil {
+		return fmt.Sprintf("Error: failed to save config: %v", err)
+	}
+	return ""
 }
 
-// DeleteCloudGameAccount deletes a QMServer Cloud game account
-func (a *App) DeleteCloudGameAccount(gameAccountID uint) string {
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return "No QMServer Cloud account. Log in first."
+// withCloudProfile selects a cloud profile for one sync or push, overriding the instance's endpoint.
+func withCloudProfile(inst *launcher.Instance, profile string) error {
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	if profile == "" {
+		return nil
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/game-accounts/%d", apiBase, gameAccountID), nil)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	if _, ok := findCloudProfile(profile); !ok {
+		return fmt.Errorf("unknown cloud profile %q", profile)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	inst.Config.CloudProfile = profile
+	inst.Config.QMServerHost = ""
+	return nil
+}
+
+// launcherSettingString reads a string key of settings.json, or "".
+func launcherSettingString(key string) string {
+	v, _ := readLauncherSettingsMap()[key].(string)
+	return strings.TrimSpace(v)
+}
+
+// QMServerEndpointSettings is the QMServer Cloud endpoint used by instances without their own host.
+type QMServerEndpointSettings struct {
+	Endpoint  string `json:"endpoint"`           // settings.json "qmserver_endpoint"
+	EnvValue  string `json:"envValue,omitempty"` // QMSERVER_ENDPOINT, overrides Endpoint
+	Effective string `json:"effective"`          // host:port in use
+}
+
+// GetQMServerEndpoint returns the configured and effective QMServer Cloud endpoint.
+func (a *App) GetQMServerEndpoint() QMServerEndpointSettings {
+	host, port := qmServerEndpoint()
+	return QMServerEndpointSettings{
+		Endpoint:  launcherSettingString("qmserver_endpoint"),
+		EnvValue:  strings.TrimSpace(os.Getenv("QMSERVER_ENDPOINT")),
+		Effective: net.JoinHostPort(host, strconv.Itoa(port)),
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+}
+
+// SetQMServerEndpoint stores the QMServer Cloud endpoint ("host:port" or a URL) for self-hosted QMServer
+// installations. An empty value restores the default. Returns empty string on success.
+func (a *App) SetQMServerEndpoint(endpoint string) string {
+	endpoint = strings.TrimSpace(endpoint)
+	var value interface{}
+	if endpoint != "" {
+		if _, _, err := parseQMServerEndpoint(endpoint); err != nil {
+			return "Error: " + err.Error()
+		}
+		value = endpoint
 	}
-	// Emit event so frontend refreshes accounts list without restart
-	if a.ctx != nil {
-		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+	if err := setLauncherSetting("qmserver_endpoint", value); err != nil {
+		return "Error: " + err.Error()
 	}
-	return "Game account deleted"
+	host, port := qmServerEndpoint()
+	logMessage(fmt.Sprintf("[QMServer] Адрес QMServer Cloud: %s:%d", host, port))
+	return ""
 }
 
-// InventorySlot represents a single inventory slot
-type InventorySlot struct {
-	Slot  int    `json:"slot"`
-	Item  string `json:"item"`
-	Count int    `json:"count"`
-	NBT   string `json:"nbt,omitempty"`
+// getQMServerBaseURL uses https on port 443, when HTTPS is enforced in the QMServer TLS settings, and for
+// the launcher-wide endpoint or a cloud profile when it is configured as an https:// URL.
+func getQMServerBaseURL(host string, port int) string {
+	if h, p, https := qmServerEndpointScheme(); https && h == host && p == port {
+		return "https://" + net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	if profile, ok := cloudProfileForHost(host, port); ok && strings.HasPrefix(strings.ToLower(profile.Endpoint), "https://") {
+		return "https://" + net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	return network.QMServerBaseURL(host, port)
 }
 
-// InventoryEntry represents inventory from one server
-type InventoryEntry struct {
-	ServerID      string          `json:"server_id"`
-	ServerName    string          `json:"server_name,omitempty"`
-	ServerVersion string          `json:"server_version,omitempty"`
-	PlayerName    string          `json:"player_name"`
-	Timestamp     int64           `json:"timestamp"`
-	Main          []InventorySlot `json:"main"`
-	Armor         []InventorySlot `json:"armor"`
-	Offhand       *InventorySlot  `json:"offhand,omitempty"`
+// applyQMServerTLSFromSettingsMap applies settings.json "qmserver_tls" to the QMServer HTTP client.
+func applyQMServerTLSFromSettingsMap(cfg map[string]interface{}) {
+	raw, ok := cfg["qmserver_tls"]
+	if !ok {
+		return
+	}
+	var tlsCfg network.QMServerTLS
+	data, _ := json.Marshal(raw)
+	if err := json.Unmarshal(data, &tlsCfg); err != nil {
+		logMessage(fmt.Sprintf("[QMServer] Некорректные настройки TLS: %v", err))
+		return
+	}
+	if err := network.ConfigureQMServerTLS(tlsCfg); err != nil {
+		logMessage(fmt.Sprintf("[QMServer] Настройки TLS не применены: %v", err))
+		return
+	}
+	logMessage(fmt.Sprintf("[QMServer] TLS: https=%v, CA=%q, пинов: %d", tlsCfg.HTTPS, tlsCfg.CABundle, len(tlsCfg.Pins)))
 }
 
-// GameAccountInventoryResponse is the response for GetGameAccountInventory
-type GameAccountInventoryResponse struct {
-	Inventories []InventoryEntry `json:"inventories"`
-	Error       string           `json:"error,omitempty"`
+// GetQMServerTLSSettings returns the TLS settings applied to QMServer Cloud traffic.
+func (a *App) GetQMServerTLSSettings() network.QMServerTLS {
+	cfg := network.CurrentQMServerTLS()
+	if cfg.Pins == nil {
+		cfg.Pins = []string{}
+	}
+	return cfg
 }
 
-// GetGameAccountInventory fetches inventory for a QMServer Cloud game account.
-// Requires Cloud account to be logged in. Returns inventories and optional error message.
-func (a *App) GetGameAccountInventory(gameAccountID uint) GameAccountInventoryResponse {
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return GameAccountInventoryResponse{Error: "No QMServer Cloud account. Log in first."}
+// SetQMServerTLSSettings enforces HTTPS, adds a CA bundle (PEM file) and pins certificates or public keys
+// ("sha256/<base64>" SPKI hashes or hex SHA-256 fingerprints) for QMServer Cloud. The settings are
+// validated before they are saved. Returns empty string on success.
+func (a *App) SetQMServerTLSSettings(cfg network.QMServerTLS) string {
+	cfg.CABundle = strings.TrimSpace(cfg.CABundle)
+	pins := []string{}
+	for _, pin := range cfg.Pins {
+		if pin = strings.TrimSpace(pin); pin != "" {
+			pins = append(pins, pin)
+		}
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/game-accounts/%d/inventory", apiBase, gameAccountID), nil)
+	cfg.Pins = pins
+	if err := network.ConfigureQMServerTLS(cfg); err != nil {
+		return "Error: " + err.Error()
+	}
+	if err := setLauncherSetting("qmserver_tls", cfg); err != nil {
+		return "Error: " + err.Error()
+	}
+	network.InvalidateServersCache()
+	return ""
+}
+
+// applyBandwidthLimitFromSettingsMap applies the download limit: QMLAUNCHER_BW_LIMIT, then settings.json
+// "bandwidth_limit" (e.g. "5MB/s").
+func applyBandwidthLimitFromSettingsMap(cfg map[string]interface{}) {
+	raw, _ := cfg["bandwidth_limit"].(string)
+	if env := strings.TrimSpace(os.Getenv("QMLAUNCHER_BW_LIMIT")); env != "" {
+		raw = env
+	}
+	limit, err := network.ParseBandwidth(raw)
 	if err != nil {
-		return GameAccountInventoryResponse{Error: fmt.Sprintf("Error: %v", err)}
+		logMessage(fmt.Sprintf("[Network] Ограничение скорости не применено: %v", err))
+		return
 	}
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
+	network.SetBandwidthLimit(limit)
+	if limit > 0 {
+		logMessage(fmt.Sprintf("[Network] Ограничение скорости загрузки: %.1f МБ/с", float64(limit)/(1<<20)))
+	}
+}
+
+// BandwidthSettings is the download speed limit shared by cloud sync, game files, Java and launcher updates.
+type BandwidthSettings struct {
+	Limit       string `json:"limit"`       // as saved, e.g. "5MB/s"; "" = unlimited
+	BytesPerSec int64  `json:"bytesPerSec"` // effective limit, 0 = unlimited
+}
+
+// GetBandwidthLimit returns the saved and the effective download limit.
+func (a *App) GetBandwidthLimit() BandwidthSettings {
+	return BandwidthSettings{Limit: launcherSettingString("bandwidth_limit"), BytesPerSec: network.BandwidthLimit()}
+}
+
+// SetBandwidthLimit limits download speed so launching or syncing doesn't saturate the connection, e.g.
+// "5MB/s" or "500KB/s"; "" or "0" removes the limit. Takes effect for running downloads immediately.
+// Returns empty string on success.
+func (a *App) SetBandwidthLimit(limit string) string {
+	limit = strings.TrimSpace(limit)
+	bytesPerSec, err := network.ParseBandwidth(limit)
 	if err != nil {
-		return GameAccountInventoryResponse{Error: fmt.Sprintf("Error: %v", err)}
+		return "Error: " + err.Error()
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return GameAccountInventoryResponse{Error: fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))}
+	var value interface{}
+	if bytesPerSec > 0 {
+		value = limit
 	}
-	var data struct {
-		Inventories []struct {
-			ServerID      string          `json:"server_id"`
-			ServerName    string          `json:"server_name"`
-			ServerVersion string          `json:"server_version"`
-			PlayerName    string          `json:"player_name"`
-			Timestamp     int64           `json:"timestamp"`
-			Main          []InventorySlot `json:"main"`
-			Armor         []InventorySlot `json:"armor"`
-			Offhand       *InventorySlot  `json:"offhand,omitempty"`
-		} `json:"inventories"`
+	if err := setLauncherSetting("bandwidth_limit", value); err != nil {
+		return "Error: " + err.Error()
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return GameAccountInventoryResponse{Error: fmt.Sprintf("Parse error: %v", err)}
+	network.SetBandwidthLimit(bytesPerSec)
+	return ""
+}
+
+// GetQMServerAPIBase returns the effective QMServer API base URL (cloud or custom; for proxy, etc.)
+func (a *App) GetQMServerAPIBase() string {
+	return network.EffectiveQMServerAPIBase()
+}
+
+// GetMicrosoftAuthAvailable returns true if Microsoft/Mojang login is allowed and a Client ID is available (server, env, or built-in default).
+func (a *App) GetMicrosoftAuthAvailable() bool {
+	if network.IsMSAAuthDisabledByQMServer() {
+		return false
 	}
-	result := make([]InventoryEntry, 0, len(data.Inventories))
-	for _, inv := range data.Inventories {
-		result = append(result, InventoryEntry{
-			ServerID:      inv.ServerID,
-			ServerName:    inv.ServerName,
-			ServerVersion: inv.ServerVersion,
-			PlayerName:    inv.PlayerName,
-			Timestamp:     inv.Timestamp,
-			Main:          inv.Main,
-			Armor:         inv.Armor,
-			Offhand:       inv.Offhand,
-		})
+	return network.GetQMLauncherMSAClientID() != ""
+}
+
+// JavaInstallResult is the outcome of InstallJavaRuntime.
+type JavaInstallResult struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Error string `json:"error,omitempty"`
+}
+
+// InstallJavaRuntime downloads a managed Java runtime into the launcher's java directory: Temurin
+// ("temurin", default) by major version, or a Mojang runtime ("mojang") by major version or component
+// name. arch ("" = this system) installs a Temurin build of another architecture, e.g. x64 for Rosetta.
+// headless links a smaller Temurin runtime without desktop modules (servers, prepare-only; Java 11+).
+// Archives are verified against the published SHA-256 before extraction. Progress is emitted as
+// "java-install-progress" {version, vendor, phase, done, total}.
+func (a *App) InstallJavaRuntime(version, vendor, arch string, headless bool) JavaInstallResult {
+	logMessage(fmt.Sprintf("[Java] Установка Java %s (%s)", version, vendor))
+	opts := launcher.JavaInstallOptions{Version: version, Vendor: vendor, Arch: strings.TrimSpace(arch), Headless: headless}
+	java, err := launcher.InstallJava(opts, func(p launcher.JavaInstallProgress) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "java-install-progress", map[string]interface{}{
+				"version": version,
+				"vendor":  vendor,
+				"phase":   p.Phase,
+				"done":    p.Done,
+				"total":   p.Total,
+			})
+		}
+	})
+	if err != nil {
+		logMessage(fmt.Sprintf("[Java] Ошибка установки Java %s: %v", version, err))
+		return JavaInstallResult{Error: err.Error()}
 	}
-	return GameAccountInventoryResponse{Inventories: result}
+	logMessage(fmt.Sprintf("[Java] Java установлена: %s", java.Path))
+	return JavaInstallResult{Name: java.Name, Path: java.Path}
 }
 
-// CreateLocalAccount creates a new local account.
-// skinModel: "steve" (male/classic) or "alex" (female/slim) - determines default skin in game
-func (a *App) CreateLocalAccount(name string, skinModel string) string {
-	if name == "" {
-		return "Account name cannot be empty"
+// defaultJavaSetting is the launcher-wide Java runtime (settings.json "default_java"), or "".
+func defaultJavaSetting() string {
+	ref, _ := readLauncherSettingsMap()["default_java"].(string)
+	return strings.TrimSpace(ref)
+}
+
+// JavaRuntimesReport is the result of ListJavaRuntimes.
+type JavaRuntimesReport struct {
+	Runtimes []launcher.JavaRuntime `json:"runtimes"`
+	Default  string                 `json:"default,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// ListJavaRuntimes lists the managed runtimes with the version, vendor and architecture reported by each
+// JVM, their disk size and the instances that use them.
+func (a *App) ListJavaRuntimes() JavaRuntimesReport {
+	def := defaultJavaSetting()
+	runtimes, err := launcher.ListJavaRuntimes(def)
+	if err != nil {
+		return JavaRuntimesReport{Error: err.Error()}
 	}
-	if skinModel != auth.SkinModelAlex {
-		skinModel = auth.SkinModelSteve
+	return JavaRuntimesReport{Runtimes: runtimes, Default: def}
+}
+
+// GetJavaRuntimesJSON returns the full Java inventory (managed and system runtimes with version, vendor,
+// arch and the instances using them, plus aliases and the default) as indented JSON for scripts and
+// runtime pickers.
+func (a *App) GetJavaRuntimesJSON() string {
+	inv, err := launcher.BuildJavaInventory(defaultJavaSetting())
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
 	}
-	auth.AddLocalAccount(name, skinModel)
-	return fmt.Sprintf("Local account '%s' created", name)
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	return string(data)
 }
 
-// CreateCloudGameAccount creates a new QMServer Cloud game account.
-// username: Minecraft nickname (required)
-// skinModel: "steve" (male/classic) or "alex" (female/slim) - determines default skin in game
-func (a *App) CreateCloudGameAccount(username string, skinModel string) string {
-	if username == "" {
-		return "Username cannot be empty"
+// JavaGCReport is the result of CollectUnusedJava.
+type JavaGCReport struct {
+	Unused     []launcher.JavaRuntime `json:"unused"`
+	Removed    []string               `json:"removed"`
+	FreedBytes int64                  `json:"freedBytes"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// CollectUnusedJava finds managed runtimes that no instance (configured or implicit Mojang runtime) and
+// not the launcher default use. With apply they are deleted; otherwise they are only reported.
+func (a *App) CollectUnusedJava(apply bool) JavaGCReport {
+	unused, err := launcher.UnusedJavaRuntimes(defaultJavaSetting())
+	if err != nil {
+		return JavaGCReport{Error: err.Error()}
 	}
-	if skinModel != auth.SkinModelAlex {
-		skinModel = auth.SkinModelSteve
+	report := JavaGCReport{Unused: unused, Removed: []string{}}
+	if report.Unused == nil {
+		report.Unused = []launcher.JavaRuntime{}
 	}
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return "No QMServer Cloud account. Log in first."
+	if !apply {
+		return report
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	bodyMap := map[string]interface{}{
-		"username":   username,
-		"skin_model": skinModel,
+	for _, r := range unused {
+		if err := os.RemoveAll(r.Path); err != nil {
+			logMessage(fmt.Sprintf("[Java] Не удалось удалить %s: %v", r.Path, err))
+			continue
+		}
+		report.Removed = append(report.Removed, r.Name)
+		report.FreedBytes += r.SizeBytes
 	}
-	body, _ := json.Marshal(bodyMap)
-	req, err := http.NewRequest("POST", apiBase+"/game-accounts", bytes.NewReader(body))
+	logMessage(fmt.Sprintf("[Java] Удалено неиспользуемых Java: %d (%d байт)", len(report.Removed), report.FreedBytes))
+	return report
+}
+
+// JavaUpdateReport is the result of UpdateJavaRuntimes.
+type JavaUpdateReport struct {
+	Updates []launcher.JavaUpdate `json:"updates"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// UpdateJavaRuntimes checks the installed Temurin and Mojang runtimes against the latest release of their
+// major version. With apply, outdated runtimes are replaced in place under the same name, so instances
+// and the default keep pointing at them; otherwise updates are only reported.
+func (a *App) UpdateJavaRuntimes(apply bool) JavaUpdateReport {
+	updates, err := launcher.CheckJavaUpdates()
 	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+		return JavaUpdateReport{Error: err.Error()}
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+cloudAcc.Token)
-	resp, err := network.QMServerHTTPClient.Do(req)
-	if err != nil {
-		return fmt.Sprintf("Error: %v", err)
+	if !apply {
+		return JavaUpdateReport{Updates: updates}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Sprintf("API error %d: %s", resp.StatusCode, string(b))
+	for i, u := range updates {
+		if !u.Available {
+			continue
+		}
+		logMessage(fmt.Sprintf("[Java] Обновление %s: %s → %s", u.Name, u.Current, u.Latest))
+		err := launcher.UpdateJava(u, func(p launcher.JavaInstallProgress) {
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "java-install-progress", map[string]interface{}{
+					"version": u.Latest,
+					"vendor":  u.Vendor,
+					"name":    u.Name,
+					"phase":   p.Phase,
+					"done":    p.Done,
+					"total":   p.Total,
+				})
+			}
+		})
+		if err != nil {
+			logMessage(fmt.Sprintf("[Java] Ошибка обновления %s: %v", u.Name, err))
+			updates[i].Error = err.Error()
+			continue
+		}
+		updates[i].Current = u.Latest
+		updates[i].Available = false
 	}
-	// Emit event so frontend refreshes accounts list without restart
-	if a.ctx != nil {
-		runtime.EventsEmit(a.ctx, "cloud-auth-success", nil)
+	return JavaUpdateReport{Updates: updates}
+}
+
+// javaArgTemplatesSetting reads settings.json "java_arg_templates" ({"8-16": "...", "21": "..."}).
+func javaArgTemplatesSetting() map[string]string {
+	raw, _ := readLauncherSettingsMap()["java_arg_templates"].(map[string]interface{})
+	out := map[string]string{}
+	for key, value := range raw {
+		_, _, err := launcher.ParseJavaMajorRange(key)
+		args, ok := value.(string)
+		if err == nil && ok && strings.TrimSpace(args) != "" {
+			out[strings.TrimSpace(key)] = strings.TrimSpace(args)
+		}
 	}
-	return fmt.Sprintf("Game account '%s' created", username)
+	return out
 }
 
-// DeleteLocalAccount deletes a local account and removes its player dirs from all instances.
-// Symlinks (mods, kubejs, etc.) are removed, not followed — shared files stay intact.
-func (a *App) DeleteLocalAccount(name string) string {
-	acc := auth.GetLocalAccountByName(name)
-	auth.LocalStore.RemoveLocalAccount(name)
-	if acc != nil && acc.UUID != "" {
-		launcher.RemovePlayerDirsForAccount(acc.UUID)
+// GetJavaArgTemplates returns the JVM argument templates keyed by Java major version or range.
+func (a *App) GetJavaArgTemplates() map[string]string {
+	return javaArgTemplatesSetting()
+}
+
+// SetJavaArgTemplates stores JVM argument templates keyed by Java major version ("17") or range ("8-16",
+// "21-" for 21 and later). At launch the template for exactly the runtime's major version, else the
+// narrowest range containing it, is added before the instance's java_args, so flags that only exist on
+// some Java versions do not break others. Empty values are dropped.
+func (a *App) SetJavaArgTemplates(templates map[string]string) string {
+	out := map[string]string{}
+	for key, args := range templates {
+		if _, _, err := launcher.ParseJavaMajorRange(key); err != nil {
+			return "Error: " + err.Error()
+		}
+		if args = strings.TrimSpace(args); args != "" {
+			out[strings.ReplaceAll(strings.TrimSpace(key), " ", "")] = args
+		}
 	}
-	return fmt.Sprintf("Local account '%s' deleted", name)
+	var value interface{}
+	if len(out) > 0 {
+		value = out
+	}
+	if err := setLauncherSetting("java_arg_templates", value); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
 }
 
-// SetDefaultAccount sets the default local account
-func (a *App) SetDefaultAccount(name string) string {
-	auth.LocalStore.SetDefaultAccount(name)
-	return fmt.Sprintf("Default account set to '%s'", name)
+// JavaMirrorSettings are alternate endpoints for Java runtime downloads ("" = official).
+type JavaMirrorSettings struct {
+	Mojang   string `json:"mojang"`   // replaces Mojang runtime hosts, e.g. https://bmclapi2.bangbang93.com
+	Adoptium string `json:"adoptium"` // replaces https://api.adoptium.net
 }
 
-// GetCloudProfile fetches the QMServer Cloud user profile (avatar_url, etc.) for the default cloud account
-func (a *App) GetCloudProfile() map[string]interface{} {
-	result := make(map[string]interface{})
-	cloudAcc := auth.GetDefaultCloudAccount()
-	if cloudAcc == nil || cloudAcc.Token == "" {
-		return result
+func applyJavaMirrorsFromSettingsMap(cfg map[string]interface{}) {
+	mojang, _ := cfg["java_mirror_mojang"].(string)
+	adoptium, _ := cfg["java_mirror_adoptium"].(string)
+	meta.SetJavaMirrors(mojang, adoptium)
+	if mojang != "" || adoptium != "" {
+		logMessage(fmt.Sprintf("[Java] Зеркала загрузки Java: Mojang=%q, Adoptium=%q", mojang, adoptium))
 	}
-	apiBase := network.EffectiveQMServerAPIBase()
-	req, err := http.NewRequest("GET", apiBase+"/auth/me?token="+url.QueryEscape(cloudAcc.Token), nil)
+}
+
+// GetJavaMirrorSettings returns the Java download mirrors from settings.json.
+func (a *App) GetJavaMirrorSettings() JavaMirrorSettings {
+	mojang, adoptium := meta.JavaMirrors()
+	return JavaMirrorSettings{Mojang: mojang, Adoptium: adoptium}
+}
+
+// SetJavaMirrorSettings persists the Java download mirrors and applies them to InstallJavaRuntime and the
+// automatic Mojang runtime download at launch. Empty values restore the official endpoints.
+func (a *App) SetJavaMirrorSettings(mojang, adoptium string) string {
+	mojang, err := meta.ValidateMirrorURL(mojang)
 	if err != nil {
-		return result
+		return "Error: " + err.Error()
 	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := network.QMServerHTTPClient.Do(req)
+	adoptium, err = meta.ValidateMirrorURL(adoptium)
 	if err != nil {
-		return result
+		return "Error: " + err.Error()
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return result
+	for key, value := range map[string]string{"java_mirror_mojang": mojang, "java_mirror_adoptium": adoptium} {
+		var v interface{}
+		if value != "" {
+			v = value
+		}
+		if err := setLauncherSetting(key, v); err != nil {
+			return "Error: " + err.Error()
+		}
 	}
-	var data map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return result
+	meta.SetJavaMirrors(mojang, adoptium)
+	return ""
+}
+
+// JavaComponentsReport is the result of ListAvailableJavaComponents.
+type JavaComponentsReport struct {
+	Platform   string               `json:"platform"`
+	Platforms  []string             `json:"platforms"`
+	Components []meta.JavaComponent `json:"components"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// ListAvailableJavaComponents lists the Mojang runtime components (jre-legacy, java-runtime-gamma, …) for
+// platform ("" = this system) with their Java version and unpacked size. Any of them can be installed with
+// InstallJavaRuntime with the "mojang" vendor.
+func (a *App) ListAvailableJavaComponents(platform string) JavaComponentsReport {
+	platform = strings.TrimSpace(platform)
+	if platform == "" {
+		platform = meta.JavaPlatform()
 	}
-	if v, ok := data["avatar_url"]; ok && v != nil {
-		if s, ok := v.(string); ok && s != "" {
-			result["avatar_url"] = s
-		}
+	report := JavaComponentsReport{Platform: platform, Platforms: []string{}, Components: []meta.JavaComponent{}}
+	if list, err := meta.FetchJavaManifestList(env.CachesDir); err == nil {
+		report.Platforms = list.Platforms()
 	}
-	if v, ok := data["is_premium"]; ok {
-		switch t := v.(type) {
-		case bool:
-			result["is_premium"] = t
-		case float64:
-			result["is_premium"] = t != 0
+	components, err := meta.ListJavaComponents(platform, env.CachesDir)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Components = append(report.Components, components...)
+	return report
+}
+
+// DetectSystemJava lists the Java runtimes installed on the system (JAVA_HOME, PATH and the usual
+// installation directories) with the version, vendor and architecture reported by each JVM. Entries with
+// an empty version failed to start. Any of them can be assigned with UseJavaRuntime.
+func (a *App) DetectSystemJava() []launcher.JavaInfo {
+	found := launcher.DetectSystemJava()
+	logMessage(fmt.Sprintf("[Java] Найдено системных Java: %d", len(found)))
+	return found
+}
+
+// JavaVerifyReport is the result of VerifyJavaRuntimes.
+type JavaVerifyReport struct {
+	Runtimes []launcher.JavaHealth `json:"runtimes"`
+	Broken   int                   `json:"broken"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// VerifyJavaRuntimes checks every managed runtime: it must start with -version, be executable, match the
+// host architecture and have a cacerts store. Broken runtimes can be reinstalled with InstallJavaRuntime.
+func (a *App) VerifyJavaRuntimes() JavaVerifyReport {
+	runtimes, err := launcher.VerifyInstalledJava()
+	if err != nil {
+		return JavaVerifyReport{Error: err.Error()}
+	}
+	report := JavaVerifyReport{Runtimes: runtimes}
+	for _, r := range runtimes {
+		if !r.OK {
+			report.Broken++
+			logMessage(fmt.Sprintf("[Java] %s повреждена: %s", r.Name, strings.Join(r.Problems, "; ")))
 		}
 	}
-	result["email"] = data["email"]
-	result["username"] = data["username"]
-	return result
+	return report
 }
 
-// GetCurrentAccount returns the current default account information
-func (a *App) GetCurrentAccount() map[string]interface{} {
-	result := make(map[string]interface{})
+// JavaDoctorEntry is the diagnosis of one runtime.
+type JavaDoctorEntry struct {
+	Name     string                 `json:"name"`
+	Path     string                 `json:"path"`
+	Info     launcher.JavaInfo      `json:"info"`
+	Findings []launcher.JavaFinding `json:"findings"`
+}
 
-	// Check if Microsoft account exists and is active (Microsoft account is always default if exists)
-	if auth.Store.MSA.RefreshToken != "" && auth.Store.Minecraft.Username != "" {
-		if auth.Store.Minecraft.Expires.After(time.Now()) {
-			result["name"] = auth.Store.Minecraft.Username
-			result["email"] = strings.ToLower(auth.Store.Minecraft.Username) + "@qmlauncher.local"
-			result["type"] = "microsoft"
-			result["isDefault"] = true
-			return result
+// JavaDoctorReport is the result of DiagnoseJava.
+type JavaDoctorReport struct {
+	Runtimes []JavaDoctorEntry `json:"runtimes"`
+	Errors   int               `json:"errors"`
+	Warnings int               `json:"warnings"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// DiagnoseJava runs deep checks (architecture and Rosetta, execute permissions, broken symlinks, musl vs
+// glibc, heap size against the JVM and installed memory) and suggests a fix for each finding. With an
+// instance name only the runtime that instance launches with is checked, against its maximum memory;
+// otherwise every managed runtime is.
+func (a *App) DiagnoseJava(instanceName string) JavaDoctorReport {
+	type target struct {
+		name, home string
+		maxMemory  int
+	}
+	var targets []target
+	if instanceName = strings.TrimSpace(instanceName); instanceName != "" {
+		inst, err := launcher.FetchInstance(instanceName)
+		if err != nil {
+			return JavaDoctorReport{Error: err.Error()}
 		}
-		// Even if expired, Microsoft account is still considered default
-		if auth.Store.Minecraft.Username != "" {
-			result["name"] = auth.Store.Minecraft.Username
-			result["email"] = strings.ToLower(auth.Store.Minecraft.Username) + "@qmlauncher.local"
-			result["type"] = "microsoft"
-			result["isDefault"] = true
-			return result
+		home := launcher.InstanceJavaHome(inst, defaultJavaSetting())
+		if home == "" {
+			return JavaDoctorReport{Error: "Java этой сборки ещё не загружена: запустите сборку один раз или укажите Java"}
+		}
+		targets = append(targets, target{filepath.Base(home), home, inst.Config.MaxMemory})
+	} else {
+		javas, err := launcher.ListInstalledJavaVersions()
+		if err != nil {
+			return JavaDoctorReport{Error: err.Error()}
+		}
+		for _, j := range javas {
+			targets = append(targets, target{j.Name, j.Path, 0})
 		}
 	}
-
-	// Check for default cloud account (takes precedence over local when set)
-	// Display QMServer Cloud account (email), NOT game account username
-	if cloudAcc := auth.GetDefaultCloudAccount(); cloudAcc != nil && cloudAcc.Token != "" {
-		result["name"] = cloudAcc.Email
-		result["email"] = cloudAcc.Email
-		result["type"] = "cloud"
-		result["isDefault"] = true
-		return result
+	report := JavaDoctorReport{Runtimes: []JavaDoctorEntry{}}
+	for _, t := range targets {
+		info, findings := launcher.DiagnoseJava(t.home, t.maxMemory)
+		for _, f := range findings {
+			if f.Severity == "error" {
+				report.Errors++
+			} else {
+				report.Warnings++
+			}
+		}
+		report.Runtimes = append(report.Runtimes, JavaDoctorEntry{Name: t.name, Path: t.home, Info: info, Findings: findings})
 	}
+	return report
+}
 
-	// Check for default local account — show "Локальный аккаунт" (local account, not game account)
-	defaultAccountName := auth.LocalStore.GetDefaultAccount()
-	if defaultAccountName != "" {
-		result["name"] = "Локальный аккаунт"
-		result["email"] = defaultAccountName
-		result["type"] = "local"
-		result["isDefault"] = true
-		return result
+// GetInstanceJavaEnv returns JAVA_HOME and PATH assignments for the runtime the instance launches with
+// (instance java, then the launcher default, then its Mojang runtime), in a form the shell can evaluate:
+// sh (default), fish, powershell or cmd. The second value is an error message.
+func (a *App) GetInstanceJavaEnv(instanceName, shell string) (string, string) {
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		return "", err.Error()
 	}
-
-	// If no default account, return empty values
-	result["name"] = ""
-	result["email"] = ""
-	result["type"] = ""
-	result["isDefault"] = false
-	return result
+	home := launcher.InstanceJavaHome(inst, defaultJavaSetting())
+	if home == "" {
+		return "", "Java этой сборки ещё не загружена: запустите сборку один раз или укажите Java"
+	}
+	if _, err := os.Stat(launcher.JavaExecutable(home)); err != nil {
+		return "", fmt.Sprintf("Java %s ещё не загружена: запустите сборку один раз", home)
+	}
+	script, err := launcher.JavaEnvScript(home, shell)
+	if err != nil {
+		return "", err.Error()
+	}
+	return script, ""
 }
 
-const defaultQMServerHost = "api.qx-dev.ru"
-const defaultQMServerPort = 443
+// GetJavaAliases returns the Java aliases with the executables they resolve to.
+func (a *App) GetJavaAliases() []launcher.JavaAlias {
+	aliases, err := launcher.JavaAliases()
+	if err != nil {
+		logMessage(fmt.Sprintf("[Java] Алиасы Java: %v", err))
+		return []launcher.JavaAlias{}
+	}
+	return aliases
+}
 
-func getQMServerBaseURL(host string, port int) string {
-	scheme := "http"
-	if port == 443 {
-		scheme = "https"
+// SetJavaAlias binds a short name (e.g. "lts21") to a managed runtime, Java home or java executable. The
+// alias can be used wherever a Java runtime is expected, including the instance's java setting.
+func (a *App) SetJavaAlias(alias, target string) string {
+	if err := launcher.SetJavaAlias(alias, target); err != nil {
+		return "Error: " + err.Error()
 	}
-	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
+	return ""
 }
 
-// GetQMServerAPIBase returns the effective QMServer API base URL (cloud or custom; for proxy, etc.)
-func (a *App) GetQMServerAPIBase() string {
-	return network.EffectiveQMServerAPIBase()
+// RemoveJavaAlias deletes a Java alias.
+func (a *App) RemoveJavaAlias(alias string) string {
+	if err := launcher.RemoveJavaAlias(alias); err != nil {
+		return "Error: " + err.Error()
+	}
+	return ""
 }
 
-// GetMicrosoftAuthAvailable returns true if Microsoft/Mojang login is allowed and a Client ID is available (server, env, or built-in default).
-func (a *App) GetMicrosoftAuthAvailable() bool {
-	if network.IsMSAAuthDisabledByQMServer() {
-		return false
+// UseJavaRuntime assigns a Java runtime (managed runtime name, alias, Java home or java executable) to an
+// instance and/or makes it the launcher default used by instances without their own Java. An empty
+// runtime clears the assignment, so the Mojang-provided JVM is used again.
+func (a *App) UseJavaRuntime(javaRuntime, instanceName string, setDefault bool) string {
+	instanceName = strings.TrimSpace(instanceName)
+	if instanceName == "" && !setDefault {
+		return "Error: укажите сборку или выберите Java по умолчанию"
 	}
-	return network.GetQMLauncherMSAClientID() != ""
+	java := ""
+	if ref := strings.TrimSpace(javaRuntime); ref != "" {
+		resolved, err := launcher.ResolveJavaRuntime(ref)
+		if err != nil {
+			return "Error: " + err.Error()
+		}
+		// Runtime names and aliases are kept as is so the config stays portable between machines
+		java = resolved
+		if !strings.ContainsAny(ref, `/\`) {
+			java = ref
+		}
+	}
+	if instanceName != "" {
+		inst, err := launcher.FetchInstance(instanceName)
+		if err != nil {
+			return "Error: " + err.Error()
+		}
+		inst.Config.Java = java
+		if err := inst.WriteConfig(); err != nil {
+			return "Error: " + err.Error()
+		}
+		logMessage(fmt.Sprintf("[Java] Сборка %s: java = %q", inst.Name, java))
+	}
+	if setDefault {
+		var value interface{}
+		if java != "" {
+			value = java
+		}
+		if err := setLauncherSetting("default_java", value); err != nil {
+			return "Error: " + err.Error()
+		}
+		logMessage(fmt.Sprintf("[Java] Java по умолчанию: %q", java))
+	}
+	return ""
 }
 
 // syncConfigFromQMServer syncs only config/ folder and options.txt from QMServer Cloud.
 // When accountUUID is set, syncs to the per-account directory (players/<uuid>/); otherwise to inst.Dir().
 func syncConfigFromQMServer(inst launcher.Instance, serverID uint, accountUUID string) error {
-	qmHost := inst.Config.QMServerHost
-	qmPort := inst.Config.QMServerPort
-	if qmHost == "" {
-		qmHost = defaultQMServerHost
-		qmPort = defaultQMServerPort
-		logMessage(fmt.Sprintf("[SyncConfig] Using default QMServer: %s:%d", qmHost, qmPort))
-	}
+	qmHost, qmPort := instanceQMServer(inst)
+	logMessage(fmt.Sprintf("[SyncConfig] Using QMServer: %s:%d", qmHost, qmPort))
 	logMessage(fmt.Sprintf("[SyncConfig] Downloading manifest for server ID: %d", serverID))
 	manifest, err := downloadDataManifest(serverID, qmHost, qmPort)
 	if err != nil {
 		return fmt.Errorf("failed to download manifest: %w", err)
 	}
+	if manifest.CachedAt != 0 {
+		logMessage("[SyncConfig] " + offlineManifestWarning(manifest))
+		return nil
+	}
 	// Filter: only config/*, journeymap/* and options.txt
 	var toSync []FileInfo
 	for _, f := range manifest.Files {
 		if f.Path == "options.txt" || strings.HasPrefix(f.Path, "config/") || strings.HasPrefix(f.Path, "journeymap/") {
+			if inst.Config.Sync.IsProtected(f.Path) {
+				logMessage(fmt.Sprintf("[SyncConfig] Skipping protected file: %s", f.Path))
+				continue
+			}
 			toSync = append(toSync, f)
 		}
 	}
@@ -3341,7 +8286,7 @@ func syncConfigFromQMServer(inst launcher.Instance, serverID uint, accountUUID s
 		logMessage("[SyncConfig] No config/, journeymap/ or options.txt in server manifest, nothing to sync")
 		return nil
 	}
-	logMessage(fmt.Sprintf("[SyncConfig] Found %d config/, journeymap/ or options.txt file(s) on server, syncing and overwriting", len(toSync)))
+	logMessage(fmt.Sprintf("[SyncConfig] Found %d config/, journeymap/ or options.txt file(s) on server, syncing", len(toSync)))
 	targetDir, err := launcher.GetAccountGameDir(inst, accountUUID)
 	if err != nil {
 		return fmt.Errorf("get account game dir: %w", err)
@@ -3349,48 +8294,277 @@ func syncConfigFromQMServer(inst launcher.Instance, serverID uint, accountUUID s
 	logMessage(fmt.Sprintf("[SyncConfig] Target directory for sync: %s", targetDir))
 	for _, fileInfo := range toSync {
 		destPath := filepath.Join(targetDir, fileInfo.Path)
+		if _, err := os.Stat(destPath); err == nil {
+			algo, want := fileInfo.checksum()
+			if existing, err := calculateFileHash(destPath, algo); err == nil && strings.EqualFold(existing, want) {
+				continue
+			}
+			if !resolveSyncConflict(inst, fileInfo.Path, destPath) {
+				continue
+			}
+		}
 		logMessage(fmt.Sprintf("[SyncConfig] Downloading %s -> %s", fileInfo.Path, destPath))
-		if err := downloadFile(serverID, fileInfo.Path, qmHost, qmPort, destPath); err != nil {
+		if err := downloadFile(serverID, fileInfo, qmHost, qmPort, destPath, nil); err != nil {
 			logMessage(fmt.Sprintf("[SyncConfig] Error downloading %s: %v", fileInfo.Path, err))
 			continue
 		}
 		logMessage(fmt.Sprintf("[SyncConfig] Synced: %s", fileInfo.Path))
 	}
-	return nil
+	return nil
+}
+
+// cloudWatchInterval is how often the manifest of a running cloud instance is checked for changes.
+const cloudWatchInterval = 3 * time.Minute
+
+// CloudUpdateNotice tells that the server files of a running instance changed since it was synced, so the
+// next launch will pull updates.
+type CloudUpdateNotice struct {
+	Instance string `json:"instance"`
+	ServerID uint   `json:"serverId"`
+	Added    int    `json:"added"`
+	Changed  int    `json:"changed"`
+	Removed  int    `json:"removed"`
+	Detected int64  `json:"detected"` // unix seconds
+}
+
+// cloudUpdateNotices holds the last notice per instance until the instance is launched again.
+var cloudUpdateNotices = struct {
+	sync.Mutex
+	byInstance map[string]CloudUpdateNotice
+}{byInstance: map[string]CloudUpdateNotice{}}
+
+// manifestChecksums maps manifest paths to their checksums.
+func manifestChecksums(m *DataManifest) map[string]string {
+	sums := make(map[string]string, len(m.Files))
+	for _, f := range m.Files {
+		_, want := f.checksum()
+		sums[f.Path] = strings.ToLower(want)
+	}
+	return sums
+}
+
+// watchCloudManifest polls the server manifest while the game runs and emits "cloud-update-available"
+// (CloudUpdateNotice) when the server files change, so the player knows a restart will pull them.
+// Polling is conditional (ETag), so an unchanged manifest costs one 304 response.
+func (a *App) watchCloudManifest(inst launcher.Instance, serverID uint, cmd *exec.Cmd) {
+	cloudUpdateNotices.Lock()
+	delete(cloudUpdateNotices.byInstance, inst.Name)
+	cloudUpdateNotices.Unlock()
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	host, port := instanceQMServer(inst)
+	// The manifest just synced is the cached one; fall back to the current one if sync did not cache it
+	var synced map[string]string
+	if cached := readCachedDataManifest(dataManifestCachePath(serverID, host, port)); cached != nil {
+		synced = manifestChecksums(&cached.Manifest)
+	} else if m, err := downloadDataManifest(serverID, host, port); err == nil {
+		synced = manifestChecksums(m)
+	} else {
+		logMessage(fmt.Sprintf("[CloudWatch] Отслеживание обновлений %s недоступно: %v", inst.Name, err))
+		return
+	}
+	last := synced
+
+	ticker := time.NewTicker(cloudWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exited:
+			return
+		case <-ticker.C:
+		}
+		m, err := downloadDataManifest(serverID, host, port)
+		if err != nil {
+			logMessage(fmt.Sprintf("[CloudWatch] Ошибка проверки обновлений %s: %v", inst.Name, err))
+			continue
+		}
+		current := manifestChecksums(m)
+		if maps.Equal(current, last) {
+			continue
+		}
+		last = current
+		notice := CloudUpdateNotice{Instance: inst.Name, ServerID: serverID, Detected: time.Now().Unix()}
+		for p, sum := range current {
+			if old, ok := synced[p]; !ok {
+				notice.Added++
+			} else if old != sum {
+				notice.Changed++
+			}
+		}
+		for p := range synced {
+			if _, ok := current[p]; !ok {
+				notice.Removed++
+			}
+		}
+		cloudUpdateNotices.Lock()
+		if notice.Added+notice.Changed+notice.Removed == 0 {
+			delete(cloudUpdateNotices.byInstance, inst.Name) // Back to the synced state
+		} else {
+			cloudUpdateNotices.byInstance[inst.Name] = notice
+		}
+		cloudUpdateNotices.Unlock()
+		logMessage(fmt.Sprintf("[CloudWatch] Файлы сервера %s изменились: добавлено %d, изменено %d, удалено %d",
+			inst.Name, notice.Added, notice.Changed, notice.Removed))
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "cloud-update-available", notice)
+		}
+	}
+}
+
+// GetCloudUpdateNotices returns the instances whose server files changed after they were launched.
+func (a *App) GetCloudUpdateNotices() []CloudUpdateNotice {
+	cloudUpdateNotices.Lock()
+	defer cloudUpdateNotices.Unlock()
+	notices := make([]CloudUpdateNotice, 0, len(cloudUpdateNotices.byInstance))
+	for _, n := range cloudUpdateNotices.byInstance {
+		notices = append(notices, n)
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].Instance < notices[j].Instance })
+	return notices
+}
+
+// SyncProgressEmitter sends progress updates to frontend (nil = no-op)
+type SyncProgressEmitter func(phase, message, currentFile string, progress float64)
+
+// cloudSyncOptions adjust how syncQMServerFiles reconciles an instance.
+type cloudSyncOptions struct {
+	only  syncSelection // manifest subtrees to reconcile; nil = all
+	force bool          // rehash every file and overwrite local changes whatever the conflict strategy
+}
+
+// hashCache returns the instance's hash cache, or a fresh one that rehashes every file in force mode.
+func (o cloudSyncOptions) hashCache(instanceDir string) *launcher.HashCache {
+	if o.force {
+		return launcher.NewHashCache(instanceDir, calculateFileHash)
+	}
+	return launcher.LoadHashCache(instanceDir, calculateFileHash)
+}
+
+// syncSelection limits a sync to some manifest subtrees, e.g. "mods" or "resourcepacks"; empty means
+// everything.
+type syncSelection []string
+
+// parseSyncSelection reads a comma-separated list of subtrees ("mods,resourcepacks"). config/ and
+// options.txt are rejected: file sync never touches them, they are synced with the config option.
+func parseSyncSelection(only string) (syncSelection, error) {
+	var sel syncSelection
+	for _, p := range strings.Split(only, ",") {
+		if p = strings.Trim(path.Clean("/"+filepath.ToSlash(strings.TrimSpace(p))), "/"); p != "" {
+			if p == "config" || p == "options.txt" || strings.HasPrefix(p, "config/") {
+				return nil, fmt.Errorf("%s is synced only with the config option, not with only", p)
+			}
+			sel = append(sel, p)
+		}
+	}
+	return sel, nil
+}
+
+// includes reports whether a manifest path is in the selection.
+func (sel syncSelection) includes(rel string) bool {
+	if len(sel) == 0 {
+		return true
+	}
+	for _, p := range sel {
+		if rel == p || strings.HasPrefix(rel, p+"/") {
+			return true
+		}
+	}
+	return false
 }
 
-// SyncProgressEmitter sends progress updates to frontend (nil = no-op)
-type SyncProgressEmitter func(phase, message, currentFile string, progress float64)
+// SyncSummary is the outcome of one cloud sync, shown once it finishes.
+type SyncSummary struct {
+	Files           int      `json:"files"` // in the server manifest
+	Downloaded      int      `json:"downloaded"`
+	DownloadedBytes int64    `json:"downloadedBytes"`
+	Updated         int      `json:"updated"`
+	UpdatedBytes    int64    `json:"updatedBytes"`
+	Deleted         int      `json:"deleted"` // moved to .sync-trash, or disabled resource/shader packs removed
+	Unchanged       int      `json:"unchanged"`
+	Protected       int      `json:"protected"`
+	Skipped         int      `json:"skipped"` // config-only and disabled files
+	KeptLocal       int      `json:"keptLocal"`
+	Incompatible    int      `json:"incompatible"`
+	Failed          []string `json:"failed"`
+	ElapsedMS       int64    `json:"elapsedMs"`
+
+	OfflineSince int64 `json:"offlineSince,omitempty"` // QMServer was unreachable: nothing synced, cached manifest from this time (unix seconds)
+}
+
+// Table formats the summary as aligned lines for the log.
+func (s SyncSummary) Table() []string {
+	mb := func(n int64) string { return fmt.Sprintf("%.1f MB", float64(n)/(1<<20)) }
+	lines := []string{
+		fmt.Sprintf("Sync summary: %d files in manifest, %.1fs", s.Files, float64(s.ElapsedMS)/1000),
+		fmt.Sprintf("  %-14s %6d  %s", "downloaded", s.Downloaded, mb(s.DownloadedBytes)),
+		fmt.Sprintf("  %-14s %6d  %s", "updated", s.Updated, mb(s.UpdatedBytes)),
+		fmt.Sprintf("  %-14s %6d", "deleted", s.Deleted),
+		fmt.Sprintf("  %-14s %6d", "unchanged", s.Unchanged),
+		fmt.Sprintf("  %-14s %6d", "protected", s.Protected),
+		fmt.Sprintf("  %-14s %6d", "skipped", s.Skipped),
+		fmt.Sprintf("  %-14s %6d", "kept local", s.KeptLocal),
+		fmt.Sprintf("  %-14s %6d", "incompatible", s.Incompatible),
+		fmt.Sprintf("  %-14s %6d", "failed", len(s.Failed)),
+	}
+	for _, f := range s.Failed {
+		lines = append(lines, "    "+f)
+	}
+	if s.OfflineSince != 0 {
+		lines = append(lines, "  cloud unreachable, nothing synced; cached state from "+time.Unix(s.OfflineSince, 0).Format(time.RFC3339))
+	}
+	return lines
+}
 
 // syncQMServerFiles synchronizes instance files with QMServer Cloud (like TUI does)
 // disabledMods: mod paths to exclude from sync and remove from local instance (e.g. mods/sodium.jar)
-// emitProgress: optional callback to send progress to UI (phase: checking|downloading|disabling, message, currentFile, progress 0-100)
-func syncQMServerFiles(inst launcher.Instance, serverID uint, disabledMods []string, emitProgress SyncProgressEmitter) error {
+// emitProgress: optional callback to send progress to UI (phase: checking|downloading|disabling|warning, message, currentFile, progress 0-100)
+// opts: subtrees to reconcile (files outside them are left alone) and force mode
+// The summary of what was done is logged as a table and returned.
+func syncQMServerFiles(inst launcher.Instance, serverID uint, disabledMods []string, opts cloudSyncOptions, emitProgress SyncProgressEmitter) (SyncSummary, error) {
 	logMessage(fmt.Sprintf("[ConnectToServer] Starting file sync with QMServer Cloud for server ID: %d", serverID))
+	start := time.Now()
+	summary := SyncSummary{Failed: []string{}}
 
 	// Get QMServer configuration from instance
-	config := inst.Config
-	if config.QMServerHost == "" {
-		logMessage("[ConnectToServer] QMServerHost not configured, skipping sync")
-		return nil
-	}
+	qmHost, qmPort := instanceQMServer(inst)
 
 	if serverID == 0 {
 		logMessage("[ConnectToServer] ServerID not set, skipping sync")
-		return nil
+		return summary, nil
 	}
 
-	logMessage(fmt.Sprintf("[ConnectToServer] Connecting to QMServer: %s:%d", config.QMServerHost, config.QMServerPort))
+	logMessage(fmt.Sprintf("[ConnectToServer] Connecting to QMServer: %s:%d", qmHost, qmPort))
 
 	// Download data manifest
 	logMessage(fmt.Sprintf("[ConnectToServer] Downloading data manifest for server ID: %d", serverID))
-	manifest, err := downloadDataManifest(serverID, config.QMServerHost, config.QMServerPort)
+	manifest, err := downloadDataManifest(serverID, qmHost, qmPort)
 	if err != nil {
 		logMessage(fmt.Sprintf("[ConnectToServer] Error downloading manifest: %v", err))
-		return fmt.Errorf("failed to download manifest: %w", err)
+		return summary, fmt.Errorf("failed to download manifest: %w", err)
+	}
+
+	// Offline grace: without the cloud nothing can be downloaded, so the instance is left as the last
+	// sync made it and the game starts with a warning
+	if manifest.CachedAt != 0 {
+		msg := offlineManifestWarning(manifest)
+		logMessage("[ConnectToServer] " + msg)
+		if emitProgress != nil {
+			emitProgress("warning", msg, "", 100)
+		}
+		summary.Files = len(manifest.Files)
+		summary.OfflineSince = manifest.CachedAt
+		summary.ElapsedMS = time.Since(start).Milliseconds()
+		return summary, nil
 	}
 
-	logMessage(fmt.Sprintf("[ConnectToServer] Manifest downloaded successfully, files in manifest: %d", len(manifest.Files)))
+	logMessage(fmt.Sprintf("[ConnectToServer] Manifest downloaded successfully, files in manifest: %d (manifest version %d)", len(manifest.Files), manifest.Version))
 
 	// Create a map of files from manifest for quick lookup
 	manifestFiles := make(map[string]FileInfo)
@@ -3410,34 +8584,20 @@ func syncQMServerFiles(inst launcher.Instance, serverID uint, disabledMods []str
 		logMessage(fmt.Sprintf("[ConnectToServer] Disabled mods: %v", disabledMods))
 	}
 
-	// Count files to sync (for progress)
-	var filesToSync []string
-	for filePath := range manifestFiles {
-		if filePath == "options.txt" || strings.HasPrefix(filePath, "config/") {
-			continue
-		}
-		filesToSync = append(filesToSync, filePath)
-	}
-	totalFiles := len(filesToSync)
-
 	// Remove orphaned files before syncing
 	logMessage("[ConnectToServer] Checking for orphaned files")
-	if err := removeOrphanedFiles(instanceDir, manifestFiles); err != nil {
+	only := opts.only
+	removed, err := removeOrphanedFiles(instanceDir, manifestFiles, inst.Config.Sync, only)
+	summary.Deleted += removed
+	if err != nil {
 		logMessage(fmt.Sprintf("[ConnectToServer] Error removing orphaned files: %v", err))
 	} else {
 		logMessage("[ConnectToServer] Orphaned files check completed")
 	}
 
-	// Sync files from manifest
-	filesProcessed := 0
-	filesDownloaded := 0
-	filesSkipped := 0
-	filesUpdated := 0
-	processedForProgress := 0
-
 	// Re-enable mods that are no longer disabled (rename .jar.disabled → .jar so we can sync)
 	for modPath := range manifestFiles {
-		if !strings.HasPrefix(modPath, "mods/") || disabledSet[modPath] {
+		if !strings.HasPrefix(modPath, "mods/") || disabledSet[modPath] || !only.includes(modPath) {
 			continue
 		}
 		disabledPath := modPath + ".disabled"
@@ -3450,12 +8610,14 @@ func syncQMServerFiles(inst launcher.Instance, serverID uint, disabledMods []str
 		}
 	}
 
+	// Select the files to check; the total size from the manifest drives the progress bar
+	var jobs []FileInfo
+	var totalBytes int64
+	filesSkipped := 0
 	for filePath, fileInfo := range manifestFiles {
-		filesProcessed++
-		instanceFilePath := filepath.Join(instanceDir, filePath)
-
-		logMessage(fmt.Sprintf("[ConnectToServer] Processing file: %s", filePath))
-
+		if !only.includes(filePath) {
+			continue
+		}
 		// By default do not sync config/ and options.txt
 		if filePath == "options.txt" || strings.HasPrefix(filePath, "config/") {
 			logMessage(fmt.Sprintf("[ConnectToServer] Skipping (sync only via config checkbox): %s", filePath))
@@ -3463,137 +8625,1193 @@ func syncQMServerFiles(inst launcher.Instance, serverID uint, disabledMods []str
 			continue
 		}
 
+		// Local customizations listed in sync.protect are never overwritten
+		if inst.Config.Sync.IsProtected(filePath) {
+			logMessage(fmt.Sprintf("[ConnectToServer] Skipping (protected): %s", filePath))
+			summary.Protected++
+			continue
+		}
+
 		// Skip disabled paths (mods, resourcepacks, shaderpacks)
 		if disabledSet[filePath] {
 			logMessage(fmt.Sprintf("[ConnectToServer] Skipping (disabled by user): %s", filePath))
 			filesSkipped++
 			// Remove local file if it exists (user disabled, shouldn't keep stale copy)
 			if strings.HasPrefix(filePath, "resourcepacks/") || strings.HasPrefix(filePath, "shaderpacks/") {
+				instanceFilePath := filepath.Join(instanceDir, filePath)
 				if _, err := os.Stat(instanceFilePath); err == nil {
 					if err := os.Remove(instanceFilePath); err == nil {
+						summary.Deleted++
 						logMessage(fmt.Sprintf("[ConnectToServer] Removed disabled: %s", filePath))
 					}
 				}
 			}
 			continue
 		}
+		jobs = append(jobs, fileInfo)
+		totalBytes += fileInfo.Size
+	}
+	// Largest files first, so a big shaderpack does not end up downloading alone at the end
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Size > jobs[j].Size })
+
+	cs := &cloudSync{
+		inst:     &inst,
+		serverID: serverID,
+		host:     qmHost,
+		port:     qmPort,
+		progress: &syncProgress{emit: emitProgress, totalFiles: len(jobs), totalBytes: totalBytes},
+		hashes:   opts.hashCache(instanceDir),
+		force:    opts.force,
+	}
+	work := make(chan FileInfo)
+	var wg sync.WaitGroup
+	for i := 0; i < min(syncWorkers, len(jobs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fileInfo := range work {
+				cs.syncFile(fileInfo)
+			}
+		}()
+	}
+	for _, fileInfo := range jobs {
+		work <- fileInfo
+	}
+	close(work)
+	wg.Wait()
+	if err := cs.hashes.Save(); err != nil {
+		logMessage(fmt.Sprintf("[ConnectToServer] Error saving %s: %v", launcher.SyncHashCacheFile, err))
+	}
+
+	// Disable mods by renaming .jar → .jar.disabled (Minecraft mod loaders skip .disabled files)
+	disabledCount := 0
+	for modPath := range disabledSet {
+		if !strings.HasPrefix(modPath, "mods/") || !only.includes(modPath) {
+			continue
+		}
+		disabledCount++
+		if emitProgress != nil {
+			denom := len(disabledSet)
+			if denom < 1 {
+				denom = 1
+			}
+			emitProgress("disabling", "Отключение мода: "+filepath.Base(modPath), modPath, 95+float64(disabledCount)*5/float64(denom))
+		}
+		localPath := filepath.Join(instanceDir, modPath)
+		disabledPath := localPath + ".disabled"
+		if _, err := os.Stat(localPath); err == nil {
+			_ = os.Remove(disabledPath) // Remove old .disabled if exists (we have fresh from sync)
+			if err := os.Rename(localPath, disabledPath); err == nil {
+				logMessage(fmt.Sprintf("[ConnectToServer] Disabled mod: %s → .disabled", modPath))
+			}
+		}
+	}
+
+	summary.Files = len(manifestFiles)
+	summary.Downloaded, summary.DownloadedBytes = int(cs.downloaded.Load()), cs.downloadedBytes.Load()
+	summary.Updated, summary.UpdatedBytes = int(cs.updated.Load()), cs.updatedBytes.Load()
+	summary.Unchanged = int(cs.unchanged.Load())
+	summary.Skipped = filesSkipped
+	summary.KeptLocal = int(cs.kept.Load())
+	summary.Incompatible = int(cs.incompatible.Load())
+	summary.Failed = append(summary.Failed, cs.failures...)
+	sort.Strings(summary.Failed)
+	summary.ElapsedMS = time.Since(start).Milliseconds()
+	for _, line := range summary.Table() {
+		logMessage("[ConnectToServer] " + line)
+	}
+	return summary, nil
+}
+
+const (
+	// syncWorkers is the number of files checked and downloaded in parallel during cloud sync.
+	syncWorkers = 6
+	// syncFileAttempts is how many times a file is downloaded before it is reported as failed.
+	syncFileAttempts = 3
+)
+
+// syncProgress aggregates the progress of parallel sync workers into one bar of files and bytes.
+type syncProgress struct {
+	mu         sync.Mutex
+	emit       SyncProgressEmitter
+	totalFiles int
+	doneFiles  int
+	totalBytes int64
+	doneBytes  int64
+	lastEmit   time.Time
+}
+
+func (p *syncProgress) percent() float64 {
+	switch {
+	case p.totalBytes > 0:
+		return math.Min(float64(p.doneBytes)/float64(p.totalBytes)*100, 100)
+	case p.totalFiles > 0:
+		return float64(p.doneFiles) / float64(p.totalFiles) * 100
+	}
+	return 100
+}
+
+func (p *syncProgress) send(phase, label, file string) {
+	if p.emit == nil {
+		return
+	}
+	msg := fmt.Sprintf("%s: %d/%d файлов, %.1f/%.1f МБ", label, p.doneFiles, p.totalFiles,
+		float64(p.doneBytes)/(1<<20), float64(p.totalBytes)/(1<<20))
+	p.emit(phase, msg, file, p.percent())
+	p.lastEmit = time.Now()
+}
+
+// transferred adds n downloaded bytes of file (negative to undo a failed attempt).
+func (p *syncProgress) transferred(file string, n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.doneBytes += n
+	// Byte updates come from every worker; a few per second are enough for the bar
+	if time.Since(p.lastEmit) >= 200*time.Millisecond {
+		p.send("downloading", "Скачивание", file)
+	}
+}
+
+// fileDone marks a file as processed. Its manifest size minus the bytes already reported by
+// transferred is added, so skipped and failed files also move the bar.
+func (p *syncProgress) fileDone(phase string, f FileInfo, transferred int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.doneFiles++
+	p.doneBytes += f.Size - transferred
+	label := "Синхронизация"
+	if phase == "skipped" {
+		label = "Пропуск"
+	}
+	p.send(phase, label, f.Path)
+}
+
+func (p *syncProgress) warn(msg, file string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.emit != nil {
+		p.emit("warning", msg, file, p.percent())
+	}
+}
+
+// SyncPlanEntry is one file in a sync plan.
+type SyncPlanEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SyncPlan is what syncQMServerFiles would do to an instance.
+type SyncPlan struct {
+	Download      []SyncPlanEntry `json:"download"` // missing locally
+	Update        []SyncPlanEntry `json:"update"`   // different from the server copy
+	Keep          []SyncPlanEntry `json:"keep"`     // protected or disabled, left as is
+	Delete        []SyncPlanEntry `json:"delete"`   // removed by sync (orphans go to .sync-trash)
+	Unchanged     int             `json:"unchanged"`
+	DownloadBytes int64           `json:"downloadBytes"`
+	DeleteBytes   int64           `json:"deleteBytes"`
+}
+
+// planQMServerSync compares an instance with the server manifest the same way syncQMServerFiles does,
+// without changing anything.
+func planQMServerSync(instanceDir string, manifestFiles map[string]FileInfo, disabledSet map[string]bool, syncCfg launcher.SyncConfig, opts cloudSyncOptions) (SyncPlan, error) {
+	only := opts.only
+	hashes := opts.hashCache(instanceDir)
+	defer func() { _ = hashes.Save() }()
+	plan := SyncPlan{Download: []SyncPlanEntry{}, Update: []SyncPlanEntry{}, Keep: []SyncPlanEntry{}, Delete: []SyncPlanEntry{}}
+	for filePath, fileInfo := range manifestFiles {
+		if !only.includes(filePath) {
+			continue
+		}
+		entry := SyncPlanEntry{Path: filePath, Size: fileInfo.Size}
+		local := filepath.Join(instanceDir, filepath.FromSlash(filePath))
+		if filePath == "options.txt" || strings.HasPrefix(filePath, "config/") {
+			entry.Reason = "synced only with the config option"
+			plan.Keep = append(plan.Keep, entry)
+			continue
+		}
+		if syncCfg.IsProtected(filePath) {
+			entry.Reason = "protected"
+			plan.Keep = append(plan.Keep, entry)
+			continue
+		}
+		if disabledSet[filePath] {
+			entry.Reason = "disabled by user"
+			if st, err := os.Stat(local); err == nil && (strings.HasPrefix(filePath, "resourcepacks/") || strings.HasPrefix(filePath, "shaderpacks/")) {
+				entry.Size = st.Size()
+				plan.Delete = append(plan.Delete, entry)
+				plan.DeleteBytes += entry.Size
+			} else {
+				plan.Keep = append(plan.Keep, entry)
+			}
+			continue
+		}
+		if _, err := os.Stat(local); err != nil && strings.HasPrefix(filePath, "mods/") {
+			// Sync re-enables a .disabled copy before comparing it
+			if _, err := os.Stat(local + ".disabled"); err == nil {
+				local += ".disabled"
+			}
+		}
+		if _, err := os.Stat(local); err != nil {
+			plan.Download = append(plan.Download, entry)
+			plan.DownloadBytes += entry.Size
+			continue
+		}
+		algo, want := fileInfo.checksum()
+		existing, err := hashes.Hash(local, algo)
+		if err != nil {
+			return SyncPlan{}, fmt.Errorf("hash %s: %w", filePath, err)
+		}
+		if strings.EqualFold(existing, want) {
+			plan.Unchanged++
+			continue
+		}
+		entry.Reason = algo + " differs"
+		strategy := syncCfg.ConflictStrategy(filePath)
+		if opts.force {
+			strategy = launcher.SyncOverwrite
+		}
+		switch strategy {
+		case launcher.SyncKeepLocal:
+			entry.Reason = "local changes kept (keep-local)"
+			plan.Keep = append(plan.Keep, entry)
+			continue
+		case launcher.SyncKeepBoth:
+			entry.Reason += ", local copy backed up (keep-both)"
+		case launcher.SyncAsk:
+			entry.Reason += ", player is asked (ask)"
+		}
+		plan.Update = append(plan.Update, entry)
+		plan.DownloadBytes += entry.Size
+	}
+	if _, err := os.Stat(filepath.Join(instanceDir, "mods")); err == nil {
+		orphans, _, err := orphanedSyncFiles(instanceDir, manifestFiles, syncCfg)
+		if err != nil {
+			return SyncPlan{}, err
+		}
+		for _, orphan := range orphans {
+			if !only.includes(orphan.Path) {
+				continue
+			}
+			plan.Delete = append(plan.Delete, orphan)
+			plan.DeleteBytes += orphan.Size
+		}
+	}
+	for _, list := range [][]SyncPlanEntry{plan.Download, plan.Update, plan.Keep, plan.Delete} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	}
+	return plan, nil
+}
+
+// localDisabledMods lists the mods disabled in the instance (mods/*.jar.disabled) as manifest paths.
+func localDisabledMods(instanceDir string) []string {
+	var mods []string
+	modsDir := filepath.Join(instanceDir, "mods")
+	_ = filepath.Walk(modsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".disabled") {
+			return nil
+		}
+		if rel, err := filepath.Rel(instanceDir, path); err == nil {
+			mods = append(mods, strings.TrimSuffix(filepath.ToSlash(rel), ".disabled"))
+		}
+		return nil
+	})
+	return mods
+}
+
+// SyncInstanceReport is the result of SyncInstance.
+type SyncInstanceReport struct {
+	DryRun   bool         `json:"dryRun"`
+	Plan     SyncPlan     `json:"plan"`
+	Summary  *SyncSummary `json:"summary,omitempty"` // what the sync did; nil for dry runs
+	Prepared bool         `json:"prepared,omitempty"`
+	Error    string       `json:"error,omitempty"`
+
+	Mismatch *CloudServerMismatch `json:"mismatch,omitempty"` // the sync was refused, see AlignInstanceWithServer
+}
+
+// InstanceSyncOptions are the options of SyncInstanceWithOptions.
+type InstanceSyncOptions struct {
+	Instance     string `json:"instance"`
+	ServerID     uint   `json:"serverId"`
+	DryRun       bool   `json:"dryRun"`       // only compute the plan
+	Only         string `json:"only"`         // comma-separated manifest subtrees, e.g. "mods,resourcepacks"; "" = everything
+	Force        bool   `json:"force"`        // rehash every file, overwrite local changes (sync.protect still applies) and skip the loader/version check
+	Prepare      bool   `json:"prepare"`      // also download the game, loader, libraries, assets and Java
+	CloudProfile string `json:"cloudProfile"` // "" = the instance's
+}
+
+// SyncInstance syncs an instance with the QMServer Cloud files of serverID without launching the game.
+// The plan of files to download, update, keep and delete is computed first; with dryRun nothing is
+// touched and only the plan is returned. Mods disabled locally (.jar.disabled) stay disabled.
+// cloudProfile ("" = the instance's) selects the cloud profile for this sync only. only limits the sync
+// to comma-separated manifest subtrees ("mods,resourcepacks"; "" = everything), e.g. to take mod updates
+// without touching anything else. config/ and options.txt are only synced with the config option, so
+// selecting them is an error.
+func (a *App) SyncInstance(instanceName string, serverID uint, dryRun bool, cloudProfile string, only string) SyncInstanceReport {
+	return a.SyncInstanceWithOptions(InstanceSyncOptions{
+		Instance:     instanceName,
+		ServerID:     serverID,
+		DryRun:       dryRun,
+		Only:         only,
+		CloudProfile: cloudProfile,
+	})
+}
+
+// SyncInstanceWithOptions is SyncInstance with every option, for server admins and batch jobs that keep
+// instances up to date without playing: Force re-checks every file and discards local changes, Prepare
+// also downloads everything the game needs so the next launch starts right away.
+func (a *App) SyncInstanceWithOptions(opts InstanceSyncOptions) SyncInstanceReport {
+	report := SyncInstanceReport{DryRun: opts.DryRun}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(opts.Instance))
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	serverID := opts.ServerID
+	if serverID == 0 {
+		report.Error = "server ID is required"
+		return report
+	}
+	only, err := parseSyncSelection(opts.Only)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	syncOpts := cloudSyncOptions{only: only, force: opts.Force}
+	if err := withCloudProfile(&inst, opts.CloudProfile); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	// Server profile states are only known for the launcher's own API
+	if inst.Config.CloudProfile == "" {
+		if err := network.CheckServerProfileConnectAllowed(serverID); err != nil {
+			report.Error = err.Error()
+			return report
+		}
+	}
+	if !opts.Force {
+		if m := cloudServerMismatch(inst, serverID); m != nil {
+			report.Mismatch = m
+			report.Error = m.Message
+			return report
+		}
+	}
+	qmHost, qmPort := instanceQMServer(inst)
+	manifest, err := downloadDataManifest(serverID, qmHost, qmPort)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	manifestFiles := make(map[string]FileInfo, len(manifest.Files))
+	for _, file := range manifest.Files {
+		manifestFiles[file.Path] = file
+	}
+	disabledMods := localDisabledMods(inst.Dir())
+	disabledSet := make(map[string]bool, len(disabledMods))
+	for _, p := range disabledMods {
+		disabledSet[p] = true
+	}
+	if len(syncOpts.only) > 0 {
+		logMessage(fmt.Sprintf("[Sync] %s: только %s", inst.Name, strings.Join(syncOpts.only, ", ")))
+	}
+	if opts.Force {
+		logMessage(fmt.Sprintf("[Sync] %s: принудительная синхронизация, локальные изменения будут перезаписаны", inst.Name))
+	}
+	report.Plan, err = planQMServerSync(inst.Dir(), manifestFiles, disabledSet, inst.Config.Sync, syncOpts)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	logMessage(fmt.Sprintf("[Sync] %s: скачать %d, обновить %d, оставить %d, удалить %d, без изменений %d",
+		inst.Name, len(report.Plan.Download), len(report.Plan.Update), len(report.Plan.Keep), len(report.Plan.Delete), report.Plan.Unchanged))
+	if opts.DryRun {
+		return report
+	}
+	emit := func(phase, msg, file string, pct float64) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "instance-sync-progress", map[string]interface{}{
+				"instance":    inst.Name,
+				"phase":       phase,
+				"message":     msg,
+				"currentFile": file,
+				"progress":    pct,
+			})
+		}
+	}
+	summary, err := syncQMServerFiles(inst, serverID, disabledMods, syncOpts, emit)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Summary = &summary
+	if opts.Prepare {
+		emit("preparing", "Загрузка Minecraft и компонентов", "", 100)
+		if err := prepareInstance(inst); err != nil {
+			report.Error = fmt.Sprintf("prepare: %v", err)
+			return report
+		}
+		report.Prepared = true
+	}
+	return report
+}
+
+// prepareInstance downloads the game, loader, libraries, assets and Java of an instance without
+// launching it.
+func prepareInstance(inst launcher.Instance) error {
+	options := launcher.LaunchOptions{InstanceConfig: inst.Config, NoJavaWindow: true}
+	options.JavaArgTemplates = javaArgTemplatesSetting()
+	if options.Java == "" {
+		if ref := defaultJavaSetting(); ref != "" {
+			if java, err := launcher.ResolveJavaRuntime(ref); err == nil {
+				options.Java = java
+			}
+		}
+	}
+	logMessage(fmt.Sprintf("[Sync] %s: подготовка файлов игры", inst.Name))
+	if _, err := launcher.Prepare(inst, options, nil); err != nil {
+		return err
+	}
+	logMessage(fmt.Sprintf("[Sync] %s: файлы игры готовы", inst.Name))
+	return nil
+}
+
+// cloudPushPaths are the instance files and directories PushInstance publishes.
+var cloudPushPaths = []string{"mods", "config", "defaultconfigs", "kubejs", "resourcepacks", "shaderpacks", "journeymap", "options.txt"}
+
+// CloudPushReport is the result of PushInstance.
+type CloudPushReport struct {
+	DryRun    bool            `json:"dryRun"`
+	Upload    []SyncPlanEntry `json:"upload"`
+	Delete    []SyncPlanEntry `json:"delete"`
+	Unchanged int             `json:"unchanged"`
+	Failed    []string        `json:"failed"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// PushInstance publishes an instance's mods, configs and packs to the QMServer Cloud server profile
+// serverID: files whose hash differs from the server manifest are uploaded and the manifest is
+// regenerated. With prune, server files under the published paths that the instance no longer has are
+// deleted. Disabled mods, partial downloads and sync.protect paths are not published. Requires the
+// cloud account of the endpoint to be allowed to manage the server profile. cloudProfile ("" = the
+// instance's) selects the cloud profile for this push only.
+func (a *App) PushInstance(instanceName string, serverID uint, prune, dryRun bool, cloudProfile string) CloudPushReport {
+	report := CloudPushReport{DryRun: dryRun, Upload: []SyncPlanEntry{}, Delete: []SyncPlanEntry{}, Failed: []string{}}
+	inst, err := launcher.FetchInstance(strings.TrimSpace(instanceName))
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	if serverID == 0 {
+		report.Error = "server ID is required"
+		return report
+	}
+	if err := withCloudProfile(&inst, cloudProfile); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	qmHost, qmPort := instanceQMServer(inst)
+	cloudAcc := cloudAccountForHost(qmHost, strconv.Itoa(qmPort))
+	if cloudAcc == nil || cloudAcc.Token == "" {
+		report.Error = "войдите в аккаунт QMServer Cloud"
+		return report
+	}
+	manifest, err := downloadDataManifest(serverID, qmHost, qmPort)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	remote := make(map[string]FileInfo, len(manifest.Files))
+	for _, f := range manifest.Files {
+		remote[f.Path] = f
+	}
+
+	instanceDir := inst.Dir()
+	hashes := launcher.LoadHashCache(instanceDir, calculateFileHash)
+	defer func() { _ = hashes.Save() }()
+	local := map[string]bool{}
+	type pushFile struct {
+		entry  SyncPlanEntry
+		path   string
+		sha256 string
+	}
+	var uploads []pushFile
+	for _, root := range cloudPushPaths {
+		_ = filepath.Walk(filepath.Join(instanceDir, root), func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(instanceDir, p)
+			if err != nil {
+				return nil
+			}
+			rel = filepath.ToSlash(rel)
+			if base, ok := strings.CutSuffix(rel, ".disabled"); ok {
+				// A mod the player disabled is still in the instance: prune must not delete it from the server
+				local[base] = true
+				return nil
+			}
+			if strings.HasSuffix(rel, ".part") || inst.Config.Sync.IsProtected(rel) {
+				return nil
+			}
+			local[rel] = true
+			sum, err := hashes.Hash(p, "sha256")
+			if err != nil {
+				report.Failed = append(report.Failed, rel)
+				return nil
+			}
+			if f, ok := remote[rel]; ok {
+				algo, want := f.checksum()
+				existing := sum
+				if algo != "sha256" {
+					existing, _ = hashes.Hash(p, algo)
+				}
+				if strings.EqualFold(existing, want) {
+					report.Unchanged++
+					return nil
+				}
+			}
+			entry := SyncPlanEntry{Path: rel, Size: info.Size(), Reason: "new"}
+			if _, ok := remote[rel]; ok {
+				entry.Reason = "changed"
+			}
+			uploads = append(uploads, pushFile{entry: entry, path: p, sha256: sum})
+			report.Upload = append(report.Upload, entry)
+			return nil
+		})
+	}
+	if prune {
+		for rel, f := range remote {
+			root, _, _ := strings.Cut(rel, "/")
+			if !local[rel] && slices.Contains(cloudPushPaths, root) && !inst.Config.Sync.IsProtected(rel) {
+				report.Delete = append(report.Delete, SyncPlanEntry{Path: rel, Size: f.Size, Reason: "not in instance"})
+			}
+		}
+		sort.Slice(report.Delete, func(i, j int) bool { return report.Delete[i].Path < report.Delete[j].Path })
+	}
+	logMessage(fmt.Sprintf("[Push] %s → сервер %d: загрузить %d, удалить %d, без изменений %d",
+		inst.Name, serverID, len(report.Upload), len(report.Delete), report.Unchanged))
+	if dryRun || (len(uploads) == 0 && len(report.Delete) == 0) {
+		return report
+	}
+
+	emit := func(phase, file string, done, total int) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "instance-push-progress", map[string]interface{}{
+				"instance":    inst.Name,
+				"phase":       phase,
+				"currentFile": file,
+				"done":        done,
+				"total":       total,
+			})
+		}
+	}
+	total := len(uploads) + len(report.Delete)
+	done := 0
+	for _, u := range uploads {
+		emit("uploading", u.entry.Path, done, total)
+		if err := network.UploadServerFile(qmHost, qmPort, cloudAcc.Token, serverID, u.entry.Path, u.path, u.sha256); err != nil {
+			logMessage(fmt.Sprintf("[Push] Ошибка загрузки %s: %v", u.entry.Path, err))
+			report.Failed = append(report.Failed, u.entry.Path)
+		}
+		done++
+	}
+	for _, d := range report.Delete {
+		emit("deleting", d.Path, done, total)
+		if err := network.DeleteServerFile(qmHost, qmPort, cloudAcc.Token, serverID, d.Path); err != nil {
+			logMessage(fmt.Sprintf("[Push] Ошибка удаления %s: %v", d.Path, err))
+			report.Failed = append(report.Failed, d.Path)
+		}
+		done++
+	}
+	emit("manifest", "", done, total)
+	if err := network.RegenerateServerManifest(qmHost, qmPort, cloudAcc.Token, serverID); err != nil {
+		report.Error = fmt.Sprintf("regenerate manifest: %v", err)
+		return report
+	}
+	if len(report.Failed) > 0 {
+		report.Error = fmt.Sprintf("%d file(s) failed", len(report.Failed))
+	}
+	return report
+}
+
+// cloudSync is the state shared by the workers of syncQMServerFiles.
+type cloudSync struct {
+	inst     *launcher.Instance
+	serverID uint
+	host     string
+	port     int
+	progress *syncProgress
+	hashes   *launcher.HashCache
+	force    bool
+
+	downloaded, updated, unchanged, kept, incompatible atomic.Int32
+	downloadedBytes, updatedBytes                      atomic.Int64
+
+	mu       sync.Mutex
+	failures []string
+}
+
+// fail records a file that could not be synced.
+func (c *cloudSync) fail(filePath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, filePath)
+}
+
+// installed records a file brought up to date: newly downloaded, or an update of a local copy.
+func (c *cloudSync) installed(fileInfo FileInfo, instanceFilePath, algo, want string, update bool) {
+	c.hashes.Put(instanceFilePath, algo, want)
+	if update {
+		c.updated.Add(1)
+		c.updatedBytes.Add(fileInfo.Size)
+	} else {
+		c.downloaded.Add(1)
+		c.downloadedBytes.Add(fileInfo.Size)
+	}
+	logMessage(fmt.Sprintf("[ConnectToServer] File downloaded successfully: %s", fileInfo.Path))
+}
+
+// syncConflictPromptTimeout is how long sync waits for the player to answer a conflict question.
+const syncConflictPromptTimeout = 2 * time.Minute
+
+// syncConflictPrompts holds the conflict questions waiting for ResolveSyncConflict.
+var syncConflictPrompts = struct {
+	sync.Mutex
+	ctx       context.Context
+	listeners int // UI components answering "sync-conflict", see SetSyncConflictListener
+	next      int
+	pending   map[int]chan string
+}{pending: map[int]chan string{}}
+
+// askSyncConflict asks the player what to do with a modified local file ("sync-conflict" event
+// {id, instance, path}) and waits for ResolveSyncConflict. When no UI listens for the question, or
+// nobody answers in time, the local file is kept.
+func askSyncConflict(instanceName, rel string) string {
+	syncConflictPrompts.Lock()
+	ctx := syncConflictPrompts.ctx
+	if ctx == nil || syncConflictPrompts.listeners == 0 {
+		syncConflictPrompts.Unlock()
+		return launcher.SyncKeepLocal
+	}
+	syncConflictPrompts.next++
+	id := syncConflictPrompts.next
+	answer := make(chan string, 1)
+	syncConflictPrompts.pending[id] = answer
+	syncConflictPrompts.Unlock()
+	defer func() {
+		syncConflictPrompts.Lock()
+		delete(syncConflictPrompts.pending, id)
+		syncConflictPrompts.Unlock()
+	}()
+
+	runtime.EventsEmit(ctx, "sync-conflict", map[string]interface{}{"id": id, "instance": instanceName, "path": rel})
+	select {
+	case strategy := <-answer:
+		return strategy
+	case <-time.After(syncConflictPromptTimeout):
+		logMessage(fmt.Sprintf("[ConnectToServer] No answer for conflict on %s, keeping local file", rel))
+		return launcher.SyncKeepLocal
+	}
+}
+
+// SetSyncConflictListener registers (on) or unregisters a UI component that answers "sync-conflict"
+// questions. Sync only asks while one is registered; unregistering the last one keeps the local file
+// for the questions still waiting.
+func (a *App) SetSyncConflictListener(on bool) {
+	syncConflictPrompts.Lock()
+	defer syncConflictPrompts.Unlock()
+	if on {
+		syncConflictPrompts.listeners++
+		return
+	}
+	if syncConflictPrompts.listeners > 0 {
+		syncConflictPrompts.listeners--
+	}
+	if syncConflictPrompts.listeners == 0 {
+		for _, answer := range syncConflictPrompts.pending {
+			select {
+			case answer <- launcher.SyncKeepLocal:
+			default:
+			}
+		}
+	}
+}
+
+// ResolveSyncConflict answers a "sync-conflict" question with overwrite, keep-local or keep-both.
+// Returns error string on failure.
+func (a *App) ResolveSyncConflict(id int, strategy string) string {
+	strategy = strings.TrimSpace(strategy)
+	if err := launcher.ValidateConflictStrategy(strategy); err != nil || strategy == launcher.SyncAsk {
+		return fmt.Sprintf("Error: invalid answer %q (overwrite, keep-local or keep-both)", strategy)
+	}
+	syncConflictPrompts.Lock()
+	answer, ok := syncConflictPrompts.pending[id]
+	syncConflictPrompts.Unlock()
+	if !ok {
+		return "Error: conflict question expired"
+	}
+	select {
+	case answer <- strategy:
+	default:
+	}
+	return ""
+}
+
+// resolveSyncConflict applies the instance's conflict strategy to a local file (rel in the manifest,
+// localPath on disk) that differs from the server copy. It reports whether sync may replace the file;
+// keep-both first saves the local version next to it.
+func resolveSyncConflict(inst launcher.Instance, rel, localPath string) bool {
+	strategy := inst.Config.Sync.ConflictStrategy(rel)
+	if strategy == launcher.SyncAsk {
+		strategy = askSyncConflict(inst.Name, rel)
+	}
+	switch strategy {
+	case launcher.SyncKeepLocal:
+		logMessage(fmt.Sprintf("[ConnectToServer] Keeping modified local file: %s", rel))
+		return false
+	case launcher.SyncKeepBoth:
+		backup := launcher.SyncConflictBackupPath(localPath)
+		if err := backupSyncConflict(localPath, backup); err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Error backing up %s, keeping local file: %v", rel, err))
+			return false
+		}
+		logMessage(fmt.Sprintf("[ConnectToServer] Local version of %s saved as %s", rel, filepath.Base(backup)))
+	}
+	return true
+}
+
+// backupSyncConflict copies a local file before sync replaces it, as a hard link when possible.
+func backupSyncConflict(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
 
-		processedForProgress++
-		fileName := filepath.Base(filePath)
-		pct := 0.0
-		if totalFiles > 0 {
-			pct = float64(processedForProgress-1) / float64(totalFiles) * 100
+// download fetches a manifest file to destPath, retrying failed transfers. Retries resume the .part
+// file left by the failed attempt.
+func (c *cloudSync) download(fileInfo FileInfo, destPath string) (int64, error) {
+	filePath := fileInfo.Path
+	var got int64
+	for attempt := 1; ; attempt++ {
+		got = 0
+		err := downloadFile(c.serverID, fileInfo, c.host, c.port, destPath, func(n int64) {
+			got += n
+			c.progress.transferred(filePath, n)
+		})
+		if err == nil {
+			return got, nil
 		}
-		if emitProgress != nil {
-			emitProgress("verifying", "Проверка: "+fileName, filePath, pct)
+		c.progress.transferred(filePath, -got)
+		if attempt == syncFileAttempts {
+			return 0, err
 		}
+		logMessage(fmt.Sprintf("[ConnectToServer] Download of %s failed (attempt %d/%d): %v", filePath, attempt, syncFileAttempts, err))
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
 
-		// Check if file exists and has matching MD5
-		if _, err := os.Stat(instanceFilePath); err == nil {
-			existingMD5, err := calculateFileMD5(instanceFilePath)
-			if err != nil {
-				logMessage(fmt.Sprintf("[ConnectToServer] Error calculating MD5 for file %s: %v", instanceFilePath, err))
-				continue
-			}
-			if existingMD5 == fileInfo.MD5 {
-				logMessage(fmt.Sprintf("[ConnectToServer] File unchanged, skipping: %s", filePath))
-				filesSkipped++
-				if emitProgress != nil && totalFiles > 0 {
-					emitProgress("skipped", "Пропуск: "+fileName, filePath, float64(processedForProgress)/float64(totalFiles)*100)
-				}
-				continue
-			}
-			filesUpdated++
-		} else {
-			filesDownloaded++
-		}
+const (
+	// maxPatchedFileSize is the largest file rebuilt from a patch; patching holds the old and new file in memory.
+	maxPatchedFileSize = 1 << 30
+	// minPatchedFileSize skips patches for files small enough to download outright.
+	minPatchedFileSize = 1 << 20
+)
 
-		if emitProgress != nil && totalFiles > 0 {
-			emitProgress("downloading", "Скачивание: "+fileName, filePath, float64(processedForProgress-1)/float64(totalFiles)*100)
+// patchFile rebuilds the new version of a file from the local copy at basePath (whose manifest hash is
+// baseHash) with a patch offered in the manifest, writing it to destPath. It reports false when no patch
+// applies or patching fails; the caller then downloads the whole file.
+func (c *cloudSync) patchFile(fileInfo FileInfo, basePath, baseHash, destPath string) bool {
+	if fileInfo.Size < minPatchedFileSize || fileInfo.Size > maxPatchedFileSize {
+		return false
+	}
+	var patch *FilePatch
+	for i := range fileInfo.Patches {
+		if strings.EqualFold(fileInfo.Patches[i].From, baseHash) {
+			patch = &fileInfo.Patches[i]
+			break
 		}
+	}
+	// A patch over half the file saves too little to be worth the extra round-trip
+	if patch == nil || patch.Size <= 0 || patch.Size > fileInfo.Size/2 {
+		return false
+	}
+	fail := func(err error) bool {
+		logMessage(fmt.Sprintf("[ConnectToServer] Patch for %s not applied, downloading whole file: %v", fileInfo.Path, err))
+		return false
+	}
 
-		// Download file
-		logMessage(fmt.Sprintf("[ConnectToServer] Downloading file: %s", filePath))
-		if err := downloadFile(serverID, filePath, config.QMServerHost, config.QMServerPort, instanceFilePath); err != nil {
-			logMessage(fmt.Sprintf("[ConnectToServer] Error downloading file %s: %v", filePath, err))
-			continue
-		}
-		logMessage(fmt.Sprintf("[ConnectToServer] File downloaded successfully: %s", filePath))
+	base := getQMServerBaseURL(c.host, c.port)
+	u := fmt.Sprintf("%s/api/v1/patch/%d/%s?from=%s", base, c.serverID, escapeManifestPath(fileInfo.Path), url.QueryEscape(baseHash))
+	req, err := newCloudRequest(http.MethodGet, u)
+	if err != nil {
+		return fail(err)
+	}
+	resp, err := network.QMServerCloudDownload.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fail(fmt.Errorf("status %d", resp.StatusCode))
+	}
+	patchData, err := io.ReadAll(io.LimitReader(resp.Body, patch.Size+1))
+	if err != nil {
+		return fail(err)
 	}
+	old, err := os.ReadFile(basePath)
+	if err != nil {
+		return fail(err)
+	}
+	out, err := bspatch.Apply(old, patchData, fileInfo.Size)
+	if err != nil {
+		return fail(err)
+	}
+	algo, want := fileInfo.checksum()
+	h, err := newManifestHash(algo)
+	if err != nil {
+		return fail(err)
+	}
+	h.Write(out)
+	if got := fmt.Sprintf("%x", h.Sum(nil)); !strings.EqualFold(got, want) {
+		return fail(fmt.Errorf("patched file has %s %s, expected %s", algo, got, want))
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fail(err)
+	}
+	if err := os.WriteFile(destPath+".part", out, 0644); err != nil {
+		return fail(err)
+	}
+	if err := os.Rename(destPath+".part", destPath); err != nil {
+		_ = os.Remove(destPath + ".part")
+		return fail(err)
+	}
+	logMessage(fmt.Sprintf("[ConnectToServer] Patched %s (%d byte patch instead of %d bytes)", fileInfo.Path, len(patchData), fileInfo.Size))
+	return true
+}
 
-	// Disable mods by renaming .jar → .jar.disabled (Minecraft mod loaders skip .disabled files)
-	disabledCount := 0
-	for modPath := range disabledSet {
-		if !strings.HasPrefix(modPath, "mods/") {
-			continue
+// syncFile brings one manifest file up to date: unchanged files are skipped, mod JARs are checked for
+// compatibility before they replace the local copy.
+func (c *cloudSync) syncFile(fileInfo FileInfo) {
+	filePath := fileInfo.Path
+	instanceFilePath := filepath.Join(c.inst.Dir(), filePath)
+	fileName := filepath.Base(filePath)
+
+	// Check if file exists and has matching hash (SHA-256, or MD5 from older servers)
+	var existing string
+	algo, want := fileInfo.checksum()
+	if _, err := os.Stat(instanceFilePath); err == nil {
+		existing, err = c.hashes.Hash(instanceFilePath, algo)
+		if err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Error calculating %s for file %s: %v", algo, instanceFilePath, err))
+			c.fail(filePath)
+			c.progress.fileDone("skipped", fileInfo, 0)
+			return
 		}
-		disabledCount++
-		if emitProgress != nil {
-			denom := len(disabledSet)
-			if denom < 1 {
-				denom = 1
-			}
-			emitProgress("disabling", "Отключение мода: "+filepath.Base(modPath), modPath, 95+float64(disabledCount)*5/float64(denom))
+		if strings.EqualFold(existing, want) {
+			logMessage(fmt.Sprintf("[ConnectToServer] File unchanged, skipping: %s", filePath))
+			c.unchanged.Add(1)
+			c.progress.fileDone("skipped", fileInfo, 0)
+			return
 		}
-		localPath := filepath.Join(instanceDir, modPath)
-		disabledPath := localPath + ".disabled"
-		if _, err := os.Stat(localPath); err == nil {
-			_ = os.Remove(disabledPath) // Remove old .disabled if exists (we have fresh from sync)
-			if err := os.Rename(localPath, disabledPath); err == nil {
-				logMessage(fmt.Sprintf("[ConnectToServer] Disabled mod: %s → .disabled", modPath))
-			}
+		if !c.force && !resolveSyncConflict(*c.inst, filePath, instanceFilePath) {
+			c.kept.Add(1)
+			c.progress.fileDone("skipped", fileInfo, 0)
+			return
 		}
 	}
 
-	logMessage(fmt.Sprintf("[ConnectToServer] Sync completed: processed %d files, downloaded %d, updated %d, skipped %d",
-		filesProcessed, filesDownloaded, filesUpdated, filesSkipped))
+	// Mod JARs are staged and checked against the instance's loader/game version before replacing
+	// the local file, so an incompatible server mod does not turn into a crash at boot.
+	isMod := strings.HasPrefix(filePath, "mods/") && strings.HasSuffix(strings.ToLower(filePath), ".jar")
+	dest := instanceFilePath
+	if isMod {
+		dest = filepath.Join(c.inst.TmpDir(), "sync-mods", filepath.FromSlash(strings.TrimPrefix(filePath, "mods/")))
+	}
+	var got int64
+	var err error
+	if existing == "" || !c.patchFile(fileInfo, instanceFilePath, existing, dest) {
+		logMessage(fmt.Sprintf("[ConnectToServer] Downloading file: %s", filePath))
+		got, err = c.download(fileInfo, dest)
+	}
+	if err != nil {
+		logMessage(fmt.Sprintf("[ConnectToServer] Error downloading file %s: %v", filePath, err))
+		if isMod {
+			_ = os.Remove(dest)
+		}
+		c.fail(filePath)
+		c.progress.fileDone("downloading", fileInfo, 0)
+		return
+	}
+	defer c.progress.fileDone("downloading", fileInfo, got)
+	if !isMod {
+		c.installed(fileInfo, instanceFilePath, algo, want, existing != "")
+		return
+	}
 
-	return nil
+	if md, err := meta.ReadModMetadata(dest); err == nil {
+		if issues := modCompatIssues(c.inst, fileName, md); len(issues) > 0 {
+			for _, issue := range issues {
+				logMessage(fmt.Sprintf("[ConnectToServer] Incompatible mod not installed: %s", issue.Message))
+				c.progress.warn(issue.Message, filePath)
+			}
+			c.incompatible.Add(1)
+			_ = os.Remove(dest)
+			return
+		}
+	}
+	if err = os.MkdirAll(filepath.Dir(instanceFilePath), 0755); err == nil {
+		err = os.Rename(dest, instanceFilePath)
+	}
+	if err != nil {
+		logMessage(fmt.Sprintf("[ConnectToServer] Error installing file %s: %v", filePath, err))
+		c.fail(filePath)
+		_ = os.Remove(dest)
+		return
+	}
+	c.installed(fileInfo, instanceFilePath, algo, want, existing != "")
 }
 
-// calculateFileMD5 calculates MD5 hash of a file
-func calculateFileMD5(filePath string) (string, error) {
+// calculateFileHash calculates the "md5" or "sha256" hash of a file as lowercase hex
+func calculateFileHash(filePath, algo string) (string, error) {
+	h, err := newManifestHash(algo)
+	if err != nil {
+		return "", err
+	}
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
 
-	hash := md5.New()
-	if _, err := io.Copy(hash, file); err != nil {
+	if _, err := io.Copy(h, file); err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
+// newManifestHash returns a hash for a manifest checksum algorithm ("md5" or "sha256").
+func newManifestHash(algo string) (hash.Hash, error) {
+	switch algo {
+	case "md5":
+		return md5.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported hash %q", algo)
+}
+
+// dataManifestVersion is the newest data manifest format the launcher understands. Version 2 adds
+// sha256 to every file; older servers ignore the request and answer with MD5-only manifests.
+const dataManifestVersion = 2
+
 // FileInfo represents file information from QMServer manifest
 type FileInfo struct {
 	Path     string `json:"path"`
 	MD5      string `json:"md5"`
+	SHA256   string `json:"sha256,omitempty"`
 	Size     int64  `json:"size"`
 	Modified int64  `json:"modified"`
+	// Patches lists the binary diffs the server offers from older versions of the file
+	Patches []FilePatch `json:"patches,omitempty"`
+}
+
+// FilePatch is a BSDIFF40 patch from an older version of a manifest file, downloadable from
+// /api/v1/patch/{serverID}/{path}?from={From}.
+type FilePatch struct {
+	From string `json:"from"` // hash of the old file, same algorithm as FileInfo.checksum
+	Size int64  `json:"size"`
+}
+
+// checksum returns the hash to verify the file with: SHA-256 when the manifest has it, MD5 otherwise.
+func (f FileInfo) checksum() (algo, want string) {
+	if f.SHA256 != "" {
+		return "sha256", f.SHA256
+	}
+	return "md5", f.MD5
 }
 
 // DataManifest represents the data.json structure from QMServer
 type DataManifest struct {
+	Version    int        `json:"version,omitempty"` // 0/1 = MD5 only, 2 = sha256
 	ServerID   uint       `json:"server_id"`
 	ServerUUID string     `json:"server_uuid"`
 	Files      []FileInfo `json:"files"`
 	Generated  int64      `json:"generated"`
+
+	// CachedAt is set (unix seconds) when QMServer was unreachable and this is the copy saved then
+	CachedAt int64 `json:"-"`
+}
+
+// offlineManifestWarning is shown when a cloud instance syncs against its cached manifest.
+func offlineManifestWarning(m *DataManifest) string {
+	return fmt.Sprintf("QMServer Cloud недоступен, используется сохранённое состояние от %s",
+		time.Unix(m.CachedAt, 0).Format("02.01.2006 15:04"))
+}
+
+// TrustServerManifestKey trusts the manifest key a server profile now publishes, after the player
+// confirmed a "manifest-key-changed" event {serverId, host, port}. Returns error string on failure.
+func (a *App) TrustServerManifestKey(host string, port int, serverID uint) string {
+	key := network.ServerManifestKey(getQMServerBaseURL(host, port)+"/api/v1", serverID)
+	if key == "" {
+		return "Error: the server publishes no manifest key"
+	}
+	if _, err := network.ParseManifestKey(key); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if err := network.PinManifestKey(network.ManifestKeyPinID(host, port, serverID), key); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	logMessage(fmt.Sprintf("[ConnectToServer] Новый ключ манифеста сервера %d принят", serverID))
+	return ""
+}
+
+// newCloudRequest builds a QMServer Cloud sync request carrying the token of the cloud account signed in
+// to its endpoint, which premium servers require for check/data and download. Requests to any other host
+// go out without credentials.
+func newCloudRequest(method, url string) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	port := req.URL.Port()
+	if port == "" {
+		port = "443"
+		if strings.EqualFold(req.URL.Scheme, "http") {
+			port = "80"
+		}
+	}
+	if cloudAcc := cloudAccountForHost(req.URL.Hostname(), port); cloudAcc != nil {
+		network.SetQMServerAuth(req, cloudAcc.Token)
+	}
+	return req, nil
+}
+
+// cloudAccountForHost returns the account signed in to the cloud profile of a QMServer endpoint, or the
+// default cloud account for the launcher-wide QMServer Cloud endpoint. Other hosts get no account, so
+// their servers never see a cloud token.
+func cloudAccountForHost(host, port string) *auth.CloudAccount {
+	n, _ := strconv.Atoi(port)
+	if n == 0 {
+		n = defaultQMServerPort
+	}
+	if profile, ok := cloudProfileForHost(host, n); ok {
+		return auth.GetCloudProfileAccount(profile.Name)
+	}
+	if h, p := qmServerEndpoint(); strings.EqualFold(h, host) && p == n {
+		return auth.GetDefaultCloudAccount()
+	}
+	return nil
+}
+
+// cachedDataManifest is a data manifest saved with the validators of the response it came from.
+type cachedDataManifest struct {
+	ETag         string       `json:"etag,omitempty"`
+	LastModified string       `json:"last_modified,omitempty"`
+	Fetched      int64        `json:"fetched"`
+	Manifest     DataManifest `json:"manifest"`
+
+	SignedBy string `json:"signed_by,omitempty"` // manifest key the signature was verified with
+}
+
+// dataManifestCachePath is where the last manifest of a server profile on a QMServer is kept.
+func dataManifestCachePath(serverID uint, qmServerHost string, qmServerPort int) string {
+	name := fmt.Sprintf("%s_%d_%d.json", strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(qmServerHost), qmServerPort, serverID)
+	return filepath.Join(env.CachesDir, "qmserver", "manifests", name)
+}
+
+func readCachedDataManifest(path string) *cachedDataManifest {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cached cachedDataManifest
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
 }
 
 // downloadDataManifest downloads data manifest from QMServer
+// The last manifest is cached with its ETag/Last-Modified; they are sent as validators, so an unchanged
+// manifest costs one 304 round-trip. When QMServer cannot be reached the cached manifest is used.
 func downloadDataManifest(serverID uint, qmServerHost string, qmServerPort int) (*DataManifest, error) {
 	base := getQMServerBaseURL(qmServerHost, qmServerPort)
-	url := fmt.Sprintf("%s/api/v1/check/data/%d", base, serverID)
+	url := fmt.Sprintf("%s/api/v1/check/data/%d?manifest_version=%d", base, serverID, dataManifestVersion)
+	cachePath := dataManifestCachePath(serverID, qmServerHost, qmServerPort)
+	cached := readCachedDataManifest(cachePath)
+	// A server profile that publishes a manifest key in its QMServer's server list only gets manifests
+	// signed with it. The first key seen is pinned (caches from before pinning carry the key they were
+	// verified with); a new key is refused until the player trusts it with TrustServerManifestKey.
+	pinID := network.ManifestKeyPinID(qmServerHost, qmServerPort, serverID)
+	pinned := network.PinnedManifestKey(pinID)
+	if pinned == "" && cached != nil {
+		pinned = cached.SignedBy
+	}
+	key, err := network.ResolveManifestKey(network.ServerManifestKey(base+"/api/v1", serverID), pinned)
+	if err != nil {
+		logMessage(fmt.Sprintf("[ConnectToServer] Data manifest of server %d rejected: %v", serverID, err))
+		emitLauncherEvent("manifest-key-changed", map[string]interface{}{"serverId": serverID, "host": qmServerHost, "port": qmServerPort})
+		return nil, fmt.Errorf("refusing to sync server %d: %w", serverID, err)
+	}
+	if key != "" && cached != nil && cached.SignedBy != key {
+		cached = nil
+	}
+	offline := func(err error) (*DataManifest, error) {
+		if cached == nil {
+			return nil, err
+		}
+		logMessage(fmt.Sprintf("[ConnectToServer] QMServer unavailable (%v), using manifest cached at %s",
+			err, time.Unix(cached.Fetched, 0).Format(time.RFC3339)))
+		manifest := cached.Manifest
+		manifest.CachedAt = cached.Fetched
+		return &manifest, nil
+	}
 
-	resp, err := network.QMServerHTTPClient.Get(url)
+	req, err := newCloudRequest(http.MethodGet, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to QMServer: %w", err)
 	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := network.QMServerCloud.Do(req)
+	if err != nil {
+		return offline(fmt.Errorf("failed to connect to QMServer: %w", err))
+	}
 	defer resp.Body.Close()
 
+	if err := network.QMServerAuthError(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		logMessage("[ConnectToServer] Data manifest not modified, using cached copy")
+		return &cached.Manifest, nil
+	}
+	if resp.StatusCode >= 500 {
+		return offline(fmt.Errorf("QMServer returned status %d", resp.StatusCode))
+	}
 	if resp.StatusCode != http.StatusOK {
 		msg := strings.TrimSpace(network.ReadQMServerError(resp))
 		if msg != "" {
@@ -3602,26 +9820,114 @@ func downloadDataManifest(serverID uint, qmServerHost string, qmServerPort int)
 		return nil, fmt.Errorf("QMServer returned status %d", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return offline(fmt.Errorf("failed to read data manifest: %w", err))
+	}
+	if key != "" {
+		if err := network.VerifyManifestSignature(body, resp.Header.Get(network.ManifestSignatureHeader), key); err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Data manifest of server %d rejected: %v", serverID, err))
+			return nil, fmt.Errorf("refusing to sync server %d: %w", serverID, err)
+		}
+	}
 	var manifest DataManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("failed to parse data manifest: %w", err)
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return offline(fmt.Errorf("failed to parse data manifest: %w", err))
+	}
+	// A replayed older manifest would roll the instance back to files the server replaced
+	if cached != nil && manifest.Generated < cached.Manifest.Generated {
+		logMessage(fmt.Sprintf("[ConnectToServer] Data manifest of server %d generated at %d, synced one at %d",
+			serverID, manifest.Generated, cached.Manifest.Generated))
+		return nil, fmt.Errorf("refusing to sync server %d: %w", serverID, network.ErrManifestRollback)
+	}
+	if key != "" && key != pinned {
+		if err := network.PinManifestKey(pinID, key); err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Failed to pin manifest key of server %d: %v", serverID, err))
+		}
+	}
+
+	entry := cachedDataManifest{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Fetched:      time.Now().Unix(),
+		Manifest:     manifest,
+		SignedBy:     key,
+	}
+	if data, err := json.Marshal(entry); err == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			_ = os.WriteFile(cachePath, data, 0644)
+		}
 	}
 
 	return &manifest, nil
 }
 
+// escapeManifestPath escapes each segment of a slash-separated manifest path for use in a URL path, so
+// spaces, "#", "?" and "%" in file names reach QMServer as part of the path.
+func escapeManifestPath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		segs[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segs, "/")
+}
+
 // downloadFile downloads a file from QMServer
-func downloadFile(serverID uint, filePath string, qmServerHost string, qmServerPort int, destPath string) error {
+// The file is written to destPath.part first and renamed once complete and matching the manifest hash.
+// A .part left by a dropped connection is resumed with a Range request on the next call.
+// onProgress, when set, is called with the number of bytes written after each chunk; bytes already in a
+// resumed .part are reported first.
+func downloadFile(serverID uint, fileInfo FileInfo, qmServerHost string, qmServerPort int, destPath string, onProgress func(n int64)) error {
 	base := getQMServerBaseURL(qmServerHost, qmServerPort)
-	url := fmt.Sprintf("%s/api/v1/download/%d/%s", base, serverID, filePath)
+	url := fmt.Sprintf("%s/api/v1/download/%d/%s", base, serverID, escapeManifestPath(fileInfo.Path))
+	if onProgress == nil {
+		onProgress = func(int64) {}
+	}
+
+	// Create destination directory
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	partPath := destPath + ".part"
+	var offset int64
+	if st, err := os.Stat(partPath); err == nil {
+		offset = st.Size()
+		if fileInfo.Size > 0 && offset >= fileInfo.Size {
+			// Complete but never renamed, or stale: verify it from scratch
+			_ = os.Remove(partPath)
+			offset = 0
+		}
+	}
 
-	resp, err := network.QMServerHTTPClient.Get(url)
+	req, err := newCloudRequest(http.MethodGet, url)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	if offset > 0 {
+		// Ranges apply to the encoded bytes, so a resumed transfer must not be compressed
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("Accept-Encoding", "identity")
+	} else {
+		req.Header.Set("Accept-Encoding", network.AcceptCompressed)
+	}
+	resp, err := network.QMServerCloudDownload.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download file: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags = os.O_WRONLY | os.O_APPEND
+		logMessage(fmt.Sprintf("[ConnectToServer] Resuming %s at %d bytes", fileInfo.Path, offset))
+		onProgress(offset)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return network.QMServerAuthError(resp)
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		_ = os.Remove(partPath)
+		return fmt.Errorf("failed to resume %s, restarting download", fileInfo.Path)
+	case resp.StatusCode != http.StatusOK:
 		msg := strings.TrimSpace(network.ReadQMServerError(resp))
 		if msg != "" {
 			return fmt.Errorf("QMServer does not serve QMLauncher: %s", msg)
@@ -3629,45 +9935,76 @@ func downloadFile(serverID uint, filePath string, qmServerHost string, qmServerP
 		return fmt.Errorf("failed to download file, status: %d", resp.StatusCode)
 	}
 
-	// Create destination directory
-	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
+	if flags&os.O_APPEND != 0 && resp.Header.Get("Content-Encoding") != "" {
+		_ = os.Remove(partPath)
+		return fmt.Errorf("failed to resume %s: server compressed a partial response, restarting download", fileInfo.Path)
+	}
+	body, err := network.DecodedBody(resp)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
 	}
+	defer body.Close()
 
 	// Create destination file
-	file, err := os.Create(destPath)
+	file, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to create destination file: %w", err)
 	}
-	defer file.Close()
 
 	// Copy data
-	_, err = io.Copy(file, resp.Body)
+	_, err = io.Copy(file, &syncProgressReader{r: network.LimitBandwidth(body), fn: onProgress})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
+		// The .part holds decoded bytes, so the next attempt resumes from here
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
+	if algo, want := fileInfo.checksum(); want != "" {
+		got, err := calculateFileHash(partPath, algo)
+		if err != nil {
+			return fmt.Errorf("failed to verify file: %w", err)
+		}
+		if !strings.EqualFold(got, want) {
+			_ = os.Remove(partPath)
+			return fmt.Errorf("checksum mismatch for %s: expected %s %s, got %s", fileInfo.Path, algo, want, got)
+		}
+	}
+	if err := os.Rename(partPath, destPath); err != nil {
+		return fmt.Errorf("failed to move file into place: %w", err)
+	}
 	return nil
 }
 
-// removeOrphanedFiles removes files and directories from mods/ that don't exist in server manifest
-func removeOrphanedFiles(instanceDir string, manifestFiles map[string]FileInfo) error {
-	logMessage("[ConnectToServer] Checking mods/ for orphaned files")
-
-	modsDir := filepath.Join(instanceDir, "mods")
+// syncProgressReader reports the size of every chunk read.
+type syncProgressReader struct {
+	r  io.Reader
+	fn func(n int64)
+}
 
-	// Check if mods directory exists
-	if _, err := os.Stat(modsDir); os.IsNotExist(err) {
-		logMessage("[ConnectToServer] mods/ directory does not exist - creating")
-		if err := os.MkdirAll(modsDir, 0755); err != nil {
-			logMessage(fmt.Sprintf("[ConnectToServer] Error creating mods/ directory: %v", err))
-			return err
-		}
-		return nil
+func (p *syncProgressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.fn(int64(n))
 	}
+	return n, err
+}
 
-	removedCount := 0
+// orphanedSyncFiles lists files and directories in mods/ that don't exist in server manifest (nor are a
+// .disabled variant of a manifest mod). Paths matching the instance's sync.protect patterns are never
+// listed. A directory is listed once, with the total size of its contents, unless it holds manifest or
+// protected files.
+func orphanedSyncFiles(instanceDir string, manifestFiles map[string]FileInfo, syncCfg launcher.SyncConfig) ([]SyncPlanEntry, int, error) {
+	modsDir := filepath.Join(instanceDir, "mods")
+	var orphans []SyncPlanEntry
 	checkedCount := 0
+	manifestDirs := make(map[string]bool)
+	for p := range manifestFiles {
+		for dir := path.Dir(p); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			manifestDirs[dir] = true
+		}
+	}
 
 	// Walk through mods directory and check each file/folder
 	err := filepath.Walk(modsDir, func(path string, info os.FileInfo, err error) error {
@@ -3697,33 +10034,87 @@ func removeOrphanedFiles(instanceDir string, manifestFiles map[string]FileInfo)
 			basePath := strings.TrimSuffix(relPath, ".disabled")
 			_, exists = manifestFiles[basePath]
 		}
-		if !exists {
+		if exists || launcher.IsSyncConflictBackup(relPath) {
+			return nil
+		}
+		if syncCfg.IsProtected(relPath) {
 			if info.IsDir() {
-				logMessage(fmt.Sprintf("[ConnectToServer] Removing orphaned directory: %s", relPath))
-				if err := os.RemoveAll(path); err != nil {
-					logMessage(fmt.Sprintf("[ConnectToServer] Error removing directory %s: %v", relPath, err))
-					return err
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		entry := SyncPlanEntry{Path: relPath, Size: info.Size(), Reason: "not in server manifest"}
+		if info.IsDir() {
+			if manifestDirs[relPath] {
+				return nil // Check its contents one by one
+			}
+			entry.Size = 0
+			keep := false
+			_ = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+				if err != nil || fi.IsDir() {
+					return nil
 				}
-				removedCount++
-				return filepath.SkipDir // Skip walking into removed directory
-			} else {
-				logMessage(fmt.Sprintf("[ConnectToServer] Removing orphaned file: %s", relPath))
-				if err := os.Remove(path); err != nil {
-					logMessage(fmt.Sprintf("[ConnectToServer] Error removing file %s: %v", relPath, err))
-					return err
+				entry.Size += fi.Size()
+				if rel, err := filepath.Rel(instanceDir, p); err == nil && syncCfg.IsProtected(filepath.ToSlash(rel)) {
+					keep = true
 				}
-				removedCount++
+				return nil
+			})
+			if keep {
+				return nil // Holds protected files: check its contents one by one
 			}
+			orphans = append(orphans, entry)
+			return filepath.SkipDir // The whole directory goes
 		}
-
+		orphans = append(orphans, entry)
 		return nil
 	})
+	return orphans, checkedCount, err
+}
+
+// removeOrphanedFiles moves files and directories from mods/ that don't exist in server manifest to .sync-trash
+// and returns how many were moved. Orphans outside only are kept.
+func removeOrphanedFiles(instanceDir string, manifestFiles map[string]FileInfo, syncCfg launcher.SyncConfig, only syncSelection) (int, error) {
+	logMessage("[ConnectToServer] Checking mods/ for orphaned files")
+
+	modsDir := filepath.Join(instanceDir, "mods")
+
+	// Check if mods directory exists
+	if _, err := os.Stat(modsDir); os.IsNotExist(err) {
+		logMessage("[ConnectToServer] mods/ directory does not exist - creating")
+		if err := os.MkdirAll(modsDir, 0755); err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Error creating mods/ directory: %v", err))
+			return 0, err
+		}
+		return 0, nil
+	}
 
+	orphans, checkedCount, err := orphanedSyncFiles(instanceDir, manifestFiles, syncCfg)
 	if err != nil {
 		logMessage(fmt.Sprintf("[ConnectToServer] Error walking mods directory: %v", err))
-		return err
+		return 0, err
 	}
 
-	logMessage(fmt.Sprintf("[ConnectToServer] Orphaned files check: checked %d items, removed %d", checkedCount, removedCount))
-	return nil
+	// Orphans are quarantined rather than deleted, so a broken server manifest cannot destroy local files
+	removedCount := 0
+	batch := launcher.NewSyncTrashBatch()
+	for _, orphan := range orphans {
+		if !only.includes(orphan.Path) {
+			continue
+		}
+		logMessage(fmt.Sprintf("[ConnectToServer] Moving orphaned file to %s/%s: %s", launcher.SyncTrashDir, batch, orphan.Path))
+		if err := launcher.QuarantineSyncFile(instanceDir, batch, orphan.Path); err != nil {
+			logMessage(fmt.Sprintf("[ConnectToServer] Error quarantining %s: %v", orphan.Path, err))
+			return removedCount, err
+		}
+		removedCount++
+	}
+
+	logMessage(fmt.Sprintf("[ConnectToServer] Orphaned files check: checked %d items, quarantined %d", checkedCount, removedCount))
+	if pruned, err := launcher.PruneSyncTrash(instanceDir, syncCfg); err != nil {
+		logMessage(fmt.Sprintf("[ConnectToServer] Error pruning %s: %v", launcher.SyncTrashDir, err))
+	} else if len(pruned) > 0 {
+		logMessage(fmt.Sprintf("[ConnectToServer] Pruned %s batches: %v", launcher.SyncTrashDir, pruned))
+	}
+	return removedCount, nil
 }
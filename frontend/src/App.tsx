@@ -41,6 +41,8 @@ import { GetInstances, GetInstanceDetails, LaunchInstanceWithAccount, GetRecentS
 import { EventsOn } from "../wailsjs/runtime/runtime";
 import { AppSidebar } from "./components/app-sidebar";
 import { ResourceStoreBrowser } from "./components/ResourceStoreBrowser";
+import { LauncherEvents } from "./components/LauncherEvents";
+import { UpdateSettings } from "./components/UpdateSettings";
 import { SiteHeader } from "./components/site-header";
 import {
   SidebarInset,
@@ -1520,6 +1522,7 @@ function App() {
           </div>
         </CardContent>
       </Card>
+      <UpdateSettings />
         </TabsContent>
         <TabsContent value="minecraft" className="space-y-6">
       <Card>
@@ -4751,6 +4754,7 @@ function App() {
         </DialogContent>
       </Dialog>
       <Toaster richColors closeButton position="top-center" />
+      <LauncherEvents onUpdateRequested={() => setShowUpdateDialog(true)} />
     </ThemeProvider>
   );
 }
@@ -0,0 +1,180 @@
+import { useEffect, useRef, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import {
+  GetAuthExpiryWarnings,
+  GetCloudUpdateNotices,
+  ResolveSyncConflict,
+  SetSyncConflictListener,
+  TrustServerManifestKey,
+} from "../../wailsjs/go/main/App";
+import { EventsOn } from "../../wailsjs/runtime/runtime";
+
+interface SyncConflict {
+  id: number;
+  instance: string;
+  path: string;
+}
+
+interface AuthExpiryWarning {
+  type: string;
+  name: string;
+  expired: boolean;
+  message: string;
+  tip: string;
+}
+
+interface CloudUpdateNotice {
+  instance: string;
+  added: number;
+  changed: number;
+  removed: number;
+}
+
+interface LauncherEventsProps {
+  onUpdateRequested?: () => void;
+}
+
+// LauncherEvents shows the backend notifications that are not tied to a page: sync and push progress,
+// sync conflicts, update notices, changed server files and expiring logins.
+export function LauncherEvents({ onUpdateRequested }: LauncherEventsProps) {
+  const [conflicts, setConflicts] = useState<SyncConflict[]>([]);
+  const [answering, setAnswering] = useState(false);
+  const conflict = conflicts[0];
+  const onUpdateRef = useRef(onUpdateRequested);
+  onUpdateRef.current = onUpdateRequested;
+
+  useEffect(() => {
+    // Progress toasts replace each other per instance and fade out once the events stop
+    const progressToast = (kind: string) => (ev: any) => {
+      if (!ev || typeof ev !== "object") return;
+      const id = `${kind}-${ev.instance}`;
+      let pct: number | undefined;
+      if (typeof ev.progress === "number") pct = Math.round(ev.progress);
+      else if (typeof ev.total === "number" && ev.total > 0) pct = Math.round((100 * ev.done) / ev.total);
+      const text = [ev.message || ev.currentFile || ev.phase, pct !== undefined ? `${pct}%` : ""]
+        .filter(Boolean)
+        .join(" · ");
+      const show = ev.phase === "warning" ? toast.warning : toast.message;
+      show(ev.instance, { id, description: text, duration: 5000 });
+    };
+    const unsubSync = EventsOn("instance-sync-progress", progressToast("sync"));
+    const unsubPush = EventsOn("instance-push-progress", progressToast("push"));
+    const unsubConflict = EventsOn("sync-conflict", (ev: SyncConflict) => {
+      if (ev && typeof ev.id === "number") setConflicts((prev) => [...prev, ev]);
+    });
+    // Sync only asks about conflicts while this dialog can answer them
+    SetSyncConflictListener(true).catch(() => {});
+    const unsubNotice = EventsOn("launcher-update-notice", (ev: any) => {
+      toast.info(`Доступна версия v${ev?.version ?? ""}`, {
+        description: ev?.message,
+        duration: 15000,
+        action: onUpdateRef.current ? { label: "Обновить", onClick: () => onUpdateRef.current?.() } : undefined,
+      });
+    });
+    const showCloudUpdate = (ev: CloudUpdateNotice) => {
+      if (!ev) return;
+      toast.info(`Файлы сервера ${ev.instance} изменились`, {
+        id: `cloud-update-${ev.instance}`,
+        description: `Добавлено ${ev.added}, изменено ${ev.changed}, удалено ${ev.removed}. Синхронизируйте инстанс перед следующим запуском.`,
+        duration: 15000,
+      });
+    };
+    const showExpiry = (warnings: AuthExpiryWarning[]) => {
+      for (const w of warnings ?? []) {
+        const show = w.expired ? toast.error : toast.warning;
+        show(w.message, { id: `auth-expiry-${w.type}-${w.name}`, description: w.tip, duration: 20000 });
+      }
+    };
+    const unsubCloudUpdate = EventsOn("cloud-update-available", showCloudUpdate);
+    const unsubExpiry = EventsOn("auth-expiry-warning", showExpiry);
+    // The startup events may fire before the page subscribes
+    GetAuthExpiryWarnings().then(showExpiry).catch(() => {});
+    GetCloudUpdateNotices()
+      .then((notices) => notices?.forEach(showCloudUpdate))
+      .catch(() => {});
+    const unsubManifestKey = EventsOn("manifest-key-changed", (ev: any) => {
+      if (!ev || typeof ev.serverId !== "number") return;
+      toast.error(`Сервер ${ev.serverId} сменил ключ подписи файлов`, {
+        id: `manifest-key-${ev.host}-${ev.port}-${ev.serverId}`,
+        description:
+          "Синхронизация остановлена. Доверяйте новому ключу, только если администратор сервера сообщил о его смене.",
+        duration: Infinity,
+        action: {
+          label: "Доверять",
+          onClick: async () => {
+            const err = await TrustServerManifestKey(ev.host, ev.port, ev.serverId);
+            if (err) toast.error(err);
+            else toast.success("Новый ключ принят, повторите синхронизацию");
+          },
+        },
+      });
+    });
+    const unsubVault = EventsOn("auth-vault-insecure", (msg: string) => {
+      toast.warning("Хранилище аккаунтов небезопасно", { description: msg, duration: 20000 });
+    });
+    const unsubNotOwned = EventsOn("microsoft-auth-not-owned", (profile: { username?: string }) => {
+      toast.warning(`На аккаунте ${profile?.username ?? ""} не найдена покупка Minecraft: Java Edition`, {
+        description: "Игра может не запуститься. Проверьте, что вы вошли нужным аккаунтом Microsoft.",
+        duration: 20000,
+      });
+    });
+    return () => {
+      unsubSync?.();
+      unsubPush?.();
+      unsubConflict?.();
+      SetSyncConflictListener(false).catch(() => {});
+      unsubNotice?.();
+      unsubCloudUpdate?.();
+      unsubExpiry?.();
+      unsubManifestKey?.();
+      unsubVault?.();
+      unsubNotOwned?.();
+    };
+  }, []);
+
+  const answer = async (strategy: string) => {
+    if (!conflict) return;
+    setAnswering(true);
+    try {
+      const err = await ResolveSyncConflict(conflict.id, strategy);
+      if (err) toast.error(err);
+    } finally {
+      setAnswering(false);
+      setConflicts((prev) => prev.slice(1));
+    }
+  };
+
+  return (
+    <Dialog open={!!conflict} onOpenChange={(open) => !open && !answering && void answer("keep-local")}>
+      <DialogContent className="sm:max-w-md">
+        <DialogHeader>
+          <DialogTitle>Конфликт синхронизации</DialogTitle>
+          <DialogDescription>
+            Файл <span className="font-mono break-all">{conflict?.path}</span> в инстансе &quot;{conflict?.instance}&quot;
+            изменён локально и отличается от версии на сервере.
+          </DialogDescription>
+        </DialogHeader>
+        <DialogFooter className="gap-2 sm:gap-0">
+          <Button variant="outline" disabled={answering} onClick={() => void answer("keep-local")}>
+            Оставить мой
+          </Button>
+          <Button variant="outline" disabled={answering} onClick={() => void answer("keep-both")}>
+            Сохранить оба
+          </Button>
+          <Button disabled={answering} onClick={() => void answer("overwrite")}>
+            Заменить серверным
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
@@ -0,0 +1,127 @@
+import { useEffect, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from "@/components/ui/card";
+import { Label } from "@/components/ui/label";
+import { NativeSelect, NativeSelectOption } from "@/components/ui/native-select";
+import { Switch } from "@/components/ui/switch";
+import { main } from "../../wailsjs/go/models";
+import {
+  GetLauncherBackups,
+  GetUpdateChannel,
+  GetUpdateNoticeEnabled,
+  RollbackLauncherUpdate,
+  SetUpdateChannel,
+  SetUpdateNoticeEnabled,
+} from "../../wailsjs/go/main/App";
+
+// UpdateSettings is the settings card for the launcher update channel, the daily update notice and
+// rolling back to a version kept by updates.
+export function UpdateSettings() {
+  const [channel, setChannel] = useState("stable");
+  const [notice, setNotice] = useState(false);
+  const [saving, setSaving] = useState(false);
+  const [backups, setBackups] = useState<main.LauncherBackupsReport | null>(null);
+  const [rollbackTo, setRollbackTo] = useState("");
+  const [rollingBack, setRollingBack] = useState(false);
+
+  useEffect(() => {
+    GetUpdateChannel().then(setChannel).catch(() => {});
+    GetUpdateNoticeEnabled().then(setNotice).catch(() => {});
+    GetLauncherBackups()
+      .then((report) => {
+        setBackups(report);
+        setRollbackTo(report.backups?.find((b) => b.version && b.version !== report.current)?.version ?? "");
+      })
+      .catch(() => {});
+  }, []);
+
+  const save = async (apply: () => Promise<string>, commit: () => void) => {
+    setSaving(true);
+    try {
+      const err = await apply();
+      if (err) {
+        toast.error(err);
+        return;
+      }
+      commit();
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  const rollbackVersions = (backups?.backups ?? []).filter((b) => b.version && b.version !== backups?.current);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="text-base">Обновления лаунчера</CardTitle>
+        <CardDescription>
+          Канал обновлений, ежедневное уведомление о новой версии и откат к предыдущей версии.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid gap-2">
+          <Label htmlFor="update-channel">Канал</Label>
+          <NativeSelect
+            id="update-channel"
+            value={channel}
+            disabled={saving}
+            onChange={(e) => {
+              const v = e.target.value;
+              void save(() => SetUpdateChannel(v), () => setChannel(v));
+            }}
+          >
+            <NativeSelectOption value="stable">Стабильный</NativeSelectOption>
+            <NativeSelectOption value="beta">Бета</NativeSelectOption>
+            <NativeSelectOption value="nightly">Ночные сборки</NativeSelectOption>
+          </NativeSelect>
+        </div>
+        <div className="flex items-center justify-between gap-4">
+          <span className="text-sm text-muted-foreground">Уведомлять о новой версии (раз в день)</span>
+          <Switch
+            checked={notice}
+            disabled={saving}
+            onCheckedChange={(on) => void save(() => SetUpdateNoticeEnabled(on), () => setNotice(on))}
+          />
+        </div>
+        <div className="grid gap-2">
+          <Label htmlFor="update-rollback">Откат (текущая версия {backups?.current || "—"})</Label>
+          <div className="flex gap-2">
+            <NativeSelect
+              id="update-rollback"
+              value={rollbackTo}
+              disabled={rollingBack || rollbackVersions.length === 0}
+              onChange={(e) => setRollbackTo(e.target.value)}
+            >
+              {rollbackVersions.length === 0 ? (
+                <NativeSelectOption value="">Нет сохранённых версий</NativeSelectOption>
+              ) : (
+                rollbackVersions.map((b) => (
+                  <NativeSelectOption key={b.path} value={b.version}>
+                    v{b.version}
+                  </NativeSelectOption>
+                ))
+              )}
+            </NativeSelect>
+            <Button
+              variant="outline"
+              disabled={rollingBack || !rollbackTo}
+              onClick={async () => {
+                setRollingBack(true);
+                try {
+                  const err = await RollbackLauncherUpdate(rollbackTo);
+                  if (err) toast.error(err);
+                } finally {
+                  setRollingBack(false);
+                }
+              }}
+            >
+              {rollingBack ? "Откат…" : "Откатить"}
+            </Button>
+          </div>
+        </div>
+      </CardContent>
+    </Card>
+  );
+}
@@ -2,11 +2,34 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 import {launcher} from '../models';
+import {auth} from '../models';
+import {network} from '../models';
+import {meta} from '../models';
+
+export function AddInstanceContentFromSource(arg1:string,arg2:string,arg3:string):Promise<main.ModInstallResult>;
+
+export function AddInstanceModFromSource(arg1:string,arg2:string):Promise<main.ModInstallResult>;
+
+export function AddServerFavorite(arg1:string,arg2:string):Promise<string>;
+
+export function AlignInstanceWithServer(arg1:string,arg2:number):Promise<string>;
+
+export function ApplyInstanceModProfile(arg1:string,arg2:string):Promise<main.ModProfileApplyReport>;
+
+export function ApplyLauncherDowngrade():Promise<string>;
 
 export function ApplyLauncherUpdate():Promise<string>;
 
+export function CheckInstanceModConflicts(arg1:string):Promise<main.ModConflictReport>;
+
+export function CheckInstanceModUpdates(arg1:string):Promise<main.ModUpdatesReport>;
+
 export function CheckLauncherUpdateAvailable():Promise<boolean>;
 
+export function ClearRecentConnections(arg1:boolean):Promise<string>;
+
+export function CollectUnusedJava(arg1:boolean):Promise<main.JavaGCReport>;
+
 export function CreateCloudGameAccount(arg1:string,arg2:string):Promise<string>;
 
 export function CreateInstance(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
@@ -19,16 +42,40 @@ export function DeleteInstance(arg1:string):Promise<string>;
 
 export function DeleteInstanceMod(arg1:string,arg2:string):Promise<string>;
 
+export function DeleteInstanceModProfile(arg1:string,arg2:string):Promise<string>;
+
 export function DeleteInstanceResource(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function DeleteLocalAccount(arg1:string):Promise<string>;
 
+export function DetectSystemJava():Promise<Array<launcher.JavaInfo>>;
+
+export function DiagnoseJava(arg1:string):Promise<main.JavaDoctorReport>;
+
 export function DownloadRemoteStoreProject(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string):Promise<string>;
 
 export function EnsureInstanceForServer(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:number):Promise<string>;
 
+export function ExportAccounts(arg1:string,arg2:string):Promise<string>;
+
+export function ExportInstanceModList(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function FixCredentialsPermissions():Promise<string>;
+
+export function FixInstanceModConflicts(arg1:string):Promise<main.ModConflictReport>;
+
+export function GetAccountAliases():Promise<Array<auth.AccountAlias>>;
+
 export function GetAccounts():Promise<Array<main.AccountInfo>>;
 
+export function GetAuthExpiryWarnings():Promise<Array<main.AuthExpiryWarning>>;
+
+export function GetAuthStatus():Promise<auth.StatusReport>;
+
+export function GetAuthStatusJSON():Promise<string>;
+
+export function GetBandwidthLimit():Promise<main.BandwidthSettings>;
+
 export function GetCatalogStoreSettings():Promise<main.CatalogStoreSettings>;
 
 export function GetCloudElyLinked():Promise<boolean>;
@@ -37,6 +84,12 @@ export function GetCloudGameAccounts():Promise<Array<main.CloudGameAccountInfo>|
 
 export function GetCloudProfile():Promise<Record<string, any>>;
 
+export function GetCloudProfiles():Promise<Array<main.CloudProfile>>;
+
+export function GetCloudStatus():Promise<network.CloudStatus>;
+
+export function GetCloudUpdateNotices():Promise<Array<main.CloudUpdateNotice>>;
+
 export function GetCreateInstanceLoaderVersions(arg1:string,arg2:string):Promise<Array<string>>;
 
 export function GetCreateInstanceMinecraftVersions():Promise<Array<string>>;
@@ -49,44 +102,136 @@ export function GetGameAccountInventory(arg1:number):Promise<main.GameAccountInv
 
 export function GetInstanceDetails(arg1:string):Promise<main.InstanceDetails>;
 
+export function GetInstanceJavaEnv(arg1:string,arg2:string):Promise<string|string>;
+
+export function GetInstanceMissingAPIMods(arg1:string):Promise<main.MissingAPIModsReport>;
+
+export function GetInstanceModDetails(arg1:string,arg2:string):Promise<main.InstanceModDetails>;
+
+export function GetInstanceModProfiles(arg1:string):Promise<main.ModProfilesResponse>;
+
+export function GetInstanceModsMetadata(arg1:string):Promise<Array<main.InstanceModInfo>>;
+
+export function GetInstanceOutdatedMods(arg1:string,arg2:boolean):Promise<main.OutdatedModsReport>;
+
+export function GetInstanceResourceModrinthMatches(arg1:string,arg2:string):Promise<Record<string, meta.ModrinthHashMatch>>;
+
+export function GetInstanceShaderPacks(arg1:string):Promise<main.ShaderPacksReport>;
+
+export function GetInstanceSyncConflicts(arg1:string):Promise<main.InstanceSyncConflicts>;
+
+export function GetInstanceSyncProtect(arg1:string):Promise<Array<string>>;
+
+export function GetInstanceSyncTrash(arg1:string):Promise<Array<launcher.SyncTrashBatch>>;
+
 export function GetInstances():Promise<Array<launcher.Instance>>;
 
-export function GetLang():Promise<string>;
+export function GetJavaAliases():Promise<Array<launcher.JavaAlias>>;
 
-export function GetLauncherDebug():Promise<boolean>;
+export function GetJavaArgTemplates():Promise<Record<string, string>>;
+
+export function GetJavaMirrorSettings():Promise<main.JavaMirrorSettings>;
+
+export function GetJavaRuntimesJSON():Promise<string>;
+
+export function GetLang():Promise<string>;
 
 export function GetLauncherAPITarget():Promise<main.LauncherAPITargetSettings>;
 
 export function GetLauncherAboutInfo():Promise<main.LauncherAboutInfo>;
 
+export function GetLauncherBackups():Promise<main.LauncherBackupsReport>;
+
+export function GetLauncherDebug():Promise<boolean>;
+
+export function GetLauncherUpdateInfo():Promise<main.LauncherUpdateInfo>;
+
 export function GetLauncherVersion():Promise<string>;
 
 export function GetMicrosoftAuthAvailable():Promise<boolean>;
 
+export function GetModUpdateChangelog(arg1:string,arg2:string,arg3:string,arg4:number,arg5:number):Promise<main.ModChangelogPage>;
+
+export function GetModrinthProjectVersions(arg1:string,arg2:string,arg3:string):Promise<main.ModrinthVersionsResponse>;
+
 export function GetNews():Promise<Array<main.NewsItem>>;
 
 export function GetQMServerAPIBase():Promise<string>;
 
+export function GetQMServerEndpoint():Promise<main.QMServerEndpointSettings>;
+
+export function GetQMServerTLSSettings():Promise<network.QMServerTLS>;
+
 export function GetQMServersError():Promise<string>;
 
-export function InvalidateQMServersCache():Promise<void>;
+export function GetRecentConnections():Promise<main.RecentConnectionsReport>;
+
+export function GetRecentConnectionsPolicy():Promise<launcher.RecentConnectionsPolicy>;
 
 export function GetRecentServers():Promise<Array<main.ServerInfo>>;
 
+export function GetServerDetails(arg1:string):Promise<main.ServerDetails>;
+
+export function GetServerFavorites():Promise<Array<main.ServerFavorite>>;
+
+export function GetServers(arg1:main.ServerListOptions):Promise<Array<main.ServerInfo>>;
+
+export function GetServersJSON(arg1:main.ServerListOptions):Promise<string>;
+
 export function GetSkinProviderConfig():Promise<Record<string, boolean>>;
 
+export function GetUpdateChannel():Promise<string>;
+
+export function GetUpdateNoticeEnabled():Promise<boolean>;
+
+export function GetUpdaterNetworkSettings():Promise<main.UpdaterNetworkSettings>;
+
 export function HasCurseForgeAPIKey():Promise<boolean>;
 
+export function ImportAccounts(arg1:string,arg2:string):Promise<main.ImportAccountsResult>;
+
+export function InstallInstanceFromLock(arg1:string):Promise<main.ModLockInstallReport>;
+
+export function InstallInstanceMissingAPIMods(arg1:string):Promise<main.BulkInstallReport>;
+
+export function InstallInstanceModsFromFile(arg1:string,arg2:string):Promise<main.BulkInstallReport>;
+
+export function InstallJavaRuntime(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<main.JavaInstallResult>;
+
+export function InstallModrinthCollection(arg1:string,arg2:string):Promise<main.BulkInstallReport>;
+
+export function InstallModrinthContent(arg1:string,arg2:string,arg3:string):Promise<main.ModInstallResult>;
+
+export function InstallModrinthProject(arg1:string,arg2:string):Promise<main.ModInstallResult>;
+
+export function InstallModrinthProjectVersion(arg1:string,arg2:string,arg3:string,arg4:string):Promise<main.ModInstallResult>;
+
+export function InvalidateQMServersCache():Promise<void>;
+
+export function JoinServer(arg1:string,arg2:string):Promise<string>;
+
 export function LaunchInstance(arg1:string,arg2:string,arg3:number,arg4:boolean):Promise<string>;
 
 export function LaunchInstanceWithAccount(arg1:string,arg2:string,arg3:number,arg4:boolean,arg5:string,arg6:string,arg7:string,arg8:string):Promise<string>;
 
+export function LaunchRecentConnection(arg1:number):Promise<string>;
+
+export function ListAvailableJavaComponents(arg1:string):Promise<main.JavaComponentsReport>;
+
+export function ListJavaRuntimes():Promise<main.JavaRuntimesReport>;
+
 export function LoginAccount(arg1:boolean):Promise<string>;
 
+export function LoginCloudProfileWithToken(arg1:string,arg2:string):Promise<string>;
+
+export function LoginCloudWithToken(arg1:string):Promise<string>;
+
 export function LogoutAccount():Promise<string>;
 
 export function LogoutCloudAccount(arg1:string):Promise<string>;
 
+export function LogoutCloudProfile(arg1:string):Promise<string>;
+
 export function OpenBrowserForMicrosoft():Promise<string>;
 
 export function OpenBrowserForQMServerCloud():Promise<string>;
@@ -95,27 +240,105 @@ export function OpenBrowserURL(arg1:string):Promise<string>;
 
 export function OpenPath(arg1:string):Promise<string>;
 
+export function PinRecentConnection(arg1:number,arg2:boolean):Promise<string>;
+
+export function PlanInstanceModRemoval(arg1:string,arg2:string):Promise<main.ModRemovePlan>;
+
+export function PruneInstanceSyncTrash(arg1:string):Promise<Array<string>>;
+
+export function PushInstance(arg1:string,arg2:number,arg3:boolean,arg4:boolean,arg5:string):Promise<main.CloudPushReport>;
+
+export function RemoveAccountAlias(arg1:string):Promise<string>;
+
+export function RemoveInstanceMod(arg1:string,arg2:string,arg3:boolean,arg4:boolean):Promise<main.ModRemovePlan>;
+
+export function RemoveJavaAlias(arg1:string):Promise<string>;
+
+export function RemoveRecentConnection(arg1:number):Promise<string>;
+
+export function RemoveServerFavorite(arg1:string):Promise<string>;
+
+export function RenderInstanceModList(arg1:string,arg2:string):Promise<string|string>;
+
+export function ResolveInstanceModLinks(arg1:string,arg2:boolean):Promise<main.ModLinksReport>;
+
 export function ResolveInstanceResourceStoreLinks(arg1:string,arg2:string,arg3:string):Promise<main.ResourceStoreLinks>;
 
+export function ResolveSyncConflict(arg1:number,arg2:string):Promise<string>;
+
+export function RestoreInstanceSyncTrash(arg1:string,arg2:string,arg3:Array<string>):Promise<main.SyncRestoreReport>;
+
+export function RollbackLauncherUpdate(arg1:string):Promise<string>;
+
+export function SaveInstanceModProfile(arg1:string,arg2:string,arg3:Array<string>,arg4:boolean):Promise<string>;
+
+export function SearchModrinthFiltered(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:number,arg9:number):Promise<main.RemoteStoreSearchResponse>;
+
+export function SearchModrinthVersions(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<main.ModrinthVersionsResponse>;
+
 export function SearchRemoteStore(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:number):Promise<main.RemoteStoreSearchResponse>;
 
+export function SetAccountAlias(arg1:string,arg2:string):Promise<string>;
+
+export function SetBandwidthLimit(arg1:string):Promise<string>;
+
 export function SetCatalogStoreSettings(arg1:boolean,arg2:boolean):Promise<string>;
 
+export function SetCloudProfiles(arg1:Array<main.CloudProfile>):Promise<string>;
+
 export function SetCurseForgeSettingsKey(arg1:string,arg2:boolean,arg3:boolean):Promise<string>;
 
 export function SetDefaultAccount(arg1:string):Promise<string>;
 
+export function SetGitHubToken(arg1:string):Promise<string>;
+
+export function SetInstanceCloudProfile(arg1:string,arg2:string):Promise<string>;
+
 export function SetInstanceMemory(arg1:string,arg2:number,arg3:number):Promise<string>;
 
 export function SetInstanceModEnabled(arg1:string,arg2:string,arg3:boolean):Promise<string>;
 
+export function SetInstanceModPin(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<string>;
+
 export function SetInstanceResourceEnabled(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<string>;
 
+export function SetInstanceSyncConflicts(arg1:string,arg2:string,arg3:Record<string, string>):Promise<string>;
+
+export function SetInstanceSyncProtect(arg1:string,arg2:Array<string>):Promise<string>;
+
+export function SetJavaAlias(arg1:string,arg2:string):Promise<string>;
+
+export function SetJavaArgTemplates(arg1:Record<string, string>):Promise<string>;
+
+export function SetJavaMirrorSettings(arg1:string,arg2:string):Promise<string>;
+
 export function SetLang(arg1:string):Promise<void>;
 
+export function SetLauncherAPITarget(arg1:boolean,arg2:string):Promise<string>;
+
+export function SetLauncherBackupsKept(arg1:number):Promise<string>;
+
 export function SetLauncherDebug(arg1:boolean):Promise<string>;
 
-export function SetLauncherAPITarget(arg1:boolean,arg2:string):Promise<string>;
+export function SetQMServerEndpoint(arg1:string):Promise<string>;
+
+export function SetQMServerTLSSettings(arg1:network.QMServerTLS):Promise<string>;
+
+export function SetRecentConnectionsPolicy(arg1:number,arg2:string,arg3:boolean):Promise<string>;
+
+export function SetSyncConflictListener(arg1:boolean):Promise<void>;
+
+export function SetUpdateChannel(arg1:string):Promise<string>;
+
+export function SetUpdateManifestURL(arg1:string):Promise<string>;
+
+export function SetUpdateNoticeEnabled(arg1:boolean):Promise<string>;
+
+export function SetUpdaterProxy(arg1:string):Promise<string>;
+
+export function SyncInstance(arg1:string,arg2:number,arg3:boolean,arg4:string,arg5:string):Promise<main.SyncInstanceReport>;
+
+export function SyncInstanceWithOptions(arg1:main.InstanceSyncOptions):Promise<main.SyncInstanceReport>;
 
 export function SyncLocalAccountToCloud(arg1:string,arg2:string):Promise<string>;
 
@@ -123,4 +346,16 @@ export function SyncMicrosoftAccountToCloud():Promise<string>;
 
 export function Translate(arg1:string):Promise<string>;
 
+export function TrustServerManifestKey(arg1:string,arg2:number,arg3:number):Promise<string>;
+
 export function UpdateCloudGameAccount(arg1:number,arg2:string,arg3:string):Promise<string>;
+
+export function UpdateInstanceMods(arg1:string,arg2:string):Promise<main.ModUpdatesReport>;
+
+export function UpdateJavaRuntimes(arg1:boolean):Promise<main.JavaUpdateReport>;
+
+export function UseJavaRuntime(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
+export function VerifyJavaRuntimes():Promise<main.JavaVerifyReport>;
+
+export function VerifyMicrosoftProfile():Promise<auth.Profile|string>;
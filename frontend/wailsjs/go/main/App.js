@@ -2,14 +2,54 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddInstanceContentFromSource(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddInstanceContentFromSource'](arg1, arg2, arg3);
+}
+
+export function AddInstanceModFromSource(arg1, arg2) {
+  return window['go']['main']['App']['AddInstanceModFromSource'](arg1, arg2);
+}
+
+export function AddServerFavorite(arg1, arg2) {
+  return window['go']['main']['App']['AddServerFavorite'](arg1, arg2);
+}
+
+export function AlignInstanceWithServer(arg1, arg2) {
+  return window['go']['main']['App']['AlignInstanceWithServer'](arg1, arg2);
+}
+
+export function ApplyInstanceModProfile(arg1, arg2) {
+  return window['go']['main']['App']['ApplyInstanceModProfile'](arg1, arg2);
+}
+
+export function ApplyLauncherDowngrade() {
+  return window['go']['main']['App']['ApplyLauncherDowngrade']();
+}
+
 export function ApplyLauncherUpdate() {
   return window['go']['main']['App']['ApplyLauncherUpdate']();
 }
 
+export function CheckInstanceModConflicts(arg1) {
+  return window['go']['main']['App']['CheckInstanceModConflicts'](arg1);
+}
+
+export function CheckInstanceModUpdates(arg1) {
+  return window['go']['main']['App']['CheckInstanceModUpdates'](arg1);
+}
+
 export function CheckLauncherUpdateAvailable() {
   return window['go']['main']['App']['CheckLauncherUpdateAvailable']();
 }
 
+export function ClearRecentConnections(arg1) {
+  return window['go']['main']['App']['ClearRecentConnections'](arg1);
+}
+
+export function CollectUnusedJava(arg1) {
+  return window['go']['main']['App']['CollectUnusedJava'](arg1);
+}
+
 export function CreateCloudGameAccount(arg1, arg2) {
   return window['go']['main']['App']['CreateCloudGameAccount'](arg1, arg2);
 }
@@ -34,6 +74,10 @@ export function DeleteInstanceMod(arg1, arg2) {
   return window['go']['main']['App']['DeleteInstanceMod'](arg1, arg2);
 }
 
+export function DeleteInstanceModProfile(arg1, arg2) {
+  return window['go']['main']['App']['DeleteInstanceModProfile'](arg1, arg2);
+}
+
 export function DeleteInstanceResource(arg1, arg2, arg3) {
   return window['go']['main']['App']['DeleteInstanceResource'](arg1, arg2, arg3);
 }
@@ -42,6 +86,14 @@ export function DeleteLocalAccount(arg1) {
   return window['go']['main']['App']['DeleteLocalAccount'](arg1);
 }
 
+export function DetectSystemJava() {
+  return window['go']['main']['App']['DetectSystemJava']();
+}
+
+export function DiagnoseJava(arg1) {
+  return window['go']['main']['App']['DiagnoseJava'](arg1);
+}
+
 export function DownloadRemoteStoreProject(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
   return window['go']['main']['App']['DownloadRemoteStoreProject'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
 }
@@ -50,10 +102,46 @@ export function EnsureInstanceForServer(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['EnsureInstanceForServer'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
+export function ExportAccounts(arg1, arg2) {
+  return window['go']['main']['App']['ExportAccounts'](arg1, arg2);
+}
+
+export function ExportInstanceModList(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportInstanceModList'](arg1, arg2, arg3);
+}
+
+export function FixCredentialsPermissions() {
+  return window['go']['main']['App']['FixCredentialsPermissions']();
+}
+
+export function FixInstanceModConflicts(arg1) {
+  return window['go']['main']['App']['FixInstanceModConflicts'](arg1);
+}
+
+export function GetAccountAliases() {
+  return window['go']['main']['App']['GetAccountAliases']();
+}
+
 export function GetAccounts() {
   return window['go']['main']['App']['GetAccounts']();
 }
 
+export function GetAuthExpiryWarnings() {
+  return window['go']['main']['App']['GetAuthExpiryWarnings']();
+}
+
+export function GetAuthStatus() {
+  return window['go']['main']['App']['GetAuthStatus']();
+}
+
+export function GetAuthStatusJSON() {
+  return window['go']['main']['App']['GetAuthStatusJSON']();
+}
+
+export function GetBandwidthLimit() {
+  return window['go']['main']['App']['GetBandwidthLimit']();
+}
+
 export function GetCatalogStoreSettings() {
   return window['go']['main']['App']['GetCatalogStoreSettings']();
 }
@@ -70,6 +158,18 @@ export function GetCloudProfile() {
   return window['go']['main']['App']['GetCloudProfile']();
 }
 
+export function GetCloudProfiles() {
+  return window['go']['main']['App']['GetCloudProfiles']();
+}
+
+export function GetCloudStatus() {
+  return window['go']['main']['App']['GetCloudStatus']();
+}
+
+export function GetCloudUpdateNotices() {
+  return window['go']['main']['App']['GetCloudUpdateNotices']();
+}
+
 export function GetCreateInstanceLoaderVersions(arg1, arg2) {
   return window['go']['main']['App']['GetCreateInstanceLoaderVersions'](arg1, arg2);
 }
@@ -94,16 +194,72 @@ export function GetInstanceDetails(arg1) {
   return window['go']['main']['App']['GetInstanceDetails'](arg1);
 }
 
+export function GetInstanceJavaEnv(arg1, arg2) {
+  return window['go']['main']['App']['GetInstanceJavaEnv'](arg1, arg2);
+}
+
+export function GetInstanceMissingAPIMods(arg1) {
+  return window['go']['main']['App']['GetInstanceMissingAPIMods'](arg1);
+}
+
+export function GetInstanceModDetails(arg1, arg2) {
+  return window['go']['main']['App']['GetInstanceModDetails'](arg1, arg2);
+}
+
+export function GetInstanceModProfiles(arg1) {
+  return window['go']['main']['App']['GetInstanceModProfiles'](arg1);
+}
+
+export function GetInstanceModsMetadata(arg1) {
+  return window['go']['main']['App']['GetInstanceModsMetadata'](arg1);
+}
+
+export function GetInstanceOutdatedMods(arg1, arg2) {
+  return window['go']['main']['App']['GetInstanceOutdatedMods'](arg1, arg2);
+}
+
+export function GetInstanceResourceModrinthMatches(arg1, arg2) {
+  return window['go']['main']['App']['GetInstanceResourceModrinthMatches'](arg1, arg2);
+}
+
+export function GetInstanceShaderPacks(arg1) {
+  return window['go']['main']['App']['GetInstanceShaderPacks'](arg1);
+}
+
+export function GetInstanceSyncConflicts(arg1) {
+  return window['go']['main']['App']['GetInstanceSyncConflicts'](arg1);
+}
+
+export function GetInstanceSyncProtect(arg1) {
+  return window['go']['main']['App']['GetInstanceSyncProtect'](arg1);
+}
+
+export function GetInstanceSyncTrash(arg1) {
+  return window['go']['main']['App']['GetInstanceSyncTrash'](arg1);
+}
+
 export function GetInstances() {
   return window['go']['main']['App']['GetInstances']();
 }
 
-export function GetLang() {
-  return window['go']['main']['App']['GetLang']();
+export function GetJavaAliases() {
+  return window['go']['main']['App']['GetJavaAliases']();
 }
 
-export function GetLauncherDebug() {
-  return window['go']['main']['App']['GetLauncherDebug']();
+export function GetJavaArgTemplates() {
+  return window['go']['main']['App']['GetJavaArgTemplates']();
+}
+
+export function GetJavaMirrorSettings() {
+  return window['go']['main']['App']['GetJavaMirrorSettings']();
+}
+
+export function GetJavaRuntimesJSON() {
+  return window['go']['main']['App']['GetJavaRuntimesJSON']();
+}
+
+export function GetLang() {
+  return window['go']['main']['App']['GetLang']();
 }
 
 export function GetLauncherAPITarget() {
@@ -114,6 +270,18 @@ export function GetLauncherAboutInfo() {
   return window['go']['main']['App']['GetLauncherAboutInfo']();
 }
 
+export function GetLauncherBackups() {
+  return window['go']['main']['App']['GetLauncherBackups']();
+}
+
+export function GetLauncherDebug() {
+  return window['go']['main']['App']['GetLauncherDebug']();
+}
+
+export function GetLauncherUpdateInfo() {
+  return window['go']['main']['App']['GetLauncherUpdateInfo']();
+}
+
 export function GetLauncherVersion() {
   return window['go']['main']['App']['GetLauncherVersion']();
 }
@@ -122,6 +290,14 @@ export function GetMicrosoftAuthAvailable() {
   return window['go']['main']['App']['GetMicrosoftAuthAvailable']();
 }
 
+export function GetModUpdateChangelog(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GetModUpdateChangelog'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GetModrinthProjectVersions(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetModrinthProjectVersions'](arg1, arg2, arg3);
+}
+
 export function GetNews() {
   return window['go']['main']['App']['GetNews']();
 }
@@ -130,26 +306,110 @@ export function GetQMServerAPIBase() {
   return window['go']['main']['App']['GetQMServerAPIBase']();
 }
 
+export function GetQMServerEndpoint() {
+  return window['go']['main']['App']['GetQMServerEndpoint']();
+}
+
+export function GetQMServerTLSSettings() {
+  return window['go']['main']['App']['GetQMServerTLSSettings']();
+}
+
 export function GetQMServersError() {
   return window['go']['main']['App']['GetQMServersError']();
 }
 
-export function InvalidateQMServersCache() {
-  return window['go']['main']['App']['InvalidateQMServersCache']();
+export function GetRecentConnections() {
+  return window['go']['main']['App']['GetRecentConnections']();
+}
+
+export function GetRecentConnectionsPolicy() {
+  return window['go']['main']['App']['GetRecentConnectionsPolicy']();
 }
 
 export function GetRecentServers() {
   return window['go']['main']['App']['GetRecentServers']();
 }
 
+export function GetServerDetails(arg1) {
+  return window['go']['main']['App']['GetServerDetails'](arg1);
+}
+
+export function GetServerFavorites() {
+  return window['go']['main']['App']['GetServerFavorites']();
+}
+
+export function GetServers(arg1) {
+  return window['go']['main']['App']['GetServers'](arg1);
+}
+
+export function GetServersJSON(arg1) {
+  return window['go']['main']['App']['GetServersJSON'](arg1);
+}
+
 export function GetSkinProviderConfig() {
   return window['go']['main']['App']['GetSkinProviderConfig']();
 }
 
+export function GetUpdateChannel() {
+  return window['go']['main']['App']['GetUpdateChannel']();
+}
+
+export function GetUpdateNoticeEnabled() {
+  return window['go']['main']['App']['GetUpdateNoticeEnabled']();
+}
+
+export function GetUpdaterNetworkSettings() {
+  return window['go']['main']['App']['GetUpdaterNetworkSettings']();
+}
+
 export function HasCurseForgeAPIKey() {
   return window['go']['main']['App']['HasCurseForgeAPIKey']();
 }
 
+export function ImportAccounts(arg1, arg2) {
+  return window['go']['main']['App']['ImportAccounts'](arg1, arg2);
+}
+
+export function InstallInstanceFromLock(arg1) {
+  return window['go']['main']['App']['InstallInstanceFromLock'](arg1);
+}
+
+export function InstallInstanceMissingAPIMods(arg1) {
+  return window['go']['main']['App']['InstallInstanceMissingAPIMods'](arg1);
+}
+
+export function InstallInstanceModsFromFile(arg1, arg2) {
+  return window['go']['main']['App']['InstallInstanceModsFromFile'](arg1, arg2);
+}
+
+export function InstallJavaRuntime(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['InstallJavaRuntime'](arg1, arg2, arg3, arg4);
+}
+
+export function InstallModrinthCollection(arg1, arg2) {
+  return window['go']['main']['App']['InstallModrinthCollection'](arg1, arg2);
+}
+
+export function InstallModrinthContent(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallModrinthContent'](arg1, arg2, arg3);
+}
+
+export function InstallModrinthProject(arg1, arg2) {
+  return window['go']['main']['App']['InstallModrinthProject'](arg1, arg2);
+}
+
+export function InstallModrinthProjectVersion(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['InstallModrinthProjectVersion'](arg1, arg2, arg3, arg4);
+}
+
+export function InvalidateQMServersCache() {
+  return window['go']['main']['App']['InvalidateQMServersCache']();
+}
+
+export function JoinServer(arg1, arg2) {
+  return window['go']['main']['App']['JoinServer'](arg1, arg2);
+}
+
 export function LaunchInstance(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['LaunchInstance'](arg1, arg2, arg3, arg4);
 }
@@ -158,10 +418,30 @@ export function LaunchInstanceWithAccount(arg1, arg2, arg3, arg4, arg5, arg6, ar
   return window['go']['main']['App']['LaunchInstanceWithAccount'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8);
 }
 
+export function LaunchRecentConnection(arg1) {
+  return window['go']['main']['App']['LaunchRecentConnection'](arg1);
+}
+
+export function ListAvailableJavaComponents(arg1) {
+  return window['go']['main']['App']['ListAvailableJavaComponents'](arg1);
+}
+
+export function ListJavaRuntimes() {
+  return window['go']['main']['App']['ListJavaRuntimes']();
+}
+
 export function LoginAccount(arg1) {
   return window['go']['main']['App']['LoginAccount'](arg1);
 }
 
+export function LoginCloudProfileWithToken(arg1, arg2) {
+  return window['go']['main']['App']['LoginCloudProfileWithToken'](arg1, arg2);
+}
+
+export function LoginCloudWithToken(arg1) {
+  return window['go']['main']['App']['LoginCloudWithToken'](arg1);
+}
+
 export function LogoutAccount() {
   return window['go']['main']['App']['LogoutAccount']();
 }
@@ -170,6 +450,10 @@ export function LogoutCloudAccount(arg1) {
   return window['go']['main']['App']['LogoutCloudAccount'](arg1);
 }
 
+export function LogoutCloudProfile(arg1) {
+  return window['go']['main']['App']['LogoutCloudProfile'](arg1);
+}
+
 export function OpenBrowserForMicrosoft() {
   return window['go']['main']['App']['OpenBrowserForMicrosoft']();
 }
@@ -186,18 +470,98 @@ export function OpenPath(arg1) {
   return window['go']['main']['App']['OpenPath'](arg1);
 }
 
+export function PinRecentConnection(arg1, arg2) {
+  return window['go']['main']['App']['PinRecentConnection'](arg1, arg2);
+}
+
+export function PlanInstanceModRemoval(arg1, arg2) {
+  return window['go']['main']['App']['PlanInstanceModRemoval'](arg1, arg2);
+}
+
+export function PruneInstanceSyncTrash(arg1) {
+  return window['go']['main']['App']['PruneInstanceSyncTrash'](arg1);
+}
+
+export function PushInstance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['PushInstance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function RemoveAccountAlias(arg1) {
+  return window['go']['main']['App']['RemoveAccountAlias'](arg1);
+}
+
+export function RemoveInstanceMod(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RemoveInstanceMod'](arg1, arg2, arg3, arg4);
+}
+
+export function RemoveJavaAlias(arg1) {
+  return window['go']['main']['App']['RemoveJavaAlias'](arg1);
+}
+
+export function RemoveRecentConnection(arg1) {
+  return window['go']['main']['App']['RemoveRecentConnection'](arg1);
+}
+
+export function RemoveServerFavorite(arg1) {
+  return window['go']['main']['App']['RemoveServerFavorite'](arg1);
+}
+
+export function RenderInstanceModList(arg1, arg2) {
+  return window['go']['main']['App']['RenderInstanceModList'](arg1, arg2);
+}
+
+export function ResolveInstanceModLinks(arg1, arg2) {
+  return window['go']['main']['App']['ResolveInstanceModLinks'](arg1, arg2);
+}
+
 export function ResolveInstanceResourceStoreLinks(arg1, arg2, arg3) {
   return window['go']['main']['App']['ResolveInstanceResourceStoreLinks'](arg1, arg2, arg3);
 }
 
+export function ResolveSyncConflict(arg1, arg2) {
+  return window['go']['main']['App']['ResolveSyncConflict'](arg1, arg2);
+}
+
+export function RestoreInstanceSyncTrash(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestoreInstanceSyncTrash'](arg1, arg2, arg3);
+}
+
+export function RollbackLauncherUpdate(arg1) {
+  return window['go']['main']['App']['RollbackLauncherUpdate'](arg1);
+}
+
+export function SaveInstanceModProfile(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SaveInstanceModProfile'](arg1, arg2, arg3, arg4);
+}
+
+export function SearchModrinthFiltered(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9) {
+  return window['go']['main']['App']['SearchModrinthFiltered'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
+}
+
+export function SearchModrinthVersions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SearchModrinthVersions'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function SearchRemoteStore(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
   return window['go']['main']['App']['SearchRemoteStore'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
 }
 
+export function SetAccountAlias(arg1, arg2) {
+  return window['go']['main']['App']['SetAccountAlias'](arg1, arg2);
+}
+
+export function SetBandwidthLimit(arg1) {
+  return window['go']['main']['App']['SetBandwidthLimit'](arg1);
+}
+
 export function SetCatalogStoreSettings(arg1, arg2) {
   return window['go']['main']['App']['SetCatalogStoreSettings'](arg1, arg2);
 }
 
+export function SetCloudProfiles(arg1) {
+  return window['go']['main']['App']['SetCloudProfiles'](arg1);
+}
+
 export function SetCurseForgeSettingsKey(arg1, arg2, arg3) {
   return window['go']['main']['App']['SetCurseForgeSettingsKey'](arg1, arg2, arg3);
 }
@@ -206,6 +570,14 @@ export function SetDefaultAccount(arg1) {
   return window['go']['main']['App']['SetDefaultAccount'](arg1);
 }
 
+export function SetGitHubToken(arg1) {
+  return window['go']['main']['App']['SetGitHubToken'](arg1);
+}
+
+export function SetInstanceCloudProfile(arg1, arg2) {
+  return window['go']['main']['App']['SetInstanceCloudProfile'](arg1, arg2);
+}
+
 export function SetInstanceMemory(arg1, arg2, arg3) {
   return window['go']['main']['App']['SetInstanceMemory'](arg1, arg2, arg3);
 }
@@ -214,20 +586,88 @@ export function SetInstanceModEnabled(arg1, arg2, arg3) {
   return window['go']['main']['App']['SetInstanceModEnabled'](arg1, arg2, arg3);
 }
 
+export function SetInstanceModPin(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SetInstanceModPin'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function SetInstanceResourceEnabled(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['SetInstanceResourceEnabled'](arg1, arg2, arg3, arg4);
 }
 
+export function SetInstanceSyncConflicts(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetInstanceSyncConflicts'](arg1, arg2, arg3);
+}
+
+export function SetInstanceSyncProtect(arg1, arg2) {
+  return window['go']['main']['App']['SetInstanceSyncProtect'](arg1, arg2);
+}
+
+export function SetJavaAlias(arg1, arg2) {
+  return window['go']['main']['App']['SetJavaAlias'](arg1, arg2);
+}
+
+export function SetJavaArgTemplates(arg1) {
+  return window['go']['main']['App']['SetJavaArgTemplates'](arg1);
+}
+
+export function SetJavaMirrorSettings(arg1, arg2) {
+  return window['go']['main']['App']['SetJavaMirrorSettings'](arg1, arg2);
+}
+
 export function SetLang(arg1) {
   return window['go']['main']['App']['SetLang'](arg1);
 }
 
+export function SetLauncherAPITarget(arg1, arg2) {
+  return window['go']['main']['App']['SetLauncherAPITarget'](arg1, arg2);
+}
+
+export function SetLauncherBackupsKept(arg1) {
+  return window['go']['main']['App']['SetLauncherBackupsKept'](arg1);
+}
+
 export function SetLauncherDebug(arg1) {
   return window['go']['main']['App']['SetLauncherDebug'](arg1);
 }
 
-export function SetLauncherAPITarget(arg1, arg2) {
-  return window['go']['main']['App']['SetLauncherAPITarget'](arg1, arg2);
+export function SetQMServerEndpoint(arg1) {
+  return window['go']['main']['App']['SetQMServerEndpoint'](arg1);
+}
+
+export function SetQMServerTLSSettings(arg1) {
+  return window['go']['main']['App']['SetQMServerTLSSettings'](arg1);
+}
+
+export function SetRecentConnectionsPolicy(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetRecentConnectionsPolicy'](arg1, arg2, arg3);
+}
+
+export function SetSyncConflictListener(arg1) {
+  return window['go']['main']['App']['SetSyncConflictListener'](arg1);
+}
+
+export function SetUpdateChannel(arg1) {
+  return window['go']['main']['App']['SetUpdateChannel'](arg1);
+}
+
+export function SetUpdateManifestURL(arg1) {
+  return window['go']['main']['App']['SetUpdateManifestURL'](arg1);
+}
+
+export function SetUpdateNoticeEnabled(arg1) {
+  return window['go']['main']['App']['SetUpdateNoticeEnabled'](arg1);
+}
+
+export function SetUpdaterProxy(arg1) {
+  return window['go']['main']['App']['SetUpdaterProxy'](arg1);
+}
+
+export function SyncInstance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SyncInstance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SyncInstanceWithOptions(arg1) {
+  return window['go']['main']['App']['SyncInstanceWithOptions'](arg1);
 }
 
 export function SyncLocalAccountToCloud(arg1, arg2) {
@@ -242,6 +682,30 @@ export function Translate(arg1) {
   return window['go']['main']['App']['Translate'](arg1);
 }
 
+export function TrustServerManifestKey(arg1, arg2, arg3) {
+  return window['go']['main']['App']['TrustServerManifestKey'](arg1, arg2, arg3);
+}
+
 export function UpdateCloudGameAccount(arg1, arg2, arg3) {
   return window['go']['main']['App']['UpdateCloudGameAccount'](arg1, arg2, arg3);
 }
+
+export function UpdateInstanceMods(arg1, arg2) {
+  return window['go']['main']['App']['UpdateInstanceMods'](arg1, arg2);
+}
+
+export function UpdateJavaRuntimes(arg1) {
+  return window['go']['main']['App']['UpdateJavaRuntimes'](arg1);
+}
+
+export function UseJavaRuntime(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UseJavaRuntime'](arg1, arg2, arg3);
+}
+
+export function VerifyJavaRuntimes() {
+  return window['go']['main']['App']['VerifyJavaRuntimes']();
+}
+
+export function VerifyMicrosoftProfile() {
+  return window['go']['main']['App']['VerifyMicrosoftProfile']();
+}
@@ -1,5 +1,174 @@
+export namespace auth {
+	
+	export class AccountAlias {
+	    alias: string;
+	    target: string;
+	    account: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccountAlias(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.alias = source["alias"];
+	        this.target = source["target"];
+	        this.account = source["account"];
+	    }
+	}
+	export class AccountStatus {
+	    type: string;
+	    name: string;
+	    uuid?: string;
+	    email?: string;
+	    status: string;
+	    isDefault: boolean;
+	    // Go type: time
+	    expiresAt?: any;
+	    refreshable: boolean;
+	    // Go type: time
+	    loginExpiresAt?: any;
+	    entitlements?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AccountStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.name = source["name"];
+	        this.uuid = source["uuid"];
+	        this.email = source["email"];
+	        this.status = source["status"];
+	        this.isDefault = source["isDefault"];
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
+	        this.refreshable = source["refreshable"];
+	        this.loginExpiresAt = this.convertValues(source["loginExpiresAt"], null);
+	        this.entitlements = source["entitlements"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Profile {
+	    username: string;
+	    uuid: string;
+	    entitlements: string[];
+	    ownsGame: boolean;
+	    // Go type: time
+	    checkedAt: any;
+	    ownershipKnown: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.uuid = source["uuid"];
+	        this.entitlements = source["entitlements"];
+	        this.ownsGame = source["ownsGame"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	        this.ownershipKnown = source["ownershipKnown"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StatusReport {
+	    // Go type: time
+	    generatedAt: any;
+	    microsoftAuthAvailable: boolean;
+	    default: string;
+	    accounts: AccountStatus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generatedAt = this.convertValues(source["generatedAt"], null);
+	        this.microsoftAuthAvailable = source["microsoftAuthAvailable"];
+	        this.default = source["default"];
+	        this.accounts = this.convertValues(source["accounts"], AccountStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace launcher {
 	
+	export class SyncConfig {
+	    protect?: string[];
+	    trash_days?: number;
+	    trash_batches?: number;
+	    on_conflict?: string;
+	    conflicts?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.protect = source["protect"];
+	        this.trash_days = source["trash_days"];
+	        this.trash_batches = source["trash_batches"];
+	        this.on_conflict = source["on_conflict"];
+	        this.conflicts = source["conflicts"];
+	    }
+	}
 	export class WindowResolution {
 	    width: number;
 	    height: number;
@@ -27,6 +196,8 @@ export namespace launcher {
 	    qmserver_port?: number;
 	    is_using_qmserver_cloud?: boolean;
 	    is_premium?: boolean;
+	    cloud_profile?: string;
+	    sync?: SyncConfig;
 	
 	    static createFrom(source: any = {}) {
 	        return new InstanceConfig(source);
@@ -46,6 +217,8 @@ export namespace launcher {
 	        this.qmserver_port = source["qmserver_port"];
 	        this.is_using_qmserver_cloud = source["is_using_qmserver_cloud"];
 	        this.is_premium = source["is_premium"];
+	        this.cloud_profile = source["cloud_profile"];
+	        this.sync = this.convertValues(source["sync"], SyncConfig);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -107,162 +280,2263 @@ export namespace launcher {
 		}
 	}
 	
-	export class RemoteInstallMeta {
-	    category: string;
-	    source: string;
-	    projectId: string;
-	    slug?: string;
-	    title?: string;
-	    /** HTTPS thumbnail from CurseForge/Modrinth when installed from catalog */
-	    iconUrl?: string;
+	export class JavaAlias {
+	    alias: string;
+	    target: string;
+	    java: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new RemoteInstallMeta(source);
+	        return new JavaAlias(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.category = source["category"];
+	        this.alias = source["alias"];
+	        this.target = source["target"];
+	        this.java = source["java"];
+	    }
+	}
+	export class JavaFinding {
+	    severity: string;
+	    check: string;
+	    message: string;
+	    fix: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaFinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.check = source["check"];
+	        this.message = source["message"];
+	        this.fix = source["fix"];
+	    }
+	}
+	export class JavaInfo {
+	    path: string;
+	    home: string;
+	    version: string;
+	    major: number;
+	    vendor: string;
+	    arch: string;
+	    source?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.home = source["home"];
+	        this.version = source["version"];
+	        this.major = source["major"];
+	        this.vendor = source["vendor"];
+	        this.arch = source["arch"];
 	        this.source = source["source"];
-	        this.projectId = source["projectId"];
-	        this.slug = source["slug"];
-	        this.title = source["title"];
-	        this.iconUrl = source["iconUrl"];
 	    }
 	}
-
-}
-
-export namespace main {
+	export class JavaHealth {
+	    name: string;
+	    path: string;
+	    info: JavaInfo;
+	    problems: string[];
+	    ok: boolean;
 	
-	export class AccountInfo {
-	    type: string;
-	    username: string;
-	    status: string;
-	    isDefault: boolean;
-	    skinModel: string;
-	    skinUuid: string;
-	    skinUrl: string;
-	    email: string;
-	    gameAccountId: number;
+	    static createFrom(source: any = {}) {
+	        return new JavaHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.info = this.convertValues(source["info"], JavaInfo);
+	        this.problems = source["problems"];
+	        this.ok = source["ok"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class JavaRuntime {
+	    name: string;
+	    path: string;
+	    version: string;
+	    major: number;
+	    vendor: string;
+	    arch: string;
+	    source: string;
+	    sizeBytes?: number;
+	    instances: string[];
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new AccountInfo(source);
+	        return new JavaRuntime(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
-	        this.username = source["username"];
-	        this.status = source["status"];
-	        this.isDefault = source["isDefault"];
-	        this.skinModel = source["skinModel"];
-	        this.skinUuid = source["skinUuid"];
-	        this.skinUrl = source["skinUrl"];
-	        this.email = source["email"];
-	        this.gameAccountId = source["gameAccountId"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.version = source["version"];
+	        this.major = source["major"];
+	        this.vendor = source["vendor"];
+	        this.arch = source["arch"];
+	        this.source = source["source"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.instances = source["instances"];
+	        this.error = source["error"];
 	    }
 	}
-	export class CatalogStoreSettings {
-	    curseforge_enabled: boolean;
-	    modrinth_enabled: boolean;
+	export class JavaUpdate {
+	    name: string;
+	    path: string;
+	    vendor: string;
+	    current: string;
+	    latest: string;
+	    available: boolean;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CatalogStoreSettings(source);
+	        return new JavaUpdate(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.curseforge_enabled = source["curseforge_enabled"];
-	        this.modrinth_enabled = source["modrinth_enabled"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.vendor = source["vendor"];
+	        this.current = source["current"];
+	        this.latest = source["latest"];
+	        this.available = source["available"];
+	        this.error = source["error"];
 	    }
 	}
-	export class CloudGameAccountInfo {
-	    id: number;
-	    username: string;
-	    uuid: string;
-	    serverUuid: string;
-	    skinModel: string;
-	    skinUrl: string;
-	    capeUrl: string;
-	    mojangUuid: string;
+	export class ModPin {
+	    provider?: string;
+	    channel?: string;
+	    versionId?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CloudGameAccountInfo(source);
+	        return new ModPin(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.username = source["username"];
-	        this.uuid = source["uuid"];
-	        this.serverUuid = source["serverUuid"];
-	        this.skinModel = source["skinModel"];
-	        this.skinUrl = source["skinUrl"];
-	        this.capeUrl = source["capeUrl"];
-	        this.mojangUuid = source["mojangUuid"];
+	        this.provider = source["provider"];
+	        this.channel = source["channel"];
+	        this.versionId = source["versionId"];
 	    }
 	}
-	export class CurseForgeKeySettings {
-	    has_effective_key: boolean;
-	    key_saved_in_file: boolean;
-	    use_my_key_default: boolean;
-	    effective_source: string;
+	export class ModLockEntry {
+	    category: string;
+	    provider: string;
+	    projectId?: string;
+	    slug?: string;
+	    title?: string;
+	    versionId?: string;
+	    versionNumber?: string;
+	    filename: string;
+	    url?: string;
+	    sha1?: string;
+	    sha512?: string;
+	    disabled?: boolean;
+	    dependencies?: string[];
+	    pin?: ModPin;
+	    // Go type: time
+	    installedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CurseForgeKeySettings(source);
+	        return new ModLockEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.has_effective_key = source["has_effective_key"];
-	        this.key_saved_in_file = source["key_saved_in_file"];
-	        this.use_my_key_default = source["use_my_key_default"];
-	        this.effective_source = source["effective_source"];
+	        this.category = source["category"];
+	        this.provider = source["provider"];
+	        this.projectId = source["projectId"];
+	        this.slug = source["slug"];
+	        this.title = source["title"];
+	        this.versionId = source["versionId"];
+	        this.versionNumber = source["versionNumber"];
+	        this.filename = source["filename"];
+	        this.url = source["url"];
+	        this.sha1 = source["sha1"];
+	        this.sha512 = source["sha512"];
+	        this.disabled = source["disabled"];
+	        this.dependencies = source["dependencies"];
+	        this.pin = this.convertValues(source["pin"], ModPin);
+	        this.installedAt = this.convertValues(source["installedAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModLockRestore {
+	    category: string;
+	    filename: string;
+	    status: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModLockRestore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.filename = source["filename"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class ModProfile {
+	    name: string;
+	    mods: string[];
+	    hasConfigs?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.mods = source["mods"];
+	        this.hasConfigs = source["hasConfigs"];
+	    }
+	}
+	export class RecentConnection {
+	    instance: string;
+	    server: string;
+	    server_id?: number;
+	    server_name?: string;
+	    account?: string;
+	    // Go type: time
+	    time: any;
+	    pinned?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentConnection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instance = source["instance"];
+	        this.server = source["server"];
+	        this.server_id = source["server_id"];
+	        this.server_name = source["server_name"];
+	        this.account = source["account"];
+	        this.time = this.convertValues(source["time"], null);
+	        this.pinned = source["pinned"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecentConnectionsPolicy {
+	    max: number;
+	    dedupe: string;
+	    keepPinned: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentConnectionsPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.max = source["max"];
+	        this.dedupe = source["dedupe"];
+	        this.keepPinned = source["keepPinned"];
+	    }
+	}
+	export class RemoteInstallMeta {
+	    category: string;
+	    source: string;
+	    projectId: string;
+	    slug?: string;
+	    title?: string;
+	    iconUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteInstallMeta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.source = source["source"];
+	        this.projectId = source["projectId"];
+	        this.slug = source["slug"];
+	        this.title = source["title"];
+	        this.iconUrl = source["iconUrl"];
+	    }
+	}
+	
+	export class SyncTrashBatch {
+	    id: string;
+	    // Go type: time
+	    created: any;
+	    files: string[];
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncTrashBatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.created = this.convertValues(source["created"], null);
+	        this.files = source["files"];
+	        this.size = source["size"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace main {
+	
+	export class AccountInfo {
+	    type: string;
+	    username: string;
+	    status: string;
+	    isDefault: boolean;
+	    skinModel: string;
+	    skinUuid: string;
+	    skinUrl: string;
+	    email: string;
+	    gameAccountId: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccountInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.username = source["username"];
+	        this.status = source["status"];
+	        this.isDefault = source["isDefault"];
+	        this.skinModel = source["skinModel"];
+	        this.skinUuid = source["skinUuid"];
+	        this.skinUrl = source["skinUrl"];
+	        this.email = source["email"];
+	        this.gameAccountId = source["gameAccountId"];
+	    }
+	}
+	export class AuthExpiryWarning {
+	    type: string;
+	    name: string;
+	    // Go type: time
+	    expiresAt: any;
+	    expired: boolean;
+	    message: string;
+	    tip: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthExpiryWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.name = source["name"];
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
+	        this.expired = source["expired"];
+	        this.message = source["message"];
+	        this.tip = source["tip"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BandwidthSettings {
+	    limit: string;
+	    bytesPerSec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BandwidthSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.limit = source["limit"];
+	        this.bytesPerSec = source["bytesPerSec"];
+	    }
+	}
+	export class BulkInstallItem {
+	    ref: string;
+	    title: string;
+	    filename: string;
+	    dependency: boolean;
+	    skipped: boolean;
+	    incompatible?: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkInstallItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ref = source["ref"];
+	        this.title = source["title"];
+	        this.filename = source["filename"];
+	        this.dependency = source["dependency"];
+	        this.skipped = source["skipped"];
+	        this.incompatible = source["incompatible"];
+	        this.error = source["error"];
+	    }
+	}
+	export class BulkInstallReport {
+	    items: BulkInstallItem[];
+	    installed: number;
+	    failed: number;
+	    cancelled: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkInstallReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], BulkInstallItem);
+	        this.installed = source["installed"];
+	        this.failed = source["failed"];
+	        this.cancelled = source["cancelled"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CatalogStoreSettings {
+	    curseforge_enabled: boolean;
+	    modrinth_enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CatalogStoreSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.curseforge_enabled = source["curseforge_enabled"];
+	        this.modrinth_enabled = source["modrinth_enabled"];
+	    }
+	}
+	export class CloudGameAccountInfo {
+	    id: number;
+	    username: string;
+	    uuid: string;
+	    serverUuid: string;
+	    skinModel: string;
+	    skinUrl: string;
+	    capeUrl: string;
+	    mojangUuid: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudGameAccountInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.username = source["username"];
+	        this.uuid = source["uuid"];
+	        this.serverUuid = source["serverUuid"];
+	        this.skinModel = source["skinModel"];
+	        this.skinUrl = source["skinUrl"];
+	        this.capeUrl = source["capeUrl"];
+	        this.mojangUuid = source["mojangUuid"];
+	    }
+	}
+	export class CloudProfile {
+	    name: string;
+	    endpoint: string;
+	    account?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.endpoint = source["endpoint"];
+	        this.account = source["account"];
+	    }
+	}
+	export class SyncPlanEntry {
+	    path: string;
+	    size: number;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncPlanEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class CloudPushReport {
+	    dryRun: boolean;
+	    upload: SyncPlanEntry[];
+	    delete: SyncPlanEntry[];
+	    unchanged: number;
+	    failed: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudPushReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.upload = this.convertValues(source["upload"], SyncPlanEntry);
+	        this.delete = this.convertValues(source["delete"], SyncPlanEntry);
+	        this.unchanged = source["unchanged"];
+	        this.failed = source["failed"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CloudServerMismatch {
+	    serverId: number;
+	    server: string;
+	    serverVersion: string;
+	    serverLoader: string;
+	    serverLoaderVersion?: string;
+	    instanceVersion: string;
+	    instanceLoader: string;
+	    instanceLoaderVersion?: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudServerMismatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.serverId = source["serverId"];
+	        this.server = source["server"];
+	        this.serverVersion = source["serverVersion"];
+	        this.serverLoader = source["serverLoader"];
+	        this.serverLoaderVersion = source["serverLoaderVersion"];
+	        this.instanceVersion = source["instanceVersion"];
+	        this.instanceLoader = source["instanceLoader"];
+	        this.instanceLoaderVersion = source["instanceLoaderVersion"];
+	        this.message = source["message"];
+	    }
+	}
+	export class CloudUpdateNotice {
+	    instance: string;
+	    serverId: number;
+	    added: number;
+	    changed: number;
+	    removed: number;
+	    detected: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudUpdateNotice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instance = source["instance"];
+	        this.serverId = source["serverId"];
+	        this.added = source["added"];
+	        this.changed = source["changed"];
+	        this.removed = source["removed"];
+	        this.detected = source["detected"];
+	    }
+	}
+	export class CurseForgeKeySettings {
+	    has_effective_key: boolean;
+	    key_saved_in_file: boolean;
+	    use_my_key_default: boolean;
+	    effective_source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CurseForgeKeySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.has_effective_key = source["has_effective_key"];
+	        this.key_saved_in_file = source["key_saved_in_file"];
+	        this.use_my_key_default = source["use_my_key_default"];
+	        this.effective_source = source["effective_source"];
+	    }
+	}
+	export class InventorySlot {
+	    slot: number;
+	    item: string;
+	    count: number;
+	    nbt?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventorySlot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.slot = source["slot"];
+	        this.item = source["item"];
+	        this.count = source["count"];
+	        this.nbt = source["nbt"];
+	    }
+	}
+	export class InventoryEntry {
+	    server_id: string;
+	    server_name?: string;
+	    server_version?: string;
+	    player_name: string;
+	    timestamp: number;
+	    main: InventorySlot[];
+	    armor: InventorySlot[];
+	    offhand?: InventorySlot;
+	
+	    static createFrom(source: any = {}) {
+	        return new InventoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.server_id = source["server_id"];
+	        this.server_name = source["server_name"];
+	        this.server_version = source["server_version"];
+	        this.player_name = source["player_name"];
+	        this.timestamp = source["timestamp"];
+	        this.main = this.convertValues(source["main"], InventorySlot);
+	        this.armor = this.convertValues(source["armor"], InventorySlot);
+	        this.offhand = this.convertValues(source["offhand"], InventorySlot);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GameAccountInventoryResponse {
+	    inventories: InventoryEntry[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GameAccountInventoryResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.inventories = this.convertValues(source["inventories"], InventoryEntry);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportAccountsResult {
+	    microsoft: boolean;
+	    local: number;
+	    cloud: number;
+	    cancelled: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportAccountsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.microsoft = source["microsoft"];
+	        this.local = source["local"];
+	        this.cloud = source["cloud"];
+	        this.cancelled = source["cancelled"];
+	        this.error = source["error"];
+	    }
+	}
+	export class InstanceDetails {
+	    name: string;
+	    uuid: string;
+	    gameVersion: string;
+	    loader: string;
+	    loaderVersion: string;
+	    dir: string;
+	    mods: string[];
+	    shaderpacks: string[];
+	    resourcepacks: string[];
+	    datapacks: string[];
+	    modpacks: string[];
+	    schematics: string[];
+	    configFiles: string[];
+	    kubejsFiles: string[];
+	    isUsingQMServerCloud: boolean;
+	    isPremium: boolean;
+	    lastServer: string;
+	    lastUser: string;
+	    remoteInstalls: Record<string, launcher.RemoteInstallMeta>;
+	    modLock: launcher.ModLockEntry[];
+	    catalogCurseforgeEnabled: boolean;
+	    catalogModrinthEnabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.uuid = source["uuid"];
+	        this.gameVersion = source["gameVersion"];
+	        this.loader = source["loader"];
+	        this.loaderVersion = source["loaderVersion"];
+	        this.dir = source["dir"];
+	        this.mods = source["mods"];
+	        this.shaderpacks = source["shaderpacks"];
+	        this.resourcepacks = source["resourcepacks"];
+	        this.datapacks = source["datapacks"];
+	        this.modpacks = source["modpacks"];
+	        this.schematics = source["schematics"];
+	        this.configFiles = source["configFiles"];
+	        this.kubejsFiles = source["kubejsFiles"];
+	        this.isUsingQMServerCloud = source["isUsingQMServerCloud"];
+	        this.isPremium = source["isPremium"];
+	        this.lastServer = source["lastServer"];
+	        this.lastUser = source["lastUser"];
+	        this.remoteInstalls = this.convertValues(source["remoteInstalls"], launcher.RemoteInstallMeta, true);
+	        this.modLock = this.convertValues(source["modLock"], launcher.ModLockEntry);
+	        this.catalogCurseforgeEnabled = source["catalogCurseforgeEnabled"];
+	        this.catalogModrinthEnabled = source["catalogModrinthEnabled"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InstanceModDetails {
+	    file: string;
+	    enabled: boolean;
+	    jar: meta.ModMetadata;
+	    modrinth?: meta.ModrinthProjectDetails;
+	    license: string;
+	    licenseUrl?: string;
+	    authors: string[];
+	    description: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceModDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.enabled = source["enabled"];
+	        this.jar = this.convertValues(source["jar"], meta.ModMetadata);
+	        this.modrinth = this.convertValues(source["modrinth"], meta.ModrinthProjectDetails);
+	        this.license = source["license"];
+	        this.licenseUrl = source["licenseUrl"];
+	        this.authors = source["authors"];
+	        this.description = source["description"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InstanceModInfo {
+	    file: string;
+	    enabled: boolean;
+	    modId: string;
+	    name: string;
+	    version: string;
+	    loader: string;
+	    authors?: string[];
+	    loaders: string[];
+	    minecraft?: string;
+	    dependencies: string[];
+	    provides?: string[];
+	    license?: string;
+	    description?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceModInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.enabled = source["enabled"];
+	        this.modId = source["modId"];
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.loader = source["loader"];
+	        this.authors = source["authors"];
+	        this.loaders = source["loaders"];
+	        this.minecraft = source["minecraft"];
+	        this.dependencies = source["dependencies"];
+	        this.provides = source["provides"];
+	        this.license = source["license"];
+	        this.description = source["description"];
+	        this.error = source["error"];
+	    }
+	}
+	export class InstanceSyncConflicts {
+	    onConflict: string;
+	    conflicts: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceSyncConflicts(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.onConflict = source["onConflict"];
+	        this.conflicts = source["conflicts"];
+	    }
+	}
+	export class InstanceSyncOptions {
+	    instance: string;
+	    serverId: number;
+	    dryRun: boolean;
+	    only: string;
+	    force: boolean;
+	    prepare: boolean;
+	    cloudProfile: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstanceSyncOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instance = source["instance"];
+	        this.serverId = source["serverId"];
+	        this.dryRun = source["dryRun"];
+	        this.only = source["only"];
+	        this.force = source["force"];
+	        this.prepare = source["prepare"];
+	        this.cloudProfile = source["cloudProfile"];
+	    }
+	}
+	
+	
+	export class JavaComponentsReport {
+	    platform: string;
+	    platforms: string[];
+	    components: meta.JavaComponent[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaComponentsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.platform = source["platform"];
+	        this.platforms = source["platforms"];
+	        this.components = this.convertValues(source["components"], meta.JavaComponent);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaDoctorEntry {
+	    name: string;
+	    path: string;
+	    info: launcher.JavaInfo;
+	    findings: launcher.JavaFinding[];
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaDoctorEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.info = this.convertValues(source["info"], launcher.JavaInfo);
+	        this.findings = this.convertValues(source["findings"], launcher.JavaFinding);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaDoctorReport {
+	    runtimes: JavaDoctorEntry[];
+	    errors: number;
+	    warnings: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaDoctorReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runtimes = this.convertValues(source["runtimes"], JavaDoctorEntry);
+	        this.errors = source["errors"];
+	        this.warnings = source["warnings"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaGCReport {
+	    unused: launcher.JavaRuntime[];
+	    removed: string[];
+	    freedBytes: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaGCReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.unused = this.convertValues(source["unused"], launcher.JavaRuntime);
+	        this.removed = source["removed"];
+	        this.freedBytes = source["freedBytes"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaInstallResult {
+	    name: string;
+	    path: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaInstallResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.error = source["error"];
+	    }
+	}
+	export class JavaMirrorSettings {
+	    mojang: string;
+	    adoptium: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaMirrorSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mojang = source["mojang"];
+	        this.adoptium = source["adoptium"];
+	    }
+	}
+	export class JavaRuntimesReport {
+	    runtimes: launcher.JavaRuntime[];
+	    default?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaRuntimesReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runtimes = this.convertValues(source["runtimes"], launcher.JavaRuntime);
+	        this.default = source["default"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaUpdateReport {
+	    updates: launcher.JavaUpdate[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaUpdateReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.updates = this.convertValues(source["updates"], launcher.JavaUpdate);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JavaVerifyReport {
+	    runtimes: launcher.JavaHealth[];
+	    broken: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JavaVerifyReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runtimes = this.convertValues(source["runtimes"], launcher.JavaHealth);
+	        this.broken = source["broken"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LauncherAPITargetSettings {
+	    use_qmserver_cloud: boolean;
+	    custom_api_base: string;
+	    effective_api_base: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LauncherAPITargetSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.use_qmserver_cloud = source["use_qmserver_cloud"];
+	        this.custom_api_base = source["custom_api_base"];
+	        this.effective_api_base = source["effective_api_base"];
+	    }
+	}
+	export class LauncherAboutInfo {
+	    version: string;
+	    os: string;
+	    arch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LauncherAboutInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.os = source["os"];
+	        this.arch = source["arch"];
+	    }
+	}
+	export class LauncherBackupsReport {
+	    current: string;
+	    keep: number;
+	    backups: updater.Backup[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LauncherBackupsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = source["current"];
+	        this.keep = source["keep"];
+	        this.backups = this.convertValues(source["backups"], updater.Backup);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LauncherUpdateInfo {
+	    available: boolean;
+	    current: string;
+	    version?: string;
+	    channel: string;
+	    releaseUrl?: string;
+	    changelog?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LauncherUpdateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.available = source["available"];
+	        this.current = source["current"];
+	        this.version = source["version"];
+	        this.channel = source["channel"];
+	        this.releaseUrl = source["releaseUrl"];
+	        this.changelog = source["changelog"];
+	        this.error = source["error"];
+	    }
+	}
+	export class MissingAPIMod {
+	    title: string;
+	    slug: string;
+	    requiredBy: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MissingAPIMod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.slug = source["slug"];
+	        this.requiredBy = source["requiredBy"];
+	    }
+	}
+	export class MissingAPIModsReport {
+	    missing: MissingAPIMod[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MissingAPIModsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.missing = this.convertValues(source["missing"], MissingAPIMod);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModChangelogPage {
+	    entries: meta.ModrinthChangelogEntry[];
+	    page: number;
+	    pageSize: number;
+	    total: number;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModChangelogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = this.convertValues(source["entries"], meta.ModrinthChangelogEntry);
+	        this.page = source["page"];
+	        this.pageSize = source["pageSize"];
+	        this.total = source["total"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModIssue {
+	    kind: string;
+	    modId: string;
+	    files: string[];
+	    message: string;
+	    disable?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ModIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.modId = source["modId"];
+	        this.files = source["files"];
+	        this.message = source["message"];
+	        this.disable = source["disable"];
+	    }
+	}
+	export class ModConflictReport {
+	    issues: ModIssue[];
+	    disabled: string[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModConflictReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.issues = this.convertValues(source["issues"], ModIssue);
+	        this.disabled = source["disabled"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModInstallResult {
+	    projectId: string;
+	    slug: string;
+	    title: string;
+	    versionNumber: string;
+	    filename: string;
+	    category?: string;
+	    missingApis?: MissingAPIMod[];
+	    cancelled?: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModInstallResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectId = source["projectId"];
+	        this.slug = source["slug"];
+	        this.title = source["title"];
+	        this.versionNumber = source["versionNumber"];
+	        this.filename = source["filename"];
+	        this.category = source["category"];
+	        this.missingApis = this.convertValues(source["missingApis"], MissingAPIMod);
+	        this.cancelled = source["cancelled"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ResourceStoreLinks {
+	    curseforgeUrl: string;
+	    modrinthUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourceStoreLinks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.curseforgeUrl = source["curseforgeUrl"];
+	        this.modrinthUrl = source["modrinthUrl"];
+	    }
+	}
+	export class ModLinksReport {
+	    links: Record<string, ResourceStoreLinks>;
+	    total: number;
+	    offline: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModLinksReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.links = this.convertValues(source["links"], ResourceStoreLinks, true);
+	        this.total = source["total"];
+	        this.offline = source["offline"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModLockInstallReport {
+	    results: launcher.ModLockRestore[];
+	    installed: number;
+	    failed: number;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModLockInstallReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.results = this.convertValues(source["results"], launcher.ModLockRestore);
+	        this.installed = source["installed"];
+	        this.failed = source["failed"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModProfileApplyReport {
+	    enabled: string[];
+	    disabled: string[];
+	    missing: string[];
+	    configsSwapped: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModProfileApplyReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.disabled = source["disabled"];
+	        this.missing = source["missing"];
+	        this.configsSwapped = source["configsSwapped"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ModProfilesResponse {
+	    active: string;
+	    profiles: launcher.ModProfile[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModProfilesResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.profiles = this.convertValues(source["profiles"], launcher.ModProfile);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModRemovePlan {
+	    matches: string[];
+	    mod: string;
+	    configs: string[];
+	    dependencies: string[];
+	    removed: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModRemovePlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.matches = source["matches"];
+	        this.mod = source["mod"];
+	        this.configs = source["configs"];
+	        this.dependencies = source["dependencies"];
+	        this.removed = source["removed"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ModUpdate {
+	    filename: string;
+	    projectId: string;
+	    title: string;
+	    currentVersion: string;
+	    currentVersionId?: string;
+	    latestVersion: string;
+	    latestFilename: string;
+	    applied: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filename = source["filename"];
+	        this.projectId = source["projectId"];
+	        this.title = source["title"];
+	        this.currentVersion = source["currentVersion"];
+	        this.currentVersionId = source["currentVersionId"];
+	        this.latestVersion = source["latestVersion"];
+	        this.latestFilename = source["latestFilename"];
+	        this.applied = source["applied"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ModUpdatesReport {
+	    checked: number;
+	    updates: ModUpdate[];
+	    pinned: string[];
+	    unknown: string[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModUpdatesReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checked = source["checked"];
+	        this.updates = this.convertValues(source["updates"], ModUpdate);
+	        this.pinned = source["pinned"];
+	        this.unknown = source["unknown"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModrinthVersionsResponse {
+	    project: meta.ModrinthProjectInfo;
+	    versions: meta.ModrinthVersionInfo[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModrinthVersionsResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = this.convertValues(source["project"], meta.ModrinthProjectInfo);
+	        this.versions = this.convertValues(source["versions"], meta.ModrinthVersionInfo);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NewsItem {
+	    id: number;
+	    title: string;
+	    content: string;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NewsItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.content = source["content"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class OutdatedMod {
+	    filename: string;
+	    title: string;
+	    installed: string;
+	    latest: string;
+	    outdated: boolean;
+	    pinned: boolean;
+	    unknown: boolean;
+	    applied: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutdatedMod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filename = source["filename"];
+	        this.title = source["title"];
+	        this.installed = source["installed"];
+	        this.latest = source["latest"];
+	        this.outdated = source["outdated"];
+	        this.pinned = source["pinned"];
+	        this.unknown = source["unknown"];
+	        this.applied = source["applied"];
+	        this.error = source["error"];
+	    }
+	}
+	export class OutdatedModsReport {
+	    mods: OutdatedMod[];
+	    outdated: number;
+	    backupDir?: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutdatedModsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mods = this.convertValues(source["mods"], OutdatedMod);
+	        this.outdated = source["outdated"];
+	        this.backupDir = source["backupDir"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class QMServerEndpointSettings {
+	    endpoint: string;
+	    envValue?: string;
+	    effective: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QMServerEndpointSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint = source["endpoint"];
+	        this.envValue = source["envValue"];
+	        this.effective = source["effective"];
+	    }
+	}
+	export class RecentConnectionsReport {
+	    connections: launcher.RecentConnection[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentConnectionsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.connections = this.convertValues(source["connections"], launcher.RecentConnection);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RemoteStoreSearchResponse {
+	    hits: meta.RemoteStoreHit[];
+	    total?: number;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteStoreSearchResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hits = this.convertValues(source["hits"], meta.RemoteStoreHit);
+	        this.total = source["total"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ServerInfo {
+	    id: string;
+	    name: string;
+	    address: string;
+	    port: number;
+	    online: boolean;
+	    enabled: boolean;
+	    gameServerOnline?: boolean;
+	    players: number;
+	    maxPlayers: number;
+	    version: string;
+	    modLoader?: string;
+	    modLoaderVersion?: string;
+	    isPremium?: boolean;
+	    serverID?: number;
+	    favorite?: string;
+	    pinged?: boolean;
+	    latencyMs?: number;
+	    motd?: string;
+	    pingError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.address = source["address"];
+	        this.port = source["port"];
+	        this.online = source["online"];
+	        this.enabled = source["enabled"];
+	        this.gameServerOnline = source["gameServerOnline"];
+	        this.players = source["players"];
+	        this.maxPlayers = source["maxPlayers"];
+	        this.version = source["version"];
+	        this.modLoader = source["modLoader"];
+	        this.modLoaderVersion = source["modLoaderVersion"];
+	        this.isPremium = source["isPremium"];
+	        this.serverID = source["serverID"];
+	        this.favorite = source["favorite"];
+	        this.pinged = source["pinged"];
+	        this.latencyMs = source["latencyMs"];
+	        this.motd = source["motd"];
+	        this.pingError = source["pingError"];
+	    }
+	}
+	export class ServerDetails {
+	    server: ServerInfo;
+	    uuid?: string;
+	    gameKind?: string;
+	    createdAt?: string;
+	    updatedAt?: string;
+	    manifestFiles: number;
+	    manifestBytes: number;
+	    manifestVersion?: number;
+	    manifestUpdated?: number;
+	    manifestError?: string;
+	    manifestSigned: boolean;
+	    linkedInstances: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.server = this.convertValues(source["server"], ServerInfo);
+	        this.uuid = source["uuid"];
+	        this.gameKind = source["gameKind"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.manifestFiles = source["manifestFiles"];
+	        this.manifestBytes = source["manifestBytes"];
+	        this.manifestVersion = source["manifestVersion"];
+	        this.manifestUpdated = source["manifestUpdated"];
+	        this.manifestError = source["manifestError"];
+	        this.manifestSigned = source["manifestSigned"];
+	        this.linkedInstances = source["linkedInstances"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ServerFavorite {
+	    name: string;
+	    serverId?: number;
+	    address: string;
+	    serverName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerFavorite(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.serverId = source["serverId"];
+	        this.address = source["address"];
+	        this.serverName = source["serverName"];
+	    }
+	}
+	
+	export class ServerListOptions {
+	    filter: string;
+	    sort: string;
+	    ping: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerListOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filter = source["filter"];
+	        this.sort = source["sort"];
+	        this.ping = source["ping"];
+	    }
+	}
+	export class ShaderPackInfo {
+	    file: string;
+	    enabled: boolean;
+	    title: string;
+	    version: string;
+	    modrinthUrl: string;
+	    curseforgeUrl: string;
+	    loaders: string[];
+	    compatible: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShaderPackInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.enabled = source["enabled"];
+	        this.title = source["title"];
+	        this.version = source["version"];
+	        this.modrinthUrl = source["modrinthUrl"];
+	        this.curseforgeUrl = source["curseforgeUrl"];
+	        this.loaders = source["loaders"];
+	        this.compatible = source["compatible"];
+	    }
+	}
+	export class ShaderPacksReport {
+	    packs: ShaderPackInfo[];
+	    shaderLoaders: string[];
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShaderPacksReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.packs = this.convertValues(source["packs"], ShaderPackInfo);
+	        this.shaderLoaders = source["shaderLoaders"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class InventorySlot {
-	    slot: number;
-	    item: string;
-	    count: number;
-	    nbt?: string;
+	export class SyncSummary {
+	    files: number;
+	    downloaded: number;
+	    downloadedBytes: number;
+	    updated: number;
+	    updatedBytes: number;
+	    deleted: number;
+	    unchanged: number;
+	    protected: number;
+	    skipped: number;
+	    keptLocal: number;
+	    incompatible: number;
+	    failed: string[];
+	    elapsedMs: number;
+	    offlineSince?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new InventorySlot(source);
+	        return new SyncSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.slot = source["slot"];
-	        this.item = source["item"];
-	        this.count = source["count"];
-	        this.nbt = source["nbt"];
+	        this.files = source["files"];
+	        this.downloaded = source["downloaded"];
+	        this.downloadedBytes = source["downloadedBytes"];
+	        this.updated = source["updated"];
+	        this.updatedBytes = source["updatedBytes"];
+	        this.deleted = source["deleted"];
+	        this.unchanged = source["unchanged"];
+	        this.protected = source["protected"];
+	        this.skipped = source["skipped"];
+	        this.keptLocal = source["keptLocal"];
+	        this.incompatible = source["incompatible"];
+	        this.failed = source["failed"];
+	        this.elapsedMs = source["elapsedMs"];
+	        this.offlineSince = source["offlineSince"];
 	    }
 	}
-	export class InventoryEntry {
-	    server_id: string;
-	    server_name?: string;
-	    server_version?: string;
-	    player_name: string;
-	    timestamp: number;
-	    main: InventorySlot[];
-	    armor: InventorySlot[];
-	    offhand?: InventorySlot;
+	export class SyncPlan {
+	    download: SyncPlanEntry[];
+	    update: SyncPlanEntry[];
+	    keep: SyncPlanEntry[];
+	    delete: SyncPlanEntry[];
+	    unchanged: number;
+	    downloadBytes: number;
+	    deleteBytes: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new InventoryEntry(source);
+	        return new SyncPlan(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.server_id = source["server_id"];
-	        this.server_name = source["server_name"];
-	        this.server_version = source["server_version"];
-	        this.player_name = source["player_name"];
-	        this.timestamp = source["timestamp"];
-	        this.main = this.convertValues(source["main"], InventorySlot);
-	        this.armor = this.convertValues(source["armor"], InventorySlot);
-	        this.offhand = this.convertValues(source["offhand"], InventorySlot);
+	        this.download = this.convertValues(source["download"], SyncPlanEntry);
+	        this.update = this.convertValues(source["update"], SyncPlanEntry);
+	        this.keep = this.convertValues(source["keep"], SyncPlanEntry);
+	        this.delete = this.convertValues(source["delete"], SyncPlanEntry);
+	        this.unchanged = source["unchanged"];
+	        this.downloadBytes = source["downloadBytes"];
+	        this.deleteBytes = source["deleteBytes"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -283,18 +2557,26 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class GameAccountInventoryResponse {
-	    inventories: InventoryEntry[];
+	export class SyncInstanceReport {
+	    dryRun: boolean;
+	    plan: SyncPlan;
+	    summary?: SyncSummary;
+	    prepared?: boolean;
 	    error?: string;
+	    mismatch?: CloudServerMismatch;
 	
 	    static createFrom(source: any = {}) {
-	        return new GameAccountInventoryResponse(source);
+	        return new SyncInstanceReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.inventories = this.convertValues(source["inventories"], InventoryEntry);
+	        this.dryRun = source["dryRun"];
+	        this.plan = this.convertValues(source["plan"], SyncPlan);
+	        this.summary = this.convertValues(source["summary"], SyncSummary);
+	        this.prepared = source["prepared"];
 	        this.error = source["error"];
+	        this.mismatch = this.convertValues(source["mismatch"], CloudServerMismatch);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -315,56 +2597,69 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class InstanceDetails {
+	
+	
+	export class SyncRestoreReport {
+	    restored: string[];
+	    skipped: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncRestoreReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.restored = source["restored"];
+	        this.skipped = source["skipped"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class UpdaterNetworkSettings {
+	    proxy: string;
+	    tokenSet: boolean;
+	    manifestUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdaterNetworkSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.proxy = source["proxy"];
+	        this.tokenSet = source["tokenSet"];
+	        this.manifestUrl = source["manifestUrl"];
+	    }
+	}
+
+}
+
+export namespace meta {
+	
+	export class JavaComponent {
 	    name: string;
-	    uuid: string;
-	    gameVersion: string;
-	    loader: string;
-	    loaderVersion: string;
-	    dir: string;
-	    mods: string[];
-	    shaderpacks: string[];
-	    resourcepacks: string[];
-	    datapacks: string[];
-	    modpacks: string[];
-	    schematics: string[];
-	    configFiles: string[];
-	    kubejsFiles: string[];
-	    isUsingQMServerCloud: boolean;
-	    isPremium: boolean;
-	    lastServer: string;
-	    lastUser: string;
-	    remoteInstalls: Record<string, launcher.RemoteInstallMeta>;
-	    catalogCurseforgeEnabled: boolean;
-	    catalogModrinthEnabled: boolean;
+	    platform: string;
+	    version: string;
+	    major: number;
+	    // Go type: time
+	    released: any;
+	    size: number;
+	    files: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new InstanceDetails(source);
+	        return new JavaComponent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.uuid = source["uuid"];
-	        this.gameVersion = source["gameVersion"];
-	        this.loader = source["loader"];
-	        this.loaderVersion = source["loaderVersion"];
-	        this.dir = source["dir"];
-	        this.mods = source["mods"];
-	        this.shaderpacks = source["shaderpacks"];
-	        this.resourcepacks = source["resourcepacks"];
-	        this.datapacks = source["datapacks"];
-	        this.modpacks = source["modpacks"];
-	        this.schematics = source["schematics"];
-	        this.configFiles = source["configFiles"];
-	        this.kubejsFiles = source["kubejsFiles"];
-	        this.isUsingQMServerCloud = source["isUsingQMServerCloud"];
-	        this.isPremium = source["isPremium"];
-	        this.lastServer = source["lastServer"];
-	        this.lastUser = source["lastUser"];
-	        this.remoteInstalls = this.convertValues(source["remoteInstalls"], launcher.RemoteInstallMeta, true);
-	        this.catalogCurseforgeEnabled = source["catalogCurseforgeEnabled"];
-	        this.catalogModrinthEnabled = source["catalogModrinthEnabled"];
+	        this.platform = source["platform"];
+	        this.version = source["version"];
+	        this.major = source["major"];
+	        this.released = this.convertValues(source["released"], null);
+	        this.size = source["size"];
+	        this.files = source["files"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -385,70 +2680,85 @@ export namespace main {
 		    return a;
 		}
 	}
-	
-	
-	export class LauncherAPITargetSettings {
-	    use_qmserver_cloud: boolean;
-	    custom_api_base: string;
-	    effective_api_base: string;
+	export class ModMetadata {
+	    modId: string;
+	    name: string;
+	    version: string;
+	    loader: string;
+	    authors?: string[];
+	    loaders: string[];
+	    minecraft?: string;
+	    dependencies: string[];
+	    provides?: string[];
+	    license?: string;
+	    description?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LauncherAPITargetSettings(source);
+	        return new ModMetadata(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.use_qmserver_cloud = source["use_qmserver_cloud"];
-	        this.custom_api_base = source["custom_api_base"];
-	        this.effective_api_base = source["effective_api_base"];
+	        this.modId = source["modId"];
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.loader = source["loader"];
+	        this.authors = source["authors"];
+	        this.loaders = source["loaders"];
+	        this.minecraft = source["minecraft"];
+	        this.dependencies = source["dependencies"];
+	        this.provides = source["provides"];
+	        this.license = source["license"];
+	        this.description = source["description"];
 	    }
 	}
-	export class LauncherAboutInfo {
-	    version: string;
-	    os: string;
-	    arch: string;
+	export class ModrinthChangelogEntry {
+	    versionId: string;
+	    versionNumber: string;
+	    versionType: string;
+	    datePublished: string;
+	    changelog: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LauncherAboutInfo(source);
+	        return new ModrinthChangelogEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.version = source["version"];
-	        this.os = source["os"];
-	        this.arch = source["arch"];
+	        this.versionId = source["versionId"];
+	        this.versionNumber = source["versionNumber"];
+	        this.versionType = source["versionType"];
+	        this.datePublished = source["datePublished"];
+	        this.changelog = source["changelog"];
 	    }
 	}
-	export class NewsItem {
-	    id: number;
+	export class ModrinthProjectDetails {
+	    id: string;
+	    slug: string;
 	    title: string;
-	    content: string;
-	    created_at: string;
+	    description: string;
+	    project_type: string;
+	    // Go type: struct { ID string "json:\"id\""; Name string "json:\"name\""; URL string "json:\"url\"" }
+	    license: any;
+	    source_url: string;
+	    issues_url: string;
+	    authors: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NewsItem(source);
+	        return new ModrinthProjectDetails(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.slug = source["slug"];
 	        this.title = source["title"];
-	        this.content = source["content"];
-	        this.created_at = source["created_at"];
-	    }
-	}
-	export class RemoteStoreSearchResponse {
-	    hits: meta.RemoteStoreHit[];
-	    error: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new RemoteStoreSearchResponse(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hits = this.convertValues(source["hits"], meta.RemoteStoreHit);
-	        this.error = source["error"];
+	        this.description = source["description"];
+	        this.project_type = source["project_type"];
+	        this.license = this.convertValues(source["license"], Object);
+	        this.source_url = source["source_url"];
+	        this.issues_url = source["issues_url"];
+	        this.authors = source["authors"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -469,63 +2779,60 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class ResourceStoreLinks {
-	    curseforgeUrl: string;
-	    modrinthUrl: string;
+	export class ModrinthProjectInfo {
+	    id: string;
+	    slug: string;
+	    title: string;
+	    description: string;
+	    project_type: string;
+	    icon_url: string;
+	    loaders?: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ResourceStoreLinks(source);
+	        return new ModrinthProjectInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.curseforgeUrl = source["curseforgeUrl"];
-	        this.modrinthUrl = source["modrinthUrl"];
+	        this.id = source["id"];
+	        this.slug = source["slug"];
+	        this.title = source["title"];
+	        this.description = source["description"];
+	        this.project_type = source["project_type"];
+	        this.icon_url = source["icon_url"];
+	        this.loaders = source["loaders"];
 	    }
 	}
-	export class ServerInfo {
+	export class ModrinthVersionInfo {
 	    id: string;
 	    name: string;
-	    address: string;
-	    port: number;
-	    online: boolean;
-	    enabled: boolean;
-	    gameServerOnline?: boolean;
-	    players: number;
-	    maxPlayers: number;
-	    version: string;
-	    modLoader?: string;
-	    modLoaderVersion?: string;
-	    isPremium?: boolean;
-	    serverID?: number;
+	    versionNumber: string;
+	    versionType: string;
+	    gameVersions: string[];
+	    loaders: string[];
+	    datePublished: string;
+	    downloads: number;
+	    filename: string;
+	    compatible: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ServerInfo(source);
+	        return new ModrinthVersionInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.address = source["address"];
-	        this.port = source["port"];
-	        this.online = source["online"];
-	        this.enabled = source["enabled"];
-	        this.gameServerOnline = source["gameServerOnline"];
-	        this.players = source["players"];
-	        this.maxPlayers = source["maxPlayers"];
-	        this.version = source["version"];
-	        this.modLoader = source["modLoader"];
-	        this.modLoaderVersion = source["modLoaderVersion"];
-	        this.isPremium = source["isPremium"];
-	        this.serverID = source["serverID"];
+	        this.versionNumber = source["versionNumber"];
+	        this.versionType = source["versionType"];
+	        this.gameVersions = source["gameVersions"];
+	        this.loaders = source["loaders"];
+	        this.datePublished = source["datePublished"];
+	        this.downloads = source["downloads"];
+	        this.filename = source["filename"];
+	        this.compatible = source["compatible"];
 	    }
 	}
-
-}
-
-export namespace meta {
-	
 	export class RemoteStoreSide {
 	    projectId: string;
 	    slug: string;
@@ -595,3 +2902,98 @@ export namespace meta {
 
 }
 
+export namespace network {
+	
+	export class CloudStatus {
+	    apiBase: string;
+	    reachable: boolean;
+	    latencyMs: number;
+	    httpStatus?: number;
+	    apiVersion?: string;
+	    auth: string;
+	    account?: string;
+	    error?: string;
+	    checked: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CloudStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.apiBase = source["apiBase"];
+	        this.reachable = source["reachable"];
+	        this.latencyMs = source["latencyMs"];
+	        this.httpStatus = source["httpStatus"];
+	        this.apiVersion = source["apiVersion"];
+	        this.auth = source["auth"];
+	        this.account = source["account"];
+	        this.error = source["error"];
+	        this.checked = source["checked"];
+	    }
+	}
+	export class QMServerTLS {
+	    https: boolean;
+	    ca_bundle?: string;
+	    pins?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new QMServerTLS(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.https = source["https"];
+	        this.ca_bundle = source["ca_bundle"];
+	        this.pins = source["pins"];
+	    }
+	}
+
+}
+
+export namespace updater {
+	
+	export class Backup {
+	    version: string;
+	    path: string;
+	    size: number;
+	    sha256?: string;
+	    // Go type: time
+	    time: any;
+	    legacy?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Backup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.sha256 = source["sha256"];
+	        this.time = this.convertValues(source["time"], null);
+	        this.legacy = source["legacy"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
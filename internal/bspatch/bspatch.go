@@ -0,0 +1,97 @@
+// Package bspatch applies binary patches in the BSDIFF40 format produced by bsdiff 4.x.
+package bspatch
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrCorrupt is returned for patches that are truncated or do not fit the old file.
+var ErrCorrupt = errors.New("corrupt BSDIFF40 patch")
+
+const headerSize = 32
+
+// offtin decodes bsdiff's sign-magnitude little-endian integer.
+func offtin(b []byte) int64 {
+	y := int64(binary.LittleEndian.Uint64(b) &^ (1 << 63))
+	if b[7]&0x80 != 0 {
+		y = -y
+	}
+	return y
+}
+
+// MaxSize caps the size of a patched file: the header's size is only trusted up to here.
+const MaxSize = 1 << 30
+
+// Apply returns the file produced by applying patch to old. size is the expected size of the result (from
+// the manifest or release), which the patch header must match, so a hostile patch can't make Apply
+// allocate an arbitrary amount of memory.
+func Apply(old, patch []byte, size int64) ([]byte, error) {
+	if len(patch) < headerSize || string(patch[:8]) != "BSDIFF40" {
+		return nil, fmt.Errorf("%w: bad header", ErrCorrupt)
+	}
+	ctrlLen, diffLen, newSize := offtin(patch[8:]), offtin(patch[16:]), offtin(patch[24:])
+	// Each length is checked against what is left of the patch on its own, so they can't overflow a sum
+	rest := int64(len(patch) - headerSize)
+	if ctrlLen < 0 || ctrlLen > rest || diffLen < 0 || diffLen > rest-ctrlLen {
+		return nil, fmt.Errorf("%w: bad lengths", ErrCorrupt)
+	}
+	if newSize != size {
+		return nil, fmt.Errorf("%w: patch makes %d bytes, expected %d", ErrCorrupt, newSize, size)
+	}
+	if newSize < 0 || newSize > MaxSize {
+		return nil, fmt.Errorf("%w: size %d out of range", ErrCorrupt, newSize)
+	}
+	diffStart := headerSize + ctrlLen
+	extraStart := diffStart + diffLen
+	ctrl := bzip2.NewReader(bytes.NewReader(patch[headerSize:diffStart]))
+	diff := bzip2.NewReader(bytes.NewReader(patch[diffStart:extraStart]))
+	extra := bzip2.NewReader(bytes.NewReader(patch[extraStart:]))
+
+	out := make([]byte, newSize)
+	maxSeek := int64(len(old)) + newSize
+	var buf [24]byte
+	var oldPos, newPos int64
+	for newPos < newSize {
+		if _, err := io.ReadFull(ctrl, buf[:]); err != nil {
+			return nil, fmt.Errorf("%w: control block: %v", ErrCorrupt, err)
+		}
+		add, copyLen, seek := offtin(buf[0:]), offtin(buf[8:]), offtin(buf[16:])
+		if add < 0 || copyLen < 0 || add > newSize-newPos {
+			return nil, fmt.Errorf("%w: control out of range", ErrCorrupt)
+		}
+
+		// Diff bytes are added to the old file
+		if _, err := io.ReadFull(diff, out[newPos:newPos+add]); err != nil {
+			return nil, fmt.Errorf("%w: diff block: %v", ErrCorrupt, err)
+		}
+		for i := int64(0); i < add; i++ {
+			if p := oldPos + i; p >= 0 && p < int64(len(old)) {
+				out[newPos+i] += old[p]
+			}
+		}
+		newPos += add
+		oldPos += add
+
+		// Extra bytes are copied as is
+		if copyLen > newSize-newPos {
+			return nil, fmt.Errorf("%w: extra out of range", ErrCorrupt)
+		}
+		if _, err := io.ReadFull(extra, out[newPos:newPos+copyLen]); err != nil {
+			return nil, fmt.Errorf("%w: extra block: %v", ErrCorrupt, err)
+		}
+		newPos += copyLen
+		if seek < -maxSeek || seek > maxSeek {
+			return nil, fmt.Errorf("%w: seek out of range", ErrCorrupt)
+		}
+		oldPos += seek
+		if oldPos < -maxSeek || oldPos > maxSeek {
+			return nil, fmt.Errorf("%w: seek out of range", ErrCorrupt)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package bspatch
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// helloPatch turns "hello world" into "hello there!" (bsdiff 4.x format, bzip2 blocks).
+const helloPatch = "4253444946463430290000000000000025000000000000000c00000000000000" +
+	"425a683931415926535916c25142000004c00049082000218c8334d09ad538bb9229c28480b6128a10" +
+	"425a6839314159265359c585438d00000040005000200021008283177245385090c585438d" +
+	"425a683931415926535941b812c60000029180200002401400200030cd00c3440c6e2ee48a70a1208370258c"
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// withHeader returns a copy of patch with the three header lengths replaced.
+func withHeader(patch []byte, ctrlLen, diffLen, newSize uint64) []byte {
+	p := append([]byte(nil), patch...)
+	binary.LittleEndian.PutUint64(p[8:], ctrlLen)
+	binary.LittleEndian.PutUint64(p[16:], diffLen)
+	binary.LittleEndian.PutUint64(p[24:], newSize)
+	return p
+}
+
+func TestApply(t *testing.T) {
+	out, err := Apply([]byte("hello world"), mustHex(t, helloPatch), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "hello there!" {
+		t.Fatalf("Apply = %q, want %q", out, "hello there!")
+	}
+}
+
+func TestApplyRejectsCorruptPatches(t *testing.T) {
+	patch := mustHex(t, helloPatch)
+	tests := []struct {
+		name  string
+		patch []byte
+		size  int64
+	}{
+		{"bad magic", append([]byte("BSDIFF41"), patch[8:]...), 12},
+		{"short header", patch[:20], 12},
+		{"truncated", patch[:len(patch)-30], 12},
+		{"size mismatch", patch, 13},
+		{"negative size", withHeader(patch, 0x29, 0x25, 1<<63|12), -12},
+		{"size over cap", withHeader(patch, 0x29, 0x25, MaxSize+1), MaxSize + 1},
+		{"huge size", withHeader(patch, 0x29, 0x25, 1<<62), 1 << 62},
+		{"ctrl too long", withHeader(patch, uint64(len(patch)), 0x25, 12), 12},
+		{"lengths overflow", withHeader(patch, 1<<62, 1<<62, 12), 12},
+		{"negative length", withHeader(patch, 1<<63|0x29, 0x25, 12), 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Apply([]byte("hello world"), tt.patch, tt.size); !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("Apply error = %v, want ErrCorrupt", err)
+			}
+		})
+	}
+}
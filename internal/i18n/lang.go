@@ -27,6 +27,11 @@ var en = translations{
 	"logout":          "Log out of an account",
 	"logout.complete": "Logged out from account.",
 
+	"auth.expiry.expired": "Sign-in for account %s has expired",
+	"auth.expiry.days":    "Account %s expires in %d day(s)",
+	"auth.expiry.hours":   "Account %s expires in %d hour(s)",
+	"auth.expiry.tip":     "Sign in with Microsoft again under Accounts to keep launching with this account.",
+
 	"create":                   "Create a new instance",
 	"create.complete":          "Created instance '%s' with Minecraft %s (%s%s)",
 	"create.arg.id":            "Instance name",
@@ -520,6 +525,11 @@ var ru = translations{
 	"logout":          "Выйти из аккаунта",
 	"logout.complete": "Выход из аккаунта выполнен.",
 
+	"auth.expiry.expired": "Срок входа в аккаунт %s истёк",
+	"auth.expiry.days":    "Срок входа в аккаунт %s истекает через %d дн.",
+	"auth.expiry.hours":   "Срок входа в аккаунт %s истекает через %d ч.",
+	"auth.expiry.tip":     "Войдите через Microsoft заново в разделе «Аккаунты», чтобы продолжить запуск с этим аккаунтом.",
+
 	"create":                   "Создать новый инстанс",
 	"create.complete":          "Создан инстанс '%s' с Minecraft %s (%s%s)",
 	"create.arg.id":            "Имя инстанса",
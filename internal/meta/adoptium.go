@@ -0,0 +1,107 @@
+package meta
+
+import (
+	"fmt"
+	"net/url"
+	"runtime"
+)
+
+const adoptiumAPIBase = "https://api.adoptium.net"
+
+// AdoptiumPackage is a downloadable Temurin archive.
+type AdoptiumPackage struct {
+	Name     string `json:"name"`
+	Link     string `json:"link"`
+	Checksum string `json:"checksum"` // SHA-256
+	Size     int64  `json:"size"`
+}
+
+// AdoptiumRelease is the latest Temurin build of a Java major version for one platform.
+type AdoptiumRelease struct {
+	ReleaseName string          `json:"release_name"` // e.g. "jdk-17.0.9+9"
+	Major       int             `json:"major"`
+	Semver      string          `json:"semver"`
+	ImageType   string          `json:"image_type"` // jre | jdk
+	OS          string          `json:"os"`
+	Arch        string          `json:"architecture"`
+	Package     AdoptiumPackage `json:"package"`
+}
+
+// AdoptiumOS maps GOOS to the Adoptium os parameter.
+func AdoptiumOS(goos string) string {
+	if goos == "darwin" {
+		return "mac"
+	}
+	return goos
+}
+
+// AdoptiumArch maps GOARCH to the Adoptium architecture parameter.
+func AdoptiumArch(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x64"
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "x32"
+	}
+	return goarch
+}
+
+// AdoptiumQuery selects a Temurin build. Empty fields default to a JRE for this system.
+type AdoptiumQuery struct {
+	Major     int
+	ImageType string // jre | jdk
+	OS        string // Adoptium os: linux, alpine-linux, windows, mac
+	Arch      string // Adoptium architecture: x64, aarch64, …
+}
+
+// FetchAdoptiumRelease returns the latest HotSpot build of Temurin matching q.
+func FetchAdoptiumRelease(q AdoptiumQuery) (AdoptiumRelease, error) {
+	if q.ImageType == "" {
+		q.ImageType = "jre"
+	}
+	if q.OS == "" {
+		q.OS = AdoptiumOS(runtime.GOOS)
+	}
+	if q.Arch == "" {
+		q.Arch = AdoptiumArch(runtime.GOARCH)
+	}
+	params := url.Values{}
+	params.Set("os", q.OS)
+	params.Set("architecture", q.Arch)
+	params.Set("image_type", q.ImageType)
+	params.Set("vendor", "eclipse")
+	var assets []struct {
+		Binary struct {
+			Architecture string          `json:"architecture"`
+			ImageType    string          `json:"image_type"`
+			OS           string          `json:"os"`
+			Package      AdoptiumPackage `json:"package"`
+		} `json:"binary"`
+		ReleaseName string `json:"release_name"`
+		Version     struct {
+			Major  int    `json:"major"`
+			Semver string `json:"semver"`
+		} `json:"version"`
+	}
+	u := fmt.Sprintf("%s/v3/assets/latest/%d/hotspot?%s", adoptiumBase(), q.Major, params.Encode())
+	if err := httpGetJSON(u, nil, &assets); err != nil {
+		return AdoptiumRelease{}, err
+	}
+	for _, a := range assets {
+		if a.Binary.Package.Link == "" {
+			continue
+		}
+		return AdoptiumRelease{
+			ReleaseName: a.ReleaseName,
+			Major:       a.Version.Major,
+			Semver:      a.Version.Semver,
+			ImageType:   a.Binary.ImageType,
+			OS:          a.Binary.OS,
+			Arch:        a.Binary.Architecture,
+			Package:     a.Binary.Package,
+		}, nil
+	}
+	return AdoptiumRelease{}, fmt.Errorf("Temurin %d (%s) is not available for %s/%s: %w", q.Major, q.ImageType, q.OS, q.Arch, ErrJavaNoVersion)
+}
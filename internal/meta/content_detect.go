@@ -0,0 +1,87 @@
+package meta
+
+import (
+	"archive/zip"
+	"slices"
+	"strings"
+)
+
+// DetectArchiveCategory inspects a JAR/ZIP and returns the instance folder it belongs in:
+// mods (mod descriptor or classes), shaderpacks (shaders/), datapacks (pack.mcmeta with data/)
+// or resourcepacks (pack.mcmeta with assets/). Unknown archives default to mods for .jar and
+// resourcepacks for .zip.
+func DetectArchiveCategory(path string) (string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	// Packs are often zipped with a single top-level folder; strip it when every entry shares it.
+	root := ""
+	for i, f := range zr.File {
+		first, _, nested := strings.Cut(f.Name, "/")
+		if !nested || (i > 0 && first+"/" != root) {
+			root = ""
+			break
+		}
+		root = first + "/"
+	}
+	switch root {
+	case "assets/", "data/", "shaders/", "META-INF/":
+		root = ""
+	}
+	var hasMcmeta, hasAssets, hasData, hasShaders, hasMod bool
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(f.Name, root)
+		switch {
+		case name == "fabric.mod.json", name == "quilt.mod.json", name == "META-INF/mods.toml",
+			name == "META-INF/neoforge.mods.toml", strings.HasSuffix(name, ".class"):
+			hasMod = true
+		case name == "pack.mcmeta":
+			hasMcmeta = true
+		case strings.HasPrefix(name, "shaders/"):
+			hasShaders = true
+		case strings.HasPrefix(name, "assets/"):
+			hasAssets = true
+		case strings.HasPrefix(name, "data/"):
+			hasData = true
+		}
+	}
+	switch {
+	case hasMod:
+		return "mods", nil
+	case hasShaders && !hasMcmeta:
+		return "shaderpacks", nil
+	case hasMcmeta && hasData && !hasAssets:
+		return "datapacks", nil
+	case hasMcmeta:
+		return "resourcepacks", nil
+	case strings.HasSuffix(strings.ToLower(strings.TrimSuffix(path, ".disabled")), ".jar"):
+		return "mods", nil
+	default:
+		return "resourcepacks", nil
+	}
+}
+
+// ModrinthProjectCategory picks the instance folder for a Modrinth project from its project_type and
+// loaders. Datapacks are published as type "mod" with the "datapack" loader; a project that is both
+// a mod and a datapack installs as a mod when it supports the instance's loader.
+func ModrinthProjectCategory(p ModrinthProjectInfo, instanceLoader string) string {
+	switch p.ProjectType {
+	case "resourcepack":
+		return "resourcepacks"
+	case "shader":
+		return "shaderpacks"
+	case "modpack":
+		return "modpacks"
+	}
+	if slices.Contains(p.Loaders, "datapack") {
+		for _, l := range normalizeModrinthLoaders(instanceLoader) {
+			if slices.Contains(p.Loaders, l) {
+				return "mods"
+			}
+		}
+		return "datapacks"
+	}
+	return "mods"
+}
@@ -0,0 +1,219 @@
+package meta
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const curseForgeAPIBase = "https://api.curseforge.com"
+
+// ErrCurseForgeNoKey is returned when no CurseForge API key is configured (settings, cloud or CURSEFORGE_API_KEY).
+var ErrCurseForgeNoKey = errors.New("CurseForge API key is not configured")
+
+// CurseForgeClient calls the official CurseForge Core API with an API key.
+type CurseForgeClient struct {
+	key string
+}
+
+// NewCurseForgeClient returns a client using the effective key (see CurseForgeAPIKey).
+func NewCurseForgeClient() (*CurseForgeClient, error) {
+	key := CurseForgeAPIKey()
+	if key == "" {
+		return nil, ErrCurseForgeNoKey
+	}
+	return &CurseForgeClient{key: key}, nil
+}
+
+// CurseForgeMod is a subset of the CurseForge mod (project) object.
+type CurseForgeMod struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Summary string `json:"summary"`
+	ClassID int    `json:"classId"`
+	Links   struct {
+		WebsiteURL string `json:"websiteUrl"`
+	} `json:"links"`
+	Logo struct {
+		ThumbnailURL string `json:"thumbnailUrl"`
+	} `json:"logo"`
+	Authors []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+}
+
+// CurseForgeFile is a subset of the CurseForge file object.
+type CurseForgeFile struct {
+	ID              int64    `json:"id"`
+	ModID           int64    `json:"modId"`
+	DisplayName     string   `json:"displayName"`
+	FileName        string   `json:"fileName"`
+	ReleaseType     int      `json:"releaseType"` // 1 release, 2 beta, 3 alpha
+	DownloadURL     string   `json:"downloadUrl"` // empty when the author disabled third-party downloads
+	FileFingerprint uint32   `json:"fileFingerprint"`
+	GameVersions    []string `json:"gameVersions"`
+	Hashes          []struct {
+		Value string `json:"value"`
+		Algo  int    `json:"algo"` // 1 SHA-1, 2 MD5
+	} `json:"hashes"`
+	Dependencies []struct {
+		ModID        int64 `json:"modId"`
+		RelationType int   `json:"relationType"` // 3 = required
+	} `json:"dependencies"`
+}
+
+// Sha1 returns the file's SHA-1 from its hash list, or "".
+func (f CurseForgeFile) Sha1() string {
+	for _, h := range f.Hashes {
+		if h.Algo == 1 {
+			return strings.ToLower(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c *CurseForgeClient) get(path string, q url.Values, out any) error {
+	u := curseForgeAPIBase + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	return curseForgeKey403Hint(httpGetJSON(u, map[string]string{"x-api-key": c.key}, out))
+}
+
+func (c *CurseForgeClient) post(path string, body, out any) error {
+	return curseForgeKey403Hint(httpPostJSON(curseForgeAPIBase+path, map[string]string{"x-api-key": c.key}, body, out))
+}
+
+// SearchMods searches Minecraft projects of a class (cfClassMods, …; 0 = any), most popular first.
+func (c *CurseForgeClient) SearchMods(query string, classID, pageSize int) ([]CurseForgeMod, error) {
+	q := url.Values{}
+	q.Set("gameId", strconv.Itoa(minecraftGameID))
+	q.Set("searchFilter", strings.TrimSpace(query))
+	q.Set("sortField", "2")
+	q.Set("sortOrder", "desc")
+	if classID > 0 {
+		q.Set("classId", strconv.Itoa(classID))
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	q.Set("pageSize", strconv.Itoa(pageSize))
+	var resp struct {
+		Data []CurseForgeMod `json:"data"`
+	}
+	if err := c.get("/v1/mods/search", q, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Mods returns projects by id (POST /v1/mods).
+func (c *CurseForgeClient) Mods(ids []int64) ([]CurseForgeMod, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var resp struct {
+		Data []CurseForgeMod `json:"data"`
+	}
+	if err := c.post("/v1/mods", map[string]any{"modIds": ids}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// FileDownloadURL returns the download URL of a file. When the API withholds it (author opted out of
+// third-party downloads) the public CDN path is derived from the file id and name.
+func (c *CurseForgeClient) FileDownloadURL(modID, fileID int64, fileName string) (string, error) {
+	var resp struct {
+		Data string `json:"data"`
+	}
+	err := c.get(fmt.Sprintf("/v1/mods/%d/files/%d/download-url", modID, fileID), nil, &resp)
+	if err == nil && resp.Data != "" {
+		return resp.Data, nil
+	}
+	if fileName == "" {
+		if err == nil {
+			err = fmt.Errorf("пустой download-url от CurseForge")
+		}
+		return "", err
+	}
+	return fmt.Sprintf("https://edge.forgecdn.net/files/%d/%d/%s", fileID/1000, fileID%1000, url.PathEscape(fileName)), nil
+}
+
+// CurseForgeFingerprintMatch is an exact fingerprint match: the project and the file it came from.
+type CurseForgeFingerprintMatch struct {
+	ModID int64          `json:"id"`
+	File  CurseForgeFile `json:"file"`
+}
+
+// MatchFingerprints identifies files by CurseForge fingerprint (see CurseForgeFingerprint).
+// Only exact matches are returned, keyed by fingerprint.
+func (c *CurseForgeClient) MatchFingerprints(fingerprints []uint32) (map[uint32]CurseForgeFingerprintMatch, error) {
+	out := map[uint32]CurseForgeFingerprintMatch{}
+	if len(fingerprints) == 0 {
+		return out, nil
+	}
+	var resp struct {
+		Data struct {
+			ExactMatches []CurseForgeFingerprintMatch `json:"exactMatches"`
+		} `json:"data"`
+	}
+	if err := c.post(fmt.Sprintf("/v1/fingerprints/%d", minecraftGameID), map[string]any{"fingerprints": fingerprints}, &resp); err != nil {
+		return nil, err
+	}
+	for _, m := range resp.Data.ExactMatches {
+		out[m.File.FileFingerprint] = m
+	}
+	return out, nil
+}
+
+// CurseForgeFingerprint computes the CurseForge file fingerprint: MurmurHash2 (seed 1) of the file
+// with tab, LF, CR and space bytes removed.
+func CurseForgeFingerprint(path string) (uint32, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 0, len(data))
+	for _, b := range data {
+		if b != 9 && b != 10 && b != 13 && b != 32 {
+			buf = append(buf, b)
+		}
+	}
+	return murmur2(buf, 1), nil
+}
+
+func murmur2(data []byte, seed uint32) uint32 {
+	const m = 0x5bd1e995
+	n := len(data)
+	h := seed ^ uint32(n)
+	i := 0
+	for ; n-i >= 4; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> 24
+		k *= m
+		h *= m
+		h ^= k
+	}
+	switch n - i {
+	case 3:
+		h ^= uint32(data[i+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[i+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[i])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
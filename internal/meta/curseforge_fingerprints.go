@@ -0,0 +1,153 @@
+package meta
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CurseForgeFileMatch is the exact CurseForge project and file a local file belongs to, found by its
+// fingerprint.
+type CurseForgeFileMatch struct {
+	ModID   int64          `json:"modId"`
+	Slug    string         `json:"slug"`
+	Name    string         `json:"name"`
+	PageURL string         `json:"pageUrl"`
+	File    CurseForgeFile `json:"file"`
+}
+
+type curseForgeFingerprintCacheEntry struct {
+	Match     *CurseForgeFileMatch `json:"match,omitempty"` // nil: not on CurseForge
+	CheckedAt int64                `json:"checkedAt"`
+}
+
+const (
+	curseForgeFingerprintHitTTL  = 30 * 24 * time.Hour // project slug/name may change
+	curseForgeFingerprintMissTTL = 24 * time.Hour
+)
+
+// curseForgeFingerprintCacheMu serializes lookups so concurrent callers share one cache file.
+var curseForgeFingerprintCacheMu sync.Mutex
+
+func curseForgeFingerprintCachePath(cachesDir string) string {
+	return filepath.Join(cachesDir, "curseforge", "fingerprint_lookup.json")
+}
+
+func loadCurseForgeFingerprintCache(cachesDir string) map[string]curseForgeFingerprintCacheEntry {
+	cache := map[string]curseForgeFingerprintCacheEntry{}
+	if cachesDir == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(curseForgeFingerprintCachePath(cachesDir)); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func saveCurseForgeFingerprintCache(cachesDir string, cache map[string]curseForgeFingerprintCacheEntry) {
+	if cachesDir == "" {
+		return
+	}
+	path := curseForgeFingerprintCachePath(cachesDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	if data, err := json.Marshal(cache); err == nil {
+		_ = os.WriteFile(path, data, 0644)
+	}
+}
+
+// IdentifyCurseForgeFingerprints resolves file fingerprints (see CurseForgeFingerprint) to the exact
+// CurseForge project and file in one batch (POST /v1/fingerprints, then POST /v1/mods for the project
+// pages). Results are cached under cachesDir; fingerprints unknown to CurseForge are absent from the map.
+func IdentifyCurseForgeFingerprints(fingerprints []uint32, cachesDir string) (map[uint32]CurseForgeFileMatch, error) {
+	curseForgeFingerprintCacheMu.Lock()
+	defer curseForgeFingerprintCacheMu.Unlock()
+
+	out := map[uint32]CurseForgeFileMatch{}
+	cache := loadCurseForgeFingerprintCache(cachesDir)
+	now := time.Now()
+
+	var lookup []uint32
+	for _, fp := range fingerprints {
+		if e, ok := cache[strconv.FormatUint(uint64(fp), 10)]; ok {
+			ttl := curseForgeFingerprintMissTTL
+			if e.Match != nil {
+				ttl = curseForgeFingerprintHitTTL
+			}
+			if now.Sub(time.Unix(e.CheckedAt, 0)) < ttl {
+				if e.Match != nil {
+					out[fp] = *e.Match
+				}
+				continue
+			}
+		}
+		lookup = append(lookup, fp)
+	}
+	if len(lookup) == 0 {
+		return out, nil
+	}
+
+	c, err := NewCurseForgeClient()
+	if err != nil {
+		return out, err
+	}
+	found, err := c.MatchFingerprints(lookup)
+	if err != nil {
+		return out, err
+	}
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, m := range found {
+		if !seen[m.ModID] {
+			seen[m.ModID] = true
+			ids = append(ids, m.ModID)
+		}
+	}
+	mods := map[int64]CurseForgeMod{}
+	if list, err := c.Mods(ids); err == nil {
+		for _, m := range list {
+			mods[m.ID] = m
+		}
+	}
+
+	for _, fp := range lookup {
+		key := strconv.FormatUint(uint64(fp), 10)
+		f, ok := found[fp]
+		if !ok {
+			cache[key] = curseForgeFingerprintCacheEntry{CheckedAt: now.Unix()}
+			continue
+		}
+		mod, ok := mods[f.ModID]
+		m := CurseForgeFileMatch{
+			ModID:   f.ModID,
+			Slug:    mod.Slug,
+			Name:    mod.Name,
+			PageURL: mod.Links.WebsiteURL,
+			File:    f.File,
+		}
+		out[fp] = m
+		if ok { // without the project the match is looked up again next time
+			cache[key] = curseForgeFingerprintCacheEntry{Match: &m, CheckedAt: now.Unix()}
+		}
+	}
+	saveCurseForgeFingerprintCache(cachesDir, cache)
+	return out, nil
+}
+
+// CachedCurseForgeFingerprints returns the cached matches of fingerprints without making requests.
+func CachedCurseForgeFingerprints(fingerprints []uint32, cachesDir string) map[uint32]CurseForgeFileMatch {
+	curseForgeFingerprintCacheMu.Lock()
+	defer curseForgeFingerprintCacheMu.Unlock()
+	out := map[uint32]CurseForgeFileMatch{}
+	cache := loadCurseForgeFingerprintCache(cachesDir)
+	for _, fp := range fingerprints {
+		if e, ok := cache[strconv.FormatUint(uint64(fp), 10)]; ok && e.Match != nil {
+			out[fp] = *e.Match
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package meta
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var javaMirrors struct {
+	sync.RWMutex
+	mojang   string
+	adoptium string
+}
+
+// mojangJavaHosts are the hosts of Mojang's Java runtime list, manifests and files.
+var mojangJavaHosts = []string{"piston-meta.mojang.com", "piston-data.mojang.com", "launcher.mojang.com", "launchermeta.mojang.com"}
+
+// ValidateMirrorURL accepts "" or an absolute http(s) URL and returns it without a trailing slash.
+func ValidateMirrorURL(raw string) (string, error) {
+	raw = strings.TrimRight(strings.TrimSpace(raw), "/")
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid mirror URL %q", raw)
+	}
+	return raw, nil
+}
+
+// SetJavaMirrors sets alternate endpoints for Java runtime downloads. mojang replaces the scheme and host of
+// Mojang runtime URLs (BMCLAPI layout, e.g. https://bmclapi2.bangbang93.com); adoptium replaces the
+// Adoptium API base (https://api.adoptium.net). Empty values restore the official endpoints.
+func SetJavaMirrors(mojang, adoptium string) {
+	javaMirrors.Lock()
+	defer javaMirrors.Unlock()
+	javaMirrors.mojang = strings.TrimRight(strings.TrimSpace(mojang), "/")
+	javaMirrors.adoptium = strings.TrimRight(strings.TrimSpace(adoptium), "/")
+}
+
+// JavaMirrors returns the endpoints set with SetJavaMirrors.
+func JavaMirrors() (mojang, adoptium string) {
+	javaMirrors.RLock()
+	defer javaMirrors.RUnlock()
+	return javaMirrors.mojang, javaMirrors.adoptium
+}
+
+// mojangJavaURL rewrites a Mojang runtime URL to the configured mirror.
+func mojangJavaURL(raw string) string {
+	mirror, _ := JavaMirrors()
+	if mirror == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	for _, host := range mojangJavaHosts {
+		if strings.EqualFold(u.Host, host) {
+			return mirror + u.RequestURI()
+		}
+	}
+	return raw
+}
+
+// adoptiumBase is the Adoptium API base, or its mirror.
+func adoptiumBase() string {
+	if _, mirror := JavaMirrors(); mirror != "" {
+		return mirror
+	}
+	return adoptiumAPIBase
+}
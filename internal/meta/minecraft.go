@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -105,7 +107,7 @@ func (manifest JavaManifest) DownloadEntries(runtimeName string) (entries []netw
 			entries = append(entries, network.DownloadEntry{
 				Sha1:     file.Downloads.Raw.Sha1,
 				Path:     path,
-				URL:      file.Downloads.Raw.URL,
+				URL:      mojangJavaURL(file.Downloads.Raw.URL),
 				FileMode: mode,
 			})
 		}
@@ -435,7 +437,7 @@ func DownloadAssetIndex(versionMeta VersionMeta, assetsDir string) (AssetIndex,
 func FetchJavaManifestList(cachesDir string) (JavaManifestList, error) {
 	cache := network.Cache[JavaManifestList]{
 		Path: filepath.Join(cachesDir, "minecraft", "java_all.json"),
-		URL:  JavaRuntimesURL,
+		URL:  mojangJavaURL(JavaRuntimesURL),
 	}
 	var list JavaManifestList
 	if err := cache.Get(&list); err != nil {
@@ -444,6 +446,119 @@ func FetchJavaManifestList(cachesDir string) (JavaManifestList, error) {
 	return list, nil
 }
 
+// JavaPlatform returns the key of this system in the Mojang Java runtime list ("linux", "mac-os-arm64", "windows-x64", …).
+func JavaPlatform() string {
+	os := strings.ReplaceAll(runtime.GOOS, "darwin", "mac-os")
+	arch := strings.ReplaceAll(runtime.GOARCH, "386", "i386")
+
+	if os == "windows" {
+		arch = strings.ReplaceAll(arch, "amd64", "x64")
+	}
+
+	if arch != "amd64" {
+		os = os + "-" + arch
+	}
+	return os
+}
+
+// ComponentForMajor returns the Mojang runtime component for this system whose Java version has the given
+// major version (e.g. 17 → "java-runtime-gamma"). The newest release wins when several match.
+func (list JavaManifestList) ComponentForMajor(major int) (string, bool) {
+	var best string
+	var bestReleased time.Time
+	for name, builds := range list[JavaPlatform()] {
+		if len(builds) == 0 || JavaMajorVersion(builds[0].Version.Name) != major {
+			continue
+		}
+		if best == "" || builds[0].Version.Released.After(bestReleased) {
+			best, bestReleased = name, builds[0].Version.Released
+		}
+	}
+	return best, best != ""
+}
+
+// JavaMajorVersion parses the major version of a Java version string: "1.8.0_51" → 8, "17.0.8" → 17.
+// It returns 0 when the string cannot be parsed.
+func JavaMajorVersion(v string) int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "1.")
+	end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		v = v[:end]
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// JavaComponent is an installable Mojang runtime component on one platform.
+type JavaComponent struct {
+	Name     string    `json:"name"` // e.g. "java-runtime-gamma"
+	Platform string    `json:"platform"`
+	Version  string    `json:"version"`
+	Major    int       `json:"major"`
+	Released time.Time `json:"released"`
+	Size     int64     `json:"size"` // unpacked bytes
+	Files    int       `json:"files"`
+}
+
+// Platforms returns the platform keys of the list, sorted.
+func (list JavaManifestList) Platforms() []string {
+	out := make([]string, 0, len(list))
+	for p := range list {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ListJavaComponents lists the Mojang runtime components available for platform ("" = this system),
+// with the unpacked size of each, sorted by name. Component manifests are cached in cachesDir.
+func ListJavaComponents(platform, cachesDir string) ([]JavaComponent, error) {
+	list, err := FetchJavaManifestList(cachesDir)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve java manifest list: %w", err)
+	}
+	if platform == "" {
+		platform = JavaPlatform()
+	}
+	components, ok := list[platform]
+	if !ok {
+		return nil, ErrJavaBadSystem
+	}
+	var out []JavaComponent
+	for name, builds := range components {
+		if len(builds) == 0 {
+			continue
+		}
+		b := builds[0]
+		c := JavaComponent{
+			Name:     name,
+			Platform: platform,
+			Version:  b.Version.Name,
+			Major:    JavaMajorVersion(b.Version.Name),
+			Released: b.Version.Released,
+		}
+		cache := network.Cache[JavaManifest]{
+			Path: filepath.Join(cachesDir, "minecraft", "java", platform, name+".json"),
+			URL:  mojangJavaURL(b.Manifest.URL),
+		}
+		var manifest JavaManifest
+		if err := cache.Get(&manifest); err == nil {
+			for _, f := range manifest.Files {
+				if f.Type == "file" {
+					c.Size += int64(f.Downloads.Raw.Size)
+					c.Files++
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
 var ErrJavaBadSystem = errors.New("system is unsupported")
 var ErrJavaNoVersion = errors.New("required version unavailable for this system")
 
@@ -456,17 +571,7 @@ func FetchJavaManifest(name string, cachesDir string) (JavaManifest, error) {
 		return JavaManifest{}, fmt.Errorf("retrieve java manifest list: %w", err)
 	}
 
-	os := strings.ReplaceAll(runtime.GOOS, "darwin", "mac-os")
-	arch := strings.ReplaceAll(runtime.GOARCH, "386", "i386")
-
-	if os == "windows" {
-		arch = strings.ReplaceAll(arch, "amd64", "x64")
-	}
-
-	if arch != "amd64" {
-		os = os + "-" + arch
-	}
-
+	os := JavaPlatform()
 	_, ok := list[os]
 	if !ok {
 		return JavaManifest{}, ErrJavaBadSystem
@@ -484,7 +589,7 @@ func FetchJavaManifest(name string, cachesDir string) (JavaManifest, error) {
 
 	cache := network.Cache[JavaManifest]{
 		Path: filepath.Join(cachesDir, "minecraft", name+".json"),
-		URL:  ref.URL,
+		URL:  mojangJavaURL(ref.URL),
 	}
 
 	var manifest JavaManifest
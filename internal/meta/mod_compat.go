@@ -0,0 +1,216 @@
+package meta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// neoForgeModsTOMLSince is the first game version whose NeoForge reads only neoforge.mods.toml; before it
+// a mods.toml JAR may be a NeoForge mod (1.20.2–1.20.4) or a Forge mod NeoForge still loads (1.20.1).
+var neoForgeModsTOMLSince = semver.MustParse("1.20.5")
+
+// LoaderAccepts reports whether an instance with instanceLoader on gameVersion can load a JAR built for
+// modLoader. Quilt loads Fabric mods; NeoForge loads Forge mods.toml JARs only before 1.20.5 (see
+// neoForgeModsTOMLSince). An unparsable gameVersion gives NeoForge the benefit of the doubt.
+func LoaderAccepts(instanceLoader, modLoader, gameVersion string) bool {
+	instanceLoader = strings.ToLower(strings.TrimSpace(instanceLoader))
+	modLoader = strings.ToLower(strings.TrimSpace(modLoader))
+	switch {
+	case instanceLoader == modLoader:
+		return true
+	case instanceLoader == "quilt" && modLoader == "fabric":
+		return true
+	case instanceLoader == "neoforge" && modLoader == "forge":
+		v, err := semver.NewVersion(strings.TrimSpace(gameVersion))
+		return err != nil || v.LessThan(neoForgeModsTOMLSince)
+	}
+	return false
+}
+
+// SupportsLoader reports whether any descriptor in the JAR is loadable by instanceLoader on gameVersion.
+// JARs without a known descriptor are assumed to be fine.
+func (md ModMetadata) SupportsLoader(instanceLoader, gameVersion string) bool {
+	if len(md.Loaders) == 0 {
+		return true
+	}
+	for _, l := range md.Loaders {
+		if LoaderAccepts(instanceLoader, l, gameVersion) {
+			return true
+		}
+	}
+	return false
+}
+
+// MinecraftSatisfies checks gameVersion against the descriptor's game version constraint, read as a
+// Maven range for Forge and NeoForge descriptors. See MinecraftVersionSatisfies.
+func (md ModMetadata) MinecraftSatisfies(gameVersion string) (ok, known bool) {
+	maven := md.Loader == "forge" || md.Loader == "neoforge"
+	return MinecraftVersionSatisfies(md.Minecraft, gameVersion, maven)
+}
+
+var mavenRangeGroup = regexp.MustCompile(`[\[(][^\])]*[\])]`)
+
+// mavenRangeToConstraint converts a Maven version range ("[1.20.1,1.21)", "[1.20.1]", "[1.18,1.19),[1.20,)")
+// to a semver constraint. A bare version ("47.1") is a soft requirement in Maven: Forge accepts it or
+// anything newer, so it becomes a minimum.
+func mavenRangeToConstraint(r string) string {
+	r = strings.TrimSpace(r)
+	groups := mavenRangeGroup.FindAllString(r, -1)
+	if len(groups) == 0 {
+		if r == "" || r == "*" {
+			return ""
+		}
+		return ">=" + r
+	}
+	var alts []string
+	for _, g := range groups {
+		lb, rb := g[0], g[len(g)-1]
+		body := g[1 : len(g)-1]
+		lo, hi, hasComma := strings.Cut(body, ",")
+		lo, hi = strings.TrimSpace(lo), strings.TrimSpace(hi)
+		if !hasComma {
+			alts = append(alts, "="+lo)
+			continue
+		}
+		var parts []string
+		if lo != "" {
+			if lb == '[' {
+				parts = append(parts, ">="+lo)
+			} else {
+				parts = append(parts, ">"+lo)
+			}
+		}
+		if hi != "" {
+			if rb == ']' {
+				parts = append(parts, "<="+hi)
+			} else {
+				parts = append(parts, "<"+hi)
+			}
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "*")
+		}
+		alts = append(alts, strings.Join(parts, ", "))
+	}
+	return strings.Join(alts, " || ")
+}
+
+// MinecraftVersionSatisfies checks gameVersion against a descriptor constraint (ModMetadata.Minecraft):
+// a Maven range when maven is set (Forge, NeoForge), otherwise a Fabric/Quilt version predicate.
+// known is false when the constraint or version cannot be evaluated (snapshots, unusual syntax);
+// callers should then not report a mismatch.
+func MinecraftVersionSatisfies(constraint, gameVersion string, maven bool) (ok, known bool) {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" || constraint == "*" {
+		return true, false
+	}
+	if maven {
+		constraint = mavenRangeToConstraint(constraint)
+		if constraint == "" {
+			return true, false
+		}
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return true, false
+	}
+	v, err := semver.NewVersion(strings.TrimSpace(gameVersion))
+	if err != nil {
+		return true, false
+	}
+	return c.Check(v), true
+}
+
+// CompareModVersions orders two mod version strings: numeric runs compare as numbers, the rest as text.
+// It returns -1, 0 or 1.
+func CompareModVersions(a, b string) int {
+	split := func(s string) []string {
+		var out []string
+		var cur strings.Builder
+		digit := false
+		flush := func() {
+			if cur.Len() > 0 {
+				out = append(out, cur.String())
+				cur.Reset()
+			}
+		}
+		for _, r := range strings.ToLower(s) {
+			isDigit := unicode.IsDigit(r)
+			if !isDigit && !unicode.IsLetter(r) {
+				flush()
+				continue
+			}
+			if cur.Len() > 0 && isDigit != digit {
+				flush()
+			}
+			digit = isDigit
+			cur.WriteRune(r)
+		}
+		flush()
+		return out
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case pa[i] != pb[i]:
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(pa) < len(pb):
+		return -1
+	case len(pa) > len(pb):
+		return 1
+	}
+	return 0
+}
+
+// LoaderAPIMod is a library mod that many mods depend on and that is easy to forget.
+type LoaderAPIMod struct {
+	Title string `json:"title"`
+	Slug  string `json:"slug"` // Modrinth slug
+	// ModIDs are the mod ids (including "provides") that satisfy a dependency on it.
+	ModIDs []string `json:"-"`
+}
+
+var (
+	fabricAPIMod       = LoaderAPIMod{Title: "Fabric API", Slug: "fabric-api", ModIDs: []string{"fabric-api", "fabric", "quilted_fabric_api"}}
+	quiltedFabricAPI   = LoaderAPIMod{Title: "QFAPI/QSL", Slug: "qsl", ModIDs: []string{"quilted_fabric_api", "qsl"}}
+	architecturyAPIMod = LoaderAPIMod{Title: "Architectury API", Slug: "architectury-api", ModIDs: []string{"architectury"}}
+	fabricModuleID     = regexp.MustCompile(`^fabric-[a-z0-9-]+-v\d+$`)
+)
+
+// LoaderAPIForDependency maps a required mod id to the API mod that provides it on instanceLoader.
+// Fabric API module ids (fabric-networking-api-v1, …) resolve to Fabric API, or to QFAPI on Quilt.
+func LoaderAPIForDependency(depID, instanceLoader string) (LoaderAPIMod, bool) {
+	id := strings.ToLower(strings.TrimSpace(depID))
+	quilt := strings.EqualFold(instanceLoader, "quilt")
+	switch {
+	case id == "fabric-api" || id == "fabric" || id == "fabric-api-base" || fabricModuleID.MatchString(id):
+		if quilt {
+			return quiltedFabricAPI, true
+		}
+		return fabricAPIMod, true
+	case id == "quilted_fabric_api" || id == "qsl" || strings.HasPrefix(id, "quilt_") && id != "quilt_loader":
+		return quiltedFabricAPI, true
+	case id == "architectury":
+		return architecturyAPIMod, true
+	}
+	return LoaderAPIMod{}, false
+}
@@ -0,0 +1,323 @@
+package meta
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ErrNoModMetadata is returned when a JAR has no Fabric, Quilt, Forge or NeoForge descriptor.
+var ErrNoModMetadata = errors.New("no mod metadata in jar")
+
+// ModMetadata is the mod descriptor read from inside a JAR.
+type ModMetadata struct {
+	ModID   string   `json:"modId"`
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Loader  string   `json:"loader"` // fabric | quilt | forge | neoforge
+	Authors []string `json:"authors,omitempty"`
+	// Loaders lists every loader the JAR has a descriptor for (multi-loader JARs have several).
+	Loaders []string `json:"loaders"`
+	// Minecraft is the game version constraint as written in the descriptor (Fabric or Maven range).
+	Minecraft string `json:"minecraft,omitempty"`
+	// Dependencies are the required mod ids (minecraft and the loader itself excluded).
+	Dependencies []string `json:"dependencies"`
+	// Provides lists extra mod ids the JAR answers to (Fabric "provides", Quilt "provides").
+	Provides []string `json:"provides,omitempty"`
+	// License is the declared license (SPDX id or free text; several are joined with " OR ").
+	License     string `json:"license,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ReadModMetadata opens a mod JAR and parses fabric.mod.json, quilt.mod.json,
+// META-INF/neoforge.mods.toml or META-INF/mods.toml (first found, in that order).
+func ReadModMetadata(jarPath string) (ModMetadata, error) {
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return ModMetadata{}, err
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	read := func(name string) ([]byte, bool) {
+		f, ok := files[name]
+		if !ok {
+			return nil, false
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, false
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, 4<<20))
+		return data, err == nil
+	}
+
+	var md ModMetadata
+	if data, ok := read("fabric.mod.json"); ok {
+		md, err = parseFabricModJSON(data)
+	} else if data, ok := read("quilt.mod.json"); ok {
+		md, err = parseQuiltModJSON(data)
+	} else if data, ok := read("META-INF/neoforge.mods.toml"); ok {
+		md, err = parseForgeModsTOML(data, "neoforge")
+	} else if data, ok := read("META-INF/mods.toml"); ok {
+		md, err = parseForgeModsTOML(data, "forge")
+	} else {
+		return ModMetadata{}, ErrNoModMetadata
+	}
+	if err != nil {
+		return ModMetadata{}, err
+	}
+	for _, d := range []struct{ file, loader string }{
+		{"fabric.mod.json", "fabric"},
+		{"quilt.mod.json", "quilt"},
+		{"META-INF/neoforge.mods.toml", "neoforge"},
+		{"META-INF/mods.toml", "forge"},
+	} {
+		if _, ok := files[d.file]; ok {
+			md.Loaders = append(md.Loaders, d.loader)
+		}
+	}
+	// Forge descriptors usually take the version from the manifest.
+	if strings.Contains(md.Version, "${") {
+		md.Version = ""
+		if data, ok := read("META-INF/MANIFEST.MF"); ok {
+			md.Version = manifestAttribute(data, "Implementation-Version")
+		}
+	}
+	if md.Name == "" {
+		md.Name = md.ModID
+	}
+	sort.Strings(md.Dependencies)
+	return md, nil
+}
+
+// isPlatformDependency reports ids that are not separate mods (game, loaders, Java).
+func isPlatformDependency(id string) bool {
+	switch strings.ToLower(id) {
+	case "minecraft", "java", "fabricloader", "fabric-loader", "quilt_loader", "forge", "neoforge", "":
+		return true
+	}
+	return false
+}
+
+func parseFabricModJSON(data []byte) (ModMetadata, error) {
+	var doc struct {
+		ID       string                     `json:"id"`
+		Name     string                     `json:"name"`
+		Version  string                     `json:"version"`
+		Authors  []json.RawMessage          `json:"authors"`
+		Depends  map[string]json.RawMessage `json:"depends"`
+		Provides []string                   `json:"provides"`
+		License  json.RawMessage            `json:"license"`
+		Desc     string                     `json:"description"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ModMetadata{}, err
+	}
+	md := ModMetadata{ModID: doc.ID, Name: doc.Name, Version: doc.Version, Loader: "fabric", Provides: doc.Provides,
+		License: jsonLicense(doc.License), Description: strings.TrimSpace(doc.Desc)}
+	// Authors are either "name" or {"name": "...", "contact": {...}}.
+	for _, raw := range doc.Authors {
+		var name string
+		if json.Unmarshal(raw, &name) != nil {
+			var obj struct {
+				Name string `json:"name"`
+			}
+			_ = json.Unmarshal(raw, &obj)
+			name = obj.Name
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			md.Authors = append(md.Authors, name)
+		}
+	}
+	for id, raw := range doc.Depends {
+		if id == "minecraft" {
+			md.Minecraft = fabricVersionPredicate(raw)
+		}
+		if !isPlatformDependency(id) {
+			md.Dependencies = append(md.Dependencies, id)
+		}
+	}
+	return md, nil
+}
+
+// jsonLicense reads a Fabric/Quilt license: "MIT", ["MIT", "Apache-2.0"] or {"id": ..., "name": ...} objects.
+func jsonLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	one := func(raw json.RawMessage) string {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		var obj struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		_ = json.Unmarshal(raw, &obj)
+		if obj.ID != "" {
+			return obj.ID
+		}
+		return obj.Name
+	}
+	var many []json.RawMessage
+	if json.Unmarshal(raw, &many) != nil {
+		return strings.TrimSpace(one(raw))
+	}
+	var out []string
+	for _, r := range many {
+		if l := strings.TrimSpace(one(r)); l != "" {
+			out = append(out, l)
+		}
+	}
+	return strings.Join(out, " OR ")
+}
+
+// fabricVersionPredicate flattens a Fabric version predicate (string or array of alternatives) to "a || b".
+func fabricVersionPredicate(raw json.RawMessage) string {
+	var one string
+	if json.Unmarshal(raw, &one) == nil {
+		return one
+	}
+	var many []string
+	if json.Unmarshal(raw, &many) == nil {
+		return strings.Join(many, " || ")
+	}
+	return ""
+}
+
+func parseQuiltModJSON(data []byte) (ModMetadata, error) {
+	var doc struct {
+		QuiltLoader struct {
+			ID       string            `json:"id"`
+			Version  string            `json:"version"`
+			Depends  []json.RawMessage `json:"depends"`
+			Provides []json.RawMessage `json:"provides"`
+			Metadata struct {
+				Name         string            `json:"name"`
+				Description  string            `json:"description"`
+				License      json.RawMessage   `json:"license"`
+				Contributors map[string]string `json:"contributors"` // name -> role
+			} `json:"metadata"`
+		} `json:"quilt_loader"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ModMetadata{}, err
+	}
+	ql := doc.QuiltLoader
+	md := ModMetadata{ModID: ql.ID, Name: ql.Metadata.Name, Version: ql.Version, Loader: "quilt",
+		License: jsonLicense(ql.Metadata.License), Description: strings.TrimSpace(ql.Metadata.Description)}
+	for name := range ql.Metadata.Contributors {
+		md.Authors = append(md.Authors, name)
+	}
+	sort.Strings(md.Authors)
+	// Entries are either "modid" or {"id": "modid", "optional": bool}.
+	refID := func(raw json.RawMessage) (string, bool) {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s, false
+		}
+		var obj struct {
+			ID       string `json:"id"`
+			Optional bool   `json:"optional"`
+		}
+		if json.Unmarshal(raw, &obj) == nil {
+			return obj.ID, obj.Optional
+		}
+		return "", false
+	}
+	for _, raw := range ql.Depends {
+		if id, optional := refID(raw); !optional && !isPlatformDependency(id) {
+			md.Dependencies = append(md.Dependencies, id)
+		}
+	}
+	for _, raw := range ql.Provides {
+		if id, _ := refID(raw); id != "" {
+			md.Provides = append(md.Provides, id)
+		}
+	}
+	return md, nil
+}
+
+func parseForgeModsTOML(data []byte, loader string) (ModMetadata, error) {
+	var doc struct {
+		License string `toml:"license"`
+		Mods    []struct {
+			ModID       string `toml:"modId"`
+			Version     string `toml:"version"`
+			DisplayName string `toml:"displayName"`
+			Authors     string `toml:"authors"`
+			Description string `toml:"description"`
+		} `toml:"mods"`
+		Dependencies map[string][]struct {
+			ModID        string `toml:"modId"`
+			Mandatory    *bool  `toml:"mandatory"`
+			VersionRange string `toml:"versionRange"`
+			Type         string `toml:"type"` // NeoForge: required | optional | incompatible | discouraged
+		} `toml:"dependencies"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return ModMetadata{}, err
+	}
+	if len(doc.Mods) == 0 {
+		return ModMetadata{}, ErrNoModMetadata
+	}
+	m := doc.Mods[0]
+	md := ModMetadata{ModID: m.ModID, Name: m.DisplayName, Version: m.Version, Loader: loader,
+		License: strings.TrimSpace(doc.License), Description: strings.TrimSpace(m.Description)}
+	for _, a := range strings.Split(m.Authors, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			md.Authors = append(md.Authors, a)
+		}
+	}
+	for _, d := range doc.Dependencies[m.ModID] {
+		if d.ModID == "minecraft" {
+			md.Minecraft = d.VersionRange
+		}
+		// NeoForge defaults type to required; the legacy mandatory flag still applies when set.
+		required := d.Mandatory != nil && *d.Mandatory || d.Mandatory == nil && loader == "neoforge"
+		if d.Type != "" {
+			required = strings.EqualFold(d.Type, "required")
+		}
+		if required && !isPlatformDependency(d.ModID) {
+			md.Dependencies = append(md.Dependencies, d.ModID)
+		}
+	}
+	for _, extra := range doc.Mods[1:] {
+		md.Provides = append(md.Provides, extra.ModID)
+	}
+	return md, nil
+}
+
+func manifestAttribute(data []byte, key string) string {
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	prefix := key + ":"
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+	return ""
+}
+
+// ExtractModInfoFromJar is ExtractModInfoFromFilename with the slug taken from the JAR's mod id when available.
+func ExtractModInfoFromJar(jarPath string) ModInfo {
+	mi := ExtractModInfoFromFilename(strings.TrimSuffix(filepath.Base(jarPath), ".disabled"))
+	if md, err := ReadModMetadata(jarPath); err == nil && md.ModID != "" {
+		mi.Slug = strings.ReplaceAll(strings.ToLower(md.ModID), "_", "-")
+	}
+	return mi
+}
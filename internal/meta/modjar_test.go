@@ -0,0 +1,56 @@
+package meta
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseForgeModsTOMLDependencies(t *testing.T) {
+	const toml = `
+[[mods]]
+modId = "example"
+version = "1.0"
+
+[[dependencies.example]]
+modId = "minecraft"
+versionRange = "[1.21,1.22)"
+
+[[dependencies.example]]
+modId = "untyped"
+
+[[dependencies.example]]
+modId = "legacyoptional"
+mandatory = false
+
+[[dependencies.example]]
+modId = "legacyrequired"
+mandatory = true
+
+[[dependencies.example]]
+modId = "typedoptional"
+type = "optional"
+
+[[dependencies.example]]
+modId = "typedrequired"
+type = "required"
+`
+	tests := []struct {
+		loader string
+		want   []string
+	}{
+		{"neoforge", []string{"untyped", "legacyrequired", "typedrequired"}},
+		{"forge", []string{"legacyrequired", "typedrequired"}},
+	}
+	for _, tt := range tests {
+		md, err := parseForgeModsTOML([]byte(toml), tt.loader)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.loader, err)
+		}
+		if !slices.Equal(md.Dependencies, tt.want) {
+			t.Errorf("%s: dependencies = %v, want %v", tt.loader, md.Dependencies, tt.want)
+		}
+		if md.Minecraft != "[1.21,1.22)" {
+			t.Errorf("%s: minecraft = %q", tt.loader, md.Minecraft)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package meta
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ModrinthHashMatch is the exact Modrinth project/version a file belongs to, found by its hash.
+type ModrinthHashMatch struct {
+	ProjectID     string `json:"projectId"`
+	VersionID     string `json:"versionId"`
+	VersionNumber string `json:"versionNumber"`
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+	ProjectType   string `json:"projectType"` // mod | resourcepack | shader | datapack | modpack
+	PageURL       string `json:"pageUrl"`
+	// Loaders of the matched version (fabric, forge, …; iris, optifine, canvas for shader packs).
+	Loaders []string `json:"loaders,omitempty"`
+}
+
+type modrinthHashCacheEntry struct {
+	Match     *ModrinthHashMatch `json:"match,omitempty"` // nil: not on Modrinth
+	CheckedAt int64              `json:"checkedAt"`
+}
+
+const (
+	modrinthHashHitTTL  = 30 * 24 * time.Hour // project slug/title may change
+	modrinthHashMissTTL = 24 * time.Hour
+)
+
+// modrinthHashCacheMu serializes lookups so concurrent callers share one cache file.
+var modrinthHashCacheMu sync.Mutex
+
+func modrinthHashCachePath(cachesDir string) string {
+	return filepath.Join(cachesDir, "modrinth", "hash_lookup.json")
+}
+
+func loadModrinthHashCache(cachesDir string) map[string]modrinthHashCacheEntry {
+	cache := map[string]modrinthHashCacheEntry{}
+	if cachesDir == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(modrinthHashCachePath(cachesDir)); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func saveModrinthHashCache(cachesDir string, cache map[string]modrinthHashCacheEntry) {
+	if cachesDir == "" {
+		return
+	}
+	path := modrinthHashCachePath(cachesDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	if data, err := json.Marshal(cache); err == nil {
+		_ = os.WriteFile(path, data, 0644)
+	}
+}
+
+// ModrinthPageURL returns the modrinth.com page of a project.
+func ModrinthPageURL(projectType, slug string) string {
+	if projectType == "" {
+		projectType = "mod"
+	}
+	return "https://modrinth.com/" + projectType + "/" + url.PathEscape(slug)
+}
+
+// IdentifyModrinthHashes resolves file SHA-1 hashes to the exact Modrinth project and version
+// (POST /v2/version_files, then GET /v2/projects for slugs). Results are cached under cachesDir;
+// hashes unknown to Modrinth are absent from the map.
+func IdentifyModrinthHashes(sha1s []string, cachesDir string) (map[string]ModrinthHashMatch, error) {
+	modrinthHashCacheMu.Lock()
+	defer modrinthHashCacheMu.Unlock()
+
+	out := map[string]ModrinthHashMatch{}
+	cache := loadModrinthHashCache(cachesDir)
+	now := time.Now()
+
+	var lookup []string
+	for _, h := range sha1s {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" {
+			continue
+		}
+		if e, ok := cache[h]; ok {
+			ttl := modrinthHashMissTTL
+			if e.Match != nil {
+				ttl = modrinthHashHitTTL
+			}
+			if now.Sub(time.Unix(e.CheckedAt, 0)) < ttl {
+				if e.Match != nil {
+					out[h] = *e.Match
+				}
+				continue
+			}
+		}
+		lookup = append(lookup, h)
+	}
+	if len(lookup) == 0 {
+		return out, nil
+	}
+
+	var versions map[string]modrinthVersion
+	if err := httpPostJSON("https://api.modrinth.com/v2/version_files", nil, map[string]any{
+		"hashes":    lookup,
+		"algorithm": "sha1",
+	}, &versions); err != nil {
+		return out, err
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, v := range versions {
+		if v.ProjectID != "" && !seen[v.ProjectID] {
+			seen[v.ProjectID] = true
+			ids = append(ids, v.ProjectID)
+		}
+	}
+	projects := map[string]ModrinthProjectInfo{}
+	if list, err := FetchModrinthProjects(ids); err == nil {
+		for _, p := range list {
+			projects[p.ID] = p
+		}
+	}
+
+	for _, h := range lookup {
+		v, ok := versions[h]
+		if !ok {
+			cache[h] = modrinthHashCacheEntry{CheckedAt: now.Unix()}
+			continue
+		}
+		p := projects[v.ProjectID]
+		slug := p.Slug
+		if slug == "" {
+			slug = v.ProjectID
+		}
+		m := ModrinthHashMatch{
+			ProjectID:     v.ProjectID,
+			VersionID:     v.ID,
+			VersionNumber: v.VersionNumber,
+			Slug:          slug,
+			Title:         p.Title,
+			ProjectType:   p.ProjectType,
+			PageURL:       ModrinthPageURL(p.ProjectType, slug),
+			Loaders:       v.Loaders,
+		}
+		out[h] = m
+		cache[h] = modrinthHashCacheEntry{Match: &m, CheckedAt: now.Unix()}
+	}
+	saveModrinthHashCache(cachesDir, cache)
+	return out, nil
+}
+
+// CachedModrinthHashes returns the cached matches of sha1s without network access, regardless of age.
+func CachedModrinthHashes(sha1s []string, cachesDir string) map[string]ModrinthHashMatch {
+	modrinthHashCacheMu.Lock()
+	defer modrinthHashCacheMu.Unlock()
+	out := map[string]ModrinthHashMatch{}
+	cache := loadModrinthHashCache(cachesDir)
+	for _, h := range sha1s {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if e, ok := cache[h]; ok && e.Match != nil {
+			out[h] = *e.Match
+		}
+	}
+	return out
+}
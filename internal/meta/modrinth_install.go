@@ -0,0 +1,361 @@
+package meta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"QMLauncher/internal/network"
+)
+
+// ModrinthFile is the resolved downloadable file of one Modrinth project version.
+type ModrinthFile struct {
+	ProjectID     string `json:"projectId"`
+	VersionID     string `json:"versionId"`
+	VersionNumber string `json:"versionNumber"`
+	VersionType   string `json:"versionType"` // release | beta | alpha
+	URL           string `json:"url"`
+	Filename      string `json:"filename"`
+	Sha1          string `json:"sha1"`
+	Sha512        string `json:"sha512"`
+	// Dependencies are the project ids this version requires.
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+func modrinthFileFromVersion(v modrinthVersion, projectSlug string) (ModrinthFile, error) {
+	i := pickModrinthFileIndex(v)
+	if i < 0 {
+		return ModrinthFile{}, fmt.Errorf("no downloadable file for %s", projectSlug)
+	}
+	f := v.Files[i]
+	name := f.Filename
+	if name == "" {
+		name = projectSlug + "-download"
+	}
+	var deps []string
+	for _, d := range v.Dependencies {
+		if d.DependencyType == "required" && d.ProjectID != "" {
+			deps = append(deps, d.ProjectID)
+		}
+	}
+	return ModrinthFile{
+		Dependencies:  deps,
+		ProjectID:     v.ProjectID,
+		VersionID:     v.ID,
+		VersionNumber: v.VersionNumber,
+		VersionType:   v.VersionType,
+		URL:           f.URL,
+		Filename:      filepath.Base(name),
+		Sha1:          f.Hashes.Sha1,
+		Sha512:        f.Hashes.Sha512,
+	}, nil
+}
+
+func fetchModrinthProjectVersions(projectSlug string) ([]modrinthVersion, error) {
+	projectSlug = strings.TrimSpace(projectSlug)
+	if projectSlug == "" {
+		return nil, fmt.Errorf("empty Modrinth project")
+	}
+	u := "https://api.modrinth.com/v2/project/" + url.PathEscape(projectSlug) + "/version"
+	var versions []modrinthVersion
+	if err := httpGetJSON(u, nil, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// ResolveModrinthFile picks the newest file of a project compatible with the instance's game version and loader.
+func ResolveModrinthFile(projectSlug, gameVersion, loader, category string) (ModrinthFile, error) {
+	versions, err := fetchModrinthProjectVersions(projectSlug)
+	if err != nil {
+		return ModrinthFile{}, err
+	}
+	chosen, err := pickModrinthVersion(versions, projectSlug, gameVersion, loader, category)
+	if err != nil {
+		return ModrinthFile{}, err
+	}
+	return modrinthFileFromVersion(*chosen, projectSlug)
+}
+
+// ModrinthChannelAllows reports whether a version type (release | beta | alpha) is accepted by a release
+// channel: "release" takes releases only, "beta" releases and betas, "alpha" or "" everything.
+func ModrinthChannelAllows(channel, versionType string) bool {
+	rank := map[string]int{"release": 0, "beta": 1, "alpha": 2}
+	c, ok := rank[strings.ToLower(strings.TrimSpace(channel))]
+	if !ok {
+		return true
+	}
+	v, ok := rank[strings.ToLower(versionType)]
+	return !ok || v <= c
+}
+
+// ResolveModrinthFileInChannel is ResolveModrinthFile restricted to versions allowed by channel.
+func ResolveModrinthFileInChannel(projectSlug, gameVersion, loader, category, channel string) (ModrinthFile, error) {
+	versions, err := fetchModrinthProjectVersions(projectSlug)
+	if err != nil {
+		return ModrinthFile{}, err
+	}
+	var allowed []modrinthVersion
+	for _, v := range versions {
+		if ModrinthChannelAllows(channel, v.VersionType) {
+			allowed = append(allowed, v)
+		}
+	}
+	chosen, err := pickModrinthVersion(allowed, projectSlug, gameVersion, loader, category)
+	if err != nil {
+		return ModrinthFile{}, err
+	}
+	return modrinthFileFromVersion(*chosen, projectSlug)
+}
+
+// ModrinthVersionInfo is one version of a project, for version pickers.
+type ModrinthVersionInfo struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	VersionNumber string   `json:"versionNumber"`
+	VersionType   string   `json:"versionType"`
+	GameVersions  []string `json:"gameVersions"`
+	Loaders       []string `json:"loaders"`
+	DatePublished string   `json:"datePublished"`
+	Downloads     int64    `json:"downloads"`
+	Filename      string   `json:"filename"`
+	// Compatible is true when the version lists the instance's game version (and loader for mods).
+	Compatible bool `json:"compatible"`
+}
+
+// ListModrinthVersions returns all versions of a project, newest first, flagging those that fit the instance.
+func ListModrinthVersions(projectSlug, gameVersion, loader, category string) ([]ModrinthVersionInfo, error) {
+	versions, err := fetchModrinthProjectVersions(projectSlug)
+	if err != nil {
+		return nil, err
+	}
+	loaders := normalizeModrinthLoaders(loader)
+	useLoader := remoteStoreCategoryUsesModLoader(category)
+	out := make([]ModrinthVersionInfo, 0, len(versions))
+	for _, v := range versions {
+		_, filename := pickModrinthFile(v)
+		out = append(out, ModrinthVersionInfo{
+			ID:            v.ID,
+			Name:          v.Name,
+			VersionNumber: v.VersionNumber,
+			VersionType:   v.VersionType,
+			GameVersions:  v.GameVersions,
+			Loaders:       v.Loaders,
+			DatePublished: v.DatePublished,
+			Downloads:     v.Downloads,
+			Filename:      filename,
+			Compatible:    mrVersionListsGame(v, gameVersion) && (!useLoader || mrVersionListsLoader(v, loaders)),
+		})
+	}
+	return out, nil
+}
+
+// FilterModrinthVersions keeps versions listing gameVersion, a loader accepted by loader (Quilt also takes
+// Fabric builds) and a type allowed by channel (see ModrinthChannelAllows). Empty filters match everything.
+func FilterModrinthVersions(versions []ModrinthVersionInfo, loader, gameVersion, channel string) []ModrinthVersionInfo {
+	loader = strings.ToLower(strings.TrimSpace(loader))
+	loaders := normalizeModrinthLoaders(loader)
+	if loaders == nil && loader != "" {
+		loaders = []string{loader}
+	}
+	gameVersion = strings.TrimSpace(gameVersion)
+	out := make([]ModrinthVersionInfo, 0, len(versions))
+	for _, v := range versions {
+		if gameVersion != "" && !slices.Contains(v.GameVersions, gameVersion) {
+			continue
+		}
+		if len(loaders) > 0 && !slices.ContainsFunc(v.Loaders, func(l string) bool { return slices.Contains(loaders, strings.ToLower(l)) }) {
+			continue
+		}
+		if !ModrinthChannelAllows(channel, v.VersionType) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// FetchModrinthVersionFile returns the downloadable file of one specific version.
+func FetchModrinthVersionFile(versionID string) (ModrinthFile, error) {
+	versionID = strings.TrimSpace(versionID)
+	var v modrinthVersion
+	if err := httpGetJSON("https://api.modrinth.com/v2/version/"+url.PathEscape(versionID), nil, &v); err != nil {
+		return ModrinthFile{}, err
+	}
+	return modrinthFileFromVersion(v, v.ProjectID)
+}
+
+// DownloadModrinthFile downloads f into destDir, verifying its SHA-1. A download failing verification is
+// removed and leaves a file already at the destination untouched.
+func DownloadModrinthFile(f ModrinthFile, destDir string) (string, error) {
+	if f.URL == "" {
+		return "", fmt.Errorf("no download URL")
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", err
+	}
+	destPath := filepath.Join(destDir, filepath.Base(f.Filename))
+	tmp := destPath + ".part"
+	if err := network.DownloadFileCached(network.DownloadEntry{URL: f.URL, Path: tmp, Sha1: f.Sha1}); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, destPath); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	return destPath, nil
+}
+
+// ModrinthProjectInfo is a subset of GET /v2/project/{id|slug}.
+type ModrinthProjectInfo struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ProjectType string `json:"project_type"`
+	IconURL     string `json:"icon_url"`
+	// Loaders are all loaders any version supports (mod loaders, "datapack", "minecraft", shader loaders).
+	Loaders []string `json:"loaders,omitempty"`
+}
+
+// ModrinthSlugFromURL extracts the project slug or id from a modrinth.com project URL
+// (https://modrinth.com/mod/sodium, …/mod/sodium/versions).
+func ModrinthSlugFromURL(s string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || (u.Host != "modrinth.com" && u.Host != "www.modrinth.com") {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", false
+	}
+	switch parts[0] {
+	case "mod", "plugin", "resourcepack", "shader", "datapack", "modpack", "project":
+		return parts[1], true
+	}
+	return "", false
+}
+
+// FetchModrinthProject returns project metadata by id or slug.
+func FetchModrinthProject(idOrSlug string) (ModrinthProjectInfo, error) {
+	var p ModrinthProjectInfo
+	err := httpGetJSON("https://api.modrinth.com/v2/project/"+url.PathEscape(strings.TrimSpace(idOrSlug)), nil, &p)
+	return p, err
+}
+
+// ModrinthProjectDetails is project metadata relevant to redistribution: license, team and links.
+type ModrinthProjectDetails struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ProjectType string `json:"project_type"`
+	License     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"license"`
+	SourceURL string `json:"source_url"`
+	IssuesURL string `json:"issues_url"`
+	// Authors are the usernames of the project team (GET /v2/project/{id}/members).
+	Authors []string `json:"authors"`
+}
+
+// FetchModrinthProjectDetails returns a project with its license and team members.
+func FetchModrinthProjectDetails(idOrSlug string) (ModrinthProjectDetails, error) {
+	base := "https://api.modrinth.com/v2/project/" + url.PathEscape(strings.TrimSpace(idOrSlug))
+	var p ModrinthProjectDetails
+	if err := httpGetJSON(base, nil, &p); err != nil {
+		return p, err
+	}
+	var members []struct {
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	if err := httpGetJSON(base+"/members", nil, &members); err == nil {
+		for _, m := range members {
+			if m.User.Username != "" {
+				p.Authors = append(p.Authors, m.User.Username)
+			}
+		}
+	}
+	return p, nil
+}
+
+// ResolveModrinthProject looks up queryOrSlug as a project id/slug first, then falls back to the top search hit.
+func ResolveModrinthProject(queryOrSlug, category, cachesDir string) (ModrinthProjectInfo, error) {
+	q := strings.TrimSpace(queryOrSlug)
+	if q == "" {
+		return ModrinthProjectInfo{}, errors.New("empty query")
+	}
+	if slug, ok := ModrinthSlugFromURL(q); ok {
+		return FetchModrinthProject(slug)
+	}
+	if !strings.ContainsAny(q, " \t") {
+		if p, err := FetchModrinthProject(q); err == nil && p.ID != "" {
+			return p, nil
+		}
+	}
+	hits, err := SearchModrinthStore(category, q, "downloads", 0, 5, cachesDir)
+	if err != nil {
+		return ModrinthProjectInfo{}, err
+	}
+	if len(hits) == 0 {
+		return ModrinthProjectInfo{}, fmt.Errorf("на Modrinth ничего не найдено по запросу %q", q)
+	}
+	h := hits[0]
+	return ModrinthProjectInfo{
+		ID:          h.ProjectID,
+		Slug:        h.Slug,
+		Title:       h.Title,
+		Description: h.Summary,
+		ProjectType: modrinthProjectType(category),
+		IconURL:     h.IconURL,
+	}, nil
+}
+
+// ModrinthCollection is a user-curated list of Modrinth projects (GET /v3/collection/{id}).
+type ModrinthCollection struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Projects    []string `json:"projects"`
+}
+
+// FetchModrinthCollection returns a collection by id or modrinth.com/collection/<id> URL.
+func FetchModrinthCollection(idOrURL string) (ModrinthCollection, error) {
+	id := strings.TrimSpace(idOrURL)
+	if u, err := url.Parse(id); err == nil && strings.HasSuffix(u.Host, "modrinth.com") {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 2 || parts[0] != "collection" || parts[1] == "" {
+			return ModrinthCollection{}, fmt.Errorf("not a Modrinth collection URL: %s", idOrURL)
+		}
+		id = parts[1]
+	}
+	if id == "" {
+		return ModrinthCollection{}, errors.New("empty collection id")
+	}
+	var c ModrinthCollection
+	err := httpGetJSON("https://api.modrinth.com/v3/collection/"+url.PathEscape(id), nil, &c)
+	return c, err
+}
+
+// FetchModrinthProjects returns several projects by id or slug in one request (GET /v2/projects).
+func FetchModrinthProjects(ids []string) ([]ModrinthProjectInfo, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	idsJSON, _ := json.Marshal(ids)
+	var list []ModrinthProjectInfo
+	if err := httpGetJSON("https://api.modrinth.com/v2/projects?ids="+url.QueryEscape(string(idsJSON)), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,123 @@
+package meta
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// FileSHA1 returns the hex SHA-1 of a file (the hash Modrinth and Mojang use to identify files).
+func FileSHA1(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func httpPostJSON(u string, headers map[string]string, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", httpUserAgent())
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := remoteStoreHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		slurp, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		if resp.StatusCode == http.StatusForbidden && strings.Contains(u, "api.curseforge.com") {
+			notifyCurseForgeAPI403()
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(slurp)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// ModrinthLatestForHashes returns, for each known file SHA-1, the newest Modrinth version compatible
+// with loader and gameVersion (POST /v2/version_files/update). Unknown hashes are absent from the map.
+func ModrinthLatestForHashes(sha1s []string, loader, gameVersion string) (map[string]ModrinthFile, error) {
+	out := map[string]ModrinthFile{}
+	if len(sha1s) == 0 {
+		return out, nil
+	}
+	body := map[string]any{
+		"hashes":    sha1s,
+		"algorithm": "sha1",
+	}
+	if loaders := normalizeModrinthLoaders(loader); len(loaders) > 0 {
+		body["loaders"] = loaders
+	}
+	if gv := strings.TrimSpace(gameVersion); gv != "" {
+		body["game_versions"] = []string{gv}
+	}
+	var raw map[string]modrinthVersion
+	if err := httpPostJSON("https://api.modrinth.com/v2/version_files/update", nil, body, &raw); err != nil {
+		return nil, err
+	}
+	for hash, v := range raw {
+		f, err := modrinthFileFromVersion(v, v.ProjectID)
+		if err != nil {
+			continue
+		}
+		out[hash] = f
+	}
+	return out, nil
+}
+
+// ModrinthChangelogEntry is the changelog of one project version (Markdown body).
+type ModrinthChangelogEntry struct {
+	VersionID     string `json:"versionId"`
+	VersionNumber string `json:"versionNumber"`
+	VersionType   string `json:"versionType"`
+	DatePublished string `json:"datePublished"`
+	Changelog     string `json:"changelog"`
+}
+
+// ModrinthChangelogSince returns the changelogs of compatible versions newer than fromVersionID, newest first.
+// When fromVersionID is not found, all compatible versions are returned.
+func ModrinthChangelogSince(projectID, fromVersionID, gameVersion, loader string) ([]ModrinthChangelogEntry, error) {
+	versions, err := fetchModrinthProjectVersions(projectID)
+	if err != nil {
+		return nil, err
+	}
+	loaders := normalizeModrinthLoaders(loader)
+	var out []ModrinthChangelogEntry
+	for _, v := range versions {
+		if v.ID == fromVersionID {
+			break
+		}
+		if !mrVersionListsGame(v, gameVersion) || !mrVersionListsLoader(v, loaders) {
+			continue
+		}
+		out = append(out, ModrinthChangelogEntry{
+			VersionID:     v.ID,
+			VersionNumber: v.VersionNumber,
+			VersionType:   v.VersionType,
+			DatePublished: v.DatePublished,
+			Changelog:     strings.TrimSpace(v.Changelog),
+		})
+	}
+	return out, nil
+}
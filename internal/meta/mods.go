@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"QMLauncher/internal/network"
@@ -33,18 +34,6 @@ type ResourcePackInfo struct {
 	ModrinthURL   string
 }
 
-// CurseForgeSearchResult represents the response from CurseForge API
-type CurseForgeSearchResult struct {
-	Data []struct {
-		ID    int    `json:"id"`
-		Name  string `json:"name"`
-		Slug  string `json:"slug"`
-		Links struct {
-			WebsiteURL string `json:"websiteUrl"`
-		} `json:"links"`
-	} `json:"data"`
-}
-
 // ModrinthSearchResult represents the response from Modrinth API
 type ModrinthSearchResult struct {
 	Hits []struct {
@@ -57,56 +46,34 @@ type ModrinthSearchResult struct {
 	} `json:"hits"`
 }
 
-// SearchModOnCurseForge searches for a mod on CurseForge using alternative methods
+// SearchModOnCurseForge returns the CurseForge page of the best search hit (official API, needs an API key).
+// Without a key, or when nothing matches, the CurseForge site search for modName is returned. Search
+// results are cached under cachesDir for a day.
 func SearchModOnCurseForge(modName string, cachesDir string) (string, error) {
-	// Try alternative CurseForge search methods
-
-	// Method 1: Try the public API endpoint without authentication
-	// Some endpoints might work without API key
-	searchURL := fmt.Sprintf("https://api.curseforge.com/v1/mods/search?gameId=432&searchFilter=%s&pageSize=1&sortField=2&sortOrder=desc", url.QueryEscape(modName))
-
-	cache := network.Cache[CurseForgeSearchResult]{
-		Path:        filepath.Join(cachesDir, "curseforge", "search_"+strings.ReplaceAll(modName, " ", "_")+".json"),
-		URL:         searchURL,
-		AlwaysFetch: false,
+	c, err := NewCurseForgeClient()
+	if err != nil {
+		return CurseForgeMinecraftSearchURL(modName), nil
 	}
-
-	var result CurseForgeSearchResult
-	if err := cache.Get(&result); err == nil {
-		if len(result.Data) > 0 {
-			modID := fmt.Sprintf("%d", result.Data[0].ID)
-			url := fmt.Sprintf("https://www.curseforge.com/minecraft/mc-mods/%s", modID)
-			return url, nil
+	cachePath := filepath.Join(cachesDir, "curseforge_mods_cache.json")
+	if entry, ok := loadModCache(cachePath).Mods[modName]; ok && time.Now().Unix()-entry.LastChecked < 86400 {
+		if entry.CurseForgeURL == "" {
+			return CurseForgeMinecraftSearchURL(modName), nil
 		}
+		return entry.CurseForgeURL, nil
 	}
-
-	// Method 2: If API fails, try to construct URL based on common patterns
-	// Many mods follow predictable URL patterns
-	// This is a fallback method
-	return constructCurseForgeURL(modName)
-}
-
-// constructCurseForgeURL creates a CurseForge URL based on common naming patterns
-func constructCurseForgeURL(modName string) (string, error) {
-	// Known correct CurseForge URLs for specific mods
-	knownURLs := map[string]string{
-		"xaeros-world-map":        "https://www.curseforge.com/minecraft/mc-mods/xaeros-world-map",
-		"xaeros-minimap":          "https://www.curseforge.com/minecraft/mc-mods/xaeros-minimap",
-		"catalogue":               "https://www.curseforge.com/minecraft/mc-mods/catalogue",
-		"configured":              "https://www.curseforge.com/minecraft/mc-mods/configured",
-		"just-enough-items-jei":   "https://www.curseforge.com/minecraft/mc-mods/just-enough-items-jei",
-		"open-parties-and-claims": "https://www.curseforge.com/minecraft/mc-mods/open-parties-and-claims",
+	mods, err := c.SearchMods(modName, cfClassMods, 1)
+	if err != nil {
+		return "", err
 	}
-
-	// Check if we have a known URL
-	if url, exists := knownURLs[modName]; exists {
-		return url, nil
+	var pageURL string
+	if len(mods) > 0 {
+		pageURL = mods[0].Links.WebsiteURL
 	}
-
-	// Fallback: generate URL from mod name
-	cleanName := strings.ToLower(strings.ReplaceAll(modName, " ", "-"))
-	url := fmt.Sprintf("https://www.curseforge.com/minecraft/mc-mods/%s", cleanName)
-	return url, nil
+	storeModCacheEntry(cachePath, modName, ModCacheEntry{CurseForgeURL: pageURL, LastChecked: time.Now().Unix()})
+	if pageURL == "" {
+		return CurseForgeMinecraftSearchURL(modName), nil
+	}
+	return pageURL, nil
 }
 
 // SearchModOnModrinth searches for a mod on Modrinth
@@ -135,23 +102,12 @@ type ModCache struct {
 func SearchModOnModrinthWithCache(modName string, cachesDir string, loader string, gameVersion string) (string, error) {
 	// First, check our persistent mod cache
 	cachePath := filepath.Join(cachesDir, "modrinth_mods_cache.json")
-	var modCache ModCache
 
 	// Try to load existing cache
-	if cacheData, err := os.ReadFile(cachePath); err == nil {
-		if err := json.Unmarshal(cacheData, &modCache); err != nil {
-			// If cache is corrupted, start fresh
-			modCache = ModCache{Mods: make(map[string]ModCacheEntry)}
-		}
-	} else {
-		modCache = ModCache{Mods: make(map[string]ModCacheEntry)}
-	}
+	modCache := loadModCache(cachePath)
 
 	// Create cache key that includes loader and version for better specificity
-	cacheKey := modName
-	if loader != "" && gameVersion != "" {
-		cacheKey = fmt.Sprintf("%s_%s_%s", modName, loader, gameVersion)
-	}
+	cacheKey := modCacheKey(modName, loader, gameVersion)
 
 	// Check if we have cached result (only if it's recent and has data)
 	if entry, exists := modCache.Mods[cacheKey]; exists {
@@ -176,10 +132,9 @@ func SearchModOnModrinthWithCache(modName string, cachesDir string, loader strin
 	var result ModrinthSearchResult
 	if err := apiCache.Get(&result); err != nil {
 		// Cache empty result to avoid repeated failed searches
-		modCache.Mods[cacheKey] = ModCacheEntry{
+		storeModCacheEntry(cachePath, cacheKey, ModCacheEntry{
 			LastChecked: time.Now().Unix(),
-		}
-		saveModCache(cachePath, modCache)
+		})
 		return "", fmt.Errorf("failed to search Modrinth: %w", err)
 	}
 	// Look for matches with loader/version compatibility check
@@ -218,23 +173,61 @@ func SearchModOnModrinthWithCache(modName string, cachesDir string, loader strin
 	// If no exact match found, leave empty
 
 	// Cache the result
-	modCache.Mods[cacheKey] = ModCacheEntry{
+	storeModCacheEntry(cachePath, cacheKey, ModCacheEntry{
 		ModrinthID:  foundID,
 		LastChecked: time.Now().Unix(),
-	}
-	saveModCache(cachePath, modCache)
+	})
 
 	return foundID, nil
 }
 
-// saveModCache saves the mod cache to disk
-func saveModCache(cachePath string, modCache ModCache) {
+// modCacheMu guards modrinth_mods_cache.json; link lookups run concurrently.
+var modCacheMu sync.Mutex
+
+func modCacheKey(modName, loader, gameVersion string) string {
+	if loader != "" && gameVersion != "" {
+		return fmt.Sprintf("%s_%s_%s", modName, loader, gameVersion)
+	}
+	return modName
+}
+
+// loadModCache reads the mod cache; a missing or corrupted file yields an empty cache.
+func loadModCache(cachePath string) ModCache {
+	modCacheMu.Lock()
+	defer modCacheMu.Unlock()
+	return readModCacheFile(cachePath)
+}
+
+func readModCacheFile(cachePath string) ModCache {
+	modCache := ModCache{Mods: make(map[string]ModCacheEntry)}
+	if cacheData, err := os.ReadFile(cachePath); err == nil {
+		if err := json.Unmarshal(cacheData, &modCache); err != nil || modCache.Mods == nil {
+			modCache = ModCache{Mods: make(map[string]ModCacheEntry)}
+		}
+	}
+	return modCache
+}
+
+// storeModCacheEntry re-reads the cache and writes it back with one entry set, so concurrent
+// lookups do not overwrite each other's results.
+func storeModCacheEntry(cachePath, key string, entry ModCacheEntry) {
+	modCacheMu.Lock()
+	defer modCacheMu.Unlock()
+	modCache := readModCacheFile(cachePath)
+	modCache.Mods[key] = entry
 	if cacheData, err := json.MarshalIndent(modCache, "", "  "); err == nil {
 		os.MkdirAll(filepath.Dir(cachePath), 0755)
 		os.WriteFile(cachePath, cacheData, 0644)
 	}
 }
 
+// CachedModrinthModID returns the Modrinth project id stored for a mod by earlier searches, without
+// any network access and regardless of age ("" when unknown).
+func CachedModrinthModID(modName, cachesDir, loader, gameVersion string) string {
+	modCache := loadModCache(filepath.Join(cachesDir, "modrinth_mods_cache.json"))
+	return modCache.Mods[modCacheKey(modName, loader, gameVersion)].ModrinthID
+}
+
 // ModrinthProject represents the structure of a Modrinth project API response
 type ModrinthProject struct {
 	ID          string   `json:"id"`
@@ -2,6 +2,7 @@ package meta
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -97,21 +98,33 @@ type modrinthSearchAPIResponse struct {
 }
 
 type modrinthVersion struct {
-	ID           string   `json:"id"`
-	GameVersions []string `json:"game_versions"`
-	Loaders      []string `json:"loaders"`
-	Files        []struct {
+	ID            string   `json:"id"`
+	ProjectID     string   `json:"project_id"`
+	Name          string   `json:"name"`
+	DatePublished string   `json:"date_published"`
+	Changelog     string   `json:"changelog"`
+	VersionNumber string   `json:"version_number"`
+	VersionType   string   `json:"version_type"`
+	Downloads     int64    `json:"downloads"`
+	GameVersions  []string `json:"game_versions"`
+	Loaders       []string `json:"loaders"`
+	Files         []struct {
 		URL      string `json:"url"`
 		Filename string `json:"filename"`
 		Primary  bool   `json:"primary"`
+		Hashes   struct {
+			Sha1   string `json:"sha1"`
+			Sha512 string `json:"sha512"`
+		} `json:"hashes"`
 	} `json:"files"`
+	Dependencies []struct {
+		ProjectID      string `json:"project_id"`
+		DependencyType string `json:"dependency_type"` // required | optional | incompatible | embedded
+	} `json:"dependencies"`
 }
 
 type curseForgeFilesResponse struct {
-	Data []struct {
-		ID       int64  `json:"id"`
-		FileName string `json:"fileName"`
-	} `json:"data"`
+	Data []CurseForgeFile `json:"data"`
 }
 
 func httpUserAgent() string {
@@ -187,13 +200,13 @@ func curseForgeSortField(sortName string) int {
 
 func modrinthProjectType(category string) string {
 	switch strings.ToLower(strings.TrimSpace(category)) {
-	case "resourcepacks":
+	case "resourcepacks", "resourcepack":
 		return "resourcepack"
-	case "shaderpacks":
+	case "shaderpacks", "shader", "shaders":
 		return "shader"
-	case "datapacks":
+	case "datapacks", "datapack":
 		return "datapack"
-	case "modpacks":
+	case "modpacks", "modpack":
 		return "modpack"
 	default:
 		return "mod"
@@ -204,6 +217,12 @@ func modrinthIndex(sortName string) string {
 	switch strings.ToLower(strings.TrimSpace(sortName)) {
 	case "downloads":
 		return "downloads"
+	case "updated":
+		return "updated"
+	case "newest":
+		return "newest"
+	case "relevance":
+		return "relevance"
 	default:
 		return "follows"
 	}
@@ -403,30 +422,74 @@ func safeCacheKey(s string) string {
 	return repl.Replace(s)
 }
 
+// ModrinthSearchFilters narrows a Modrinth search; zero values mean "no filter".
+type ModrinthSearchFilters struct {
+	Loader      string   // fabric, forge, neoforge, quilt, …
+	GameVersion string   // e.g. 1.20.1
+	Categories  []string // Modrinth categories, e.g. optimization, storage
+	Sort        string   // downloads | updated | newest | relevance | follows
+	Limit       int      // 1..100, default 20
+	Offset      int
+}
+
 // SearchModrinthStore searches Modrinth with project_type facet.
 func SearchModrinthStore(category, query, sort string, page, pageSize int, cachesDir string) ([]RemoteStoreHit, error) {
-	pt := modrinthProjectType(category)
-	idx := modrinthIndex(sort)
 	offset := 0
 	if page > 0 {
 		offset = page * pageSize
 	}
+	hits, _, err := SearchModrinthStoreFiltered(category, query, ModrinthSearchFilters{Sort: sort, Limit: pageSize, Offset: offset}, cachesDir)
+	return hits, err
+}
+
+// SearchModrinthStoreFiltered searches Modrinth with project type, loader, game version and category facets.
+// It also returns the total number of hits for pagination.
+func SearchModrinthStoreFiltered(category, query string, f ModrinthSearchFilters, cachesDir string) ([]RemoteStoreHit, int, error) {
+	pt := modrinthProjectType(category)
+	idx := modrinthIndex(f.Sort)
+	if f.Limit <= 0 {
+		f.Limit = 20
+	}
+	if f.Limit > 100 {
+		f.Limit = 100
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	// Each inner list is OR-ed, lists are AND-ed.
+	facets := [][]string{{"project_type:" + pt}}
+	if l := strings.ToLower(strings.TrimSpace(f.Loader)); l != "" {
+		facets = append(facets, []string{"categories:" + l})
+	}
+	if gv := strings.TrimSpace(f.GameVersion); gv != "" {
+		facets = append(facets, []string{"versions:" + gv})
+	}
+	for _, c := range f.Categories {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			facets = append(facets, []string{"categories:" + c})
+		}
+	}
+	facetsJSON, err := json.Marshal(facets)
+	if err != nil {
+		return nil, 0, err
+	}
 	uu, err := url.Parse("https://api.modrinth.com/v2/search")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	q := uu.Query()
 	q.Set("query", strings.TrimSpace(query))
-	q.Set("limit", strconv.Itoa(pageSize))
-	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(f.Limit))
+	q.Set("offset", strconv.Itoa(f.Offset))
 	q.Set("index", idx)
-	q.Set("facets", fmt.Sprintf(`[["project_type:%s"]]`, pt))
+	q.Set("facets", string(facetsJSON))
 	uu.RawQuery = q.Encode()
 	if debuglog.Enabled() {
-		debuglog.Printf("Modrinth: SearchModrinthStore category=%q query=%q page=%d pageSize=%d url=%s", category, strings.TrimSpace(query), page, pageSize, uu.String())
+		debuglog.Printf("Modrinth: SearchModrinthStore category=%q query=%q offset=%d limit=%d url=%s", category, strings.TrimSpace(query), f.Offset, f.Limit, uu.String())
 	}
 
-	cachePath := filepath.Join(cachesDir, "modrinth", fmt.Sprintf("store_%s_%s_%s_o%d.json", pt, idx, safeCacheKey(query), offset))
+	filterKey := safeCacheKey(strings.Join([]string{f.Loader, f.GameVersion, strings.Join(f.Categories, "+")}, "_"))
+	cachePath := filepath.Join(cachesDir, "modrinth", fmt.Sprintf("store_%s_%s_%s_%s_o%d_l%d.json", pt, idx, safeCacheKey(query), filterKey, f.Offset, f.Limit))
 	cache := network.Cache[modrinthSearchAPIResponse]{
 		Path:        cachePath,
 		URL:         uu.String(),
@@ -434,7 +497,7 @@ func SearchModrinthStore(category, query, sort string, page, pageSize int, cache
 	}
 	var raw modrinthSearchAPIResponse
 	if err := cache.Get(&raw); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	var out []RemoteStoreHit
 	for _, h := range raw.Hits {
@@ -445,11 +508,11 @@ func SearchModrinthStore(category, query, sort string, page, pageSize int, cache
 			Title:     h.Title,
 			Summary:   trimSummary(h.Description, 220),
 			IconURL:   h.IconURL,
-			PageURL:   "https://modrinth.com/" + modrinthPathSegment(category) + "/" + h.Slug,
+			PageURL:   "https://modrinth.com/" + pt + "/" + h.Slug,
 			Downloads: h.Downloads,
 		})
 	}
-	return out, nil
+	return out, raw.TotalHits, nil
 }
 
 func modrinthPathSegment(category string) string {
@@ -700,110 +763,92 @@ func mrVersionListsLoader(v modrinthVersion, want []string) bool {
 }
 
 func pickModrinthFile(v modrinthVersion) (fileURL, filename string) {
-	for _, f := range v.Files {
+	if i := pickModrinthFileIndex(v); i >= 0 {
+		return v.Files[i].URL, v.Files[i].Filename
+	}
+	return "", ""
+}
+
+func pickModrinthFileIndex(v modrinthVersion) int {
+	for i, f := range v.Files {
 		if f.Primary && f.URL != "" {
-			return f.URL, f.Filename
+			return i
 		}
 	}
-	for _, f := range v.Files {
+	for i, f := range v.Files {
 		if f.URL != "" {
-			return f.URL, f.Filename
+			return i
 		}
 	}
-	return "", ""
+	return -1
 }
 
-// DownloadModrinthProjectTo writes the best-matching primary file into destDir using the remote file name.
+// ErrNoCompatibleVersion matches errors of projects that have no version for the instance's game version/loader.
+var ErrNoCompatibleVersion = errors.New("no compatible version")
+
+// noCompatibleVersionError keeps the user-facing message while matching ErrNoCompatibleVersion.
+type noCompatibleVersionError string
+
+func (e noCompatibleVersionError) Error() string      { return string(e) }
+func (noCompatibleVersionError) Is(target error) bool { return target == ErrNoCompatibleVersion }
+
+// pickModrinthVersion chooses the newest version matching the instance (versions are newest-first from the API).
 // category: mods и modpacks — фильтр по gameVersion и загрузчику инстанса; остальное — в основном по версии игры.
-func DownloadModrinthProjectTo(projectSlug, gameVersion, loader, category, destDir string) (savedPath string, err error) {
-	projectSlug = strings.TrimSpace(projectSlug)
-	if projectSlug == "" {
-		return "", fmt.Errorf("empty Modrinth project")
-	}
-	u := "https://api.modrinth.com/v2/project/" + url.PathEscape(projectSlug) + "/version"
-	var versions []modrinthVersion
-	if err := httpGetJSON(u, nil, &versions); err != nil {
-		return "", err
-	}
+func pickModrinthVersion(versions []modrinthVersion, projectSlug, gameVersion, loader, category string) (*modrinthVersion, error) {
 	if len(versions) == 0 {
-		return "", fmt.Errorf("no versions for project %s", projectSlug)
+		return nil, fmt.Errorf("no versions for project %s", projectSlug)
 	}
 	gameVersion = strings.TrimSpace(gameVersion)
 	loader = strings.TrimSpace(loader)
 	filterLoader := remoteStoreCategoryUsesModLoader(category)
 	loaders := normalizeModrinthLoaders(loader)
 
-	var chosen *modrinthVersion
 	switch {
 	case filterLoader && len(loaders) > 0 && gameVersion != "":
 		for i := range versions {
-			v := &versions[i]
-			if mrVersionListsGame(*v, gameVersion) && mrVersionListsLoader(*v, loaders) {
-				chosen = v
-				break
+			if mrVersionListsGame(versions[i], gameVersion) && mrVersionListsLoader(versions[i], loaders) {
+				return &versions[i], nil
 			}
 		}
-		if chosen == nil {
-			return "", fmt.Errorf("на Modrinth нет сборки для Minecraft %s и загрузчика %s (проект %s)", gameVersion, loader, projectSlug)
-		}
+		return nil, noCompatibleVersionError(fmt.Sprintf("на Modrinth нет сборки для Minecraft %s и загрузчика %s (проект %s)", gameVersion, loader, projectSlug))
 	case filterLoader && len(loaders) > 0 && gameVersion == "":
-		return "", fmt.Errorf("в инстансе не указана версия Minecraft — нужна для выбора файла мода на Modrinth")
-	case filterLoader && len(loaders) == 0:
-		if gameVersion == "" {
-			return "", fmt.Errorf("в инстансе не указаны версия Minecraft или поддерживаемый загрузчик для мода с Modrinth")
-		}
-		for i := range versions {
-			v := &versions[i]
-			if mrVersionListsGame(*v, gameVersion) {
-				chosen = v
-				break
-			}
-		}
-		if chosen == nil {
-			return "", fmt.Errorf("на Modrinth нет файла для Minecraft %s (проект %s)", gameVersion, projectSlug)
-		}
+		return nil, fmt.Errorf("в инстансе не указана версия Minecraft — нужна для выбора файла мода на Modrinth")
+	case filterLoader && len(loaders) == 0 && gameVersion == "":
+		return nil, fmt.Errorf("в инстансе не указаны версия Minecraft или поддерживаемый загрузчик для мода с Modrinth")
 	case gameVersion != "":
 		for i := range versions {
-			v := &versions[i]
-			if mrVersionListsGame(*v, gameVersion) {
-				chosen = v
-				break
+			if mrVersionListsGame(versions[i], gameVersion) {
+				return &versions[i], nil
 			}
 		}
-		if chosen == nil {
-			return "", fmt.Errorf("на Modrinth нет файла для Minecraft %s (проект %s)", gameVersion, projectSlug)
-		}
+		return nil, noCompatibleVersionError(fmt.Sprintf("на Modrinth нет файла для Minecraft %s (проект %s)", gameVersion, projectSlug))
 	default:
-		chosen = &versions[0]
-	}
-	fileURL, fname := pickModrinthFile(*chosen)
-	if fileURL == "" {
-		return "", fmt.Errorf("no downloadable file for %s", projectSlug)
-	}
-	if fname == "" {
-		fname = projectSlug + "-download"
-	}
-	destPath := filepath.Join(destDir, filepath.Base(fname))
-	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return "", err
+		return &versions[0], nil
 	}
-	if err := network.DownloadFile(network.DownloadEntry{URL: fileURL, Path: destPath}); err != nil {
+}
+
+// DownloadModrinthProjectTo writes the best-matching primary file into destDir using the remote file name.
+// category: mods и modpacks — фильтр по gameVersion и загрузчику инстанса; остальное — в основном по версии игры.
+func DownloadModrinthProjectTo(projectSlug, gameVersion, loader, category, destDir string) (savedPath string, err error) {
+	f, err := ResolveModrinthFile(projectSlug, gameVersion, loader, category)
+	if err != nil {
 		return "", err
 	}
-	return destPath, nil
+	return DownloadModrinthFile(f, destDir)
 }
 
 // DownloadCurseForgeProjectTo выбирает файл по версии игры и загрузчику инстанса (API CurseForge).
 // category: mods и modpacks — передаётся modLoaderType вместе с gameVersion.
-func DownloadCurseForgeProjectTo(modIDStr, gameVersion, loader, category, apiKey, destDir string) (savedPath string, err error) {
+// Returns the chosen file with DownloadURL set to the URL it was downloaded from (for mods.lock).
+func DownloadCurseForgeProjectTo(modIDStr, gameVersion, loader, category, apiKey, destDir string) (savedPath string, file CurseForgeFile, err error) {
 	modID, err := strconv.ParseInt(strings.TrimSpace(modIDStr), 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("curseforge mod id: %w", err)
+		return "", CurseForgeFile{}, fmt.Errorf("curseforge mod id: %w", err)
 	}
 	gameVersion = strings.TrimSpace(gameVersion)
 	useLoader := remoteStoreCategoryUsesModLoader(category)
 	if useLoader && gameVersion == "" {
-		return "", fmt.Errorf("в инстансе не указана версия Minecraft — нужна для выбора файла на CurseForge")
+		return "", CurseForgeFile{}, fmt.Errorf("в инстансе не указана версия Minecraft — нужна для выбора файла на CurseForge")
 	}
 	mlt := curseForgeModLoaderType(loader)
 	if debuglog.Enabled() {
@@ -817,7 +862,7 @@ func DownloadCurseForgeProjectTo(modIDStr, gameVersion, loader, category, apiKey
 			k = CurseForgeAPIKey()
 		}
 		if k == "" {
-			return "", fmt.Errorf("не удалось загрузить файл с CurseForge")
+			return "", CurseForgeFile{}, fmt.Errorf("не удалось загрузить файл с CurseForge")
 		}
 
 		listing, err1 := fetchCurseForgeModFiles(modID, gameVersion, mlt, useLoader, k)
@@ -826,16 +871,16 @@ func DownloadCurseForgeProjectTo(modIDStr, gameVersion, loader, category, apiKey
 			if attempt == 0 && strings.HasPrefix(err1.Error(), "HTTP 403:") {
 				continue
 			}
-			return "", curseForgeKey403Hint(err1)
+			return "", CurseForgeFile{}, curseForgeKey403Hint(err1)
 		}
 		if len(listing.Data) == 0 {
 			if useLoader && gameVersion != "" && mlt > 0 {
-				return "", fmt.Errorf("на CurseForge нет файла для Minecraft %s и загрузчика %s (проект %d)", gameVersion, strings.TrimSpace(loader), modID)
+				return "", CurseForgeFile{}, fmt.Errorf("на CurseForge нет файла для Minecraft %s и загрузчика %s (проект %d)", gameVersion, strings.TrimSpace(loader), modID)
 			}
 			if gameVersion != "" {
-				return "", fmt.Errorf("на CurseForge нет файла для Minecraft %s (проект %d)", gameVersion, modID)
+				return "", CurseForgeFile{}, fmt.Errorf("на CurseForge нет файла для Minecraft %s (проект %d)", gameVersion, modID)
 			}
-			return "", fmt.Errorf("нет файлов на CurseForge для проекта %d", modID)
+			return "", CurseForgeFile{}, fmt.Errorf("нет файлов на CurseForge для проекта %d", modID)
 		}
 		fileID := listing.Data[0].ID
 		baseName := listing.Data[0].FileName
@@ -851,22 +896,24 @@ func DownloadCurseForgeProjectTo(modIDStr, gameVersion, loader, category, apiKey
 			if attempt == 0 && strings.HasPrefix(err2.Error(), "HTTP 403:") {
 				continue
 			}
-			return "", curseForgeKey403Hint(err2)
+			return "", CurseForgeFile{}, curseForgeKey403Hint(err2)
 		}
 		if dlPayload.Data == "" {
-			return "", fmt.Errorf("пустой download-url от CurseForge")
+			return "", CurseForgeFile{}, fmt.Errorf("пустой download-url от CurseForge")
 		}
 		if err := os.MkdirAll(destDir, 0755); err != nil {
-			return "", err
+			return "", CurseForgeFile{}, err
 		}
 		destPath := filepath.Join(destDir, filepath.Base(baseName))
-		if err := network.DownloadFile(network.DownloadEntry{URL: dlPayload.Data, Path: destPath}); err != nil {
-			return "", err
+		if err := network.DownloadFileCached(network.DownloadEntry{URL: dlPayload.Data, Path: destPath, Sha1: listing.Data[0].Sha1()}); err != nil {
+			return "", CurseForgeFile{}, err
 		}
-		return destPath, nil
+		file = listing.Data[0]
+		file.DownloadURL = dlPayload.Data
+		return destPath, file, nil
 	}
 	if lastErr != nil {
-		return "", curseForgeKey403Hint(lastErr)
+		return "", CurseForgeFile{}, curseForgeKey403Hint(lastErr)
 	}
-	return "", fmt.Errorf("не удалось загрузить файл с CurseForge")
+	return "", CurseForgeFile{}, fmt.Errorf("не удалось загрузить файл с CurseForge")
 }
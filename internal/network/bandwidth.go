@@ -0,0 +1,111 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bandwidth is a token bucket shared by every limited download, so parallel transfers together stay
+// under the limit.
+var bandwidth struct {
+	sync.Mutex
+	rate   int64 // bytes per second, 0 = unlimited
+	tokens float64
+	last   time.Time
+}
+
+// SetBandwidthLimit limits downloads (cloud sync, game files, Java, launcher updates) to bytesPerSec.
+// 0 removes the limit.
+func SetBandwidthLimit(bytesPerSec int64) {
+	bandwidth.Lock()
+	defer bandwidth.Unlock()
+	if bytesPerSec < 0 {
+		bytesPerSec = 0
+	}
+	bandwidth.rate = bytesPerSec
+	bandwidth.tokens = float64(bytesPerSec)
+	bandwidth.last = time.Now()
+}
+
+// BandwidthLimit returns the download limit in bytes per second (0 = unlimited).
+func BandwidthLimit() int64 {
+	bandwidth.Lock()
+	defer bandwidth.Unlock()
+	return bandwidth.rate
+}
+
+// takeBandwidth accounts for n received bytes and sleeps as long as the bucket is in debt.
+func takeBandwidth(n int) {
+	bandwidth.Lock()
+	rate := bandwidth.rate
+	if rate == 0 {
+		bandwidth.Unlock()
+		return
+	}
+	now := time.Now()
+	bandwidth.tokens += now.Sub(bandwidth.last).Seconds() * float64(rate)
+	if bandwidth.tokens > float64(rate) {
+		bandwidth.tokens = float64(rate) // burst of at most one second
+	}
+	bandwidth.last = now
+	bandwidth.tokens -= float64(n)
+	wait := time.Duration(0)
+	if bandwidth.tokens < 0 {
+		wait = time.Duration(-bandwidth.tokens / float64(rate) * float64(time.Second))
+	}
+	bandwidth.Unlock()
+	time.Sleep(wait)
+}
+
+type limitedReader struct {
+	r io.Reader
+}
+
+func (l limitedReader) Read(p []byte) (int, error) {
+	// Small reads keep the transfer smooth instead of bursting a whole buffer then stalling
+	if rate := BandwidthLimit(); rate > 0 {
+		chunk := int(max(rate/20, 4<<10))
+		if len(p) > chunk {
+			p = p[:chunk]
+		}
+	}
+	n, err := l.r.Read(p)
+	if n > 0 {
+		takeBandwidth(n)
+	}
+	return n, err
+}
+
+// LimitBandwidth wraps a download body so reads honour the global bandwidth limit.
+func LimitBandwidth(r io.Reader) io.Reader {
+	return limitedReader{r: r}
+}
+
+// ParseBandwidth parses a limit like "5MB/s", "500KB/s", "2M" or "1048576" (bytes per second; KB and MB
+// are 1024-based). "", "0" and "off" mean unlimited.
+func ParseBandwidth(raw string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" || s == "0" || s == "off" || s == "unlimited" {
+		return 0, nil
+	}
+	s = strings.TrimSuffix(s, "/s")
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "ib"), "b")
+	mult := float64(1)
+	switch {
+	case strings.HasSuffix(s, "g"):
+		mult, s = 1<<30, strings.TrimSuffix(s, "g")
+	case strings.HasSuffix(s, "m"):
+		mult, s = 1<<20, strings.TrimSuffix(s, "m")
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1<<10, strings.TrimSuffix(s, "k")
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid bandwidth limit %q (e.g. 5MB/s)", raw)
+	}
+	return int64(v * mult), nil
+}
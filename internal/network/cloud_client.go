@@ -0,0 +1,106 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CloudRetry is how a CloudClient retries transient failures: connection errors and 429/502/503/504
+// responses, with exponential backoff and full jitter.
+type CloudRetry struct {
+	Attempts  int           // total tries, including the first
+	BaseDelay time.Duration // delay before the first retry, doubled for each further one
+	MaxDelay  time.Duration // cap for one delay, also for Retry-After
+}
+
+// DefaultCloudRetry is used by the shared QMServer Cloud clients.
+var DefaultCloudRetry = CloudRetry{Attempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+// CloudClient sends QMServer Cloud requests through a shared HTTP client and retries transient failures.
+// Waiting between tries stops as soon as the request's context is cancelled.
+type CloudClient struct {
+	HTTP  *http.Client
+	Retry CloudRetry
+}
+
+var (
+	// QMServerCloud is the client for QMServer Cloud API calls (servers, manifests, status).
+	QMServerCloud = &CloudClient{HTTP: QMServerHTTPClient, Retry: DefaultCloudRetry}
+	// QMServerCloudDownload is the client for cloud sync files and uploads (no overall deadline).
+	QMServerCloudDownload = &CloudClient{HTTP: QMServerDownloadHTTPClient, Retry: DefaultCloudRetry}
+)
+
+// retryableStatus reports whether a response status is worth another try.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry n (1-based): a random duration up to BaseDelay*2^(n-1), capped
+// by MaxDelay. A Retry-After from the server takes precedence when it is not longer than MaxDelay.
+func (r CloudRetry) backoff(n int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			if d := time.Duration(secs) * time.Second; d <= r.MaxDelay {
+				return d
+			}
+		}
+	}
+	d := r.BaseDelay << (n - 1)
+	if d <= 0 || d > r.MaxDelay {
+		d = r.MaxDelay
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// Do sends req, retrying transient failures. Requests with a body are only retried when the body can be
+// recreated (req.GetBody, set by http.NewRequest for in-memory bodies).
+func (c *CloudClient) Do(req *http.Request) (*http.Response, error) {
+	attempts := max(c.Retry.Attempts, 1)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+	ctx := req.Context()
+	for n := 1; ; n++ {
+		try := req
+		if n > 1 {
+			try = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				try.Body = body
+			}
+		}
+		resp, err := c.HTTP.Do(try)
+		transient := false
+		switch {
+		case err != nil:
+			transient = !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+		case retryableStatus(resp.StatusCode):
+			transient = true
+		}
+		if !transient || n >= attempts {
+			return resp, err
+		}
+		delay := c.Retry.backoff(n, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
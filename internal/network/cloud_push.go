@@ -0,0 +1,82 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// qmserverUploadURL is the upload API URL of a file of a server profile's data directory.
+func qmserverUploadURL(qmHost string, qmPort int, serverID uint, rel string) string {
+	parts := strings.Split(rel, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return fmt.Sprintf("%s/api/v1/upload/%d/files/%s", QMServerBaseURL(qmHost, qmPort), serverID, strings.Join(parts, "/"))
+}
+
+// doQMServerUpload sends an authorized upload API request and turns error responses into errors.
+func doQMServerUpload(req *http.Request, bearerToken string) error {
+	SetQMServerAuth(req, bearerToken)
+	resp, err := QMServerCloudDownload.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := QMServerAuthError(resp); err != nil {
+		return err
+	}
+	if msg := strings.TrimSpace(ReadQMServerError(resp)); msg != "" {
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, msg)
+	}
+	return fmt.Errorf("%s %s: HTTP %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+// UploadServerFile uploads localPath as rel (slash-separated, e.g. "mods/jei.jar") into the data
+// directory of a QMServer server profile. sha256Hex lets the server verify the upload.
+func UploadServerFile(qmHost string, qmPort int, bearerToken string, serverID uint, rel, localPath, sha256Hex string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, qmserverUploadURL(qmHost, qmPort, serverID, rel), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = st.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Content-SHA256", sha256Hex)
+	return doQMServerUpload(req, bearerToken)
+}
+
+// DeleteServerFile removes rel from the data directory of a QMServer server profile.
+func DeleteServerFile(qmHost string, qmPort int, bearerToken string, serverID uint, rel string) error {
+	req, err := http.NewRequest(http.MethodDelete, qmserverUploadURL(qmHost, qmPort, serverID, rel), nil)
+	if err != nil {
+		return err
+	}
+	return doQMServerUpload(req, bearerToken)
+}
+
+// RegenerateServerManifest asks QMServer to rebuild the data manifest of a server profile after uploads,
+// so clients pick up the new files on their next sync.
+func RegenerateServerManifest(qmHost string, qmPort int, bearerToken string, serverID uint) error {
+	u := fmt.Sprintf("%s/api/v1/upload/%d/manifest", QMServerBaseURL(qmHost, qmPort), serverID)
+	req, err := http.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return err
+	}
+	return doQMServerUpload(req, bearerToken)
+}
@@ -0,0 +1,96 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CloudStatus is the state of the QMServer Cloud API as seen from this machine.
+type CloudStatus struct {
+	APIBase    string `json:"apiBase"`
+	Reachable  bool   `json:"reachable"`
+	LatencyMS  int64  `json:"latencyMs"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Auth       string `json:"auth"` // none | valid | expired | forbidden | unknown
+	Account    string `json:"account,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Checked    int64  `json:"checked"` // unix seconds
+}
+
+var apiVersionPathRe = regexp.MustCompile(`/api/(v\d+)(?:/|$)`)
+
+// cloudAPIVersion reads the API version QMServer reports in its headers, falling back to the version in
+// the API base path (".../api/v1" -> "v1").
+func cloudAPIVersion(resp *http.Response, apiBase string) string {
+	for _, h := range []string{"X-API-Version", "X-QMServer-Version"} {
+		if v := strings.TrimSpace(resp.Header.Get(h)); v != "" {
+			return v
+		}
+	}
+	if m := apiVersionPathRe.FindStringSubmatch(apiBase); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// CheckCloudStatus measures one round-trip to the effective QMServer Cloud API (/auth/me) and reports
+// reachability, latency, API version and whether token is accepted. An empty token only checks the API.
+func CheckCloudStatus(token string) CloudStatus {
+	base := EffectiveQMServerAPIBase()
+	st := CloudStatus{APIBase: base, Auth: "none", Checked: time.Now().Unix()}
+	req, err := http.NewRequest(http.MethodGet, base+"/auth/me", nil)
+	if err != nil {
+		st.Error = err.Error()
+		return st
+	}
+	SetQMServerAuth(req, token)
+	start := time.Now()
+	resp, err := QMServerHTTPClient.Do(req) // A single try, so the latency is one round-trip
+	st.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		st.Error = err.Error()
+		if token != "" {
+			st.Auth = "unknown"
+		}
+		return st
+	}
+	defer resp.Body.Close()
+	st.HTTPStatus = resp.StatusCode
+	st.APIVersion = cloudAPIVersion(resp, base)
+	if resp.StatusCode >= 500 {
+		st.Error = CheckResponse(resp).Error()
+		if token != "" {
+			st.Auth = "unknown"
+		}
+		return st
+	}
+	st.Reachable = true
+	if token == "" {
+		return st
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		st.Auth = "valid"
+		var me struct {
+			Email    string `json:"email"`
+			Username string `json:"username"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&me) == nil {
+			st.Account = strings.TrimSpace(me.Username)
+			if st.Account == "" {
+				st.Account = strings.TrimSpace(me.Email)
+			}
+		}
+	case http.StatusUnauthorized:
+		st.Auth = "expired"
+	case http.StatusForbidden:
+		st.Auth = "forbidden"
+	default:
+		st.Auth = "unknown"
+	}
+	return st
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// AcceptCompressed is the Accept-Encoding sent with QMServer downloads. The transport only negotiates
+// gzip by itself for requests without a Range header and hides the encoding, so downloads set it
+// explicitly and decode with DecodedBody.
+const AcceptCompressed = "zstd, gzip, deflate"
+
+// DecodedBody returns the response body with its Content-Encoding (zstd, gzip, deflate) removed. The
+// caller closes the returned reader instead of resp.Body.
+func DecodedBody(resp *http.Response) (io.ReadCloser, error) {
+	switch enc := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); enc {
+	case "", "identity":
+		return resp.Body, nil
+	case "zstd":
+		zr, err := zstd.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("zstd response: %w", err)
+		}
+		return readCloser{Reader: zr, close: func() error { zr.Close(); return resp.Body.Close() }}, nil
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gzip response: %w", err)
+		}
+		return readCloser{Reader: zr, close: func() error { zr.Close(); return resp.Body.Close() }}, nil
+	case "deflate":
+		// "deflate" is zlib-wrapped per RFC 9110, but some servers send raw deflate
+		br := bufio.NewReader(resp.Body)
+		var fr io.ReadCloser
+		if hdr, err := br.Peek(2); err == nil && isZlibHeader(hdr) {
+			if fr, err = zlib.NewReader(br); err != nil {
+				return nil, fmt.Errorf("deflate response: %w", err)
+			}
+		} else {
+			fr = flate.NewReader(br)
+		}
+		return readCloser{Reader: fr, close: func() error { fr.Close(); return resp.Body.Close() }}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Content-Encoding %q", enc)
+	}
+}
+
+// isZlibHeader reports whether hdr starts a zlib stream: deflate compression method and a valid header
+// checksum.
+func isZlibHeader(hdr []byte) bool {
+	return len(hdr) >= 2 && hdr[0]&0x0f == 8 && (uint16(hdr[0])<<8|uint16(hdr[1]))%31 == 0
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r readCloser) Close() error { return r.close() }
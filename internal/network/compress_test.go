@@ -0,0 +1,47 @@
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecodedBody(t *testing.T) {
+	const want = "qmlauncher compressed response body"
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		io.WriteString(w, want)
+		w.Close()
+		return buf.Bytes()
+	}
+	tests := []struct {
+		enc  string
+		body []byte
+	}{
+		{"", []byte(want)},
+		{"gzip", compress(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })},
+		{"deflate", compress(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })},
+		{"deflate", compress(func(w io.Writer) io.WriteCloser { fw, _ := flate.NewWriter(w, flate.DefaultCompression); return fw })},
+		{"zstd", compress(func(w io.Writer) io.WriteCloser { zw, _ := zstd.NewWriter(w); return zw })},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(tt.body))}
+		resp.Header.Set("Content-Encoding", tt.enc)
+		body, err := DecodedBody(resp)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.enc, err)
+		}
+		got, err := io.ReadAll(body)
+		body.Close()
+		if err != nil || string(got) != want {
+			t.Fatalf("%q: got %q, %v", tt.enc, got, err)
+		}
+	}
+}
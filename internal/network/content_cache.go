@@ -0,0 +1,109 @@
+package network
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	env "QMLauncher/pkg"
+)
+
+// contentPath is the location of a file with the given SHA-1 in the shared content store.
+func contentPath(sha1Hex string) string {
+	return filepath.Join(env.ContentDir, sha1Hex[:2], sha1Hex)
+}
+
+func fileSHA1(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DownloadFileCached is DownloadFile backed by the shared content store (env.ContentDir): a file whose
+// SHA-1 is already stored is hard-linked (or copied) into place instead of downloaded, and new downloads
+// are added to the store. Entries without a SHA-1 are downloaded directly.
+func DownloadFileCached(entry DownloadEntry) error {
+	sum := strings.ToLower(strings.TrimSpace(entry.Sha1))
+	if len(sum) != 40 || env.ContentDir == "" {
+		return DownloadFile(entry)
+	}
+	entry.Sha1 = sum
+	stored := contentPath(sum)
+	if got, err := fileSHA1(stored); err != nil || got != sum {
+		_ = os.Remove(stored)
+		tmp := stored + ".part"
+		if err := DownloadFile(DownloadEntry{URL: entry.URL, Path: tmp, Sha1: sum}); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+		if err := os.Rename(tmp, stored); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	return linkOrCopy(stored, entry.Path)
+}
+
+// AddToContentStore adds an existing file to the shared content store and returns its SHA-1.
+func AddToContentStore(path string) (string, error) {
+	sum, err := fileSHA1(path)
+	if err != nil {
+		return "", err
+	}
+	stored := contentPath(sum)
+	if got, err := fileSHA1(stored); err == nil && got == sum {
+		return sum, nil
+	}
+	return sum, linkOrCopy(path, stored)
+}
+
+// LinkFromContentStore places the stored file with the given SHA-1 at dest; it fails when the
+// store does not hold a valid copy.
+func LinkFromContentStore(sha1Hex, dest string) error {
+	sum := strings.ToLower(strings.TrimSpace(sha1Hex))
+	if len(sum) != 40 || env.ContentDir == "" {
+		return fmt.Errorf("no stored copy")
+	}
+	stored := contentPath(sum)
+	if got, err := fileSHA1(stored); err != nil || got != sum {
+		return fmt.Errorf("no stored copy of %s", sum)
+	}
+	return linkOrCopy(stored, dest)
+}
+
+// linkOrCopy hard-links src to dst, falling back to a copy across file systems.
+func linkOrCopy(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("create directory for file %q: %w", dst, err)
+	}
+	_ = os.Remove(dst)
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
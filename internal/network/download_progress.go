@@ -0,0 +1,73 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// progressReader reports the number of bytes read so far.
+type progressReader struct {
+	r     io.Reader
+	done  int64
+	total int64
+	fn    func(done, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	if p.fn != nil && n > 0 {
+		p.fn(p.done, p.total)
+	}
+	return n, err
+}
+
+// DownloadWithProgress downloads url to path, calling progress with the bytes received and the expected size
+// (-1 when unknown). When sha256Hex is set, the file is verified and removed on mismatch.
+func DownloadWithProgress(url, path, sha256Hex string, progress func(done, total int64)) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := HTTPClientLongDownload.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := CheckResponse(resp); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for file %q: %w", path, err)
+	}
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file %q: %w", path, err)
+	}
+
+	hash := sha256.New()
+	body := &progressReader{r: io.TeeReader(LimitBandwidth(resp.Body), hash), total: resp.ContentLength, fn: progress}
+	if _, err := io.Copy(out, body); err != nil {
+		out.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	if want := strings.ToLower(strings.TrimSpace(sha256Hex)); want != "" {
+		if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+			_ = os.Remove(path)
+			return fmt.Errorf("invalid checksum from %q: expected sha256 %s, got %s: %w", url, want, got, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
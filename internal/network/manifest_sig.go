@@ -0,0 +1,148 @@
+package network
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	env "QMLauncher/pkg"
+)
+
+// ManifestSignatureHeader carries the detached ed25519 signature of a data manifest (base64 of the
+// signature over the exact response body).
+const ManifestSignatureHeader = "X-Manifest-Signature"
+
+var (
+	// ErrManifestUnsigned is returned when a server profile publishes a manifest key but the manifest
+	// came without a signature.
+	ErrManifestUnsigned = errors.New("data manifest is not signed")
+	// ErrManifestSignature is returned when a manifest signature does not match the server profile's key.
+	ErrManifestSignature = errors.New("data manifest signature does not match the server key")
+	// ErrManifestKeyChanged is returned when a server profile publishes a manifest key other than the one
+	// trusted on first use, until the player confirms the new key.
+	ErrManifestKeyChanged = errors.New("server manifest key changed since it was first trusted")
+	// ErrManifestRollback is returned for a manifest generated before the one already synced.
+	ErrManifestRollback = errors.New("data manifest is older than the one already synced")
+)
+
+// decodeBase64 accepts standard and URL-safe base64, with or without padding.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "=")
+	if b, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// ParseManifestKey decodes a server profile's manifest key: base64 of a 32-byte ed25519 public key,
+// optionally prefixed with "ed25519:".
+func ParseManifestKey(key string) (ed25519.PublicKey, error) {
+	raw, err := decodeBase64(strings.TrimPrefix(strings.TrimSpace(key), "ed25519:"))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 manifest key %q", key)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// VerifyManifestSignature checks the detached signature of a manifest body against key (see
+// ParseManifestKey).
+func VerifyManifestSignature(body []byte, signature, key string) error {
+	pub, err := ParseManifestKey(key)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(signature) == "" {
+		return ErrManifestUnsigned
+	}
+	sig, err := decodeBase64(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize || !ed25519.Verify(pub, body, sig) {
+		return ErrManifestSignature
+	}
+	return nil
+}
+
+// ServerManifestKey returns the manifest key a server profile publishes in the /servers list of the
+// QMServer at apiBase (".../api/v1", see GetQMServersListAt), or "" when the profile has none or the list
+// is unavailable.
+func ServerManifestKey(apiBase string, serverID uint) string {
+	resp, err := GetQMServersListAt(apiBase)
+	if err != nil {
+		return ""
+	}
+	for _, s := range resp.ServerProfiles {
+		if s.ID == serverID {
+			return strings.TrimSpace(s.ManifestKey)
+		}
+	}
+	return ""
+}
+
+// ResolveManifestKey picks the key a manifest must be signed with from the key the server list publishes
+// and the key pinned on first use. Once a key is pinned it stays required, also when the list stops
+// publishing one; a different published key is refused with ErrManifestKeyChanged.
+func ResolveManifestKey(published, pinned string) (string, error) {
+	published, pinned = strings.TrimSpace(published), strings.TrimSpace(pinned)
+	switch {
+	case pinned == "":
+		return published, nil
+	case published == "" || published == pinned:
+		return pinned, nil
+	}
+	return "", fmt.Errorf("%w: trusted %s, server now publishes %s", ErrManifestKeyChanged, pinned, published)
+}
+
+// manifestKeyPinsMu guards manifest_keys.json.
+var manifestKeyPinsMu sync.Mutex
+
+func manifestKeyPinsPath() string {
+	return filepath.Join(env.RootDir, "manifest_keys.json")
+}
+
+func readManifestKeyPins() map[string]string {
+	pins := map[string]string{}
+	if data, err := os.ReadFile(manifestKeyPinsPath()); err == nil {
+		_ = json.Unmarshal(data, &pins)
+	}
+	if pins == nil {
+		pins = map[string]string{}
+	}
+	return pins
+}
+
+// ManifestKeyPinID names a server profile on a QMServer for the manifest key pins.
+func ManifestKeyPinID(host string, port int, serverID uint) string {
+	return fmt.Sprintf("%s:%d/%d", strings.ToLower(strings.TrimSpace(host)), port, serverID)
+}
+
+// PinnedManifestKey returns the manifest key trusted for a server profile (see ManifestKeyPinID), or "".
+func PinnedManifestKey(id string) string {
+	manifestKeyPinsMu.Lock()
+	defer manifestKeyPinsMu.Unlock()
+	return readManifestKeyPins()[id]
+}
+
+// PinManifestKey trusts key for a server profile's manifests from now on; "" forgets the pin.
+func PinManifestKey(id, key string) error {
+	manifestKeyPinsMu.Lock()
+	defer manifestKeyPinsMu.Unlock()
+	pins := readManifestKeyPins()
+	if key = strings.TrimSpace(key); key == "" {
+		delete(pins, id)
+	} else {
+		pins[id] = key
+	}
+	data, err := json.MarshalIndent(pins, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(manifestKeyPinsPath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(manifestKeyPinsPath(), data, 0600)
+}
@@ -0,0 +1,134 @@
+package network
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	env "QMLauncher/pkg"
+)
+
+func TestVerifyManifestSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	body := []byte(`{"server_id":1,"files":[],"generated":1760000000}`)
+	sig := ed25519.Sign(priv, body)
+	key := base64.StdEncoding.EncodeToString(pub)
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		key       string
+		want      error
+	}{
+		{"valid", body, base64.StdEncoding.EncodeToString(sig), key, nil},
+		{"url-safe unpadded", body, base64.RawURLEncoding.EncodeToString(sig), "ed25519:" + base64.RawURLEncoding.EncodeToString(pub), nil},
+		{"unsigned", body, "", key, ErrManifestUnsigned},
+		{"tampered body", []byte(`{"server_id":2,"files":[],"generated":1760000000}`), base64.StdEncoding.EncodeToString(sig), key, ErrManifestSignature},
+		{"other key", body, base64.StdEncoding.EncodeToString(sig), base64.StdEncoding.EncodeToString(otherPub), ErrManifestSignature},
+		{"garbage signature", body, "not base64!", key, ErrManifestSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyManifestSignature(tt.body, tt.signature, tt.key); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyManifestSignature = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if err := VerifyManifestSignature(body, base64.StdEncoding.EncodeToString(sig), "c2hvcnQ="); err == nil {
+		t.Fatal("VerifyManifestSignature accepted a short key")
+	}
+}
+
+func TestResolveManifestKey(t *testing.T) {
+	tests := []struct {
+		published, pinned, want string
+		err                     error
+	}{
+		{"", "", "", nil},
+		{"A", "", "A", nil},
+		{"A", "A", "A", nil},
+		{"", "A", "A", nil},
+		{"B", "A", "", ErrManifestKeyChanged},
+	}
+	for _, tt := range tests {
+		got, err := ResolveManifestKey(tt.published, tt.pinned)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ResolveManifestKey(%q, %q) = %q, %v; want %q, %v", tt.published, tt.pinned, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestPinManifestKey(t *testing.T) {
+	old := env.RootDir
+	env.RootDir = t.TempDir()
+	t.Cleanup(func() { env.RootDir = old })
+
+	id := ManifestKeyPinID("Cloud.Example.org", 443, 7)
+	if got := PinnedManifestKey(id); got != "" {
+		t.Fatalf("PinnedManifestKey before pinning = %q", got)
+	}
+	if err := PinManifestKey(id, "A"); err != nil {
+		t.Fatal(err)
+	}
+	if got := PinnedManifestKey(ManifestKeyPinID("cloud.example.org", 443, 7)); got != "A" {
+		t.Fatalf("PinnedManifestKey = %q, want A", got)
+	}
+	if got := PinnedManifestKey(ManifestKeyPinID("cloud.example.org", 443, 8)); got != "" {
+		t.Fatalf("PinnedManifestKey of another server = %q", got)
+	}
+	if err := PinManifestKey(id, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := PinnedManifestKey(id); got != "" {
+		t.Fatalf("PinnedManifestKey after forgetting = %q", got)
+	}
+}
+
+func TestServerManifestKeyUsesEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"count":2,"server_profiles":[{"id":7,"manifest_key":" self-hosted-key "},{"id":8}]}`)
+	}))
+	defer srv.Close()
+	defer InvalidateServersCache()
+
+	base := srv.URL + "/api/v1/"
+	if key := ServerManifestKey(base, 7); key != "self-hosted-key" {
+		t.Fatalf("ServerManifestKey(7) = %q, want self-hosted-key", key)
+	}
+	if key := ServerManifestKey(base, 8); key != "" {
+		t.Fatalf("ServerManifestKey(8) = %q, want none", key)
+	}
+	if key := ServerManifestKey(base, 9); key != "" {
+		t.Fatalf("ServerManifestKey(9) = %q, want none", key)
+	}
+}
+
+func TestSameAPIBase(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://api.example.com/api/v1", "https://api.example.com:443/api/v1/", true},
+		{"http://example.com:80/api/v1", "http://example.com/api/v1", true},
+		{"https://example.com:8443/api/v1", "https://example.com/api/v1", false},
+		{"https://a.example.com/api/v1", "https://b.example.com/api/v1", false},
+	}
+	for _, tt := range tests {
+		if got := sameAPIBase(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameAPIBase(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -39,10 +41,7 @@ func (t *qmserverTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	return t.rt.RoundTrip(req2)
 }
 
-var qmserverBaseHTTPTransport http.RoundTripper = &http.Transport{
-	Proxy:               http.ProxyFromEnvironment,
-	TLSHandshakeTimeout: 30 * time.Second,
-}
+var qmserverBaseHTTPTransport = &qmserverSwitchTransport{rt: newQMServerHTTPTransport(nil)}
 
 // QMServerHTTPClient is the HTTP client for QMServer API (with proper User-Agent).
 // When Debug mode is enabled in launcher settings, requests/responses are traced to *_debug.log.
@@ -53,6 +52,12 @@ var QMServerHTTPClient = &http.Client{
 	},
 }
 
+// QMServerDownloadHTTPClient is QMServerHTTPClient without an overall deadline, for cloud sync files
+// (shaderpacks and modpacks can take far longer than 45 seconds).
+var QMServerDownloadHTTPClient = &http.Client{
+	Transport: QMServerHTTPClient.Transport,
+}
+
 var externalHTTPTransport http.RoundTripper = &http.Transport{
 	Proxy:               http.ProxyFromEnvironment,
 	TLSHandshakeTimeout: 30 * time.Second,
@@ -76,6 +81,9 @@ var HTTPClientLongDownload = HTTPClientForExternal(0)
 
 const MaxConcurrentDownloads = 6
 
+// ErrChecksumMismatch is wrapped by download errors when the received file does not match the expected hash.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 type DownloadEntry struct {
 	URL      string
 	Path     string
@@ -115,7 +123,7 @@ func DownloadFile(entry DownloadEntry) error {
 	}
 
 	hash := sha1.New()
-	tee := io.TeeReader(resp.Body, hash)
+	tee := io.TeeReader(LimitBandwidth(resp.Body), hash)
 
 	if _, err := io.Copy(out, tee); err != nil {
 		return err
@@ -123,7 +131,7 @@ func DownloadFile(entry DownloadEntry) error {
 
 	if entry.Sha1 != "" {
 		if hex.EncodeToString(hash.Sum(nil)) != entry.Sha1 {
-			return fmt.Errorf("invalid checksum from %q", entry.URL)
+			return fmt.Errorf("invalid checksum from %q: %w", entry.URL, ErrChecksumMismatch)
 		}
 	}
 
@@ -215,6 +223,7 @@ type QMServerInfo struct {
 	GameServerOnline *bool  `json:"game_server_online,omitempty"`
 	CreatedAt        string `json:"created_at"`
 	UpdatedAt        string `json:"updated_at"`
+	ManifestKey      string `json:"manifest_key,omitempty"` // ed25519 public key signing data.json, base64
 }
 
 // ErrServerProfileDisabled is returned when a game server profile exists but is turned off in QMAdmin.
@@ -298,13 +307,9 @@ func EffectiveQMServerAPIBase() string {
 	return DefaultQMServerAPIBase()
 }
 
-// QMServerBaseURL returns the base URL for a QMServer host:port (uses https for port 443)
+// QMServerBaseURL returns the base URL for a QMServer host:port (uses https for port 443 or when HTTPS is enforced)
 func QMServerBaseURL(host string, port int) string {
-	scheme := "http"
-	if port == 443 {
-		scheme = "https"
-	}
-	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
+	return fmt.Sprintf("%s://%s", QMServerScheme(port), net.JoinHostPort(host, strconv.Itoa(port)))
 }
 
 const (
@@ -353,24 +358,12 @@ func GetQMServersList() (*QMServersResponse, error) {
 	}
 	serversCacheMu.RUnlock()
 
-	// 2. Fetch from API (with retries for transient errors: unexpected EOF, connection reset)
-	const maxRetries = 3
-	var resp *http.Response
-	var err error
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(time.Duration(attempt) * time.Second)
-		}
-		resp, err = QMServerHTTPClient.Get(url)
-		if err == nil {
-			break
-		}
-		errStr := err.Error()
-		if !strings.Contains(errStr, "unexpected EOF") &&
-			!strings.Contains(errStr, "connection reset") {
-			break
-		}
+	// 2. Fetch from API (transient errors are retried with backoff)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
 	}
+	resp, err := QMServerCloud.Do(req)
 	if err != nil {
 		if disk := loadServersFromDisk(); disk != nil {
 			keepMinecraftLauncherServers(disk)
@@ -413,6 +406,69 @@ func GetQMServersList() (*QMServersResponse, error) {
 	return &serversResponse, nil
 }
 
+var (
+	endpointServersMu    sync.Mutex
+	endpointServers      = map[string]*QMServersResponse{}
+	endpointServersTimes = map[string]time.Time{}
+)
+
+// sameAPIBase compares two API base URLs, ignoring a trailing slash and the scheme's default port.
+func sameAPIBase(a, b string) bool {
+	norm := func(s string) string {
+		s = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(s), "/"))
+		if u, err := url.Parse(s); err == nil && u.Host != "" {
+			if p := u.Port(); p == "443" && u.Scheme == "https" || p == "80" && u.Scheme == "http" {
+				u.Host = u.Hostname()
+			}
+			return u.String()
+		}
+		return s
+	}
+	return norm(a) == norm(b)
+}
+
+// GetQMServersListAt fetches the server list of the QMServer at base (".../api/v1"). The launcher-wide API
+// base goes through GetQMServersList; other endpoints (self-hosted, cloud profiles) are cached in memory.
+func GetQMServersListAt(base string) (*QMServersResponse, error) {
+	base = strings.TrimSuffix(strings.TrimSpace(base), "/")
+	if base == "" || sameAPIBase(base, EffectiveQMServerAPIBase()) {
+		return GetQMServersList()
+	}
+	endpointServersMu.Lock()
+	if cached, ok := endpointServers[base]; ok && time.Since(endpointServersTimes[base]) < serversCacheTTL {
+		endpointServersMu.Unlock()
+		return cached, nil
+	}
+	endpointServersMu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, base+"/servers", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := QMServerCloud.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to QMServer: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if msg := ReadQMServerError(resp); msg != "" {
+			return nil, fmt.Errorf("QMServer does not serve QMLauncher: %s", msg)
+		}
+		return nil, fmt.Errorf("QMServer returned status %d", resp.StatusCode)
+	}
+	var servers QMServersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&servers); err != nil {
+		return nil, fmt.Errorf("failed to parse servers list: %w", err)
+	}
+	keepMinecraftLauncherServers(&servers)
+
+	endpointServersMu.Lock()
+	endpointServers[base] = &servers
+	endpointServersTimes[base] = time.Now()
+	endpointServersMu.Unlock()
+	return &servers, nil
+}
+
 func serversCachePath() string {
 	return filepath.Join(env.RootDir, serversCacheFile)
 }
@@ -457,6 +513,10 @@ func InvalidateServersCache() {
 	serversCacheMu.Lock()
 	serversCache = nil
 	serversCacheMu.Unlock()
+	endpointServersMu.Lock()
+	clear(endpointServers)
+	clear(endpointServersTimes)
+	endpointServersMu.Unlock()
 }
 
 // MSAServerSettings is the QMServer public response for Microsoft auth in the launcher.
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrQMServerUnauthorized is returned when QMServer rejects a request for a missing or expired token.
+	ErrQMServerUnauthorized = errors.New("QMServer Cloud sign-in required")
+	// ErrQMServerForbidden is returned when the signed-in account may not access the resource.
+	ErrQMServerForbidden = errors.New("QMServer Cloud access denied")
+)
+
+// SetQMServerAuth adds the cloud token to a QMServer request. Empty tokens leave it anonymous.
+func SetQMServerAuth(req *http.Request, bearerToken string) {
+	if token := strings.TrimSpace(bearerToken); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// QMServerAuthError turns 401 and 403 responses into errors that say what to do; other statuses
+// return nil. It reads the error body, so call it before reading the response otherwise.
+func QMServerAuthError(resp *http.Response) error {
+	var base error
+	var hint string
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		base, hint = ErrQMServerUnauthorized, "log in to QMServer Cloud again or set a new API token"
+	case http.StatusForbidden:
+		base, hint = ErrQMServerForbidden, "this account has no access here (premium servers need an active subscription, publishing needs admin rights on the server)"
+	default:
+		return nil
+	}
+	if msg := strings.TrimSpace(ReadQMServerError(resp)); msg != "" {
+		return fmt.Errorf("%w: %s (%s)", base, hint, msg)
+	}
+	return fmt.Errorf("%w: %s", base, hint)
+}
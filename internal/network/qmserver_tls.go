@@ -0,0 +1,178 @@
+package network
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrQMServerPinMismatch is returned when a QMServer certificate matches none of the configured pins.
+var ErrQMServerPinMismatch = errors.New("QMServer certificate does not match any pinned key")
+
+// QMServerTLS configures TLS for QMServer Cloud traffic, which delivers executable mod files.
+type QMServerTLS struct {
+	// HTTPS uses https for every QMServer host (not only port 443) and refuses plain http requests.
+	HTTPS bool `json:"https"`
+	// CABundle is a PEM file of additional trusted CAs (self-hosted QMServer with a private CA).
+	CABundle string `json:"ca_bundle,omitempty"`
+	// Pins are "sha256/<base64>" hashes of a SubjectPublicKeyInfo or hex SHA-256 certificate
+	// fingerprints; the server chain must contain at least one of them.
+	Pins []string `json:"pins,omitempty"`
+}
+
+// qmserverPin is a parsed pin: a public key (SPKI) or a whole certificate hash.
+type qmserverPin struct {
+	spki bool
+	hash [sha256.Size]byte
+}
+
+// parseQMServerPin accepts "sha256/<base64 SPKI hash>" or a hex certificate fingerprint (colons allowed).
+func parseQMServerPin(raw string) (qmserverPin, error) {
+	raw = strings.TrimSpace(raw)
+	var pin qmserverPin
+	var data []byte
+	var err error
+	if b64, ok := strings.CutPrefix(raw, "sha256/"); ok {
+		pin.spki = true
+		data, err = base64.StdEncoding.DecodeString(b64)
+	} else {
+		data, err = hex.DecodeString(strings.ReplaceAll(raw, ":", ""))
+	}
+	if err != nil || len(data) != sha256.Size {
+		return qmserverPin{}, fmt.Errorf("invalid pin %q: expected sha256/<base64> or a SHA-256 fingerprint", raw)
+	}
+	copy(pin.hash[:], data)
+	return pin, nil
+}
+
+func (p qmserverPin) matches(cert *x509.Certificate) bool {
+	if p.spki {
+		return sha256.Sum256(cert.RawSubjectPublicKeyInfo) == p.hash
+	}
+	return sha256.Sum256(cert.Raw) == p.hash
+}
+
+// ValidateQMServerTLS checks that the CA bundle can be loaded and the pins parse.
+func ValidateQMServerTLS(cfg QMServerTLS) error {
+	_, err := qmserverTLSConfig(cfg)
+	return err
+}
+
+func qmserverTLSConfig(cfg QMServerTLS) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if path := strings.TrimSpace(cfg.CABundle); path != "" {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %q contains no PEM certificates", path)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	var pins []qmserverPin
+	for _, raw := range cfg.Pins {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		pin, err := parseQMServerPin(raw)
+		if err != nil {
+			return nil, err
+		}
+		pins = append(pins, pin)
+	}
+	if len(pins) > 0 {
+		// Runs after the regular chain verification, so pins narrow trust and never replace it
+		tlsCfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			for _, cert := range cs.PeerCertificates {
+				for _, pin := range pins {
+					if pin.matches(cert) {
+						return nil
+					}
+				}
+			}
+			return fmt.Errorf("%s: %w", cs.ServerName, ErrQMServerPinMismatch)
+		}
+	}
+	return tlsCfg, nil
+}
+
+// qmserverSwitchTransport lets ConfigureQMServerTLS replace the transport of QMServerHTTPClient.
+type qmserverSwitchTransport struct {
+	mu    sync.RWMutex
+	rt    http.RoundTripper
+	https bool
+}
+
+func (t *qmserverSwitchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	rt, https := t.rt, t.https
+	t.mu.RUnlock()
+	if https && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("QMServer request to %s refused: HTTPS is required", req.URL.Redacted())
+	}
+	return rt.RoundTrip(req)
+}
+
+func newQMServerHTTPTransport(tlsCfg *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSHandshakeTimeout: 30 * time.Second,
+		TLSClientConfig:     tlsCfg,
+	}
+}
+
+var qmserverTLSState struct {
+	sync.RWMutex
+	cfg QMServerTLS
+}
+
+// ConfigureQMServerTLS applies cfg to QMServerHTTPClient. Idle connections made with the previous
+// settings are closed so the new CA bundle and pins apply to the next request.
+func ConfigureQMServerTLS(cfg QMServerTLS) error {
+	tlsCfg, err := qmserverTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
+	qmserverBaseHTTPTransport.mu.Lock()
+	old := qmserverBaseHTTPTransport.rt
+	qmserverBaseHTTPTransport.rt = newQMServerHTTPTransport(tlsCfg)
+	qmserverBaseHTTPTransport.https = cfg.HTTPS
+	qmserverBaseHTTPTransport.mu.Unlock()
+	if t, ok := old.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	qmserverTLSState.Lock()
+	qmserverTLSState.cfg = cfg
+	qmserverTLSState.Unlock()
+	return nil
+}
+
+// CurrentQMServerTLS returns the settings last applied with ConfigureQMServerTLS.
+func CurrentQMServerTLS() QMServerTLS {
+	qmserverTLSState.RLock()
+	defer qmserverTLSState.RUnlock()
+	return qmserverTLSState.cfg
+}
+
+// QMServerScheme returns the scheme for a QMServer host:port: https on port 443 or when HTTPS is
+// enforced, http otherwise.
+func QMServerScheme(port int) string {
+	if port == 443 || CurrentQMServerTLS().HTTPS {
+		return "https"
+	}
+	return "http"
+}
@@ -21,11 +21,7 @@ func UploadLocalSkinsToQMServer(instanceDir, qmHost string, qmPort int, bearerTo
 		logFn = func(s string) { log.Print(s) }
 	}
 
-	scheme := "http"
-	if qmPort == 443 {
-		scheme = "https"
-	}
-	apiBase := fmt.Sprintf("%s://%s:%d/api/v1", scheme, qmHost, qmPort)
+	apiBase := QMServerBaseURL(qmHost, qmPort) + "/api/v1"
 
 	uploaded := 0
 	for _, subdir := range []string{"skins", "capes", "elytras"} {
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AliasPrefix marks an account reference as an alias (e.g. "@main").
+const AliasPrefix = "@"
+
+var aliasNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ErrUnknownAlias is returned by ResolveAccount for an "@alias" that is not defined.
+var ErrUnknownAlias = errors.New("unknown account alias")
+
+// accountAliases maps alias name -> account identifier (username, UUID or cloud email); guarded by vaultMu.
+var accountAliases map[string]string
+
+// AccountAlias is one alias entry as shown in the UI.
+type AccountAlias struct {
+	Alias   string `json:"alias"`
+	Target  string `json:"target"`
+	Account string `json:"account"` // resolved account name; empty if the target no longer exists
+}
+
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(alias), AliasPrefix))
+}
+
+// SetAlias binds alias to an account identified by username, UUID or QMServer Cloud email.
+func SetAlias(alias, target string) error {
+	alias = normalizeAlias(alias)
+	target = strings.TrimSpace(target)
+	if !aliasNameRe.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q: use 1-32 lowercase letters, digits, '-' or '_'", alias)
+	}
+	if target == "" {
+		return fmt.Errorf("alias %q: empty account", alias)
+	}
+	if strings.HasPrefix(target, AliasPrefix) {
+		return fmt.Errorf("alias %q cannot point to another alias", alias)
+	}
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+	if accountAliases == nil {
+		accountAliases = map[string]string{}
+	}
+	accountAliases[alias] = target
+	return writeVaultLocked()
+}
+
+// RemoveAlias deletes an alias; removing a missing alias is not an error.
+func RemoveAlias(alias string) error {
+	alias = normalizeAlias(alias)
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+	if _, ok := accountAliases[alias]; !ok {
+		return nil
+	}
+	delete(accountAliases, alias)
+	return writeVaultLocked()
+}
+
+// Aliases returns all aliases sorted by name, with their resolved account names.
+func Aliases() []AccountAlias {
+	vaultMu.Lock()
+	out := make([]AccountAlias, 0, len(accountAliases))
+	for alias, target := range accountAliases {
+		out = append(out, AccountAlias{Alias: alias, Target: target})
+	}
+	vaultMu.Unlock()
+	for i := range out {
+		out[i].Account = accountNameFor(out[i].Target)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Alias < out[j].Alias })
+	return out
+}
+
+// ResolveAccount turns "@alias" into the account name used for launch; other values are returned unchanged.
+// An alias of a QMServer Cloud account resolves to its email, which launch accepts for the account's first
+// game account.
+func ResolveAccount(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !strings.HasPrefix(ref, AliasPrefix) {
+		return ref, nil
+	}
+	alias := normalizeAlias(ref)
+	vaultMu.Lock()
+	target, ok := accountAliases[alias]
+	vaultMu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownAlias, AliasPrefix+alias)
+	}
+	if name := accountNameFor(target); name != "" {
+		return name, nil
+	}
+	// Not a stored account (e.g. a QMServer Cloud game account name): let the caller match it.
+	return target, nil
+}
+
+// accountNameFor maps a username, UUID or cloud email to the account name, or "" if none matches.
+func accountNameFor(target string) string {
+	uuidKey := func(s string) string { return strings.ToLower(strings.ReplaceAll(s, "-", "")) }
+	if Store.MSA.RefreshToken != "" && Store.Minecraft.Username != "" {
+		if strings.EqualFold(Store.Minecraft.Username, target) || (Store.Minecraft.UUID != "" && uuidKey(Store.Minecraft.UUID) == uuidKey(target)) {
+			return Store.Minecraft.Username
+		}
+	}
+	for _, l := range LocalStore.Accounts {
+		if l.Name == target || (l.UUID != "" && uuidKey(l.UUID) == uuidKey(target)) {
+			return l.Name
+		}
+	}
+	if c, _ := ReadCloudStore(); c != nil {
+		for _, acc := range c.Accounts {
+			if normalizeEmail(acc.Email) == normalizeEmail(target) {
+				return acc.Email
+			}
+		}
+	}
+	return ""
+}
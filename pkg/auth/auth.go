@@ -3,7 +3,8 @@ package auth
 
 import (
 	"bytes"
-	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -51,6 +52,11 @@ type Session struct {
 //
 // Used for the OAuth2 authorization code grant
 func AuthCodeURL() *url.URL {
+	return AuthCodeURLWithState("")
+}
+
+// AuthCodeURLWithState is AuthCodeURL with an OAuth2 state value that the callback must echo back.
+func AuthCodeURLWithState(state string) *url.URL {
 	query := url.Values{
 		"client_id":     {ClientID},
 		"response_type": {"code"},
@@ -58,11 +64,23 @@ func AuthCodeURL() *url.URL {
 		"scope":         {scope},
 		"response_mode": {"query"},
 	}
+	if state != "" {
+		query.Set("state", state)
+	}
 	uri, _ := url.Parse("https://login.microsoftonline.com/consumers/oauth2/v2.0/authorize")
 	uri.RawQuery = query.Encode()
 	return uri
 }
 
+// NewAuthState returns a random OAuth2 state value (protects the local callback from forged requests).
+func NewAuthState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate OAuth state: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // A deviceCodeResponse contains information about device codes to be entered by the user to complete authentication, when they expire, and how often they should be polled for.
 type deviceCodeResponse struct {
 	DeviceCode      string `json:"device_code"`
@@ -296,32 +314,47 @@ func authenticateMinecraft(xstsToken string, userhash string) (minecraftResponse
 		return minecraftResponse{}, minecraftProfile{}, err
 	}
 
+	profile, err := fetchMinecraftProfile(data.AccessToken)
+	if err != nil {
+		return minecraftResponse{}, minecraftProfile{}, err
+	}
+	return data, profile, nil
+}
+
+// fetchMinecraftProfile returns the Java Edition player profile, or ErrNoMinecraftProfile when there is none.
+func fetchMinecraftProfile(accessToken string) (minecraftProfile, error) {
 	profReq, _ := http.NewRequest("GET", "https://api.minecraftservices.com/minecraft/profile", nil)
-	profReq.Header.Add("Authorization", "Bearer "+data.AccessToken)
+	profReq.Header.Add("Authorization", "Bearer "+accessToken)
 	profReq.Header.Set("Accept", "application/json")
 	profResp, err := authHTTP.Do(profReq)
 	if err != nil {
-		return minecraftResponse{}, minecraftProfile{}, err
+		return minecraftProfile{}, err
 	}
 	defer profResp.Body.Close()
+	if profResp.StatusCode == http.StatusNotFound {
+		return minecraftProfile{}, ErrNoMinecraftProfile
+	}
 	profBody, err := io.ReadAll(profResp.Body)
 	if err != nil {
-		return minecraftResponse{}, minecraftProfile{}, err
+		return minecraftProfile{}, err
 	}
 	var profile minecraftProfile
 	if err := json.Unmarshal(profBody, &profile); err != nil {
-		return minecraftResponse{}, minecraftProfile{}, err
+		return minecraftProfile{}, err
 	}
 	if err := network.CheckResponse(profResp); err != nil {
 		if profile.ErrorMessage != "" {
-			return minecraftResponse{}, minecraftProfile{}, errors.New(profile.ErrorMessage)
+			return minecraftProfile{}, errors.New(profile.ErrorMessage)
 		}
 		if profile.Error != "" {
-			return minecraftResponse{}, minecraftProfile{}, errors.New(profile.Error)
+			return minecraftProfile{}, errors.New(profile.Error)
 		}
-		return minecraftResponse{}, minecraftProfile{}, err
+		return minecraftProfile{}, err
 	}
-	return data, profile, nil
+	if profile.ID == "" {
+		return minecraftProfile{}, ErrNoMinecraftProfile
+	}
+	return profile, nil
 }
 
 var ErrNoAccount = errors.New("no account found")
@@ -361,52 +394,6 @@ func Authenticate() (Session, error) {
 	}, nil
 }
 
-// AuthenticateWithRedirect authenticates using the OAuth2 Code flow.
-//
-// success is a string to be shown to the user upon successful authentication.
-// fail is shown if an authentication error occurs.
-//
-// This function blocks until a response has been received on the local authentication server.
-func AuthenticateWithRedirect(success, fail string) (Session, error) {
-	var code string
-	var err error
-
-	port := RedirectURI.Port()
-	if port == "" {
-		return Session{}, fmt.Errorf("redirect URL must have port specified")
-	}
-	server := &http.Server{Addr: ":" + port, Handler: nil}
-	http.HandleFunc(RedirectURI.Path, func(w http.ResponseWriter, req *http.Request) {
-		params := req.URL.Query()
-		if params.Get("error") != "" {
-			fmt.Fprint(w, fail+"\n"+params.Get("error_description"))
-			err = fmt.Errorf("got error: %s", params.Get("error_description"))
-		} else {
-			fmt.Fprint(w, success)
-		}
-		code = params.Get("code")
-		go server.Shutdown(context.Background())
-	})
-	server.ListenAndServe()
-	if err != nil {
-		return Session{}, err
-	}
-
-	resp, err := authenticateMSA(url.Values{
-		"client_id":    {ClientID},
-		"scope":        {scope},
-		"redirect_uri": {RedirectURI.String()},
-		"grant_type":   {"authorization_code"},
-		"code":         {code},
-	})
-	if err != nil {
-		return Session{}, fmt.Errorf("authenticate with MSA: %w", err)
-	}
-	Store.MSA.write(resp)
-
-	return Authenticate()
-}
-
 // AuthenticateWithCode authenticates with a device code.
 //
 // This function blocks until the user has been authenticated, or another error has occurred.
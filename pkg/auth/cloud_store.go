@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"maps"
 	"strings"
 )
 
@@ -15,6 +16,8 @@ type CloudAccount struct {
 type CloudStore struct {
 	Accounts []CloudAccount `json:"accounts"`
 	Default  string         `json:"default"`
+	// Profiles holds the account signed in to each named cloud profile (another QMServer endpoint)
+	Profiles map[string]CloudAccount `json:"profiles,omitempty"`
 }
 
 // ReadCloudStore returns a snapshot of cloud accounts from the encrypted vault.
@@ -26,6 +29,7 @@ func ReadCloudStore() (*CloudStore, error) {
 		c.Accounts = []CloudAccount{}
 	}
 	out := c
+	out.Profiles = maps.Clone(c.Profiles)
 	return &out, nil
 }
 
@@ -135,6 +139,46 @@ func RemoveCloudAccount(email string) error {
 	return writeVaultLocked()
 }
 
+// SetCloudProfileAccount stores the account used for a cloud profile, replacing the previous one.
+func SetCloudProfileAccount(profile, token, email, username string) error {
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+	email = normalizeEmail(email)
+	if username == "" {
+		username = emailToUsername(email)
+	}
+	if cloudPersisted.Profiles == nil {
+		cloudPersisted.Profiles = map[string]CloudAccount{}
+	}
+	cloudPersisted.Profiles[profile] = CloudAccount{Token: token, Email: email, Username: username}
+	return writeVaultLocked()
+}
+
+// GetCloudProfileAccount returns the account of a cloud profile, or the default cloud account for "".
+func GetCloudProfileAccount(profile string) *CloudAccount {
+	if profile == "" {
+		return GetDefaultCloudAccount()
+	}
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+	c, ok := cloudPersisted.Profiles[profile]
+	if !ok {
+		return nil
+	}
+	return &c
+}
+
+// RemoveCloudProfileAccount signs a cloud profile out.
+func RemoveCloudProfileAccount(profile string) error {
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+	if _, ok := cloudPersisted.Profiles[profile]; !ok {
+		return nil
+	}
+	delete(cloudPersisted.Profiles, profile)
+	return writeVaultLocked()
+}
+
 func normalizeEmail(e string) string {
 	return strings.TrimSpace(strings.ToLower(e))
 }
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	exportMagic      = "QMLX"
+	exportVersion    = byte(1)
+	exportIterations = 600_000
+)
+
+// ErrExportPassphrase is returned when an export/import passphrase is empty or does not decrypt the file.
+var ErrExportPassphrase = errors.New("wrong or empty passphrase")
+
+// ImportSummary reports what ImportCredentials merged into the vault.
+type ImportSummary struct {
+	Microsoft bool `json:"microsoft"` // Microsoft session replaced
+	Local     int  `json:"local"`     // offline accounts added
+	Cloud     int  `json:"cloud"`     // QMServer Cloud accounts added or updated
+}
+
+// Unlike the vault (bound to this machine), exports are keyed by a user passphrase so they can be moved.
+func deriveExportKey(passphrase string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, exportIterations, 32)
+}
+
+// ExportCredentials writes all stored accounts to path, encrypted with passphrase (AES-GCM, PBKDF2-SHA256).
+func ExportCredentials(path, passphrase string) error {
+	if passphrase == "" {
+		return ErrExportPassphrase
+	}
+	vaultMu.Lock()
+	payload := credentialsPayload{
+		Version:   1,
+		Microsoft: Store,
+		Local:     LocalStore,
+		Cloud:     cloudPersisted,
+		Aliases:   accountAliases,
+	}
+	vaultMu.Unlock()
+	plain, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+	key, err := deriveExportKey(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	header := append([]byte(exportMagic), exportVersion)
+	ct := gcm.Seal(nil, nonce, plain, header)
+
+	out := make([]byte, 0, len(header)+len(salt)+len(nonce)+len(ct))
+	out = append(out, header...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	out = append(out, ct...)
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, out, 0600)
+}
+
+// ImportCredentials decrypts an export made by ExportCredentials and merges it into the vault.
+// The Microsoft session is replaced when the file has one; offline accounts are added by name and
+// QMServer Cloud accounts by email. Existing offline accounts with the same name are kept.
+func ImportCredentials(path, passphrase string) (ImportSummary, error) {
+	if passphrase == "" {
+		return ImportSummary{}, ErrExportPassphrase
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	hdrLen := len(exportMagic) + 1
+	if len(raw) < hdrLen+16+12 || string(raw[:len(exportMagic)]) != exportMagic {
+		return ImportSummary{}, errors.New("not a QMLauncher account export")
+	}
+	if raw[len(exportMagic)] != exportVersion {
+		return ImportSummary{}, fmt.Errorf("unsupported export version %d", raw[len(exportMagic)])
+	}
+	header := raw[:hdrLen]
+	salt := raw[hdrLen : hdrLen+16]
+	nonce := raw[hdrLen+16 : hdrLen+16+12]
+	ct := raw[hdrLen+16+12:]
+
+	key, err := deriveExportKey(passphrase, salt)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	plain, err := gcm.Open(nil, nonce, ct, header)
+	if err != nil {
+		return ImportSummary{}, ErrExportPassphrase
+	}
+	var payload credentialsPayload
+	if err := json.Unmarshal(plain, &payload); err != nil {
+		return ImportSummary{}, fmt.Errorf("parse export json: %w", err)
+	}
+
+	vaultMu.Lock()
+	defer vaultMu.Unlock()
+
+	var summary ImportSummary
+	if payload.Microsoft.MSA.RefreshToken != "" {
+		Store = payload.Microsoft
+		summary.Microsoft = true
+	}
+
+	for _, in := range payload.Local.Accounts {
+		if strings.TrimSpace(in.Name) == "" {
+			continue
+		}
+		exists := false
+		for _, have := range LocalStore.Accounts {
+			if have.Name == in.Name {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		LocalStore.Accounts = append(LocalStore.Accounts, in)
+		summary.Local++
+	}
+	if LocalStore.DefaultAccount == "" && len(LocalStore.Accounts) > 0 {
+		// The exported default may have been skipped (blank name); fall back to the first account.
+		LocalStore.DefaultAccount = LocalStore.Accounts[0].Name
+		for _, have := range LocalStore.Accounts {
+			if have.Name == payload.Local.DefaultAccount {
+				LocalStore.DefaultAccount = have.Name
+				break
+			}
+		}
+	}
+
+	for _, in := range payload.Cloud.Accounts {
+		if in.Token == "" || normalizeEmail(in.Email) == "" {
+			continue
+		}
+		found := false
+		for i := range cloudPersisted.Accounts {
+			if normalizeEmail(cloudPersisted.Accounts[i].Email) == normalizeEmail(in.Email) {
+				cloudPersisted.Accounts[i] = in
+				found = true
+				break
+			}
+		}
+		if !found {
+			cloudPersisted.Accounts = append(cloudPersisted.Accounts, in)
+		}
+		summary.Cloud++
+	}
+	if cloudPersisted.Default == "" && len(cloudPersisted.Accounts) > 0 {
+		cloudPersisted.Default = normalizeEmail(cloudPersisted.Accounts[0].Email)
+	}
+	for profile, in := range payload.Cloud.Profiles {
+		if in.Token == "" {
+			continue
+		}
+		if cloudPersisted.Profiles == nil {
+			cloudPersisted.Profiles = map[string]CloudAccount{}
+		}
+		cloudPersisted.Profiles[profile] = in
+		summary.Cloud++
+	}
+
+	for alias, target := range payload.Aliases {
+		if accountAliases == nil {
+			accountAliases = map[string]string{}
+		}
+		if _, exists := accountAliases[alias]; !exists {
+			accountAliases[alias] = target
+		}
+	}
+
+	normalizeLoadedLocalAccountsLocked()
+	if err := writeVaultLocked(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
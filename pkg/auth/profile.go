@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"QMLauncher/internal/network"
+)
+
+// ErrNoMinecraftProfile is returned when a Microsoft account has no Minecraft: Java Edition profile
+// (the game is not owned, or a player name was never chosen on minecraft.net).
+var ErrNoMinecraftProfile = errors.New("this Microsoft account has no Minecraft: Java Edition profile (game not owned or player name not set)")
+
+// Profile is the verified Minecraft identity of the signed-in Microsoft account.
+type Profile struct {
+	Username     string    `json:"username"`
+	UUID         string    `json:"uuid"`
+	Entitlements []string  `json:"entitlements"`
+	OwnsGame     bool      `json:"ownsGame"`
+	CheckedAt    time.Time `json:"checkedAt"`
+
+	// OwnershipKnown is false when the entitlements could not be read; OwnsGame is then false too.
+	OwnershipKnown bool `json:"ownershipKnown"`
+}
+
+type entitlementsResponse struct {
+	Items []struct {
+		Name string `json:"name"`
+	} `json:"items"`
+}
+
+// fetchEntitlements returns the Minecraft store entitlement names (e.g. "game_minecraft") for the token.
+// Game Pass accounts may have none here yet still own a profile.
+func fetchEntitlements(accessToken string) ([]string, error) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.minecraftservices.com/entitlements/mcstore", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	resp, err := authHTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := network.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var data entitlementsResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(data.Items))
+	for _, it := range data.Items {
+		if it.Name != "" {
+			names = append(names, it.Name)
+		}
+	}
+	return names, nil
+}
+
+// hasJavaEntitlement reports whether the entitlements include Minecraft: Java Edition, bought or through
+// PC Game Pass.
+func hasJavaEntitlement(names []string) bool {
+	for _, n := range names {
+		switch n {
+		case "game_minecraft", "product_minecraft", "product_game_pass_pc", "product_game_pass_ultimate":
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyProfile re-reads the player profile and entitlements for the signed-in Microsoft account,
+// stores them and returns them. It returns ErrNoMinecraftProfile if the account cannot play.
+func VerifyProfile() (Profile, error) {
+	if _, err := Authenticate(); err != nil {
+		return Profile{}, err
+	}
+	token := Store.Minecraft.AccessToken
+	profile, err := fetchMinecraftProfile(token)
+	if err != nil {
+		return Profile{}, err
+	}
+	ents, err := fetchEntitlements(token)
+	if err != nil {
+		return Profile{}, fmt.Errorf("fetch entitlements: %w", err)
+	}
+
+	Store.Minecraft.Username = profile.Name
+	Store.Minecraft.UUID = profile.ID
+	Store.Minecraft.Entitlements = ents
+	Store.Minecraft.ProfileCheckedAt = time.Now()
+	if err := Store.WriteToCache(); err != nil {
+		return Profile{}, fmt.Errorf("write auth store: %w", err)
+	}
+	return Profile{
+		Username:       profile.Name,
+		UUID:           profile.ID,
+		Entitlements:   ents,
+		OwnsGame:       hasJavaEntitlement(ents),
+		CheckedAt:      Store.Minecraft.ProfileCheckedAt,
+		OwnershipKnown: true,
+	}, nil
+}
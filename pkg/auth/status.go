@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"time"
+)
+
+// AccountStatus is one stored account in a machine-readable auth snapshot.
+type AccountStatus struct {
+	Type      string     `json:"type"` // "microsoft", "local", "cloud"
+	Name      string     `json:"name"`
+	UUID      string     `json:"uuid,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	Status    string     `json:"status"` // "active", "expired"
+	IsDefault bool       `json:"isDefault"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Refreshable is true when an expired Microsoft session can be renewed without a new login.
+	Refreshable bool `json:"refreshable"`
+	// LoginExpiresAt is when the saved Microsoft sign-in itself runs out (a new login is needed after it).
+	LoginExpiresAt *time.Time `json:"loginExpiresAt,omitempty"`
+	// Entitlements are the Minecraft store entitlements recorded by VerifyProfile (Microsoft only).
+	Entitlements []string `json:"entitlements,omitempty"`
+}
+
+// StatusReport is the full auth state (accounts, expiry, default) for GUI frontends and scripts.
+type StatusReport struct {
+	GeneratedAt            time.Time       `json:"generatedAt"`
+	MicrosoftAuthAvailable bool            `json:"microsoftAuthAvailable"`
+	Default                string          `json:"default"` // "<type>:<name>" of the account used for launch, or ""
+	Accounts               []AccountStatus `json:"accounts"`
+}
+
+// Status returns a snapshot of all stored accounts. Tokens are never included.
+func Status() StatusReport {
+	now := time.Now()
+	report := StatusReport{
+		GeneratedAt:            now,
+		MicrosoftAuthAvailable: IsMicrosoftAuthConfigured(),
+		Accounts:               []AccountStatus{},
+	}
+
+	if Store.MSA.RefreshToken != "" {
+		status := "expired"
+		if Store.Minecraft.Username != "" && Store.Minecraft.Expires.After(now) {
+			status = "active"
+		}
+		acc := AccountStatus{
+			Type:         "microsoft",
+			Name:         Store.Minecraft.Username,
+			UUID:         Store.Minecraft.UUID,
+			Status:       status,
+			IsDefault:    true, // Microsoft account is always default if exists
+			Refreshable:  true,
+			Entitlements: Store.Minecraft.Entitlements,
+		}
+		if !Store.Minecraft.Expires.IsZero() {
+			exp := Store.Minecraft.Expires
+			acc.ExpiresAt = &exp
+		}
+		if !Store.MSA.RefreshExpires.IsZero() {
+			exp := Store.MSA.RefreshExpires
+			acc.LoginExpiresAt = &exp
+			acc.Refreshable = exp.After(now)
+		}
+		report.Accounts = append(report.Accounts, acc)
+		report.Default = "microsoft:" + acc.Name
+	}
+
+	cloud, _ := ReadCloudStore()
+	defCloud := GetDefaultCloudAccount()
+	for _, c := range cloud.Accounts {
+		if c.Token == "" {
+			continue
+		}
+		isDefault := report.Default == "" && defCloud != nil && normalizeEmail(defCloud.Email) == normalizeEmail(c.Email)
+		report.Accounts = append(report.Accounts, AccountStatus{
+			Type:      "cloud",
+			Name:      c.Username,
+			Email:     c.Email,
+			Status:    "active",
+			IsDefault: isDefault,
+		})
+		if isDefault {
+			report.Default = "cloud:" + c.Email
+		}
+	}
+
+	for _, l := range LocalStore.Accounts {
+		isDefault := report.Default == "" && l.Name == LocalStore.DefaultAccount
+		report.Accounts = append(report.Accounts, AccountStatus{
+			Type:      "local",
+			Name:      l.Name,
+			UUID:      l.UUID,
+			Status:    "active",
+			IsDefault: isDefault,
+		})
+		if isDefault {
+			report.Default = "local:" + l.Name
+		}
+	}
+
+	return report
+}
+
+// DefaultExpiryWarningWindow is how far ahead ExpiryWarnings reports sign-ins that are about to run out.
+const DefaultExpiryWarningWindow = 3 * 24 * time.Hour
+
+// ExpiryWarning describes an account whose saved sign-in has run out or will soon.
+type ExpiryWarning struct {
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+	Expired   bool          `json:"expired"`
+	Remaining time.Duration `json:"remaining"` // zero when already expired
+}
+
+// ExpiryWarnings returns accounts whose sign-in expires within the given window (or already has).
+// Only Microsoft sign-ins expire; offline and QMServer Cloud accounts are never reported.
+func ExpiryWarnings(within time.Duration) []ExpiryWarning {
+	var out []ExpiryWarning
+	if Store.MSA.RefreshToken == "" || Store.MSA.RefreshExpires.IsZero() {
+		return out
+	}
+	now := time.Now()
+	exp := Store.MSA.RefreshExpires
+	if exp.After(now.Add(within)) {
+		return out
+	}
+	w := ExpiryWarning{
+		Type:      "microsoft",
+		Name:      Store.Minecraft.Username,
+		ExpiresAt: exp,
+		Expired:   !exp.After(now),
+	}
+	if !w.Expired {
+		w.Remaining = exp.Sub(now)
+	}
+	return append(out, w)
+}
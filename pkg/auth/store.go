@@ -13,10 +13,15 @@ var Store AuthStore
 // LocalStore is the global local accounts store.
 var LocalStore LocalAccountsStore
 
+// msaRefreshTokenLifetime is how long Microsoft keeps a consumer refresh token valid without use.
+const msaRefreshTokenLifetime = 90 * 24 * time.Hour
+
 type msaAuthStore struct {
 	AccessToken  string    `json:"access_token"`
 	Expires      time.Time `json:"expires"`
 	RefreshToken string    `json:"refresh_token"`
+	// RefreshExpires is the estimated refresh token expiry; after it a new Microsoft login is required.
+	RefreshExpires time.Time `json:"refresh_expires,omitempty"`
 }
 
 func (store *msaAuthStore) isValid() bool {
@@ -39,6 +44,7 @@ func (store *msaAuthStore) write(resp msaResponse) {
 	store.AccessToken = resp.AccessToken
 	store.Expires = time.Now().Add(time.Second * time.Duration(resp.ExpiresIn))
 	store.RefreshToken = resp.RefreshToken
+	store.RefreshExpires = time.Now().Add(msaRefreshTokenLifetime)
 }
 
 type xblAuthStore struct {
@@ -90,6 +96,9 @@ type minecraftAuthStore struct {
 	Expires     time.Time `json:"expires"`
 	Username    string    `json:"name"`
 	UUID        string    `json:"id"`
+	// Entitlements and ProfileCheckedAt are filled by VerifyProfile after login.
+	Entitlements     []string  `json:"entitlements,omitempty"`
+	ProfileCheckedAt time.Time `json:"profile_checked_at,omitempty"`
 }
 
 func (store *minecraftAuthStore) isValid() bool {
@@ -27,8 +27,12 @@ type credentialsPayload struct {
 	Microsoft AuthStore          `json:"microsoft"`
 	Local     LocalAccountsStore `json:"local"`
 	Cloud     CloudStore         `json:"cloud"`
+	Aliases   map[string]string  `json:"aliases,omitempty"`
 }
 
+// ErrInsecureVault is returned when the credentials vault is accessible to other users.
+var ErrInsecureVault = errors.New("credentials vault permissions are too open")
+
 var (
 	vaultMu sync.Mutex
 
@@ -44,8 +48,11 @@ func LoadCredentials() error {
 	defer vaultMu.Unlock()
 
 	vaultPath := env.CredentialsVaultPath
-	_, statErr := os.Stat(vaultPath)
+	info, statErr := os.Stat(vaultPath)
 	if statErr == nil {
+		if err := checkVaultPermissions(vaultPath, info); err != nil {
+			return err
+		}
 		if err := readVaultLocked(vaultPath); err != nil {
 			return err
 		}
@@ -203,6 +210,7 @@ func readVaultLocked(path string) error {
 	if cloudPersisted.Accounts == nil {
 		cloudPersisted.Accounts = []CloudAccount{}
 	}
+	accountAliases = payload.Aliases
 	return nil
 }
 
@@ -210,12 +218,19 @@ func writeVaultLocked() error {
 	if err := os.MkdirAll(env.RootDir, 0755); err != nil {
 		return err
 	}
+	// Never overwrite a vault we refused to load: it still holds the user's accounts.
+	if info, err := os.Stat(env.CredentialsVaultPath); err == nil {
+		if err := checkVaultPermissions(env.CredentialsVaultPath, info); err != nil {
+			return err
+		}
+	}
 
 	payload := credentialsPayload{
 		Version:   1,
 		Microsoft: Store,
 		Local:     LocalStore,
 		Cloud:     cloudPersisted,
+		Aliases:   accountAliases,
 	}
 	plain, err := json.Marshal(payload)
 	if err != nil {
@@ -251,13 +266,23 @@ func writeVaultLocked() error {
 	out = append(out, nonce...)
 	out = append(out, ct...)
 
+	// Remove a stale temp file first: WriteFile only applies 0600 when it creates the file.
 	tmp := env.CredentialsVaultPath + ".tmp"
+	_ = os.Remove(tmp)
 	if err := os.WriteFile(tmp, out, 0600); err != nil {
 		return err
 	}
 	return os.Rename(tmp, env.CredentialsVaultPath)
 }
 
+// FixVaultPermissions restricts the vault to the current user (0600) and reloads it.
+func FixVaultPermissions() error {
+	if err := os.Chmod(env.CredentialsVaultPath, 0600); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return LoadCredentials()
+}
+
 func persistVault() error {
 	vaultMu.Lock()
 	defer vaultMu.Unlock()
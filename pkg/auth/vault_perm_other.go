@@ -0,0 +1,16 @@
+//go:build !windows
+
+package auth
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkVaultPermissions rejects a vault that other users on this machine can read or write.
+func checkVaultPermissions(path string, info os.FileInfo) error {
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("%w: %s has mode %#o; run `chmod 600 %s` (or use \"Fix permissions\" in Accounts) and restart the launcher", ErrInsecureVault, path, perm, path)
+	}
+	return nil
+}
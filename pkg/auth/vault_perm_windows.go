@@ -0,0 +1,10 @@
+//go:build windows
+
+package auth
+
+import "os"
+
+// checkVaultPermissions is a no-op on Windows: the vault lives in the user profile, which is ACL-protected.
+func checkVaultPermissions(path string, info os.FileInfo) error {
+	return nil
+}
@@ -21,6 +21,9 @@ var TmpDir string // Directory for temporary files
 
 var JavaDir string // Mojang Java installations
 
+// ContentDir is the content-addressed store (by SHA-1) of mods, resource packs and shaders shared by instances.
+var ContentDir string
+
 // CredentialsVaultPath is the encrypted file storing Microsoft session, offline and QMServer Cloud accounts.
 var CredentialsVaultPath string
 
@@ -37,6 +40,7 @@ func SetDirs(rootDir string) error {
 	AssetsDir = filepath.Join(RootDir, "assets")
 	TmpDir = filepath.Join(RootDir, "tmp")
 	JavaDir = filepath.Join(RootDir, "java")
+	ContentDir = filepath.Join(RootDir, "content")
 	CredentialsVaultPath = filepath.Join(RootDir, "credentials.vault")
 	AuthStorePath = CredentialsVaultPath
 
@@ -0,0 +1,135 @@
+package launcher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SyncHashCacheFile is the file of an instance where sync remembers the hashes of local files.
+const SyncHashCacheFile = ".sync-hashes.json"
+
+// HashFunc hashes a file with a manifest checksum algorithm ("md5" or "sha256"), as lowercase hex.
+type HashFunc func(path, algo string) (string, error)
+
+type hashCacheEntry struct {
+	Size    int64             `json:"size"`
+	ModTime int64             `json:"mtime"` // unix nanoseconds
+	Hashes  map[string]string `json:"hashes"`
+}
+
+// HashCache remembers the hashes of the files of an instance by path, size and modification time, so
+// sync only rehashes files that changed since the last run. It is safe for concurrent use.
+type HashCache struct {
+	dir     string
+	hash    HashFunc
+	mu      sync.Mutex
+	entries map[string]hashCacheEntry
+	dirty   bool
+}
+
+// NewHashCache returns an empty hash cache for an instance; saving it replaces the stored one.
+func NewHashCache(instanceDir string, hash HashFunc) *HashCache {
+	return &HashCache{dir: instanceDir, hash: hash, entries: map[string]hashCacheEntry{}, dirty: true}
+}
+
+// LoadHashCache reads the hash cache of an instance. A missing or unreadable cache starts empty.
+func LoadHashCache(instanceDir string, hash HashFunc) *HashCache {
+	c := NewHashCache(instanceDir, hash)
+	c.dirty = false
+	if data, err := os.ReadFile(filepath.Join(instanceDir, SyncHashCacheFile)); err == nil {
+		_ = json.Unmarshal(data, &c.entries)
+		if c.entries == nil {
+			c.entries = map[string]hashCacheEntry{}
+		}
+	}
+	return c
+}
+
+// key is the slash-separated path relative to the instance, or the absolute path outside it.
+func (c *HashCache) key(path string) string {
+	if rel, err := filepath.Rel(c.dir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// Hash returns the algo hash of a file, from the cache when its size and modification time are
+// unchanged, otherwise by hashing it.
+func (c *HashCache) Hash(path, algo string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	key := c.key(path)
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && e.Size == info.Size() && e.ModTime == info.ModTime().UnixNano() {
+		if sum, ok := e.Hashes[algo]; ok {
+			return sum, nil
+		}
+	} else {
+		e = hashCacheEntry{Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+	}
+	sum, err := c.hash(path, algo)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hashes := map[string]string{algo: sum}
+	for a, h := range e.Hashes {
+		if a != algo {
+			hashes[a] = h
+		}
+	}
+	e.Hashes = hashes
+	c.entries[key] = e
+	c.dirty = true
+	return sum, nil
+}
+
+// Put records the known hash of a file just written, e.g. a download verified against the manifest.
+func (c *HashCache) Put(path, algo, sum string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[c.key(path)] = hashCacheEntry{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Hashes:  map[string]string{algo: sum},
+	}
+	c.dirty = true
+}
+
+// Save writes the cache when it changed, dropping the entries of files that no longer exist.
+func (c *HashCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	for key := range c.entries {
+		path := filepath.FromSlash(key)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.dir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			delete(c.entries, key)
+		}
+	}
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, SyncHashCacheFile), data, 0644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,159 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingHash returns a HashFunc whose result depends on the file content and counts its calls.
+func countingHash(calls *int) HashFunc {
+	return func(path, algo string) (string, error) {
+		*calls++
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s:%s", algo, data), nil
+	}
+}
+
+func writeHashCacheFile(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHashCacheHash(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mods", "a.jar")
+	mtime := time.Unix(1700000000, 0)
+	writeHashCacheFile(t, path, "one", mtime)
+
+	calls := 0
+	c := NewHashCache(dir, countingHash(&calls))
+	for i := 0; i < 2; i++ {
+		sum, err := c.Hash(path, "md5")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum != "md5:one" {
+			t.Fatalf("Hash = %q, want md5:one", sum)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("hashed %d times, want 1", calls)
+	}
+
+	// Another algorithm is hashed once and kept next to the first.
+	if sum, _ := c.Hash(path, "sha256"); sum != "sha256:one" {
+		t.Fatalf("Hash sha256 = %q", sum)
+	}
+	if sum, _ := c.Hash(path, "md5"); sum != "md5:one" || calls != 2 {
+		t.Fatalf("Hash md5 = %q after %d calls, want md5:one after 2", sum, calls)
+	}
+
+	// A changed modification time invalidates every hash of the file.
+	writeHashCacheFile(t, path, "two", mtime.Add(time.Second))
+	if sum, _ := c.Hash(path, "md5"); sum != "md5:two" {
+		t.Fatalf("Hash after change = %q, want md5:two", sum)
+	}
+	if sum, _ := c.Hash(path, "sha256"); sum != "sha256:two" || calls != 4 {
+		t.Fatalf("Hash sha256 after change = %q after %d calls, want sha256:two after 4", sum, calls)
+	}
+
+	if _, err := c.Hash(filepath.Join(dir, "missing.jar"), "md5"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Hash of a missing file: %v", err)
+	}
+}
+
+func TestHashCachePut(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.jar")
+	writeHashCacheFile(t, path, "one", time.Unix(1700000000, 0))
+
+	calls := 0
+	c := NewHashCache(dir, countingHash(&calls))
+	c.Put(path, "md5", "known")
+	if sum, _ := c.Hash(path, "md5"); sum != "known" || calls != 0 {
+		t.Fatalf("Hash after Put = %q after %d calls, want known after 0", sum, calls)
+	}
+	// Put replaces the hashes of other algorithms.
+	c.Put(path, "sha256", "other")
+	if sum, _ := c.Hash(path, "md5"); sum != "md5:one" || calls != 1 {
+		t.Fatalf("Hash md5 after Put sha256 = %q after %d calls", sum, calls)
+	}
+}
+
+func TestHashCacheSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	mtime := time.Unix(1700000000, 0)
+	kept := filepath.Join(dir, "mods", "kept.jar")
+	gone := filepath.Join(dir, "mods", "gone.jar")
+	writeHashCacheFile(t, kept, "kept", mtime)
+	writeHashCacheFile(t, gone, "gone", mtime)
+
+	calls := 0
+	c := NewHashCache(dir, countingHash(&calls))
+	c.Hash(kept, "md5")
+	c.Hash(gone, "md5")
+	if err := os.Remove(gone); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, SyncHashCacheFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries map[string]hashCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entries["mods/kept.jar"]; !ok || len(entries) != 1 {
+		t.Fatalf("saved entries = %v, want only mods/kept.jar", entries)
+	}
+
+	loaded := LoadHashCache(dir, countingHash(&calls))
+	if sum, _ := loaded.Hash(kept, "md5"); sum != "md5:kept" || calls != 2 {
+		t.Fatalf("Hash from loaded cache = %q after %d calls, want md5:kept after 2", sum, calls)
+	}
+
+	// An unchanged loaded cache is not written back.
+	if err := os.Remove(filepath.Join(dir, SyncHashCacheFile)); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, SyncHashCacheFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("unchanged cache was saved: %v", err)
+	}
+}
+
+func TestLoadHashCacheCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, SyncHashCacheFile), []byte("null"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "a.jar")
+	writeHashCacheFile(t, path, "one", time.Unix(1700000000, 0))
+	calls := 0
+	c := LoadHashCache(dir, countingHash(&calls))
+	if sum, err := c.Hash(path, "md5"); err != nil || sum != "md5:one" {
+		t.Fatalf("Hash = %q, %v", sum, err)
+	}
+}
@@ -5,11 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
-	"runtime"
-	"strconv"
 	"strings"
 
 	"QMLauncher/internal/meta"
@@ -107,6 +104,19 @@ type InstanceConfig struct {
 	QMServerPort         int    `toml:"qmserver_port,omitempty" json:"qmserver_port,omitempty"         comment:"QMServer port"`
 	IsUsingQMServerCloud bool   `toml:"is_using_qmserver_cloud,omitempty" json:"is_using_qmserver_cloud,omitempty" comment:"Whether this instance uses QMServer"`
 	IsPremium            bool   `toml:"is_premium,omitempty" json:"is_premium,omitempty"               comment:"Whether the connected server is premium"`
+	CloudProfile         string `toml:"cloud_profile,omitempty" json:"cloud_profile,omitempty"         comment:"QMServer Cloud profile (launcher settings) used when qmserver_host is empty"`
+
+	Sync SyncConfig `toml:"sync,omitempty" json:"sync,omitempty" comment:"QMServer Cloud sync settings"`
+}
+
+// SyncConfig holds the per-instance QMServer Cloud sync settings.
+type SyncConfig struct {
+	Protect      []string `toml:"protect,omitempty" json:"protect,omitempty" comment:"Paths sync never overwrites or deletes, e.g. [\"config/xaero/**\", \"shaderpacks/my-custom.zip\"]"`
+	TrashDays    int      `toml:"trash_days,omitempty" json:"trash_days,omitempty" comment:"Days files removed by sync stay in .sync-trash (0 = 14, -1 = forever)"`
+	TrashBatches int      `toml:"trash_batches,omitempty" json:"trash_batches,omitempty" comment:"Number of sync runs kept in .sync-trash (0 = 10, -1 = all)"`
+
+	OnConflict string            `toml:"on_conflict,omitempty" json:"on_conflict,omitempty" comment:"What sync does with a local file that differs from the server: overwrite (default), keep-local, keep-both or ask"`
+	Conflicts  map[string]string `toml:"conflicts,omitempty" json:"conflicts,omitempty" comment:"Per-path conflict strategy, e.g. {\"config/**\" = \"keep-both\"}; the most specific pattern wins"`
 }
 
 // InstanceOptions are options used to designate an instance's version and other parameters on creation.
@@ -187,6 +197,23 @@ func CreateInstance(options InstanceOptions) (Instance, error) {
 	return inst, nil
 }
 
+// SwitchInstanceVersion changes the game version and mod loader of an existing instance, resolving
+// "latest" loader versions like CreateInstance. Game files are downloaded on the next launch; mods are
+// left to the caller.
+func SwitchInstanceVersion(inst *Instance, gameVersion string, loader Loader, loaderVersion string) error {
+	version, err := fetchVersion(loader, gameVersion, loaderVersion, inst.CachesDir(), inst.LibrariesDir(), inst.TmpDir())
+	if err != nil {
+		return err
+	}
+	inst.GameVersion = version.ID
+	inst.Loader = loader
+	inst.LoaderVersion = version.LoaderID
+	if err := inst.WriteConfig(); err != nil {
+		return fmt.Errorf("write instance configuration: %w", err)
+	}
+	return nil
+}
+
 // launcherInstanceLogTimestampSuffix matches the time.Format("2006-01-02_15-04-05") used in log filenames.
 var launcherInstanceLogTimestampSuffix = regexp.MustCompile(`_\d{4}-\d{2}-\d{2}_\d{2}-\d{2}-\d{2}$`)
 
@@ -373,85 +400,13 @@ func DoesInstanceExist(name string) bool {
 	return false
 }
 
-// FindSystemJava attempts to find a suitable Java installation on the system
+// FindSystemJava returns the first Java installation found on the system (JAVA_HOME, then PATH, then the
+// usual installation directories; see FindSystemJavaCandidates), or "" to use the Mojang Java runtime.
 func FindSystemJava() string {
-	// Check JAVA_HOME environment variable
-	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" {
-		javaPath := filepath.Join(javaHome, "bin", "java")
-		if runtime.GOOS == "windows" {
-			javaPath += ".exe"
-		}
-		if fileExists(javaPath) {
-			return javaPath
-		}
+	if candidates := FindSystemJavaCandidates(); len(candidates) > 0 {
+		return candidates[0].Path
 	}
-
-	// Check PATH for java executable
-	if javaPath, err := exec.LookPath("java"); err == nil {
-		// Verify it's actually executable
-		if info, err := os.Stat(javaPath); err == nil && !info.IsDir() {
-			if runtime.GOOS == "windows" || (info.Mode()&0111 != 0) {
-				return javaPath
-			}
-		}
-	}
-
-	// On Windows, check common installation paths
-	if runtime.GOOS == "windows" {
-		commonPaths := []string{
-			`C:\Program Files\Java\`,
-			`C:\Program Files (x86)\Java\`,
-		}
-
-		for _, basePath := range commonPaths {
-			if entries, err := os.ReadDir(basePath); err == nil {
-				// Find the latest Java version
-				var latestJava string
-				var latestVersion int
-
-				for _, entry := range entries {
-					if entry.IsDir() && strings.HasPrefix(entry.Name(), "jdk") {
-						versionStr := strings.TrimPrefix(entry.Name(), "jdk")
-						if version, err := strconv.Atoi(versionStr); err == nil {
-							if version > latestVersion {
-								latestVersion = version
-								latestJava = filepath.Join(basePath, entry.Name(), "bin", "java.exe")
-							}
-						}
-					}
-				}
-
-				if latestJava != "" && fileExists(latestJava) {
-					return latestJava
-				}
-			}
-		}
-	}
-
-	// On Linux/macOS, check common paths
-	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
-		commonPaths := []string{
-			"/usr/lib/jvm/",
-			"/usr/java/",
-			"/opt/java/",
-			"/Library/Java/JavaVirtualMachines/",
-		}
-
-		for _, basePath := range commonPaths {
-			if entries, err := os.ReadDir(basePath); err == nil {
-				for _, entry := range entries {
-					if entry.IsDir() {
-						javaPath := filepath.Join(basePath, entry.Name(), "bin", "java")
-						if fileExists(javaPath) {
-							return javaPath
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return "" // No Java found, will use Mojang Java runtime
+	return ""
 }
 
 // fileExists checks if a file exists and is accessible
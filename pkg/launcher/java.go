@@ -0,0 +1,656 @@
+package launcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"QMLauncher/internal/meta"
+	env "QMLauncher/pkg"
+)
+
+// JavaExecutable returns the java binary inside a Java home directory.
+func JavaExecutable(home string) string {
+	return filepath.Join(home, "bin", javaExecutableName())
+}
+
+// ResolveJavaRuntime turns a runtime reference into the path of a java executable. ref may be the name of
+// a managed runtime in env.JavaDir ("temurin-21", "java-runtime-delta"), a Java alias ("lts21" or
+// "@lts21", see SetJavaAlias), a Java home directory or the executable itself.
+func ResolveJavaRuntime(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("empty Java runtime")
+	}
+	if !strings.ContainsAny(ref, `/\`) {
+		if exe := JavaExecutable(filepath.Join(env.JavaDir, ref)); fileExists(exe) {
+			return exe, nil
+		}
+		if target, ok := lookupJavaAlias(ref); ok {
+			exe, err := resolveJavaTarget(target)
+			if err != nil {
+				return "", fmt.Errorf("Java alias %q: %w", ref, err)
+			}
+			return exe, nil
+		}
+	}
+	return resolveJavaTarget(ref)
+}
+
+// resolveJavaTarget resolves a managed runtime name, Java home or java executable (no aliases).
+func resolveJavaTarget(ref string) (string, error) {
+	if !strings.ContainsAny(ref, `/\`) {
+		if exe := JavaExecutable(filepath.Join(env.JavaDir, ref)); fileExists(exe) {
+			return exe, nil
+		}
+	}
+	abs, err := filepath.Abs(ref)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("Java runtime %q not found", ref)
+	}
+	if !info.IsDir() {
+		return abs, nil
+	}
+	if exe := JavaExecutable(abs); fileExists(exe) {
+		return exe, nil
+	}
+	return "", fmt.Errorf("%q is not a Java home (bin/%s not found)", ref, javaExecutableName())
+}
+
+// JavaInfo describes a Java runtime as reported by the JVM itself.
+type JavaInfo struct {
+	Path    string `json:"path"` // java executable
+	Home    string `json:"home"`
+	Version string `json:"version"` // java.version, e.g. "17.0.9" or "1.8.0_392"
+	Major   int    `json:"major"`
+	Vendor  string `json:"vendor"`
+	Arch    string `json:"arch"`             // os.arch: amd64, aarch64, x86, …
+	Source  string `json:"source,omitempty"` // where it was found: managed, JAVA_HOME, PATH, system
+}
+
+// javaProbeTimeout bounds a single "java -version" run; a hung JVM must not block detection.
+const javaProbeTimeout = 15 * time.Second
+
+// ProbeJava runs the executable with -XshowSettings:properties -version and reads the runtime properties.
+func ProbeJava(exe string) (JavaInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), javaProbeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, exe, "-XshowSettings:properties", "-version")
+	setCmdNoWindow(cmd)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return JavaInfo{Path: exe}, fmt.Errorf("run %s -version: %w", exe, err)
+	}
+	info := JavaInfo{Path: exe}
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), " = ")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "java.version":
+			info.Version = value
+		case "java.vendor":
+			info.Vendor = value
+		case "os.arch":
+			info.Arch = value
+		case "java.home":
+			info.Home = value
+		}
+	}
+	if info.Version == "" {
+		return info, fmt.Errorf("%s did not report java.version", exe)
+	}
+	info.Major = meta.JavaMajorVersion(info.Version)
+	// Java 8 reports the nested jre/ as java.home
+	if filepath.Base(info.Home) == "jre" && fileExists(JavaExecutable(filepath.Dir(info.Home))) {
+		info.Home = filepath.Dir(info.Home)
+	}
+	return info, nil
+}
+
+// systemJavaDirs are directories whose subdirectories are usually Java homes.
+func systemJavaDirs() []string {
+	switch runtime.GOOS {
+	case "windows":
+		var dirs []string
+		for _, pf := range []string{os.Getenv("ProgramFiles"), os.Getenv("ProgramFiles(x86)"), `C:\Program Files`, `C:\Program Files (x86)`} {
+			if pf == "" {
+				continue
+			}
+			for _, vendor := range []string{"Java", "Eclipse Adoptium", "Eclipse Foundation", "AdoptOpenJDK", "Zulu", "Microsoft", "Amazon Corretto", "BellSoft", "Semeru"} {
+				dirs = append(dirs, filepath.Join(pf, vendor))
+			}
+		}
+		return dirs
+	case "darwin":
+		home, _ := os.UserHomeDir()
+		return []string{"/Library/Java/JavaVirtualMachines", filepath.Join(home, "Library/Java/JavaVirtualMachines"), "/opt/homebrew/opt", "/usr/local/opt"}
+	default:
+		home, _ := os.UserHomeDir()
+		return []string{"/usr/lib/jvm", "/usr/java", "/opt/java", "/opt", filepath.Join(home, ".sdkman/candidates/java"), filepath.Join(home, ".jdks")}
+	}
+}
+
+// FindSystemJavaCandidates lists java executables found outside the launcher: JAVA_HOME, every PATH entry
+// and the usual installation directories. Symlinked duplicates are reported once.
+func FindSystemJavaCandidates() []JavaInfo {
+	var found []JavaInfo
+	seen := map[string]bool{}
+	add := func(exe, source string) {
+		if !fileExists(exe) {
+			return
+		}
+		key := exe
+		if real, err := filepath.EvalSymlinks(exe); err == nil {
+			key = real
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		found = append(found, JavaInfo{Path: exe, Source: source})
+	}
+
+	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" {
+		add(JavaExecutable(javaHome), "JAVA_HOME")
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir != "" {
+			add(filepath.Join(dir, javaExecutableName()), "PATH")
+		}
+	}
+	for _, base := range systemJavaDirs() {
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			dir := filepath.Join(base, entry.Name())
+			add(JavaExecutable(dir), "system")
+			add(JavaExecutable(filepath.Join(dir, "Contents", "Home")), "system")                           // macOS bundles
+			add(JavaExecutable(filepath.Join(dir, "libexec", "openjdk.jdk", "Contents", "Home")), "system") // Homebrew
+		}
+	}
+	return found
+}
+
+// DetectSystemJava probes every candidate from FindSystemJavaCandidates. Runtimes that fail to start are
+// returned with Version empty so callers can report them.
+func DetectSystemJava() []JavaInfo {
+	candidates := FindSystemJavaCandidates()
+	out := make([]JavaInfo, 0, len(candidates))
+	for _, c := range candidates {
+		info, err := ProbeJava(c.Path)
+		info.Source = c.Source
+		if err != nil {
+			info = JavaInfo{Path: c.Path, Source: c.Source}
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// NormalizeJavaArch maps an os.arch value to its GOARCH name ("x86_64" → "amd64", "aarch64" → "arm64").
+func NormalizeJavaArch(arch string) string {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case "amd64", "x86_64", "x64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	case "x86", "i386", "i486", "i586", "i686", "x32":
+		return "386"
+	}
+	return strings.ToLower(strings.TrimSpace(arch))
+}
+
+// JavaHealth is the result of checking one managed runtime.
+type JavaHealth struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Info     JavaInfo `json:"info"`
+	Problems []string `json:"problems"`
+	OK       bool     `json:"ok"`
+}
+
+// VerifyJava checks a Java home: the java binary exists and is executable, starts with -version, matches
+// the host architecture and ships a CA certificate store (without it HTTPS in mods fails).
+func VerifyJava(name, home string) JavaHealth {
+	h := JavaHealth{Name: name, Path: home, Problems: []string{}}
+	exe := JavaExecutable(home)
+	st, err := os.Stat(exe)
+	switch {
+	case err != nil:
+		h.Problems = append(h.Problems, fmt.Sprintf("bin/%s is missing", javaExecutableName()))
+		return h
+	case runtime.GOOS != "windows" && st.Mode()&0111 == 0:
+		h.Problems = append(h.Problems, fmt.Sprintf("bin/%s is not executable", javaExecutableName()))
+		return h
+	}
+	info, err := ProbeJava(exe)
+	h.Info = info
+	if err != nil {
+		h.Problems = append(h.Problems, err.Error())
+		return h
+	}
+	if arch := NormalizeJavaArch(info.Arch); arch != runtime.GOARCH {
+		h.Problems = append(h.Problems, fmt.Sprintf("built for %s, host is %s", info.Arch, runtime.GOARCH))
+	}
+	if !fileExists(filepath.Join(home, "lib", "security", "cacerts")) && !fileExists(filepath.Join(home, "jre", "lib", "security", "cacerts")) {
+		h.Problems = append(h.Problems, "lib/security/cacerts is missing")
+	}
+	h.OK = len(h.Problems) == 0
+	return h
+}
+
+// VerifyInstalledJava runs VerifyJava on every runtime in env.JavaDir.
+func VerifyInstalledJava() ([]JavaHealth, error) {
+	javas, err := ListInstalledJavaVersions()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]JavaHealth, 0, len(javas))
+	for _, j := range javas {
+		out = append(out, VerifyJava(j.Name, j.Path))
+	}
+	return out, nil
+}
+
+// RequiredJavaMajor returns the Java major version a Minecraft version needs: declared (majorVersion from the
+// version JSON) when set, otherwise 8 up to 1.16, 17 up to 1.20.4 and 21 after that. It returns 0 for
+// versions it cannot place, e.g. snapshots without a declared version.
+func RequiredJavaMajor(gameVersion string, declared int) int {
+	if declared > 0 {
+		return declared
+	}
+	v := strings.TrimSpace(gameVersion)
+	if !strings.HasPrefix(v, "1.") {
+		return 0
+	}
+	switch {
+	case meta.CompareModVersions(v, "1.17") < 0:
+		return 8
+	case meta.CompareModVersions(v, "1.20.4") <= 0:
+		return 17
+	}
+	return 21
+}
+
+// JavaSatisfies reports whether a runtime of major version found can run a game that requires required.
+// Newer runtimes are accepted except for Java 8 games, whose loaders (old Forge, LaunchWrapper) break on 9+.
+func JavaSatisfies(required, found int) bool {
+	if required == 0 || found == 0 {
+		return true
+	}
+	if required == 8 {
+		return found == 8
+	}
+	return found >= required
+}
+
+// javaRuntimeSource tells whether Prepare installs the Mojang runtime component on this system (nil) or a
+// Temurin build of the required major instead: meta.ErrJavaBadSystem where Mojang ships no runtimes (musl,
+// platforms missing from list) and meta.ErrJavaNoVersion where it ships none of component.
+func javaRuntimeSource(list meta.JavaManifestList, component string) error {
+	if IsMuslHost() {
+		// Mojang only ships glibc runtimes
+		return meta.ErrJavaBadSystem
+	}
+	builds, ok := list[meta.JavaPlatform()]
+	if !ok {
+		return meta.ErrJavaBadSystem
+	}
+	if b, ok := builds[component]; ok && len(b) == 0 {
+		return meta.ErrJavaNoVersion
+	}
+	return nil
+}
+
+// managedJavaHome returns the installed temurin-<major> runtime managedJava launches with, or "".
+func managedJavaHome(major int) string {
+	rosetta := runtime.GOOS == "darwin" && runtime.GOARCH == "arm64"
+	for _, arch := range []string{"", "amd64"} {
+		if home := filepath.Join(env.JavaDir, temurinRuntimeName(major, arch, false)); fileExists(JavaExecutable(home)) {
+			return home
+		}
+		if !rosetta {
+			break
+		}
+	}
+	return ""
+}
+
+// managedJava returns the java executable of temurin-<major>, installing it when it is missing. On Apple
+// Silicon, majors without an aarch64 build (Java 8) fall back to the x86_64 build, which runs under Rosetta.
+func managedJava(major int, watcher EventWatcher) (string, error) {
+	rosetta := runtime.GOOS == "darwin" && runtime.GOARCH == "arm64"
+	if home := managedJavaHome(major); home != "" {
+		return JavaExecutable(home), nil
+	}
+	install := func(arch string) (JavaVersion, error) {
+		return installTemurin(major, arch, false, func(p JavaInstallProgress) {
+			if watcher != nil {
+				watcher(JavaInstallingEvent{Major: major, Phase: p.Phase, Done: p.Done, Total: p.Total})
+			}
+		})
+	}
+	java, err := install("")
+	if err != nil && rosetta && errors.Is(err, meta.ErrJavaNoVersion) {
+		java, err = install("amd64")
+	}
+	if err != nil {
+		return "", err
+	}
+	return JavaExecutable(java.Path), nil
+}
+
+// JavaHomeOf returns the Java home of a java executable (<home>/bin/java).
+func JavaHomeOf(exe string) string {
+	return filepath.Dir(filepath.Dir(exe))
+}
+
+// InstanceJavaHome returns the Java home an instance launches with: its configured runtime, else
+// defaultJava, else the runtime Prepare installs for its cached version metadata: the Mojang component,
+// or temurin-<major> where Mojang ships none. It returns "" when this cannot be determined without network
+// access.
+func InstanceJavaHome(inst Instance, defaultJava string) string {
+	for _, ref := range []string{inst.Config.Java, defaultJava} {
+		if strings.TrimSpace(ref) == "" {
+			continue
+		}
+		if exe, err := ResolveJavaRuntime(ref); err == nil {
+			return filepath.Clean(JavaHomeOf(exe))
+		}
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(inst.CachesDir(), "minecraft", inst.GameVersion+".json"))
+	if err != nil {
+		return ""
+	}
+	var version struct {
+		JavaVersion struct {
+			Component    string `json:"component"`
+			MajorVersion int    `json:"majorVersion"`
+		} `json:"javaVersion"`
+	}
+	if json.Unmarshal(data, &version) != nil {
+		return ""
+	}
+	component := version.JavaVersion.Component
+	if component == "" {
+		component = "jre-legacy"
+	}
+
+	// Same choice as Prepare, from the cached Mojang runtime list
+	var list meta.JavaManifestList
+	if !IsMuslHost() {
+		data, err := os.ReadFile(filepath.Join(inst.CachesDir(), "minecraft", "java_all.json"))
+		if err != nil || json.Unmarshal(data, &list) != nil {
+			return ""
+		}
+	}
+	if javaRuntimeSource(list, component) == nil {
+		return filepath.Join(env.JavaDir, component)
+	}
+	if major := RequiredJavaMajor(inst.GameVersion, version.JavaVersion.MajorVersion); major > 0 {
+		return managedJavaHome(major)
+	}
+	return ""
+}
+
+// JavaRuntimeUsers maps each Java home (cleaned path) to the names of the instances that use it.
+func JavaRuntimeUsers(defaultJava string) (map[string][]string, error) {
+	instances, err := FetchAllInstances()
+	if err != nil {
+		return nil, err
+	}
+	users := map[string][]string{}
+	for _, inst := range instances {
+		if home := InstanceJavaHome(inst, defaultJava); home != "" {
+			users[home] = append(users[home], inst.Name)
+		}
+	}
+	return users, nil
+}
+
+// JavaRuntime is one managed runtime with the properties reported by the JVM.
+type JavaRuntime struct {
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Version   string   `json:"version"`
+	Major     int      `json:"major"`
+	Vendor    string   `json:"vendor"`
+	Arch      string   `json:"arch"`
+	Source    string   `json:"source"` // managed, or where a system runtime was found (JAVA_HOME, PATH, system)
+	SizeBytes int64    `json:"sizeBytes,omitempty"`
+	Instances []string `json:"instances"`
+	Error     string   `json:"error,omitempty"` // the runtime did not start
+}
+
+// ListJavaRuntimes describes every runtime in env.JavaDir: version, vendor and architecture (by running
+// it), disk size and the instances that use it (see InstanceJavaHome).
+func ListJavaRuntimes(defaultJava string) ([]JavaRuntime, error) {
+	javas, err := ListInstalledJavaVersions()
+	if err != nil {
+		return nil, err
+	}
+	users, err := JavaRuntimeUsers(defaultJava)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]JavaRuntime, 0, len(javas))
+	for _, j := range javas {
+		r := JavaRuntime{Name: j.Name, Path: j.Path, Source: "managed", Instances: users[filepath.Clean(j.Path)]}
+		if r.Instances == nil {
+			r.Instances = []string{}
+		}
+		if info, err := ProbeJava(JavaExecutable(j.Path)); err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Version, r.Major, r.Vendor, r.Arch = info.Version, info.Major, info.Vendor, info.Arch
+		}
+		r.SizeBytes, _ = dirSize(j.Path)
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// JavaInventory is every Java runtime the launcher knows about.
+type JavaInventory struct {
+	Default  string        `json:"default,omitempty"`
+	Runtimes []JavaRuntime `json:"runtimes"`
+	Aliases  []JavaAlias   `json:"aliases"`
+}
+
+// BuildJavaInventory lists managed runtimes (ListJavaRuntimes), the system runtimes found by
+// DetectSystemJava and the aliases, each runtime with the instances that use it.
+func BuildJavaInventory(defaultJava string) (JavaInventory, error) {
+	inv := JavaInventory{Default: defaultJava}
+	var err error
+	if inv.Runtimes, err = ListJavaRuntimes(defaultJava); err != nil {
+		return JavaInventory{}, err
+	}
+	users, err := JavaRuntimeUsers(defaultJava)
+	if err != nil {
+		return JavaInventory{}, err
+	}
+	for _, info := range DetectSystemJava() {
+		home := info.Home
+		if home == "" {
+			home = JavaHomeOf(info.Path)
+		}
+		r := JavaRuntime{
+			Name:      filepath.Base(home),
+			Path:      home,
+			Version:   info.Version,
+			Major:     info.Major,
+			Vendor:    info.Vendor,
+			Arch:      info.Arch,
+			Source:    info.Source,
+			Instances: users[filepath.Clean(home)],
+		}
+		if r.Instances == nil {
+			r.Instances = []string{}
+		}
+		if info.Version == "" {
+			r.Error = "does not start"
+		}
+		inv.Runtimes = append(inv.Runtimes, r)
+	}
+	if inv.Aliases, err = JavaAliases(); err != nil {
+		return JavaInventory{}, err
+	}
+	return inv, nil
+}
+
+// dirSize sums the sizes of the regular files under dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size, err
+}
+
+// UnusedJavaRuntimes returns the managed runtimes no instance and not the launcher default refers to.
+// An instance whose runtime cannot be determined offline keeps every runtime of the Java major version
+// its game version requires.
+func UnusedJavaRuntimes(defaultJava string) ([]JavaRuntime, error) {
+	runtimes, err := ListJavaRuntimes(defaultJava)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := FetchAllInstances()
+	if err != nil {
+		return nil, err
+	}
+	keepMajor := map[int]bool{}
+	for _, inst := range instances {
+		if InstanceJavaHome(inst, defaultJava) == "" {
+			keepMajor[RequiredJavaMajor(inst.GameVersion, 0)] = true
+		}
+	}
+	var defaultHome string
+	if exe, err := ResolveJavaRuntime(defaultJava); err == nil {
+		defaultHome = filepath.Clean(JavaHomeOf(exe))
+	}
+	var unused []JavaRuntime
+	for _, r := range runtimes {
+		if len(r.Instances) > 0 || filepath.Clean(r.Path) == defaultHome || keepMajor[r.Major] || keepMajor[0] {
+			continue
+		}
+		unused = append(unused, r)
+	}
+	return unused, nil
+}
+
+// ParseJavaMajorRange parses a JVM argument template key: a Java major version ("17"), a range ("8-16")
+// or an open range ("21-"). hi is 0 for an open range.
+func ParseJavaMajorRange(key string) (lo, hi int, err error) {
+	key = strings.TrimSpace(key)
+	loStr, hiStr, isRange := strings.Cut(key, "-")
+	if lo, err = strconv.Atoi(strings.TrimSpace(loStr)); err != nil || lo <= 0 {
+		return 0, 0, fmt.Errorf("invalid Java version %q", key)
+	}
+	if !isRange {
+		return lo, lo, nil
+	}
+	if hiStr = strings.TrimSpace(hiStr); hiStr == "" {
+		return lo, 0, nil
+	}
+	if hi, err = strconv.Atoi(hiStr); err != nil || hi < lo {
+		return 0, 0, fmt.Errorf("invalid Java version range %q", key)
+	}
+	return lo, hi, nil
+}
+
+// JavaArgTemplate picks the JVM argument template for a Java major version: the template keyed by exactly
+// that major, else the narrowest range containing it ("8-16", "21-"), so flags that only exist on some Java
+// versions do not reach the others. Unknown majors (0) get no template.
+func JavaArgTemplate(templates map[string]string, major int) string {
+	if major <= 0 {
+		return ""
+	}
+	bestKey, bestWidth := "", -1
+	for key := range templates {
+		lo, hi, err := ParseJavaMajorRange(key)
+		if err != nil || major < lo || (hi != 0 && major > hi) {
+			continue
+		}
+		width := hi - lo
+		if hi == 0 {
+			width = math.MaxInt
+		}
+		if bestWidth < 0 || width < bestWidth || (width == bestWidth && key < bestKey) {
+			bestKey, bestWidth = key, width
+		}
+	}
+	if bestWidth < 0 {
+		return ""
+	}
+	return strings.TrimSpace(templates[bestKey])
+}
+
+// JavaEnvShells are the shells accepted by JavaEnvScript.
+var JavaEnvShells = []string{"sh", "fish", "powershell", "cmd"}
+
+// JavaEnvScript renders JAVA_HOME and a PATH entry for home so that `eval "$(…)"` (or the shell's
+// equivalent) points external tools at the same JVM. shell is one of JavaEnvShells; "" means sh, or
+// powershell on Windows.
+func JavaEnvScript(home, shell string) (string, error) {
+	shell = strings.ToLower(strings.TrimSpace(shell))
+	if shell == "" {
+		shell = "sh"
+		if runtime.GOOS == "windows" {
+			shell = "powershell"
+		}
+	}
+	bin := filepath.Join(home, "bin")
+	switch shell {
+	case "sh", "bash", "zsh":
+		quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'" }
+		return fmt.Sprintf("export JAVA_HOME=%s\nexport PATH=%s:\"$PATH\"\n", quote(home), quote(bin)), nil
+	case "fish":
+		quote := func(s string) string {
+			return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+		}
+		return fmt.Sprintf("set -gx JAVA_HOME %s\nset -gx PATH %s $PATH\n", quote(home), quote(bin)), nil
+	case "powershell", "pwsh":
+		quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+		return fmt.Sprintf("$env:JAVA_HOME = %s\n$env:PATH = %s + [IO.Path]::PathSeparator + $env:PATH\n", quote(home), quote(bin)), nil
+	case "cmd":
+		return fmt.Sprintf("set \"JAVA_HOME=%s\"\r\nset \"PATH=%s;%%PATH%%\"\r\n", home, bin), nil
+	}
+	return "", fmt.Errorf("unknown shell %q (%s)", shell, strings.Join(JavaEnvShells, ", "))
+}
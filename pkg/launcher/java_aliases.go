@@ -0,0 +1,141 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	env "QMLauncher/pkg"
+)
+
+// Java aliases are kept per machine, outside instance configs, so "java = lts21" in instance.toml keeps
+// working after the instance is copied to another machine that defines its own lts21.
+
+var (
+	javaAliasesMu sync.Mutex
+	javaAliasRe   = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,31}$`)
+)
+
+// JavaAlias is one alias entry as shown in the UI.
+type JavaAlias struct {
+	Alias  string `json:"alias"`
+	Target string `json:"target"`
+	Java   string `json:"java"` // resolved java executable; empty if the target no longer exists
+}
+
+func javaAliasesPath() string {
+	return filepath.Join(env.RootDir, "java-aliases.json")
+}
+
+func normalizeJavaAlias(alias string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(alias), "@"))
+}
+
+func readJavaAliases() (map[string]string, error) {
+	aliases := map[string]string{}
+	data, err := os.ReadFile(javaAliasesPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return aliases, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(javaAliasesPath()), err)
+	}
+	return aliases, nil
+}
+
+func writeJavaAliases(aliases map[string]string) error {
+	data, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(javaAliasesPath(), data, 0644)
+}
+
+// lookupJavaAlias returns the target of an alias ("lts21" or "@lts21").
+func lookupJavaAlias(ref string) (string, bool) {
+	javaAliasesMu.Lock()
+	defer javaAliasesMu.Unlock()
+	aliases, err := readJavaAliases()
+	if err != nil {
+		return "", false
+	}
+	target, ok := aliases[normalizeJavaAlias(ref)]
+	return target, ok
+}
+
+// SetJavaAlias binds alias to a managed runtime name, Java home or java executable. Paths are stored
+// absolute; the target must exist and may not be another alias. Managed runtime names cannot be used as
+// aliases, since they take precedence when resolving.
+func SetJavaAlias(alias, target string) error {
+	alias = normalizeJavaAlias(alias)
+	target = strings.TrimSpace(target)
+	if !javaAliasRe.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q: use 1-32 lowercase letters, digits, '.', '-' or '_'", alias)
+	}
+	if fileExists(JavaExecutable(filepath.Join(env.JavaDir, alias))) {
+		return fmt.Errorf("alias %q is the name of an installed runtime", alias)
+	}
+	if _, err := resolveJavaTarget(target); err != nil {
+		if _, isAlias := lookupJavaAlias(target); isAlias {
+			return fmt.Errorf("alias %q cannot point to another alias", alias)
+		}
+		return err
+	}
+	if strings.ContainsAny(target, `/\`) {
+		abs, err := filepath.Abs(target)
+		if err != nil {
+			return err
+		}
+		target = abs
+	}
+	javaAliasesMu.Lock()
+	defer javaAliasesMu.Unlock()
+	aliases, err := readJavaAliases()
+	if err != nil {
+		return err
+	}
+	aliases[alias] = target
+	return writeJavaAliases(aliases)
+}
+
+// RemoveJavaAlias deletes an alias; removing a missing alias is not an error.
+func RemoveJavaAlias(alias string) error {
+	javaAliasesMu.Lock()
+	defer javaAliasesMu.Unlock()
+	aliases, err := readJavaAliases()
+	if err != nil {
+		return err
+	}
+	if _, ok := aliases[normalizeJavaAlias(alias)]; !ok {
+		return nil
+	}
+	delete(aliases, normalizeJavaAlias(alias))
+	return writeJavaAliases(aliases)
+}
+
+// JavaAliases returns all aliases sorted by name, with their resolved executables.
+func JavaAliases() ([]JavaAlias, error) {
+	javaAliasesMu.Lock()
+	aliases, err := readJavaAliases()
+	javaAliasesMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]JavaAlias, 0, len(aliases))
+	for alias, target := range aliases {
+		a := JavaAlias{Alias: alias, Target: target}
+		a.Java, _ = resolveJavaTarget(target)
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Alias < out[j].Alias })
+	return out, nil
+}
@@ -0,0 +1,160 @@
+package launcher
+
+import (
+	"debug/elf"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// JavaFinding is a problem found by DiagnoseJava, with a suggested fix.
+type JavaFinding struct {
+	Severity string `json:"severity"` // error | warning
+	Check    string `json:"check"`    // permissions, symlinks, libc, start, arch, heap
+	Message  string `json:"message"`
+	Fix      string `json:"fix"`
+}
+
+// IsMuslHost reports whether this is a musl-based Linux (Alpine, postmarketOS, Void musl).
+func IsMuslHost() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	matches, _ := filepath.Glob("/lib/ld-musl-*.so.1")
+	return len(matches) > 0
+}
+
+// elfInterpreter returns the dynamic loader and machine of an ELF executable.
+func elfInterpreter(path string) (string, elf.Machine, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	for _, p := range f.Progs {
+		if p.Type == elf.PT_INTERP {
+			data := make([]byte, p.Filesz)
+			if _, err := p.ReadAt(data, 0); err != nil {
+				return "", f.Machine, err
+			}
+			return strings.TrimRight(string(data), "\x00"), f.Machine, nil
+		}
+	}
+	return "", f.Machine, nil
+}
+
+// elfMachineArch maps an ELF machine to GOARCH.
+func elfMachineArch(m elf.Machine) string {
+	switch m {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_ARM:
+		return "arm"
+	}
+	return strings.ToLower(strings.TrimPrefix(m.String(), "EM_"))
+}
+
+// DiagnoseJava inspects a Java home for problems that usually only show up as a failed launch: missing
+// execute permissions, broken symlinks, a musl/glibc mismatch, a runtime built for another architecture
+// and a maximum heap (maxMemoryMB, 0 = skip) the JVM or the machine cannot provide.
+func DiagnoseJava(home string, maxMemoryMB int) (JavaInfo, []JavaFinding) {
+	findings := []JavaFinding{}
+	add := func(severity, check, fix, format string, args ...any) {
+		findings = append(findings, JavaFinding{Severity: severity, Check: check, Message: fmt.Sprintf(format, args...), Fix: fix})
+	}
+	exe := JavaExecutable(home)
+	if !fileExists(exe) {
+		add("error", "start", "Reinstall the runtime", "bin/%s is missing", javaExecutableName())
+		return JavaInfo{Path: exe}, findings
+	}
+
+	if runtime.GOOS != "windows" {
+		var notExec []string
+		for _, dir := range []string{filepath.Join(home, "bin"), filepath.Join(home, "lib")} {
+			entries, _ := os.ReadDir(dir)
+			for _, e := range entries {
+				if !e.Type().IsRegular() || (dir != filepath.Join(home, "bin") && e.Name() != "jspawnhelper") {
+					continue
+				}
+				if info, err := e.Info(); err == nil && info.Mode()&0111 == 0 {
+					notExec = append(notExec, filepath.Join(filepath.Base(dir), e.Name()))
+				}
+			}
+		}
+		if len(notExec) > 0 {
+			add("error", "permissions", fmt.Sprintf("chmod +x %q/bin/* %q/lib/jspawnhelper", home, home),
+				"not executable: %s", strings.Join(notExec, ", "))
+		}
+	}
+
+	var broken []string
+	_ = filepath.WalkDir(home, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type()&fs.ModeSymlink != 0 {
+			if _, err := os.Stat(path); err != nil {
+				rel, _ := filepath.Rel(home, path)
+				broken = append(broken, rel)
+			}
+		}
+		return nil
+	})
+	if len(broken) > 0 {
+		add("error", "symlinks", "Reinstall the runtime", "broken symlinks: %s", strings.Join(broken, ", "))
+	}
+
+	elfArch := ""
+	if runtime.GOOS == "linux" {
+		if interp, machine, err := elfInterpreter(exe); err == nil {
+			elfArch = elfMachineArch(machine)
+			runtimeMusl := strings.Contains(interp, "musl")
+			switch hostMusl := IsMuslHost(); {
+			case hostMusl && !runtimeMusl:
+				add("error", "libc", "Install an Alpine (musl) build of the runtime, or install gcompat", "glibc runtime (%s) on a musl system", interp)
+			case !hostMusl && runtimeMusl:
+				add("error", "libc", "Install a regular (glibc) build of the runtime", "musl runtime (%s) on a glibc system", interp)
+			}
+		}
+	}
+
+	info, err := ProbeJava(exe)
+	if err != nil {
+		if elfArch != "" && elfArch != runtime.GOARCH {
+			add("error", "arch", fmt.Sprintf("Install a %s build of the runtime", runtime.GOARCH), "runtime is built for %s, host is %s", elfArch, runtime.GOARCH)
+		} else {
+			add("error", "start", "Reinstall the runtime", "%v", err)
+		}
+		return info, findings
+	}
+
+	arch := NormalizeJavaArch(info.Arch)
+	switch {
+	case arch == runtime.GOARCH:
+	case runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" && arch == "amd64":
+		add("warning", "arch", "Install an aarch64 runtime (InstallJavaRuntime) for native performance", "x86_64 runtime runs under Rosetta 2 and is noticeably slower")
+	case runtime.GOOS == "windows" && runtime.GOARCH == "amd64" && arch == "386":
+		add("warning", "arch", "Install a 64-bit runtime", "32-bit runtime on a 64-bit system limits the heap to about 1.5 GB")
+	default:
+		add("error", "arch", fmt.Sprintf("Install a %s build of the runtime", runtime.GOARCH), "runtime is built for %s, host is %s", info.Arch, runtime.GOARCH)
+	}
+
+	if maxMemoryMB > 0 {
+		if (arch == "386" || arch == "arm") && maxMemoryMB > 1536 {
+			add("error", "heap", "Use a 64-bit runtime or lower the maximum memory to 1536 MB", "a 32-bit JVM cannot reserve a %d MB heap", maxMemoryMB)
+		}
+		if total := totalMemoryMB(); total > 0 {
+			switch {
+			case maxMemoryMB >= total:
+				add("error", "heap", fmt.Sprintf("Lower the maximum memory below %d MB", total*3/4), "maximum memory %d MB is not below the installed %d MB", maxMemoryMB, total)
+			case maxMemoryMB > total*3/4:
+				add("warning", "heap", fmt.Sprintf("Lower the maximum memory to %d MB or less", total*3/4), "maximum memory %d MB leaves little of the installed %d MB for the system", maxMemoryMB, total)
+			}
+		}
+	}
+	return info, findings
+}
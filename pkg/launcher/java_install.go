@@ -0,0 +1,418 @@
+package launcher
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"QMLauncher/internal/meta"
+	"QMLauncher/internal/network"
+	env "QMLauncher/pkg"
+)
+
+// Java runtime vendors accepted by InstallJava.
+const (
+	JavaVendorTemurin = "temurin"
+	JavaVendorMojang  = "mojang"
+)
+
+// javaDownloadAttempts is how often a runtime download is tried when the checksum does not match.
+const javaDownloadAttempts = 3
+
+// JavaInstallOptions selects the runtime installed by InstallJava.
+type JavaInstallOptions struct {
+	// Version is a Java major version ("17", "21"). With JavaVendorMojang it may also be a
+	// runtime component name ("java-runtime-gamma").
+	Version string
+	// Vendor is JavaVendorTemurin (default) or JavaVendorMojang.
+	Vendor string
+	// Arch overrides the architecture of a Temurin build ("amd64"/"x64", "arm64"/"aarch64"), e.g. to
+	// install an x86_64 runtime for Rosetta on Apple Silicon. Empty means this system's architecture.
+	Arch string
+	// Headless installs a trimmed Temurin runtime without the desktop (AWT/Swing) modules, built with
+	// jlink, for servers and prepare-only use. It needs Java 11 or newer and cannot run the game client.
+	Headless bool
+}
+
+// JavaInstallProgress is reported while a runtime is installed. Phase is "download" or "extract";
+// Done and Total are bytes for Temurin archives and files for Mojang components (Total -1 when unknown).
+type JavaInstallProgress struct {
+	Phase string
+	Done  int64
+	Total int64
+}
+
+// InstallJava downloads a managed runtime into env.JavaDir and returns it. Temurin builds are installed
+// into "temurin-<major>", Mojang components into a directory named after the component (the same place
+// Prepare downloads them to). An existing installation of the same name is replaced.
+func InstallJava(opts JavaInstallOptions, progress func(JavaInstallProgress)) (JavaVersion, error) {
+	if progress == nil {
+		progress = func(JavaInstallProgress) {}
+	}
+	vendor := strings.ToLower(strings.TrimSpace(opts.Vendor))
+	version := strings.TrimSpace(opts.Version)
+	switch vendor {
+	case "", JavaVendorTemurin, "adoptium":
+		major, err := strconv.Atoi(version)
+		if err != nil || major < 8 {
+			return JavaVersion{}, fmt.Errorf("invalid Java version %q: expected a major version such as 17 or 21", opts.Version)
+		}
+		if opts.Headless && major < 11 {
+			return JavaVersion{}, fmt.Errorf("headless runtimes need Java 11 or newer (jlink)")
+		}
+		return installTemurin(major, opts.Arch, opts.Headless, progress)
+	case JavaVendorMojang:
+		if opts.Headless {
+			return JavaVersion{}, fmt.Errorf("Mojang does not publish headless runtimes; use Temurin")
+		}
+		if opts.Arch != "" && NormalizeJavaArch(opts.Arch) != runtime.GOARCH {
+			return JavaVersion{}, fmt.Errorf("Mojang runtimes are installed for this system's architecture only")
+		}
+		return installMojangJava(version, progress)
+	default:
+		return JavaVersion{}, fmt.Errorf("unknown Java vendor %q (temurin, mojang)", opts.Vendor)
+	}
+}
+
+// temurinRuntimeName is the directory of a Temurin runtime: "temurin-21", "temurin-21-x64" for a build of
+// another architecture than the host's, and "-headless" for jlinked headless runtimes.
+func temurinRuntimeName(major int, arch string, headless bool) string {
+	name := fmt.Sprintf("%s-%d", JavaVendorTemurin, major)
+	if arch != "" && NormalizeJavaArch(arch) != runtime.GOARCH {
+		name += "-" + meta.AdoptiumArch(NormalizeJavaArch(arch))
+	}
+	if headless {
+		name += "-headless"
+	}
+	return name
+}
+
+// headlessJavaModules are the modules kept in a headless runtime: enough for Minecraft servers and mod
+// loaders' installers, without java.desktop.
+var headlessJavaModules = []string{
+	"java.base", "java.compiler", "java.instrument", "java.logging", "java.management", "java.naming",
+	"java.net.http", "java.prefs", "java.rmi", "java.scripting", "java.security.jgss", "java.security.sasl",
+	"java.sql", "java.xml", "java.xml.crypto", "jdk.crypto.ec", "jdk.management", "jdk.naming.dns",
+	"jdk.net", "jdk.unsupported", "jdk.zipfs",
+}
+
+// jlinkHeadless builds a headless runtime from the JDK at jdkHome into out.
+func jlinkHeadless(jdkHome, out string) error {
+	jlink := filepath.Join(jdkHome, "bin", "jlink")
+	if runtime.GOOS == "windows" {
+		jlink += ".exe"
+	}
+	args := []string{"--add-modules", strings.Join(headlessJavaModules, ","), "--strip-debug", "--no-man-pages", "--no-header-files", "--output", out}
+	if jmods := filepath.Join(jdkHome, "jmods"); fileExists(filepath.Join(jmods, "java.base.jmod")) {
+		args = append([]string{"--module-path", jmods}, args...)
+	}
+	cmd := exec.Command(jlink, args...)
+	setCmdNoWindow(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("jlink: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// temurinQuery selects the Temurin build installed for a major version, architecture and variant.
+func temurinQuery(major int, arch string, headless bool) meta.AdoptiumQuery {
+	q := meta.AdoptiumQuery{Major: major}
+	if headless {
+		// jlink and jmods only ship with the JDK
+		q.ImageType = "jdk"
+	}
+	if arch != "" {
+		q.Arch = meta.AdoptiumArch(NormalizeJavaArch(arch))
+	}
+	if IsMuslHost() {
+		// glibc builds do not start on musl; Adoptium publishes Alpine builds separately
+		q.OS = "alpine-linux"
+	}
+	return q
+}
+
+func installTemurin(major int, arch string, headless bool, progress func(JavaInstallProgress)) (JavaVersion, error) {
+	release, err := meta.FetchAdoptiumRelease(temurinQuery(major, arch, headless))
+	if err != nil {
+		return JavaVersion{}, fmt.Errorf("fetch Temurin release: %w", err)
+	}
+	if release.Package.Checksum == "" {
+		return JavaVersion{}, fmt.Errorf("Temurin release %s has no published checksum", release.ReleaseName)
+	}
+	archive := filepath.Join(env.TmpDir, "java", release.Package.Name)
+	defer os.Remove(archive)
+	for attempt := 1; ; attempt++ {
+		var reported int64
+		progress(JavaInstallProgress{Phase: "download", Done: 0, Total: release.Package.Size})
+		err = network.DownloadWithProgress(release.Package.Link, archive, release.Package.Checksum, func(done, total int64) {
+			// Reads are small; report every 512 KiB and at the end.
+			if done-reported >= 512<<10 || done == total {
+				reported = done
+				progress(JavaInstallProgress{Phase: "download", Done: done, Total: total})
+			}
+		})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, network.ErrChecksumMismatch) || attempt == javaDownloadAttempts {
+			return JavaVersion{}, fmt.Errorf("download %s: %w", release.Package.Name, err)
+		}
+	}
+	progress(JavaInstallProgress{Phase: "extract", Done: 0, Total: -1})
+	name := temurinRuntimeName(major, arch, headless)
+	path, err := installJavaArchive(archive, name, headless)
+	if err != nil {
+		return JavaVersion{}, err
+	}
+	return JavaVersion{Name: name, Path: path}, nil
+}
+
+func installMojangJava(version string, progress func(JavaInstallProgress)) (JavaVersion, error) {
+	if IsMuslHost() {
+		return JavaVersion{}, fmt.Errorf("Mojang runtimes are built for glibc and do not run on this musl system; install Temurin instead")
+	}
+	component := version
+	if major, err := strconv.Atoi(version); err == nil {
+		list, err := meta.FetchJavaManifestList(env.CachesDir)
+		if err != nil {
+			return JavaVersion{}, fmt.Errorf("retrieve java manifest list: %w", err)
+		}
+		var ok bool
+		if component, ok = list.ComponentForMajor(major); !ok {
+			return JavaVersion{}, fmt.Errorf("no Mojang runtime with Java %d for %s: %w", major, meta.JavaPlatform(), meta.ErrJavaNoVersion)
+		}
+	}
+	if component == "" || strings.ContainsAny(component, `/\`) || component == "." || component == ".." {
+		return JavaVersion{}, fmt.Errorf("invalid runtime component %q", version)
+	}
+	manifest, err := meta.FetchJavaManifest(component, env.CachesDir)
+	if err != nil {
+		return JavaVersion{}, fmt.Errorf("fetch Java manifest: %w", err)
+	}
+	if err := downloadMojangJava(manifest, component, progress); err != nil {
+		return JavaVersion{}, err
+	}
+	return JavaVersion{Name: component, Path: filepath.Join(env.JavaDir, component)}, nil
+}
+
+// downloadMojangJava downloads the files of a Mojang runtime manifest into env.JavaDir/dirName.
+func downloadMojangJava(manifest meta.JavaManifest, dirName string, progress func(JavaInstallProgress)) error {
+	// Each attempt only fetches the files that are still missing or do not match their SHA-1
+	for attempt := 1; ; attempt++ {
+		entries, symlinks := manifest.DownloadEntries(dirName)
+		total := int64(len(entries))
+		progress(JavaInstallProgress{Phase: "download", Done: 0, Total: total})
+		err := download(entries, symlinks, func(event any) {
+			if e, ok := event.(DownloadingEvent); ok {
+				progress(JavaInstallProgress{Phase: "download", Done: int64(e.Completed), Total: total})
+			}
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, network.ErrChecksumMismatch) || attempt == javaDownloadAttempts {
+			return fmt.Errorf("download files: %w", err)
+		}
+	}
+}
+
+// swapJavaDir replaces dest with the runtime in newDir. The previous runtime is moved aside first and
+// restored when the new one cannot be put in place, so dest is never left half-written.
+func swapJavaDir(newDir, dest string) error {
+	old := filepath.Join(filepath.Dir(dest), "."+filepath.Base(dest)+".old")
+	if err := os.RemoveAll(old); err != nil {
+		return fmt.Errorf("remove previous backup: %w", err)
+	}
+	hadOld := false
+	if _, err := os.Stat(dest); err == nil {
+		if err := os.Rename(dest, old); err != nil {
+			return fmt.Errorf("move previous runtime aside: %w", err)
+		}
+		hadOld = true
+	}
+	if err := os.Rename(newDir, dest); err != nil {
+		if hadOld {
+			_ = os.Rename(old, dest)
+		}
+		return fmt.Errorf("move runtime into place: %w", err)
+	}
+	_ = os.RemoveAll(old)
+	return nil
+}
+
+// installJavaArchive extracts a JDK/JRE archive and moves its Java home to env.JavaDir/name. With headless
+// the archive must be a JDK, from which a headless runtime is linked instead.
+func installJavaArchive(archive, name string, headless bool) (string, error) {
+	staging := filepath.Join(env.JavaDir, "."+name+".tmp")
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+
+	var err error
+	if strings.HasSuffix(strings.ToLower(archive), ".zip") {
+		err = extractZip(archive, staging)
+	} else {
+		err = extractTarGz(archive, staging)
+	}
+	if err != nil {
+		return "", fmt.Errorf("extract %s: %w", filepath.Base(archive), err)
+	}
+	home, err := findJavaHome(staging)
+	if err != nil {
+		return "", err
+	}
+	if headless {
+		linked := filepath.Join(staging, ".headless")
+		if err := jlinkHeadless(home, linked); err != nil {
+			return "", err
+		}
+		home = linked
+	}
+	dest := filepath.Join(env.JavaDir, name)
+	if err := swapJavaDir(home, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// javaExecutableName is the java launcher binary of this platform.
+func javaExecutableName() string {
+	if runtime.GOOS == "windows" {
+		return "java.exe"
+	}
+	return "java"
+}
+
+// findJavaHome returns the directory under root that contains bin/java (macOS bundles keep it in Contents/Home).
+func findJavaHome(root string) (string, error) {
+	var home string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == javaExecutableName() && filepath.Base(filepath.Dir(path)) == "bin" {
+			home = filepath.Dir(filepath.Dir(path))
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if home == "" {
+		return "", errors.New("archive does not contain a Java runtime (bin/java not found)")
+	}
+	return home, nil
+}
+
+// archiveTarget joins an archive entry name to dir, rejecting entries that escape it.
+func archiveTarget(dir, name string) (string, error) {
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal path in archive: %q", name)
+	}
+	return target, nil
+}
+
+func extractZip(archive, dir string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		target, err := archiveTarget(dir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := archiveTarget(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if _, err := archiveTarget(dir, filepath.Join(filepath.Dir(hdr.Name), hdr.Linkname)); err != nil || filepath.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("illegal symlink in archive: %q -> %q", hdr.Name, hdr.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeArchiveFile(target string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	perm := mode.Perm() | 0600
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,126 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"QMLauncher/internal/meta"
+	env "QMLauncher/pkg"
+)
+
+// JavaUpdate is the update state of one managed runtime.
+type JavaUpdate struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Vendor    string `json:"vendor"` // temurin | mojang
+	Current   string `json:"current"`
+	Latest    string `json:"latest"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
+var temurinNameRe = regexp.MustCompile(`^temurin-(\d+)(?:-(x64|aarch64|x32|arm|ppc64le|s390x|riscv64))?(-headless)?$`)
+
+var legacyJavaVersionRe = regexp.MustCompile(`^(\d+)u(\d+)`)
+
+// comparableJavaVersion strips build metadata and the legacy "1." prefix: "1.8.0_392" → "8.0.392",
+// "8u51" → "8.0.51", "21.0.2+13.0.LTS" → "21.0.2".
+func comparableJavaVersion(v string) string {
+	v = strings.TrimSpace(v)
+	if i := strings.IndexAny(v, "+-"); i >= 0 {
+		v = v[:i]
+	}
+	v = legacyJavaVersionRe.ReplaceAllString(v, "$1.0.$2")
+	return strings.ReplaceAll(strings.TrimPrefix(v, "1."), "_", ".")
+}
+
+// installedJavaVersion reads JAVA_VERSION from the runtime's release file, falling back to running it.
+func installedJavaVersion(home string) string {
+	if data, err := os.ReadFile(filepath.Join(home, "release")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(line), "JAVA_VERSION="); ok {
+				return strings.Trim(v, `"`)
+			}
+		}
+	}
+	if info, err := ProbeJava(JavaExecutable(home)); err == nil {
+		return info.Version
+	}
+	return ""
+}
+
+// CheckJavaUpdates compares every Temurin and Mojang runtime in env.JavaDir with the latest release of
+// its major version (Temurin) or component (Mojang). Other runtimes are not listed.
+func CheckJavaUpdates() ([]JavaUpdate, error) {
+	javas, err := ListInstalledJavaVersions()
+	if err != nil {
+		return nil, err
+	}
+	var mojang meta.JavaManifestList
+	out := []JavaUpdate{}
+	for _, j := range javas {
+		u := JavaUpdate{Name: j.Name, Path: j.Path, Current: installedJavaVersion(j.Path)}
+		if m := temurinNameRe.FindStringSubmatch(j.Name); m != nil {
+			u.Vendor = JavaVendorTemurin
+			major, _ := strconv.Atoi(m[1])
+			if release, err := meta.FetchAdoptiumRelease(temurinQuery(major, m[2], m[3] != "")); err != nil {
+				u.Error = err.Error()
+			} else {
+				u.Latest = release.Semver
+			}
+		} else {
+			if mojang == nil {
+				if mojang, err = meta.FetchJavaManifestList(env.CachesDir); err != nil {
+					return nil, fmt.Errorf("retrieve java manifest list: %w", err)
+				}
+			}
+			builds, ok := mojang[meta.JavaPlatform()][j.Name]
+			if !ok || len(builds) == 0 {
+				continue
+			}
+			u.Vendor = JavaVendorMojang
+			u.Latest = builds[0].Version.Name
+		}
+		if u.Error == "" && u.Current != "" && u.Latest != "" {
+			u.Available = meta.CompareModVersions(comparableJavaVersion(u.Current), comparableJavaVersion(u.Latest)) < 0
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// UpdateJava installs the latest release of a runtime found by CheckJavaUpdates in place. The new runtime
+// is prepared next to the old one and swapped in, so the directory name and every instance reference to it
+// stay valid.
+func UpdateJava(u JavaUpdate, progress func(JavaInstallProgress)) error {
+	if progress == nil {
+		progress = func(JavaInstallProgress) {}
+	}
+	switch u.Vendor {
+	case JavaVendorTemurin:
+		m := temurinNameRe.FindStringSubmatch(u.Name)
+		if m == nil {
+			return fmt.Errorf("%s is not a Temurin runtime", u.Name)
+		}
+		major, _ := strconv.Atoi(m[1])
+		_, err := installTemurin(major, m[2], m[3] != "", progress)
+		return err
+	case JavaVendorMojang:
+		manifest, err := meta.FetchJavaManifest(u.Name, env.CachesDir)
+		if err != nil {
+			return fmt.Errorf("fetch Java manifest: %w", err)
+		}
+		staging := "." + u.Name + ".update"
+		_ = os.RemoveAll(filepath.Join(env.JavaDir, staging))
+		defer os.RemoveAll(filepath.Join(env.JavaDir, staging))
+		if err := downloadMojangJava(manifest, staging, progress); err != nil {
+			return err
+		}
+		return swapJavaDir(filepath.Join(env.JavaDir, staging), filepath.Join(env.JavaDir, u.Name))
+	}
+	return fmt.Errorf("%s cannot be updated", u.Name)
+}
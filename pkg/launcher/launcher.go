@@ -3,6 +3,7 @@ package launcher
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -50,6 +51,10 @@ type LaunchOptions struct {
 	SkinURL string
 	CapeURL string
 
+	// JavaArgTemplates are JVM arguments keyed by Java major version or range ("17", "8-16", "21-"), added
+	// before JavaArgs (see JavaArgTemplate).
+	JavaArgTemplates map[string]string
+
 	skipAssets    bool
 	skipLibraries bool
 }
@@ -79,6 +84,26 @@ type DownloadingEvent struct {
 // PostProcessingEvent is called when, usually Forge, pre-processing begins.
 type PostProcessingEvent struct{}
 
+// JavaMismatchEvent is called when the configured Java does not match the major version the game requires.
+type JavaMismatchEvent struct {
+	Required int
+	Found    int
+	Path     string
+}
+
+// JavaRosettaEvent is called on Apple Silicon when the game will run on an x86_64 JVM through Rosetta 2.
+type JavaRosettaEvent struct {
+	Path string
+}
+
+// JavaInstallingEvent is called while a Temurin runtime is installed on demand (no Mojang runtime for this system).
+type JavaInstallingEvent struct {
+	Major int
+	Phase string
+	Done  int64
+	Total int64
+}
+
 // A Runner is a controller which manages the starting of the game.
 type Runner func(cmd *exec.Cmd) error
 
@@ -108,7 +133,8 @@ func ListInstalledJavaVersions() ([]JavaVersion, error) {
 
 	var javas []JavaVersion
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// Dot directories are staging areas of installs and updates in progress
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			name := entry.Name()
 			path := filepath.Join(env.JavaDir, name)
 
@@ -135,6 +161,7 @@ func ListInstalledJavaVersions() ([]JavaVersion, error) {
 type LaunchEnvironment struct {
 	GameDir   string
 	Java      string
+	JavaMajor int // major version of Java; 0 when unknown
 	MainClass string
 	Classpath []string
 	JavaArgs  []string
@@ -432,6 +459,13 @@ func Prepare(inst Instance, options LaunchOptions, watcher EventWatcher) (Launch
 		MainClass: version.MainClass,
 	}
 
+	// The configured Java may be a runtime name, alias or Java home rather than an executable path
+	if launchEnv.Java != "" && !fileExists(launchEnv.Java) {
+		if launchEnv.Java, err = ResolveJavaRuntime(options.Java); err != nil {
+			return LaunchEnvironment{}, fmt.Errorf("resolve Java: %w", err)
+		}
+	}
+
 	// On Windows, replace java.exe with javaw.exe if NoJavaWindow is requested
 	if runtime.GOOS == "windows" && options.NoJavaWindow && strings.HasSuffix(strings.ToLower(launchEnv.Java), "java.exe") {
 		launchEnv.Java = strings.TrimSuffix(launchEnv.Java, "java.exe") + "javaw.exe"
@@ -469,32 +503,63 @@ func Prepare(inst Instance, options LaunchOptions, watcher EventWatcher) (Launch
 		watcher(AssetsResolvedEvent{Total: len(assetIndex.Objects)})
 	}
 
-	// If no Java path is present, fetch Mojang Java downloads
+	// If no Java path is present, fetch Mojang Java downloads. Systems Mojang does not ship runtimes for
+	// get a Temurin build of the required major version instead.
+	requiredJava := RequiredJavaMajor(inst.GameVersion, version.JavaVersion.MajorVersion)
 	var symlinks map[string]string
 	if launchEnv.Java == "" {
-		manifest, err := meta.FetchJavaManifest(version.JavaVersion.Component, inst.CachesDir())
-		if err != nil {
+		component := version.JavaVersion.Component
+		if component == "" {
+			component = "jre-legacy"
+		}
+		var manifest meta.JavaManifest
+		if IsMuslHost() {
+			err = javaRuntimeSource(nil, component)
+		} else if list, listErr := meta.FetchJavaManifestList(inst.CachesDir()); listErr != nil {
+			err = fmt.Errorf("retrieve java manifest list: %w", listErr)
+		} else if err = javaRuntimeSource(list, component); err == nil {
+			manifest, err = meta.FetchJavaManifest(component, inst.CachesDir())
+		}
+		switch {
+		case err == nil:
+			var entries []network.DownloadEntry
+			entries, symlinks = manifest.DownloadEntries(component)
+			downloads = append(downloads, entries...)
+			launchEnv.Java = filepath.Join(env.JavaDir, component, "bin", "java")
+			launchEnv.JavaMajor = requiredJava
+		case (errors.Is(err, meta.ErrJavaBadSystem) || errors.Is(err, meta.ErrJavaNoVersion)) && requiredJava > 0:
+			if launchEnv.Java, err = managedJava(requiredJava, watcher); err != nil {
+				return LaunchEnvironment{}, fmt.Errorf("install Java %d: %w", requiredJava, err)
+			}
+			launchEnv.JavaMajor = requiredJava
+		default:
 			return LaunchEnvironment{}, fmt.Errorf("fetch Java manifest: %w", err)
 		}
-		var entries []network.DownloadEntry
-		entries, symlinks = manifest.DownloadEntries(version.JavaVersion.Component)
-		downloads = append(downloads, entries...)
 
-		exeName := "java"
 		if runtime.GOOS == "windows" {
+			exeName := "java.exe"
 			if options.NoJavaWindow {
 				exeName = "javaw.exe"
-			} else {
-				exeName = "java.exe"
 			}
+			launchEnv.Java = filepath.Join(filepath.Dir(launchEnv.Java), exeName)
+		}
+	} else if info, err := ProbeJava(launchEnv.Java); err == nil {
+		launchEnv.JavaMajor = info.Major
+		if !JavaSatisfies(requiredJava, info.Major) && watcher != nil {
+			watcher(JavaMismatchEvent{Required: requiredJava, Found: info.Major, Path: launchEnv.Java})
 		}
-		launchEnv.Java = filepath.Join(env.JavaDir, version.JavaVersion.Component, "bin", exeName)
 	}
 
 	if err := download(downloads, symlinks, watcher); err != nil {
 		return LaunchEnvironment{}, fmt.Errorf("download files: %w", err)
 	}
 
+	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" && watcher != nil {
+		if info, err := ProbeJava(launchEnv.Java); err == nil && NormalizeJavaArch(info.Arch) == "amd64" {
+			watcher(JavaRosettaEvent{Path: launchEnv.Java})
+		}
+	}
+
 	// Fetch Forge post processors, if any
 
 	var processors []meta.ForgeProcessor
@@ -623,6 +688,9 @@ func createArgs(launchEnv LaunchEnvironment, version meta.VersionMeta, options L
 	if options.MaxMemory != 0 {
 		java = append(java, fmt.Sprintf("-Xmx%dm", options.MaxMemory))
 	}
+	if template := JavaArgTemplate(options.JavaArgTemplates, launchEnv.JavaMajor); template != "" {
+		java = append(java, strings.Fields(template)...)
+	}
 	if options.JavaArgs != "" {
 		java = append(java, strings.Split(options.JavaArgs, " ")...)
 	}
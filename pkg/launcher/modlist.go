@@ -0,0 +1,112 @@
+package launcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// ModListEntry is one row of an exported mod list.
+type ModListEntry struct {
+	Name     string   `json:"name"`
+	ModID    string   `json:"modId,omitempty"`
+	Version  string   `json:"version,omitempty"`
+	Authors  []string `json:"authors,omitempty"`
+	Filename string   `json:"filename"`
+	Enabled  bool     `json:"enabled"`
+	Provider string   `json:"provider,omitempty"` // modrinth | curseforge | "" (unknown)
+	URL      string   `json:"url,omitempty"`      // project page
+}
+
+// ModList is the shareable list of an instance's mods.
+type ModList struct {
+	Instance    string         `json:"instance"`
+	GameVersion string         `json:"gameVersion"`
+	Loader      string         `json:"loader"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Mods        []ModListEntry `json:"mods"`
+}
+
+// ModListFormats are the formats accepted by RenderModList, with their file extensions.
+var ModListFormats = map[string]string{"md": ".md", "json": ".json", "html": ".html"}
+
+// RenderModList renders list as markdown ("md"), JSON ("json") or a standalone HTML page ("html").
+func RenderModList(list ModList, format string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "json":
+		return json.MarshalIndent(list, "", "  ")
+	case "md", "markdown":
+		return renderModListMarkdown(list), nil
+	case "html":
+		var buf bytes.Buffer
+		if err := modListHTML.Execute(&buf, list); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown mod list format %q (md, json, html)", format)
+	}
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "\r", "").Replace(s)
+}
+
+func renderModListMarkdown(list ModList) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", markdownCell(list.Instance))
+	fmt.Fprintf(&b, "Minecraft %s · %s · %d mods\n\n", list.GameVersion, list.Loader, len(list.Mods))
+	b.WriteString("| Mod | Version | Authors | Link |\n|---|---|---|---|\n")
+	for _, m := range list.Mods {
+		name := markdownCell(m.Name)
+		if !m.Enabled {
+			name = "~~" + name + "~~"
+		}
+		link := ""
+		if m.URL != "" {
+			link = "[" + providerLabel(m.Provider) + "](" + m.URL + ")"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", name, markdownCell(m.Version), markdownCell(strings.Join(m.Authors, ", ")), link)
+	}
+	return []byte(b.String())
+}
+
+func providerLabel(provider string) string {
+	switch provider {
+	case "modrinth":
+		return "Modrinth"
+	case "curseforge":
+		return "CurseForge"
+	default:
+		return "Link"
+	}
+}
+
+var modListHTML = template.Must(template.New("modlist").Funcs(template.FuncMap{
+	"join":     strings.Join,
+	"provider": providerLabel,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Instance}}</title>
+<style>
+body{font-family:sans-serif;margin:2em}
+table{border-collapse:collapse}
+td,th{border:1px solid #ccc;padding:4px 8px;text-align:left}
+.disabled{color:#999;text-decoration:line-through}
+</style>
+</head>
+<body>
+<h1>{{.Instance}}</h1>
+<p>Minecraft {{.GameVersion}} · {{.Loader}} · {{len .Mods}} mods</p>
+<table>
+<tr><th>Mod</th><th>Version</th><th>Authors</th><th>Link</th></tr>
+{{range .Mods}}<tr{{if not .Enabled}} class="disabled"{{end}}><td>{{.Name}}</td><td>{{.Version}}</td><td>{{join .Authors ", "}}</td><td>{{if .URL}}<a href="{{.URL}}">{{provider .Provider}}</a>{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,327 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"QMLauncher/internal/meta"
+	"QMLauncher/internal/network"
+)
+
+// ModLockFileName is the per-instance lockfile of managed content (mods, resource packs, shaders).
+const ModLockFileName = "mods.lock"
+
+// ModLockEntry records exactly which file was installed for a managed project.
+type ModLockEntry struct {
+	Category      string `json:"category"` // mods | resourcepacks | shaderpacks | datapacks
+	Provider      string `json:"provider"` // modrinth | curseforge | url | local
+	ProjectID     string `json:"projectId,omitempty"`
+	Slug          string `json:"slug,omitempty"`
+	Title         string `json:"title,omitempty"`
+	VersionID     string `json:"versionId,omitempty"`
+	VersionNumber string `json:"versionNumber,omitempty"`
+	Filename      string `json:"filename"`
+	URL           string `json:"url,omitempty"`
+	Sha1          string `json:"sha1,omitempty"`
+	Sha512        string `json:"sha512,omitempty"`
+	Disabled      bool   `json:"disabled,omitempty"` // file is kept as *.disabled
+	// Dependencies are the provider project ids this file requires.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Pin restricts what mod updates may replace this file with (nil: newest compatible version).
+	Pin         *ModPin   `json:"pin,omitempty"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// ModPin holds a mod to a provider, a release channel or one exact version.
+type ModPin struct {
+	Provider  string `json:"provider,omitempty"`  // modrinth | curseforge: only updates from this provider
+	Channel   string `json:"channel,omitempty"`   // release | beta | alpha: newest version in the channel
+	VersionID string `json:"versionId,omitempty"` // exact version: never updated
+}
+
+// IsZero reports whether the pin restricts nothing.
+func (p *ModPin) IsZero() bool {
+	return p == nil || (p.Provider == "" && p.Channel == "" && p.VersionID == "")
+}
+
+// ModLock is the content of mods.lock.
+type ModLock struct {
+	Version     int            `json:"version"`
+	GameVersion string         `json:"gameVersion,omitempty"`
+	Loader      string         `json:"loader,omitempty"`
+	Entries     []ModLockEntry `json:"entries"`
+}
+
+func modLockPath(instanceDir string) string {
+	return filepath.Join(instanceDir, ModLockFileName)
+}
+
+// plainModLockFilename reports whether a lock file name is a plain file name: a shared or edited
+// mods.lock must not place files outside the category directory.
+func plainModLockFilename(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`) && filepath.Base(name) == name
+}
+
+// LoadModLock reads mods.lock; a missing file yields an empty lock. Entries whose file name is not a
+// plain name are dropped.
+func LoadModLock(instanceDir string) (ModLock, error) {
+	data, err := os.ReadFile(modLockPath(instanceDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ModLock{Version: 1, Entries: []ModLockEntry{}}, nil
+		}
+		return ModLock{}, err
+	}
+	var lock ModLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return ModLock{}, err
+	}
+	lock.Entries = slices.DeleteFunc(lock.Entries, func(e ModLockEntry) bool { return !plainModLockFilename(e.Filename) })
+	if lock.Entries == nil {
+		lock.Entries = []ModLockEntry{}
+	}
+	return lock, nil
+}
+
+// SaveModLock writes mods.lock with entries sorted by category and file name (stable diffs).
+func SaveModLock(instanceDir string, lock ModLock) error {
+	lock.Version = 1
+	sort.SliceStable(lock.Entries, func(i, j int) bool {
+		if lock.Entries[i].Category != lock.Entries[j].Category {
+			return lock.Entries[i].Category < lock.Entries[j].Category
+		}
+		return strings.ToLower(lock.Entries[i].Filename) < strings.ToLower(lock.Entries[j].Filename)
+	})
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(modLockPath(instanceDir), data, 0644)
+}
+
+// Find returns the index of the entry for category and file name (".disabled" ignored), or -1.
+func (lock *ModLock) Find(category, filename string) int {
+	key := installMapKey(category, filename)
+	for i, e := range lock.Entries {
+		if installMapKey(e.Category, e.Filename) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindProject returns the index of the entry for a provider project id or slug in category, or -1.
+func (lock *ModLock) FindProject(category, provider, projectIDOrSlug string) int {
+	category = strings.ToLower(strings.TrimSpace(category))
+	for i, e := range lock.Entries {
+		if e.Category != category || !strings.EqualFold(e.Provider, provider) {
+			continue
+		}
+		if (e.ProjectID != "" && e.ProjectID == projectIDOrSlug) || (e.Slug != "" && strings.EqualFold(e.Slug, projectIDOrSlug)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Upsert adds e or replaces the entry for the same project (or, for unmanaged files, the same file name).
+func (lock *ModLock) Upsert(e ModLockEntry) {
+	e.Category = strings.ToLower(strings.TrimSpace(e.Category))
+	e.Provider = strings.ToLower(strings.TrimSpace(e.Provider))
+	e.Filename = normalizeInstallMapKeySegment(filepath.Base(e.Filename))
+	if e.InstalledAt.IsZero() {
+		e.InstalledAt = time.Now().UTC()
+	}
+	idx := -1
+	if e.ProjectID != "" || e.Slug != "" {
+		id := e.ProjectID
+		if id == "" {
+			id = e.Slug
+		}
+		idx = lock.FindProject(e.Category, e.Provider, id)
+	}
+	if idx < 0 {
+		idx = lock.Find(e.Category, e.Filename)
+	}
+	if idx >= 0 {
+		if e.Pin == nil {
+			e.Pin = lock.Entries[idx].Pin // reinstalling a project keeps its pin
+		}
+		lock.Entries[idx] = e
+		return
+	}
+	lock.Entries = append(lock.Entries, e)
+}
+
+// Remove deletes the entry for category and file name; it reports whether one was removed.
+func (lock *ModLock) Remove(category, filename string) bool {
+	idx := lock.Find(category, filename)
+	if idx < 0 {
+		return false
+	}
+	lock.Entries = append(lock.Entries[:idx], lock.Entries[idx+1:]...)
+	return true
+}
+
+// SetDisabled records the enabled/disabled state of the entry for category and file name.
+// It reports whether the entry exists and its state changed.
+func (lock *ModLock) SetDisabled(category, filename string, disabled bool) bool {
+	idx := lock.Find(category, filename)
+	if idx < 0 || lock.Entries[idx].Disabled == disabled {
+		return false
+	}
+	lock.Entries[idx].Disabled = disabled
+	return true
+}
+
+// SetModLockDisabled updates the disabled flag in mods.lock; unmanaged files are ignored.
+func SetModLockDisabled(instanceDir, category, filename string, disabled bool) error {
+	lock, err := LoadModLock(instanceDir)
+	if err != nil {
+		return err
+	}
+	if !lock.SetDisabled(category, filename, disabled) {
+		return nil
+	}
+	return SaveModLock(instanceDir, lock)
+}
+
+// SetModLockPin sets (or with a zero pin clears) the pin of a locked file.
+func SetModLockPin(instanceDir, category, filename string, pin *ModPin) error {
+	lock, err := LoadModLock(instanceDir)
+	if err != nil {
+		return err
+	}
+	idx := lock.Find(category, filename)
+	if idx < 0 {
+		return fmt.Errorf("%s is not in %s", filename, ModLockFileName)
+	}
+	if pin.IsZero() {
+		pin = nil
+	}
+	lock.Entries[idx].Pin = pin
+	return SaveModLock(instanceDir, lock)
+}
+
+// RecordModLockEntry loads mods.lock, upserts e and saves it.
+func RecordModLockEntry(inst Instance, e ModLockEntry) error {
+	dir := inst.Dir()
+	lock, err := LoadModLock(dir)
+	if err != nil {
+		return err
+	}
+	lock.GameVersion = inst.GameVersion
+	lock.Loader = string(inst.Loader)
+	lock.Upsert(e)
+	return SaveModLock(dir, lock)
+}
+
+// ModLockRestore is the outcome of RestoreFromModLock for one entry.
+type ModLockRestore struct {
+	Category string `json:"category"`
+	Filename string `json:"filename"`
+	Status   string `json:"status"` // present | installed | failed
+	Error    string `json:"error,omitempty"`
+}
+
+func modLockCategoryDir(instanceDir, category string) (string, error) {
+	switch category {
+	case "mods", "resourcepacks", "shaderpacks", "datapacks":
+		return filepath.Join(instanceDir, category), nil
+	default:
+		return "", fmt.Errorf("unknown category %q", category)
+	}
+}
+
+// RestoreFromModLock makes the instance match mods.lock: every locked file that is missing or whose
+// SHA-1 differs is downloaded again from its recorded URL (CurseForge files by project and file id) and
+// verified. Files not in the lock are left alone.
+func RestoreFromModLock(inst Instance) ([]ModLockRestore, error) {
+	dir := inst.Dir()
+	lock, err := LoadModLock(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ModLockRestore, 0, len(lock.Entries))
+	for _, e := range lock.Entries {
+		r := ModLockRestore{Category: e.Category, Filename: e.Filename}
+		catDir, err := modLockCategoryDir(dir, e.Category)
+		if err != nil {
+			r.Status, r.Error = "failed", err.Error()
+			out = append(out, r)
+			continue
+		}
+		name := e.Filename
+		if e.Disabled {
+			name += ".disabled"
+		}
+		dest := filepath.Join(catDir, name)
+		if sum, err := meta.FileSHA1(dest); err == nil && (e.Sha1 == "" || strings.EqualFold(sum, e.Sha1)) {
+			r.Status = "present"
+			out = append(out, r)
+			continue
+		}
+		url := e.URL
+		if e.Provider == "curseforge" {
+			// CurseForge download URLs are resolved per file; the recorded one may be missing or stale
+			if u, err := curseForgeLockURL(e); err == nil {
+				url = u
+			}
+		}
+		if url == "" {
+			// Side-loaded files have no URL; the shared content store may still hold them.
+			if err := network.LinkFromContentStore(e.Sha1, dest); err == nil {
+				r.Status = "installed"
+			} else {
+				r.Status, r.Error = "failed", "no download URL in lock"
+			}
+			out = append(out, r)
+			continue
+		}
+		if err := os.MkdirAll(catDir, 0755); err != nil {
+			r.Status, r.Error = "failed", err.Error()
+			out = append(out, r)
+			continue
+		}
+		if err := network.DownloadFileCached(network.DownloadEntry{URL: url, Path: dest, Sha1: e.Sha1}); err != nil {
+			_ = os.Remove(dest)
+			r.Status, r.Error = "failed", err.Error()
+			out = append(out, r)
+			continue
+		}
+		// The other state of the same file would be loaded (or shadowed) too.
+		other := filepath.Join(catDir, e.Filename)
+		if !e.Disabled {
+			other += ".disabled"
+		}
+		_ = os.Remove(other)
+		r.Status = "installed"
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// curseForgeLockURL resolves the download URL of a CurseForge lock entry from its project and file id.
+func curseForgeLockURL(e ModLockEntry) (string, error) {
+	modID, err := strconv.ParseInt(e.ProjectID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("curseforge project id %q: %w", e.ProjectID, err)
+	}
+	fileID, err := strconv.ParseInt(e.VersionID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("curseforge file id %q: %w", e.VersionID, err)
+	}
+	client, err := meta.NewCurseForgeClient()
+	if err != nil {
+		return "", err
+	}
+	return client.FileDownloadURL(modID, fileID, e.Filename)
+}
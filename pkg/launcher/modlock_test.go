@@ -0,0 +1,29 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadModLockDropsUnsafeFilenames(t *testing.T) {
+	dir := t.TempDir()
+	const lock = `{"version":1,"entries":[
+		{"category":"mods","provider":"modrinth","filename":"sodium.jar"},
+		{"category":"mods","provider":"modrinth","filename":"../../evil.jar"},
+		{"category":"mods","provider":"modrinth","filename":"sub/evil.jar"},
+		{"category":"mods","provider":"modrinth","filename":"..\\evil.jar"},
+		{"category":"mods","provider":"modrinth","filename":".."},
+		{"category":"mods","provider":"modrinth","filename":""}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, ModLockFileName), []byte(lock), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadModLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Entries) != 1 || got.Entries[0].Filename != "sodium.jar" {
+		t.Fatalf("entries = %+v, want only sodium.jar", got.Entries)
+	}
+}
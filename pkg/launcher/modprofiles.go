@@ -0,0 +1,142 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ModProfile is a named set of enabled mods (e.g. "performance", "shaders", "debug").
+// Mods in mods/ that are not listed are disabled when the profile is applied.
+type ModProfile struct {
+	Name string `json:"name"`
+	// Mods are file names in mods/ without the .disabled suffix.
+	Mods []string `json:"mods"`
+	// HasConfigs is true when a snapshot of config/ is stored with the profile.
+	HasConfigs bool `json:"hasConfigs,omitempty"`
+}
+
+// ModProfiles is the content of .qmlauncher/mod-profiles.json.
+type ModProfiles struct {
+	Active   string       `json:"active,omitempty"`
+	Profiles []ModProfile `json:"profiles"`
+}
+
+func modProfilesFilePath(instanceDir string) string {
+	return filepath.Join(instanceDir, ".qmlauncher", "mod-profiles.json")
+}
+
+// ModProfileConfigDir is where a profile's config/ snapshot is kept.
+func ModProfileConfigDir(instanceDir, name string) string {
+	return filepath.Join(instanceDir, ".qmlauncher", "mod-profiles", name, "config")
+}
+
+// ValidateModProfileName rejects names that cannot be used as a directory name.
+func ValidateModProfileName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:*?"<>|`) {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	return nil
+}
+
+// LoadModProfiles reads the instance's mod profiles; a missing file yields none.
+func LoadModProfiles(instanceDir string) (ModProfiles, error) {
+	data, err := os.ReadFile(modProfilesFilePath(instanceDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ModProfiles{Profiles: []ModProfile{}}, nil
+		}
+		return ModProfiles{}, err
+	}
+	var p ModProfiles
+	if err := json.Unmarshal(data, &p); err != nil {
+		return ModProfiles{}, err
+	}
+	if p.Profiles == nil {
+		p.Profiles = []ModProfile{}
+	}
+	return p, nil
+}
+
+// SaveModProfiles writes .qmlauncher/mod-profiles.json.
+func SaveModProfiles(instanceDir string, p ModProfiles) error {
+	path := modProfilesFilePath(instanceDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Find returns the index of the profile with the given name (case-insensitive), or -1.
+func (p *ModProfiles) Find(name string) int {
+	return slices.IndexFunc(p.Profiles, func(m ModProfile) bool { return strings.EqualFold(m.Name, name) })
+}
+
+// CopyTree copies every regular file under src into dst, overwriting existing files.
+// A missing src is not an error.
+func CopyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && path == src {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
+// ReplaceTree makes dst an exact copy of src: files missing from src are removed from dst. The copy is
+// made next to dst first, so a failed copy leaves dst untouched. A missing src yields an empty dst.
+func ReplaceTree(src, dst string) error {
+	tmp := dst + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := CopyTree(src, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
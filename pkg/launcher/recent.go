@@ -0,0 +1,199 @@
+package launcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	env "QMLauncher/pkg"
+)
+
+// DefaultRecentConnectionsMax is the number of entries the recent connections list keeps by default.
+const DefaultRecentConnectionsMax = 20
+
+// Dedupe rules of the recent connections list.
+const (
+	RecentDedupeInstanceServer = "instance_server" // one entry per instance and server (default)
+	RecentDedupeServer         = "server"          // one entry per server, whatever instance joined it
+)
+
+// RecentConnectionsPolicy is how RecordRecentConnection dedupes and caps the list.
+type RecentConnectionsPolicy struct {
+	Max        int    `json:"max"`        // entries kept; 0 = DefaultRecentConnectionsMax, negative = no limit
+	Dedupe     string `json:"dedupe"`     // RecentDedupeInstanceServer or RecentDedupeServer
+	KeepPinned bool   `json:"keepPinned"` // pinned entries don't count against Max and are never dropped
+}
+
+// DefaultRecentConnectionsPolicy returns the policy used when nothing is configured.
+func DefaultRecentConnectionsPolicy() RecentConnectionsPolicy {
+	return RecentConnectionsPolicy{Max: DefaultRecentConnectionsMax, Dedupe: RecentDedupeInstanceServer, KeepPinned: true}
+}
+
+// ValidateRecentDedupe checks a dedupe rule; "" means the default.
+func ValidateRecentDedupe(rule string) error {
+	switch rule {
+	case "", RecentDedupeInstanceServer, RecentDedupeServer:
+		return nil
+	}
+	return fmt.Errorf("unknown dedupe rule %q (use %s or %s)", rule, RecentDedupeInstanceServer, RecentDedupeServer)
+}
+
+// duplicate reports whether a and b are the same entry under the policy.
+func (p RecentConnectionsPolicy) duplicate(a, b RecentConnection) bool {
+	if p.Dedupe == RecentDedupeServer {
+		return a.Server == b.Server || (a.ServerID != 0 && a.ServerID == b.ServerID)
+	}
+	return a.Instance == b.Instance && a.Server == b.Server
+}
+
+// RecentConnection is one quick-launch entry: an instance started connected to a server.
+type RecentConnection struct {
+	Instance   string    `json:"instance"`
+	Server     string    `json:"server"` // host:port
+	ServerID   uint      `json:"server_id,omitempty"`
+	ServerName string    `json:"server_name,omitempty"`
+	Account    string    `json:"account,omitempty"`
+	Time       time.Time `json:"time"`
+	Pinned     bool      `json:"pinned,omitempty"`
+}
+
+var recentMu sync.Mutex
+
+func recentConnectionsPath() string {
+	return filepath.Join(env.RootDir, ".recent_connections.json")
+}
+
+// sortRecentConnections orders pinned entries first, then newest first.
+func sortRecentConnections(list []RecentConnection) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Pinned != list[j].Pinned {
+			return list[i].Pinned
+		}
+		return list[i].Time.After(list[j].Time)
+	})
+}
+
+func readRecentConnections() ([]RecentConnection, error) {
+	data, err := os.ReadFile(recentConnectionsPath())
+	if os.IsNotExist(err) {
+		return []RecentConnection{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	list := []RecentConnection{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("read recent connections: %w", err)
+	}
+	sortRecentConnections(list)
+	return list, nil
+}
+
+func writeRecentConnections(list []RecentConnection) error {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(recentConnectionsPath(), data, 0644)
+}
+
+// RecentConnections returns the recent connections, pinned first, then newest first.
+func RecentConnections() ([]RecentConnection, error) {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+	return readRecentConnections()
+}
+
+// RecordRecentConnection moves the connection to the top of the list, replacing its duplicates under
+// the policy, and drops the oldest entries beyond the policy's cap. A duplicate's pin and display name
+// carry over.
+func RecordRecentConnection(c RecentConnection, policy RecentConnectionsPolicy) error {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+	list, err := readRecentConnections()
+	if err != nil {
+		list = []RecentConnection{}
+	}
+	if c.Time.IsZero() {
+		c.Time = time.Now()
+	}
+	if policy.Max == 0 {
+		policy.Max = DefaultRecentConnectionsMax
+	}
+	kept := []RecentConnection{}
+	for _, e := range list {
+		if policy.duplicate(e, c) {
+			c.Pinned = c.Pinned || e.Pinned
+			if c.ServerName == "" {
+				c.ServerName = e.ServerName
+			}
+			continue
+		}
+		kept = append(kept, e)
+	}
+	list = append([]RecentConnection{c}, kept...)
+	sortRecentConnections(list)
+	counted := 0
+	capped := list[:0]
+	for _, e := range list {
+		if !(e.Pinned && policy.KeepPinned) {
+			if counted++; policy.Max > 0 && counted > policy.Max {
+				continue
+			}
+		}
+		capped = append(capped, e)
+	}
+	return writeRecentConnections(capped)
+}
+
+// updateRecentConnection applies fn to entry n (1-based, in RecentConnections order); fn returning
+// false removes the entry.
+func updateRecentConnection(n int, fn func(*RecentConnection) bool) error {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+	list, err := readRecentConnections()
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > len(list) {
+		return fmt.Errorf("no recent connection #%d (%d in list)", n, len(list))
+	}
+	if !fn(&list[n-1]) {
+		list = append(list[:n-1], list[n:]...)
+	}
+	sortRecentConnections(list)
+	return writeRecentConnections(list)
+}
+
+// RemoveRecentConnection deletes entry n (1-based).
+func RemoveRecentConnection(n int) error {
+	return updateRecentConnection(n, func(*RecentConnection) bool { return false })
+}
+
+// PinRecentConnection pins or unpins entry n (1-based). Pinned entries stay on top.
+func PinRecentConnection(n int, pinned bool) error {
+	return updateRecentConnection(n, func(c *RecentConnection) bool {
+		c.Pinned = pinned
+		return true
+	})
+}
+
+// ClearRecentConnections removes all entries, or only the unpinned ones when keepPinned is set.
+func ClearRecentConnections(keepPinned bool) error {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+	list, err := readRecentConnections()
+	if err != nil {
+		list = []RecentConnection{}
+	}
+	kept := []RecentConnection{}
+	for _, e := range list {
+		if keepPinned && e.Pinned {
+			kept = append(kept, e)
+		}
+	}
+	return writeRecentConnections(kept)
+}
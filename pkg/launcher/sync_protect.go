@@ -0,0 +1,133 @@
+package launcher
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// ValidateSyncPattern checks a sync.protect pattern: a slash-separated path relative to the instance
+// where segments may use path.Match wildcards and "**" matches any number of directories.
+func ValidateSyncPattern(pattern string) error {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	for _, seg := range strings.Split(pattern, "/") {
+		if seg == ".." {
+			return fmt.Errorf("pattern %q leaves the instance directory", pattern)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchSyncSegments matches path segments against pattern segments, "**" spanning zero or more segments.
+func matchSyncSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSyncSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchSyncPattern reports whether rel (slash-separated, relative to the instance) or one of its parent
+// directories matches pattern.
+func matchSyncPattern(pattern, rel string) bool {
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	if pattern == "" {
+		return false
+	}
+	rel = strings.Trim(path.Clean("/"+strings.ReplaceAll(rel, "\\", "/")), "/")
+	name := strings.Split(rel, "/")
+	segs := strings.Split(pattern, "/")
+	for n := len(name); n > 0; n-- {
+		if matchSyncSegments(segs, name[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProtected reports whether rel (slash-separated, relative to the instance) or one of its parent
+// directories matches a sync.protect pattern, so "config/xaero" protects everything below it as well.
+func (c SyncConfig) IsProtected(rel string) bool {
+	for _, pattern := range c.Protect {
+		if matchSyncPattern(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflict strategies for local files whose content differs from the server manifest.
+const (
+	SyncOverwrite = "overwrite"  // replace the local file (default)
+	SyncKeepLocal = "keep-local" // leave the local file as is
+	SyncKeepBoth  = "keep-both"  // keep a backup of the local file next to the server copy
+	SyncAsk       = "ask"        // let the player choose
+)
+
+// ValidateConflictStrategy checks a sync.on_conflict / sync.conflicts value.
+func ValidateConflictStrategy(strategy string) error {
+	switch strategy {
+	case SyncOverwrite, SyncKeepLocal, SyncKeepBoth, SyncAsk:
+		return nil
+	}
+	return fmt.Errorf("invalid conflict strategy %q (overwrite, keep-local, keep-both or ask)", strategy)
+}
+
+// ConflictStrategy returns the strategy for a modified local file: the sync.conflicts entry with the
+// most specific (longest) matching pattern, then sync.on_conflict, then overwrite.
+func (c SyncConfig) ConflictStrategy(rel string) string {
+	best, strategy := "", ""
+	for pattern, s := range c.Conflicts {
+		// Ties are broken by name so the result does not depend on map order
+		longer := len(pattern) > len(best) || (len(pattern) == len(best) && pattern < best)
+		if (strategy == "" || longer) && matchSyncPattern(pattern, rel) && ValidateConflictStrategy(s) == nil {
+			best, strategy = pattern, s
+		}
+	}
+	if strategy != "" {
+		return strategy
+	}
+	if ValidateConflictStrategy(c.OnConflict) == nil {
+		return c.OnConflict
+	}
+	return SyncOverwrite
+}
+
+// syncConflictBackupMarker separates a file name from the timestamp of its keep-both backup.
+const syncConflictBackupMarker = ".local-"
+
+// SyncConflictBackupPath returns where keep-both saves the local version of file, e.g.
+// "options.txt.local-20240131-184502". The suffix keeps mods and packs from being loaded by the game.
+func SyncConflictBackupPath(file string) string {
+	return file + syncConflictBackupMarker + time.Now().Format(syncTrashBatchLayout)
+}
+
+// IsSyncConflictBackup reports whether rel is a backup made by keep-both.
+func IsSyncConflictBackup(rel string) bool {
+	i := strings.LastIndex(rel, syncConflictBackupMarker)
+	if i < 0 {
+		return false
+	}
+	_, err := time.Parse(syncTrashBatchLayout, rel[i+len(syncConflictBackupMarker):])
+	return err == nil
+}
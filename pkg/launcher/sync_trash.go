@@ -0,0 +1,195 @@
+package launcher
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncTrashDir is the directory of an instance where sync moves files the server manifest no longer lists.
+const SyncTrashDir = ".sync-trash"
+
+// syncTrashBatchLayout names a batch: one directory per sync run.
+const syncTrashBatchLayout = "20060102-150405"
+
+const (
+	defaultSyncTrashDays    = 14
+	defaultSyncTrashBatches = 10
+)
+
+// SyncTrashBatch is the set of files quarantined by one sync run.
+type SyncTrashBatch struct {
+	ID      string    `json:"id"` // directory name under .sync-trash, e.g. 20240131-184502
+	Created time.Time `json:"created"`
+	Files   []string  `json:"files"` // slash-separated, relative to the instance
+	Size    int64     `json:"size"`
+}
+
+// NewSyncTrashBatch returns the ID of a new quarantine batch for a sync starting now.
+func NewSyncTrashBatch() string {
+	return time.Now().Format(syncTrashBatchLayout)
+}
+
+// trashBatchDir returns the directory of a batch, rejecting IDs that would leave .sync-trash.
+func trashBatchDir(instanceDir, batch string) (string, error) {
+	if _, err := time.ParseInLocation(syncTrashBatchLayout, batch, time.Local); err != nil {
+		return "", fmt.Errorf("invalid sync trash batch %q", batch)
+	}
+	return filepath.Join(instanceDir, SyncTrashDir, batch), nil
+}
+
+// QuarantineSyncFile moves rel (a file or directory, slash-separated, relative to the instance) into
+// the batch under .sync-trash, keeping its path so it can be restored.
+func QuarantineSyncFile(instanceDir, batch, rel string) error {
+	dir, err := trashBatchDir(instanceDir, batch)
+	if err != nil {
+		return err
+	}
+	rel = strings.Trim(path.Clean("/"+filepath.ToSlash(rel)), "/")
+	if rel == "" || rel == SyncTrashDir || strings.HasPrefix(rel, SyncTrashDir+"/") {
+		return fmt.Errorf("cannot quarantine %q", rel)
+	}
+	dest := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(instanceDir, filepath.FromSlash(rel)), dest)
+}
+
+// ListSyncTrash returns the quarantine batches of an instance, newest first.
+func ListSyncTrash(instanceDir string) ([]SyncTrashBatch, error) {
+	entries, err := os.ReadDir(filepath.Join(instanceDir, SyncTrashDir))
+	if os.IsNotExist(err) {
+		return []SyncTrashBatch{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	batches := []SyncTrashBatch{}
+	for _, e := range entries {
+		created, err := time.ParseInLocation(syncTrashBatchLayout, e.Name(), time.Local)
+		if !e.IsDir() || err != nil {
+			continue
+		}
+		batch := SyncTrashBatch{ID: e.Name(), Created: created, Files: []string{}}
+		root := filepath.Join(instanceDir, SyncTrashDir, e.Name())
+		_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(root, p)
+			batch.Files = append(batch.Files, filepath.ToSlash(rel))
+			if info, err := d.Info(); err == nil {
+				batch.Size += info.Size()
+			}
+			return nil
+		})
+		batches = append(batches, batch)
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].ID > batches[j].ID })
+	return batches, nil
+}
+
+// RestoreSyncTrash moves files of a batch back into the instance: only the given paths (files or
+// directories, relative to the instance), or the whole batch when paths is empty. Files that exist in
+// the instance again are left in the trash and returned as skipped.
+func RestoreSyncTrash(instanceDir, batch string, paths []string) (restored, skipped []string, err error) {
+	root, err := trashBatchDir(instanceDir, batch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, nil, fmt.Errorf("sync trash batch %q not found", batch)
+	}
+	var wanted []string
+	for _, p := range paths {
+		if p = strings.Trim(path.Clean("/"+filepath.ToSlash(strings.TrimSpace(p))), "/"); p != "" {
+			wanted = append(wanted, p)
+		}
+	}
+	selected := func(rel string) bool {
+		if len(wanted) == 0 {
+			return true
+		}
+		for _, w := range wanted {
+			if rel == w || strings.HasPrefix(rel, w+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	restored, skipped = []string{}, []string{}
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		r, _ := filepath.Rel(root, p)
+		rel := filepath.ToSlash(r)
+		if !selected(rel) {
+			return nil
+		}
+		dest := filepath.Join(instanceDir, r)
+		if _, err := os.Lstat(dest); err == nil {
+			skipped = append(skipped, rel)
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(p, dest); err != nil {
+			return err
+		}
+		restored = append(restored, rel)
+		return nil
+	})
+	removeEmptyDirs(root)
+	return restored, skipped, err
+}
+
+// removeEmptyDirs removes dir and its subdirectories that hold no files.
+func removeEmptyDirs(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			removeEmptyDirs(filepath.Join(dir, e.Name()))
+		}
+	}
+	_ = os.Remove(dir) // fails while the directory is not empty
+}
+
+// PruneSyncTrash deletes quarantine batches older than the retention period and beyond the number of
+// batches kept (sync.trash_days and sync.trash_batches; 0 = default, negative = no limit). It returns the
+// IDs of the deleted batches.
+func PruneSyncTrash(instanceDir string, cfg SyncConfig) ([]string, error) {
+	days, keep := cfg.TrashDays, cfg.TrashBatches
+	if days == 0 {
+		days = defaultSyncTrashDays
+	}
+	if keep == 0 {
+		keep = defaultSyncTrashBatches
+	}
+	batches, err := ListSyncTrash(instanceDir)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	pruned := []string{}
+	for i, b := range batches {
+		if (keep < 0 || i < keep) && (days < 0 || b.Created.After(cutoff)) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(instanceDir, SyncTrashDir, b.ID)); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, b.ID)
+	}
+	return pruned, nil
+}
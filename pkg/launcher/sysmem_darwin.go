@@ -0,0 +1,19 @@
+//go:build darwin
+
+package launcher
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// totalMemoryMB returns the physical memory of the host in MB, or 0 when unknown.
+func totalMemoryMB() int {
+	out, err := exec.Command("sysctl", "-n", "hw.memsize").Output()
+	if err != nil {
+		return 0
+	}
+	bytes, _ := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	return int(bytes >> 20)
+}
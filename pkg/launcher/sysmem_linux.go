@@ -0,0 +1,28 @@
+//go:build linux
+
+package launcher
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// totalMemoryMB returns the physical memory of the host in MB, or 0 when unknown.
+func totalMemoryMB() int {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, _ := strconv.Atoi(fields[1])
+			return kb / 1024
+		}
+	}
+	return 0
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package launcher
+
+// totalMemoryMB returns 0: the host memory is unknown on this platform.
+func totalMemoryMB() int {
+	return 0
+}
@@ -0,0 +1,32 @@
+//go:build windows
+
+package launcher
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+var procGlobalMemoryStatusEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GlobalMemoryStatusEx")
+
+// totalMemoryMB returns the physical memory of the host in MB, or 0 when unknown.
+func totalMemoryMB() int {
+	var st memoryStatusEx
+	st.Length = uint32(unsafe.Sizeof(st))
+	if r, _, _ := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&st))); r == 0 {
+		return 0
+	}
+	return int(st.TotalPhys >> 20)
+}
@@ -0,0 +1,337 @@
+// Package serverping queries Minecraft servers with the Server List Ping protocol (the server list's
+// MOTD, player count and latency).
+package serverping
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPort is the Minecraft server port used when an address has none.
+const DefaultPort = 25565
+
+// maxPacket caps a status response (favicons are base64 PNGs of a few KB).
+const maxPacket = 1 << 20
+
+// Status is a server's answer to a status request.
+type Status struct {
+	Version  string        `json:"version"`
+	Protocol int           `json:"protocol"`
+	Online   int           `json:"online"`
+	Max      int           `json:"max"`
+	Sample   []string      `json:"sample,omitempty"`
+	MOTD     string        `json:"motd"`
+	Favicon  string        `json:"favicon,omitempty"` // data:image/png;base64,…
+	Latency  time.Duration `json:"-"`
+}
+
+// ErrBadResponse is returned when the server does not speak the status protocol.
+var ErrBadResponse = errors.New("invalid server list ping response")
+
+// SplitAddress splits "host", "host:port", "[v6]:port" or a bare IPv6 address into host and port,
+// defaulting to DefaultPort.
+func SplitAddress(addr string) (string, int, error) {
+	host, port, _, err := splitAddress(addr)
+	return host, port, err
+}
+
+// splitAddress is SplitAddress that also reports whether the address had a port.
+func splitAddress(addr string) (string, int, bool, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return "", 0, false, fmt.Errorf("empty server address")
+	}
+	if host, p, err := net.SplitHostPort(addr); err == nil {
+		port, err := strconv.Atoi(p)
+		if err != nil || port <= 0 || port > 65535 || host == "" {
+			return "", 0, false, fmt.Errorf("invalid server address %q", addr)
+		}
+		return host, port, true, nil
+	}
+	// No port: a bracketed or bare IPv6 address, or a host name
+	host := strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return "", 0, false, fmt.Errorf("invalid server address %q", addr)
+	}
+	return host, DefaultPort, false, nil
+}
+
+// JoinAddress formats host and port as an address, bracketing IPv6 hosts.
+func JoinAddress(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// NormalizeAddress returns addr in canonical form: "host" when it has no port, so the game still looks
+// up its SRV record, otherwise "host:port" ("[v6]:port" for IPv6 addresses, which always get a port).
+// Host names are lowercased.
+func NormalizeAddress(addr string) (string, error) {
+	host, port, explicit, err := splitAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	host = strings.ToLower(host)
+	if explicit || strings.Contains(host, ":") {
+		return JoinAddress(host, port), nil
+	}
+	return host, nil
+}
+
+// SameAddress reports whether a and b name the same host and port, with DefaultPort for addresses
+// without one. It does not resolve either address.
+func SameAddress(a, b string) bool {
+	ha, pa, errA := SplitAddress(a)
+	hb, pb, errB := SplitAddress(b)
+	return errA == nil && errB == nil && pa == pb && strings.EqualFold(ha, hb)
+}
+
+// Resolve returns the host and port the game connects to for addr. Like the game, it looks up the
+// _minecraft._tcp SRV record of host names given without a port, and falls back to the host on
+// DefaultPort when there is none.
+func Resolve(ctx context.Context, addr string) (string, int, error) {
+	host, port, explicit, err := splitAddress(addr)
+	if err != nil || explicit || net.ParseIP(host) != nil {
+		return host, port, err
+	}
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "minecraft", "tcp", host)
+	if err != nil || len(records) == 0 {
+		return host, port, nil
+	}
+	return strings.TrimSuffix(records[0].Target, "."), int(records[0].Port), nil
+}
+
+// Ping connects to addr (host[:port], resolving SRV records like the game) and returns its status. The
+// latency is the ping/pong round-trip, or the status round-trip for servers that close the connection
+// after the status response.
+func Ping(ctx context.Context, addr string) (Status, error) {
+	host, port, err := Resolve(ctx, addr)
+	if err != nil {
+		return Status{}, err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", JoinAddress(host, port))
+	if err != nil {
+		return Status{}, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	return handshake(conn, host, port)
+}
+
+func handshake(conn net.Conn, host string, port int) (Status, error) {
+	// Handshake (protocol -1: "any", next state 1: status), then a status request
+	var hs bytes.Buffer
+	writeVarInt(&hs, 0x00)
+	writeVarInt(&hs, -1)
+	writeString(&hs, host)
+	_ = binary.Write(&hs, binary.BigEndian, uint16(port))
+	writeVarInt(&hs, 1)
+	start := time.Now()
+	if err := writePacket(conn, hs.Bytes()); err != nil {
+		return Status{}, err
+	}
+	if err := writePacket(conn, []byte{0x00}); err != nil {
+		return Status{}, err
+	}
+
+	r := bufio.NewReader(conn)
+	id, body, err := readPacket(r)
+	if err != nil {
+		return Status{}, err
+	}
+	statusRTT := time.Since(start)
+	if id != 0x00 {
+		return Status{}, ErrBadResponse
+	}
+	data, err := readString(bytes.NewReader(body))
+	if err != nil {
+		return Status{}, err
+	}
+	st, err := parseStatus([]byte(data))
+	if err != nil {
+		return Status{}, err
+	}
+	st.Latency = statusRTT
+
+	var ping bytes.Buffer
+	writeVarInt(&ping, 0x01)
+	payload := time.Now().UnixMilli()
+	_ = binary.Write(&ping, binary.BigEndian, payload)
+	start = time.Now()
+	if err := writePacket(conn, ping.Bytes()); err != nil {
+		return st, nil
+	}
+	if id, body, err := readPacket(r); err == nil && id == 0x01 && len(body) == 8 && int64(binary.BigEndian.Uint64(body)) == payload {
+		st.Latency = time.Since(start)
+	}
+	return st, nil
+}
+
+// parseStatus decodes the status JSON.
+func parseStatus(data []byte) (Status, error) {
+	var raw struct {
+		Version struct {
+			Name     string `json:"name"`
+			Protocol int    `json:"protocol"`
+		} `json:"version"`
+		Players struct {
+			Max    int `json:"max"`
+			Online int `json:"online"`
+			Sample []struct {
+				Name string `json:"name"`
+			} `json:"sample"`
+		} `json:"players"`
+		Description json.RawMessage `json:"description"`
+		Favicon     string          `json:"favicon"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Status{}, fmt.Errorf("%w: %v", ErrBadResponse, err)
+	}
+	st := Status{
+		Version:  raw.Version.Name,
+		Protocol: raw.Version.Protocol,
+		Online:   raw.Players.Online,
+		Max:      raw.Players.Max,
+		MOTD:     StripFormatting(chatText(raw.Description)),
+		Favicon:  raw.Favicon,
+	}
+	for _, p := range raw.Players.Sample {
+		st.Sample = append(st.Sample, p.Name)
+	}
+	return st, nil
+}
+
+// chatText flattens a chat component (a string, an array or {"text", "extra"}) to plain text.
+func chatText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		var b strings.Builder
+		for _, c := range list {
+			b.WriteString(chatText(c))
+		}
+		return b.String()
+	}
+	var c struct {
+		Text      string            `json:"text"`
+		Translate string            `json:"translate"`
+		Extra     []json.RawMessage `json:"extra"`
+	}
+	if json.Unmarshal(raw, &c) != nil {
+		return ""
+	}
+	text := c.Text
+	if text == "" {
+		text = c.Translate
+	}
+	for _, e := range c.Extra {
+		text += chatText(e)
+	}
+	return text
+}
+
+// StripFormatting removes § formatting codes from a legacy MOTD.
+func StripFormatting(s string) string {
+	var b strings.Builder
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		if rs[i] == '§' {
+			i++
+			continue
+		}
+		b.WriteRune(rs[i])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeVarInt(w *bytes.Buffer, v int32) {
+	u := uint32(v)
+	for {
+		if u&^0x7F == 0 {
+			w.WriteByte(byte(u))
+			return
+		}
+		w.WriteByte(byte(u&0x7F | 0x80))
+		u >>= 7
+	}
+}
+
+func readVarInt(r io.ByteReader) (int32, error) {
+	var v uint32
+	for i := 0; i < 5; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint32(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			return int32(v), nil
+		}
+	}
+	return 0, ErrBadResponse
+}
+
+func writeString(w *bytes.Buffer, s string) {
+	writeVarInt(w, int32(len(s)))
+	w.WriteString(s)
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	n, err := readVarInt(r)
+	if err != nil {
+		return "", err
+	}
+	if n < 0 || int(n) > r.Len() {
+		return "", ErrBadResponse
+	}
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	return string(buf), err
+}
+
+func writePacket(w io.Writer, payload []byte) error {
+	var p bytes.Buffer
+	writeVarInt(&p, int32(len(payload)))
+	p.Write(payload)
+	_, err := w.Write(p.Bytes())
+	return err
+}
+
+func readPacket(r *bufio.Reader) (int32, []byte, error) {
+	n, err := readVarInt(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if n <= 0 || n > maxPacket {
+		return 0, nil, ErrBadResponse
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+	body := bytes.NewReader(data)
+	id, err := readVarInt(body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, data[len(data)-body.Len():], nil
+}
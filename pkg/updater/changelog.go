@@ -0,0 +1,49 @@
+package updater
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// changelog returns the release notes of every release on the channel newer than the current version up
+// to latest, newest first and each under its version, so skipped releases aren't lost. A downgrade, or a
+// current version that isn't semver, gets latest's notes only.
+func (u *Updater) changelog(releases []GitHubRelease, latest *GitHubRelease) string {
+	name, _ := ParseChannel(u.Channel)
+	if cmp, ok := compareVersions(latest.TagName, u.CurrentVer); !ok || cmp <= 0 {
+		return latest.Body
+	}
+	between := []GitHubRelease{}
+	for _, r := range releases {
+		if !channelIncludes(name, r) {
+			continue
+		}
+		newer, ok1 := compareVersions(r.TagName, u.CurrentVer)
+		notAfter, ok2 := compareVersions(r.TagName, latest.TagName)
+		if ok1 && ok2 && newer > 0 && notAfter <= 0 {
+			between = append(between, r)
+		}
+	}
+	if len(between) <= 1 {
+		return latest.Body
+	}
+	sort.SliceStable(between, func(i, j int) bool {
+		cmp, _ := compareVersions(between[i].TagName, between[j].TagName)
+		return cmp > 0
+	})
+	var b strings.Builder
+	for i, r := range between {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		fmt.Fprintf(&b, "## %s", r.TagName)
+		if !r.PublishedAt.IsZero() {
+			fmt.Fprintf(&b, " (%s)", r.PublishedAt.Format("2006-01-02"))
+		}
+		if body := strings.TrimSpace(r.Body); body != "" {
+			b.WriteString("\n\n" + body)
+		}
+	}
+	return b.String()
+}
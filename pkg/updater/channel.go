@@ -0,0 +1,71 @@
+package updater
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Update channels. Stable follows full releases only, beta also follows beta and release candidate
+// prereleases, nightly follows every published release.
+const (
+	ChannelStable  = "stable"
+	ChannelBeta    = "beta"
+	ChannelNightly = "nightly"
+)
+
+var channel struct {
+	sync.RWMutex
+	name string
+}
+
+// ParseChannel validates a channel name; "" means ChannelStable.
+func ParseChannel(name string) (string, error) {
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "":
+		return ChannelStable, nil
+	case ChannelStable, ChannelBeta, ChannelNightly:
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown update channel %q (use %s, %s or %s)", name, ChannelStable, ChannelBeta, ChannelNightly)
+}
+
+// SetChannel sets the channel New uses for GitHub release checks. Unknown names fall back to stable.
+func SetChannel(name string) {
+	name, err := ParseChannel(name)
+	if err != nil {
+		name = ChannelStable
+	}
+	channel.Lock()
+	defer channel.Unlock()
+	channel.name = name
+}
+
+// Channel returns the configured update channel.
+func Channel() string {
+	channel.RLock()
+	defer channel.RUnlock()
+	if channel.name == "" {
+		return ChannelStable
+	}
+	return channel.name
+}
+
+// channelIncludes reports whether a release is offered on the channel. Prereleases tagged beta or rc
+// (e.g. "v1.4.0-beta.2", "v1.4.0-rc1") are betas; any other prerelease is a nightly build.
+func channelIncludes(name string, r GitHubRelease) bool {
+	if r.Draft {
+		return false
+	}
+	if !r.Prerelease {
+		return true
+	}
+	switch name {
+	case ChannelNightly:
+		return true
+	case ChannelBeta:
+		tag := strings.ToLower(r.TagName)
+		return strings.Contains(tag, "beta") || strings.Contains(tag, "-rc")
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package updater
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"QMLauncher/internal/version"
+	env "QMLauncher/pkg"
+)
+
+// NoticeCheckInterval is how often the opt-in update notice checks GitHub.
+const NoticeCheckInterval = 24 * time.Hour
+
+// CheckState is the remembered result of the last throttled update check.
+type CheckState struct {
+	Checked   time.Time `json:"checked"`
+	Current   string    `json:"current"`
+	Channel   string    `json:"channel"`
+	LatestVer string    `json:"latest,omitempty"`
+	Available bool      `json:"available"`
+	Cached    bool      `json:"-"` // returned without contacting GitHub
+}
+
+func checkStatePath() string {
+	return filepath.Join(env.CachesDir, "updater", "last_check.json")
+}
+
+// ThrottledCheck checks GitHub for a newer release on the update channel at most once per interval and
+// remembers the time and the result; until the interval passes it returns the remembered result. A new
+// launcher version or channel checks again right away.
+func ThrottledCheck(interval time.Duration) (CheckState, error) {
+	var state CheckState
+	if data, err := os.ReadFile(checkStatePath()); err == nil && json.Unmarshal(data, &state) == nil {
+		if state.Current == version.Current && state.Channel == Channel() && time.Since(state.Checked) < interval {
+			state.Cached = true
+			return state, nil
+		}
+	}
+
+	up := New("mindevis", "QMLauncher", version.Current, env.CachesDir)
+	info, err := up.CheckForUpdates()
+	if err != nil {
+		return CheckState{}, err
+	}
+	state = CheckState{Checked: time.Now(), Current: version.Current, Channel: up.Channel, LatestVer: info.LatestVer, Available: info.Available}
+	if data, err := json.Marshal(state); err == nil {
+		_ = os.MkdirAll(filepath.Dir(checkStatePath()), 0755)
+		_ = os.WriteFile(checkStatePath(), data, 0644)
+	}
+	return state, nil
+}
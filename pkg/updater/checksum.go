@@ -0,0 +1,102 @@
+package updater
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// checksumFiles are the names of release assets listing the SHA-256 of every other asset, used when an
+// asset has no "<asset>.sha256" of its own.
+var checksumFiles = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+var (
+	// ErrChecksumMissing is returned when a release publishes no SHA-256 checksum for the downloaded asset.
+	ErrChecksumMissing = errors.New("release publishes no SHA-256 checksum for the update")
+	// ErrChecksumMismatch is returned when a download does not match its published SHA-256 checksum.
+	ErrChecksumMismatch = errors.New("update checksum mismatch")
+)
+
+// isChecksumAsset reports whether a (lowercase) asset name is a checksum file.
+func isChecksumAsset(name string) bool {
+	if strings.HasSuffix(name, ".sha256") {
+		return true
+	}
+	for _, f := range checksumFiles {
+		if name == strings.ToLower(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksumURL returns the checksum file covering the named asset: "<asset>.sha256" (perAsset), else a
+// release-wide checksums file, else "".
+func checksumURL(assets []Asset, name string) (url string, perAsset bool) {
+	if u := assetURL(assets, name+".sha256"); u != "" {
+		return u, true
+	}
+	for _, f := range checksumFiles {
+		if u := assetURL(assets, f); u != "" {
+			return u, false
+		}
+	}
+	return "", false
+}
+
+// fetchSHA256 reads a sha256sum checksum file ("<hex>  <name>" per line) and returns the hash listed for
+// the named asset. A bare hash without a file name is only accepted from a per-asset "<asset>.sha256"
+// file holding nothing else.
+func fetchSHA256(url, name string, perAsset bool) (string, error) {
+	text, err := fetchTextURLLimit(url, 64<<10)
+	if err != nil {
+		return "", err
+	}
+	var bare string
+	entries := 0
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		sum := strings.ToLower(fields[0])
+		if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+			return "", fmt.Errorf("invalid checksum file %s", url)
+		}
+		entries++
+		if len(fields) == 1 {
+			bare = sum
+			continue
+		}
+		// sha256sum marks binary mode with "*" and keeps the path it was given
+		if strings.EqualFold(path.Base(strings.TrimPrefix(fields[1], "*")), name) {
+			return sum, nil
+		}
+	}
+	if perAsset && entries == 1 && bare != "" {
+		return bare, nil
+	}
+	return "", fmt.Errorf("%w: %s is not listed in %s", ErrChecksumMissing, name, url)
+}
+
+// verifyChecksum checks a downloaded release asset against the SHA-256 checksum published with it.
+func verifyChecksum(file string, info *UpdateInfo) error {
+	if info.ChecksumURL == "" {
+		return ErrChecksumMissing
+	}
+	want, err := fetchSHA256(info.ChecksumURL, info.AssetName, info.ChecksumPerAsset)
+	if err != nil {
+		return fmt.Errorf("checksum of %s: %w", info.AssetName, err)
+	}
+	_, got, err := fileSHA256(file)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: %s has SHA-256 %s, release publishes %s", ErrChecksumMismatch, info.AssetName, got, want)
+	}
+	return nil
+}
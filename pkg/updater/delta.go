@@ -0,0 +1,64 @@
+package updater
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"QMLauncher/internal/bspatch"
+)
+
+// PatchSuffix ends the name of a binary patch asset: "<asset>.from-<version>.bsdiff" turns the asset of
+// release <version> into the asset of the release it is published with.
+const PatchSuffix = ".bsdiff"
+
+// patchAssetName returns the name of the patch asset that updates asset name from version from.
+func patchAssetName(name, from string) string {
+	return fmt.Sprintf("%s.from-%s%s", name, strings.TrimPrefix(strings.TrimSpace(from), "v"), PatchSuffix)
+}
+
+// applyPatchUpdate writes the new release binary to dest by patching the running executable with the
+// release's binary patch, so small releases don't cost a full download. The result must match the
+// release checksum; on any error the caller falls back to the full download.
+func applyPatchUpdate(info *UpdateInfo, currentExe, dest string) error {
+	if info.PatchURL == "" {
+		return fmt.Errorf("no patch from this version")
+	}
+	if info.ChecksumURL == "" {
+		return fmt.Errorf("release has no checksum to verify the patched binary")
+	}
+	if info.Size <= 0 {
+		return fmt.Errorf("release does not state the size of the binary")
+	}
+	want, err := fetchSHA256(info.ChecksumURL, info.AssetName, info.ChecksumPerAsset)
+	if err != nil {
+		return err
+	}
+	old, err := os.ReadFile(currentExe)
+	if err != nil {
+		return err
+	}
+	patchFile := dest + PatchSuffix
+	if err := downloadFileToPath(info.PatchURL, patchFile); err != nil {
+		return err
+	}
+	defer os.Remove(patchFile)
+	patch, err := os.ReadFile(patchFile)
+	if err != nil {
+		return err
+	}
+	out, err := bspatch.Apply(old, patch, info.Size)
+	if err != nil {
+		return err
+	}
+	if got := sha256.Sum256(out); hex.EncodeToString(got[:]) != want {
+		return fmt.Errorf("patched binary does not match the release checksum")
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, out, 0755)
+}
@@ -11,7 +11,8 @@ import (
 	env "QMLauncher/pkg"
 )
 
-// GitHubBinaryUpdateAvailable reports whether GitHub releases/latest is newer than version.Current.
+// GitHubBinaryUpdateAvailable reports whether the newest GitHub release on the update channel differs
+// from version.Current.
 func GitHubBinaryUpdateAvailable() bool {
 	if runtime.GOOS != "windows" && runtime.GOOS != "linux" {
 		return false
@@ -21,12 +22,32 @@ func GitHubBinaryUpdateAvailable() bool {
 	return err == nil && info != nil && info.Available
 }
 
-// CheckAndApplyGitHubBinaryUpdate uses GitHub releases/latest (raw exe / linux binary, not zip). Returns true if process exits.
+// CheckGitHubRelease checks the launcher's GitHub releases for an update on the update channel. The
+// changelog covers every release since version.Current.
+func CheckGitHubRelease() (*UpdateInfo, error) {
+	return New("mindevis", "QMLauncher", version.Current, env.CachesDir).CheckForUpdates()
+}
+
+// CheckAndApplyGitHubBinaryUpdate uses the newest GitHub release on the update channel (raw exe / linux
+// binary, not zip). Returns true if process exits.
+// A release the launcher was rolled back from is skipped.
 func CheckAndApplyGitHubBinaryUpdate(logFn func(string)) bool {
+	return applyGitHubBinaryUpdate(false, false, logFn)
+}
+
+// ApplyGitHubBinaryUpdate is CheckAndApplyGitHubBinaryUpdate for an update the user asked for: it also
+// installs a release rolled back from and, with allowDowngrade, a release older than version.Current
+// (e.g. to return from the beta channel to stable).
+func ApplyGitHubBinaryUpdate(allowDowngrade bool, logFn func(string)) bool {
+	return applyGitHubBinaryUpdate(allowDowngrade, true, logFn)
+}
+
+func applyGitHubBinaryUpdate(allowDowngrade, manual bool, logFn func(string)) bool {
 	if runtime.GOOS != "windows" && runtime.GOOS != "linux" {
 		return false
 	}
 	up := New("mindevis", "QMLauncher", version.Current, env.CachesDir)
+	up.AllowDowngrade = allowDowngrade
 	info, err := up.CheckForUpdates()
 	if err != nil || info == nil || !info.Available {
 		if err != nil && logFn != nil {
@@ -34,6 +55,12 @@ func CheckAndApplyGitHubBinaryUpdate(logFn func(string)) bool {
 		}
 		return false
 	}
+	if !manual && updateSkipped(info.LatestVer, "") {
+		if logFn != nil {
+			logFn(fmt.Sprintf("[AutoUpdate] Skip GitHub release %s: launcher was rolled back from it", info.LatestVer))
+		}
+		return false
+	}
 	dlURL := strings.TrimSpace(info.DownloadURL)
 	if dlURL == "" {
 		return false
@@ -47,15 +74,7 @@ func CheckAndApplyGitHubBinaryUpdate(logFn func(string)) bool {
 	if err != nil {
 		return false
 	}
-	var expectName string
-	switch runtime.GOOS {
-	case "windows":
-		expectName = launcherExeName
-	case "linux":
-		expectName = "QMLauncher-linux-amd64"
-	default:
-		return false
-	}
+	expectName := platformBinaryName()
 	if !strings.EqualFold(filepath.Base(exePath), expectName) {
 		return false
 	}
@@ -63,9 +82,38 @@ func CheckAndApplyGitHubBinaryUpdate(logFn func(string)) bool {
 	tempDir := filepath.Join(os.TempDir(), "qmlauncher-github-update")
 	_ = os.MkdirAll(tempDir, 0755)
 	tempBin := filepath.Join(tempDir, expectName)
-	if err := downloadFileToPath(dlURL, tempBin); err != nil {
+	patched := false
+	if info.PatchURL != "" {
+		if err := applyPatchUpdate(info, exePath, tempBin); err != nil {
+			if logFn != nil {
+				logFn(fmt.Sprintf("[AutoUpdate] Binary patch not applied, downloading the full release: %v", err))
+			}
+		} else {
+			patched = true
+			if logFn != nil {
+				logFn(fmt.Sprintf("[AutoUpdate] Applied binary patch (%d KB instead of %d KB)", info.PatchSize>>10, info.Size>>10))
+			}
+		}
+	}
+	if !patched {
+		if err := downloadFileToPath(dlURL, tempBin); err != nil {
+			if logFn != nil {
+				logFn(fmt.Sprintf("[AutoUpdate] GitHub download failed: %v", err))
+			}
+			return false
+		}
+		if err := verifyChecksum(tempBin, info); err != nil {
+			_ = os.Remove(tempBin)
+			if logFn != nil {
+				logFn(fmt.Sprintf("[AutoUpdate] GitHub update rejected: %v", err))
+			}
+			return false
+		}
+	}
+	if err := verifyUpdate(tempBin, info.SignatureURL); err != nil {
+		_ = os.Remove(tempBin)
 		if logFn != nil {
-			logFn(fmt.Sprintf("[AutoUpdate] GitHub download failed: %v", err))
+			logFn(fmt.Sprintf("[AutoUpdate] GitHub update rejected: %v", err))
 		}
 		return false
 	}
@@ -84,12 +132,19 @@ func CheckAndApplyGitHubBinaryUpdate(logFn func(string)) bool {
 	}
 
 	if logFn != nil {
-		logFn(fmt.Sprintf("[AutoUpdate] Applying GitHub release %s", info.LatestVer))
+		verb := "Applying"
+		if info.Downgrade {
+			verb = "Downgrading to"
+		}
+		logFn(fmt.Sprintf("[AutoUpdate] %s GitHub release %s (%s channel)", verb, info.LatestVer, info.Channel))
+	}
+	if manual {
+		clearSkippedUpdate()
 	}
 
 	switch runtime.GOOS {
 	case "windows":
-		if err := runWindowsUpdater(exePath, tempBin, logFn); err != nil {
+		if err := windowsReplaceExecutableAndRelaunch(exePath, tempBin, logFn); err != nil {
 			if logFn != nil {
 				logFn(fmt.Sprintf("[AutoUpdate] GitHub apply failed: %v", err))
 			}
@@ -0,0 +1,85 @@
+package updater
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// updateNet is the proxy and GitHub token the updater uses on top of the environment.
+var updateNet struct {
+	sync.RWMutex
+	proxy *url.URL
+	token string
+}
+
+// ParseProxy validates a proxy URL ("http://host:3128", "socks5://host:1080"); "" means none.
+func ParseProxy(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", u.Scheme)
+}
+
+// SetProxy sets the proxy for update checks and downloads; "" uses HTTP_PROXY / HTTPS_PROXY / NO_PROXY
+// from the environment.
+func SetProxy(raw string) error {
+	u, err := ParseProxy(raw)
+	if err != nil {
+		return err
+	}
+	updateNet.Lock()
+	defer updateNet.Unlock()
+	updateNet.proxy = u
+	return nil
+}
+
+// SetGitHubToken sets the token sent to the GitHub releases API, which raises the anonymous rate limit.
+func SetGitHubToken(token string) {
+	updateNet.Lock()
+	defer updateNet.Unlock()
+	updateNet.token = strings.TrimSpace(token)
+}
+
+// GitHubToken returns the token for the GitHub releases API: the one set with SetGitHubToken, else
+// QMLAUNCHER_GITHUB_TOKEN or GITHUB_TOKEN from the environment.
+func GitHubToken() string {
+	updateNet.RLock()
+	token := updateNet.token
+	updateNet.RUnlock()
+	if token != "" {
+		return token
+	}
+	for _, key := range []string{"QMLAUNCHER_GITHUB_TOKEN", "GITHUB_TOKEN"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// updateProxy is the Proxy of the updater's transports.
+func updateProxy(req *http.Request) (*url.URL, error) {
+	updateNet.RLock()
+	u := updateNet.proxy
+	updateNet.RUnlock()
+	if u != nil {
+		return u, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
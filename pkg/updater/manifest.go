@@ -0,0 +1,123 @@
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UpdateManifest is a self-hosted list of launcher releases, served in place of the GitHub releases API
+// by organizations distributing their own launcher build:
+//
+//	{"releases": [{"version": "1.4.0", "published_at": "2026-05-01T12:00:00Z", "notes": "…",
+//	  "assets": [{"name": "QMLauncher-windows-amd64.exe", "url": "https://updates.example.org/1.4.0/QMLauncher-windows-amd64.exe"},
+//	             {"name": "QMLauncher-windows-amd64.exe.sha256", "url": "1.4.0/QMLauncher-windows-amd64.exe.sha256"}]}]}
+//
+// Asset names follow the GitHub releases (binaries, "<asset>.sha256", "<asset>.minisig", patches), and
+// relative asset URLs are resolved against the manifest URL. Prereleases are offered per update channel
+// as on GitHub.
+type UpdateManifest struct {
+	Releases []ManifestRelease `json:"releases"`
+}
+
+// ManifestRelease is one release of an UpdateManifest.
+type ManifestRelease struct {
+	Version     string          `json:"version"`
+	Prerelease  bool            `json:"prerelease,omitempty"`
+	PublishedAt time.Time       `json:"published_at"`
+	Notes       string          `json:"notes,omitempty"`
+	URL         string          `json:"url,omitempty"` // release page
+	Assets      []ManifestAsset `json:"assets"`
+}
+
+// ManifestAsset is a downloadable file of a ManifestRelease.
+type ManifestAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int64  `json:"size,omitempty"`
+}
+
+var manifestURL struct {
+	sync.RWMutex
+	url string
+}
+
+// ParseManifestURL validates an update manifest URL; "" means GitHub releases.
+func ParseManifestURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("invalid update manifest URL %q", raw)
+	}
+	return u.String(), nil
+}
+
+// SetManifestURL makes New use a self-hosted update manifest instead of GitHub releases; "" restores
+// GitHub.
+func SetManifestURL(raw string) error {
+	u, err := ParseManifestURL(raw)
+	if err != nil {
+		return err
+	}
+	manifestURL.Lock()
+	defer manifestURL.Unlock()
+	manifestURL.url = u
+	return nil
+}
+
+// ManifestURL returns the configured update manifest URL, or "" for GitHub releases.
+func ManifestURL() string {
+	manifestURL.RLock()
+	defer manifestURL.RUnlock()
+	return manifestURL.url
+}
+
+// manifestReleases fetches the update manifest and returns its releases in the GitHub release form.
+func (u *Updater) manifestReleases() ([]GitHubRelease, error) {
+	base, err := url.Parse(u.ManifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update manifest URL %q", u.ManifestURL)
+	}
+	body, err := fetchCached(u.ManifestURL, filepath.Join(u.CacheDir, "updater", "manifest.json"), false)
+	if err != nil {
+		return nil, err
+	}
+	var m UpdateManifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse update manifest: %w", err)
+	}
+	releases := make([]GitHubRelease, 0, len(m.Releases))
+	for _, r := range m.Releases {
+		tag := strings.TrimSpace(r.Version)
+		if tag == "" {
+			continue
+		}
+		if !strings.HasPrefix(tag, "v") {
+			tag = "v" + tag
+		}
+		rel := GitHubRelease{
+			TagName:     tag,
+			Name:        tag,
+			Body:        r.Notes,
+			PublishedAt: r.PublishedAt,
+			Prerelease:  r.Prerelease,
+			HTMLURL:     r.URL,
+		}
+		for _, a := range r.Assets {
+			ref, err := url.Parse(strings.TrimSpace(a.URL))
+			if err != nil || a.Name == "" {
+				continue
+			}
+			rel.Assets = append(rel.Assets, Asset{Name: a.Name, BrowserDownloadURL: base.ResolveReference(ref).String(), Size: a.Size})
+		}
+		releases = append(releases, rel)
+	}
+	return releases, nil
+}
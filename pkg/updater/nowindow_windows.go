@@ -1,12 +0,0 @@
-//go:build windows
-
-package updater
-
-import (
-	"os/exec"
-	"syscall"
-)
-
-func setCmdNoWindow(cmd *exec.Cmd) {
-	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: 0x08000000} // CREATE_NO_WINDOW
-}
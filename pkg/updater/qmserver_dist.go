@@ -28,12 +28,14 @@ type qmserverDistJSON struct {
 		Filename    string `json:"filename"`
 		DownloadURL string `json:"download_url"`
 		MD5URL      string `json:"md5_url"`
+		SigURL      string `json:"sig_url,omitempty"` // minisign signature; default download_url + ".minisig"
 		Size        int64  `json:"size"`
 	} `json:"windows"`
 	Linux struct {
 		Filename    string `json:"filename"`
 		DownloadURL string `json:"download_url"`
 		MD5URL      string `json:"md5_url"`
+		SigURL      string `json:"sig_url,omitempty"` // minisign signature; default download_url + ".minisig"
 		Size        int64  `json:"size"`
 	} `json:"linux"`
 }
@@ -103,6 +105,11 @@ func qmserverSkipUpdateSameMD5(exePath, remoteMD5 string, logFn func(string)) bo
 }
 
 func fetchTextURL(u string) (string, error) {
+	return fetchTextURLLimit(u, 1024)
+}
+
+// fetchTextURLLimit is fetchTextURL for files of up to limit bytes.
+func fetchTextURLLimit(u string, limit int64) (string, error) {
 	req, err := http.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
 		return "", err
@@ -117,7 +124,7 @@ func fetchTextURL(u string) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("status %d", resp.StatusCode)
 	}
-	b, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	b, err := io.ReadAll(io.LimitReader(resp.Body, limit))
 	if err != nil {
 		return "", err
 	}
@@ -144,7 +151,7 @@ func downloadFileToPath(rawURL, dest string) error {
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(out, resp.Body)
+	_, err = io.Copy(out, network.LimitBandwidth(resp.Body))
 	cerr := out.Close()
 	if err != nil {
 		os.Remove(tmp)
@@ -204,8 +211,20 @@ func QMServerDistributionUpdateAvailable(logFn func(string)) bool {
 	return true
 }
 
-// CheckAndApplyQMServerDistributionUpdate downloads from QMServer and restarts when newer. Returns true if process exits for update.
+// CheckAndApplyQMServerDistributionUpdate downloads from QMServer and restarts when newer. The download
+// must match its MD5 and, in builds with a ReleasePublicKey, its minisign signature. A release the
+// launcher was rolled back from is skipped. Returns true if process exits for update.
 func CheckAndApplyQMServerDistributionUpdate(logFn func(string)) bool {
+	return applyQMServerDistributionUpdate(false, logFn)
+}
+
+// ApplyQMServerDistributionUpdate is CheckAndApplyQMServerDistributionUpdate for an update the user asked
+// for, which also installs a release rolled back from.
+func ApplyQMServerDistributionUpdate(logFn func(string)) bool {
+	return applyQMServerDistributionUpdate(true, logFn)
+}
+
+func applyQMServerDistributionUpdate(manual bool, logFn func(string)) bool {
 	if runtime.GOOS != "windows" && runtime.GOOS != "linux" {
 		return false
 	}
@@ -221,16 +240,24 @@ func CheckAndApplyQMServerDistributionUpdate(logFn func(string)) bool {
 	if semver.Compare(remote, local) <= 0 {
 		return false
 	}
+	if !manual && updateSkipped(dist.Version, "") {
+		if logFn != nil {
+			logFn(fmt.Sprintf("[AutoUpdate] Skip QMServer release %s: launcher was rolled back from it", dist.Version))
+		}
+		return false
+	}
 
-	var dlURL, md5URL, expectName string
+	var dlURL, md5URL, sigURL, expectName string
 	switch runtime.GOOS {
 	case "windows":
 		dlURL = strings.TrimSpace(dist.Windows.DownloadURL)
 		md5URL = strings.TrimSpace(dist.Windows.MD5URL)
+		sigURL = strings.TrimSpace(dist.Windows.SigURL)
 		expectName = launcherExeName
 	case "linux":
 		dlURL = strings.TrimSpace(dist.Linux.DownloadURL)
 		md5URL = strings.TrimSpace(dist.Linux.MD5URL)
+		sigURL = strings.TrimSpace(dist.Linux.SigURL)
 		expectName = "QMLauncher-linux-amd64"
 	default:
 		return false
@@ -238,6 +265,9 @@ func CheckAndApplyQMServerDistributionUpdate(logFn func(string)) bool {
 	if dlURL == "" || md5URL == "" {
 		return false
 	}
+	if sigURL == "" {
+		sigURL = dlURL + SignatureSuffix
+	}
 
 	exePath, err := os.Executable()
 	if err != nil {
@@ -296,14 +326,25 @@ func CheckAndApplyQMServerDistributionUpdate(logFn func(string)) bool {
 		}
 		return false
 	}
+	// MD5 only guards against corrupt downloads; the signature proves the release is ours
+	if err := verifyUpdate(tempBin, sigURL); err != nil {
+		os.Remove(tempBin)
+		if logFn != nil {
+			logFn(fmt.Sprintf("[AutoUpdate] QMServer update rejected: %v", err))
+		}
+		return false
+	}
 
 	if logFn != nil {
 		logFn(fmt.Sprintf("[AutoUpdate] Applying QMServer release %s (was %s)", dist.Version, version.Current))
 	}
+	if manual {
+		clearSkippedUpdate()
+	}
 
 	switch runtime.GOOS {
 	case "windows":
-		if err := runWindowsUpdater(exePath, tempBin, logFn); err != nil {
+		if err := windowsReplaceExecutableAndRelaunch(exePath, tempBin, logFn); err != nil {
 			if logFn != nil {
 				logFn(fmt.Sprintf("[AutoUpdate] QMServer apply failed: %v", err))
 			}
@@ -322,6 +363,7 @@ func CheckAndApplyQMServerDistributionUpdate(logFn func(string)) bool {
 }
 
 func linuxReplaceExecutableAndRelaunch(currentExe, newExe string) error {
+	_ = BackupExecutable(currentExe)
 	backupPath := currentExe + ".backup"
 	if err := copyFileLinux(currentExe, backupPath); err != nil {
 		return fmt.Errorf("backup: %w", err)
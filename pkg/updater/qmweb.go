@@ -47,7 +47,7 @@ func ComputeFileMD5(path string) (string, error) {
 // qmWebHTTPClient returns a client tuned for Cloudflare / high-latency TLS (longer handshakes than default).
 func qmWebHTTPClient(totalTimeout time.Duration) *http.Client {
 	base := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: updateProxy,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -129,9 +129,14 @@ func CheckForQMWebUpdate(logFn func(string)) bool {
 	return !strings.EqualFold(localMD5, remoteMD5)
 }
 
-// ApplyAndRestartQMWebUpdate downloads the update, applies it, and exits (Windows only).
+// ApplyAndRestartQMWebUpdate downloads the update, applies it, and exits (Windows only). Like the other
+// sources, the download must carry a valid "<exe>.minisig" in builds with a ReleasePublicKey.
 // Does not return on success — process exits. Returns error only if download/apply fails.
 func ApplyAndRestartQMWebUpdate(logFn func(string)) error {
+	return applyQMWebUpdate(true, logFn)
+}
+
+func applyQMWebUpdate(manual bool, logFn func(string)) error {
 	if runtime.GOOS != "windows" {
 		return fmt.Errorf("updates only supported on Windows")
 	}
@@ -153,6 +158,12 @@ func ApplyAndRestartQMWebUpdate(logFn func(string)) error {
 	if strings.EqualFold(localMD5, remoteMD5) {
 		return nil // already up to date
 	}
+	if !manual && updateSkipped("", remoteMD5) {
+		if logFn != nil {
+			logFn("[AutoUpdate] Skip QMWeb update: launcher was rolled back from it")
+		}
+		return nil
+	}
 	if logFn != nil {
 		logFn(fmt.Sprintf("[AutoUpdate] Update available: local=%s remote=%s", localMD5, remoteMD5))
 	}
@@ -171,20 +182,27 @@ func ApplyAndRestartQMWebUpdate(logFn func(string)) error {
 		os.Remove(tempExe)
 		return fmt.Errorf("downloaded file MD5 mismatch")
 	}
-	if err := runWindowsUpdater(exePath, tempExe, logFn); err != nil {
+	if err := verifyUpdate(tempExe, downloadURL+SignatureSuffix); err != nil {
+		os.Remove(tempExe)
+		return err
+	}
+	if manual {
+		clearSkippedUpdate()
+	}
+	if err := windowsReplaceExecutableAndRelaunch(exePath, tempExe, logFn); err != nil {
 		return err
 	}
 	os.Exit(0)
 	return nil // unreachable
 }
 
-// CheckAndApplyQMWebUpdate checks MD5 and applies update if needed (Windows only) at startup.
-// If update is applied, the process exits and does not return.
+// CheckAndApplyQMWebUpdate checks MD5 and applies update if needed (Windows only) at startup, except the
+// build the launcher was rolled back from. If update is applied, the process exits and does not return.
 func CheckAndApplyQMWebUpdate(logFn func(string)) bool {
 	if !CheckForQMWebUpdate(logFn) {
 		return false
 	}
-	if err := ApplyAndRestartQMWebUpdate(logFn); err != nil {
+	if err := applyQMWebUpdate(false, logFn); err != nil {
 		if logFn != nil {
 			logFn(fmt.Sprintf("[AutoUpdate] Failed: %v", err))
 		}
@@ -208,25 +226,28 @@ func downloadFile(url, dest string) error {
 		return err
 	}
 	defer out.Close()
-	_, err = io.Copy(out, resp.Body)
+	_, err = io.Copy(out, network.LimitBandwidth(resp.Body))
 	return err
 }
 
-func runWindowsUpdater(currentExe, newExe string, logFn func(string)) error {
-	bat := fmt.Sprintf(`@echo off
-ping -n 4 127.0.0.1 >nul
-copy /Y "%s" "%s"
-if errorlevel 1 exit /b 1
-start "" "%s"
-del "%%~f0"
-`, newExe, currentExe, currentExe)
-	batPath := filepath.Join(filepath.Dir(newExe), "qmlauncher-updater.bat")
-	if err := os.WriteFile(batPath, []byte(bat), 0755); err != nil {
+// windowsReplaceExecutableAndRelaunch swaps newExe in for the running launcher and starts it; the caller
+// exits right after. Windows keeps a running executable locked for writing but lets it be renamed, so the
+// old one is moved aside (CleanupOldBinary removes it on the next start) instead of waiting for the
+// process to exit.
+func windowsReplaceExecutableAndRelaunch(currentExe, newExe string, logFn func(string)) error {
+	if err := BackupExecutable(currentExe); err != nil && logFn != nil {
+		logFn(fmt.Sprintf("[AutoUpdate] Backup of the current version failed: %v", err))
+	}
+	if err := replaceRunningBinary(currentExe, newExe); err != nil {
 		return err
 	}
-	cmd := exec.Command("cmd", "/C", "start", "/B", "", batPath)
-	setCmdNoWindow(cmd)
+	cmd := exec.Command(currentExe, os.Args[1:]...)
 	if err := cmd.Start(); err != nil {
+		// Put the running version back so the launcher still starts next time
+		_ = os.Remove(currentExe)
+		if rerr := os.Rename(currentExe+oldBinarySuffix, currentExe); rerr != nil && logFn != nil {
+			logFn(fmt.Sprintf("[AutoUpdate] Restore failed: %v; the previous version is %s", rerr, currentExe+oldBinarySuffix))
+		}
 		return err
 	}
 	return nil
@@ -0,0 +1,291 @@
+package updater
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"QMLauncher/internal/version"
+	env "QMLauncher/pkg"
+)
+
+// DefaultBackupsKept is the number of previous launcher versions kept for rollback by default.
+const DefaultBackupsKept = 3
+
+// Backup is a previous launcher executable kept for rollback.
+type Backup struct {
+	Version string    `json:"version"` // "" for the legacy "<exe>.backup"
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256,omitempty"`
+	Time    time.Time `json:"time"`
+	Legacy  bool      `json:"legacy,omitempty"`
+}
+
+var backups struct {
+	sync.Mutex
+	keep int
+}
+
+var unsafeVersionChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// SetBackupsKept sets how many previous versions updates keep; n < 1 means DefaultBackupsKept.
+func SetBackupsKept(n int) {
+	backups.Lock()
+	defer backups.Unlock()
+	backups.keep = n
+}
+
+// BackupsKept returns how many previous versions updates keep.
+func BackupsKept() int {
+	backups.Lock()
+	defer backups.Unlock()
+	if backups.keep < 1 {
+		return DefaultBackupsKept
+	}
+	return backups.keep
+}
+
+func backupsDir() string {
+	return filepath.Join(env.RootDir, "launcher-backups")
+}
+
+func readBackupsIndex() []Backup {
+	list := []Backup{}
+	if data, err := os.ReadFile(filepath.Join(backupsDir(), "index.json")); err == nil {
+		_ = json.Unmarshal(data, &list)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Time.After(list[j].Time) })
+	return list
+}
+
+func writeBackupsIndex(list []Backup) error {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(backupsDir(), "index.json"), data, 0644)
+}
+
+// fileSHA256 returns the size and SHA-256 of a file.
+func fileSHA256(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// BackupExecutable keeps a copy of the launcher executable as version.Current before an update replaces
+// it, and drops the oldest copies beyond BackupsKept. Backing up the same version twice is a no-op.
+func BackupExecutable(exePath string) error {
+	backups.Lock()
+	defer backups.Unlock()
+	keep := backups.keep
+	if keep < 1 {
+		keep = DefaultBackupsKept
+	}
+	size, sum, err := fileSHA256(exePath)
+	if err != nil {
+		return err
+	}
+	ver := strings.TrimSpace(version.Current)
+	list := readBackupsIndex()
+	for _, b := range list {
+		if b.Version == ver && b.SHA256 == sum {
+			if _, err := os.Stat(b.Path); err == nil {
+				return nil
+			}
+		}
+	}
+
+	dir := filepath.Join(backupsDir(), unsafeVersionChars.ReplaceAllString(ver, "_"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	dst := filepath.Join(dir, filepath.Base(exePath))
+	if err := copyFile(exePath, dst); err != nil {
+		return err
+	}
+	_ = os.Chmod(dst, 0755)
+
+	kept := []Backup{{Version: ver, Path: dst, Size: size, SHA256: sum, Time: time.Now()}}
+	for _, b := range list {
+		if b.Version == ver {
+			continue
+		}
+		if len(kept) >= keep {
+			_ = os.RemoveAll(filepath.Dir(b.Path))
+			continue
+		}
+		kept = append(kept, b)
+	}
+	return writeBackupsIndex(kept)
+}
+
+// Backups lists the launcher versions kept for rollback, newest first. The "<exe>.backup" left by
+// updates is listed last when it is not one of them.
+func Backups() ([]Backup, error) {
+	backups.Lock()
+	list := readBackupsIndex()
+	backups.Unlock()
+	exePath, err := executablePath()
+	if err != nil {
+		return list, nil
+	}
+	legacy := exePath + ".backup"
+	info, err := os.Stat(legacy)
+	if err != nil {
+		return list, nil
+	}
+	_, sum, err := fileSHA256(legacy)
+	if err != nil {
+		return list, nil
+	}
+	for _, b := range list {
+		if b.SHA256 == sum {
+			return list, nil
+		}
+	}
+	return append(list, Backup{Path: legacy, Size: info.Size(), SHA256: sum, Time: info.ModTime(), Legacy: true}), nil
+}
+
+// executablePath returns the running executable with symlinks resolved.
+func executablePath() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exePath)
+}
+
+// executableMagic are the leading bytes of executables for the current platform.
+func executableMagic() [][]byte {
+	switch runtime.GOOS {
+	case "windows":
+		return [][]byte{[]byte("MZ")}
+	case "darwin":
+		return [][]byte{{0xcf, 0xfa, 0xed, 0xfe}, {0xca, 0xfe, 0xba, 0xbe}}
+	}
+	return [][]byte{[]byte("\x7fELF")}
+}
+
+// ValidateBackup checks a backup before it is swapped in: it must be an executable for this platform and,
+// unless it is the legacy backup, still match the checksum recorded when it was made.
+func ValidateBackup(b Backup) error {
+	f, err := os.Open(b.Path)
+	if err != nil {
+		return fmt.Errorf("backup %s: %w", b.Path, err)
+	}
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	f.Close()
+	ok := false
+	for _, magic := range executableMagic() {
+		ok = ok || bytes.HasPrefix(head[:n], magic)
+	}
+	if !ok {
+		return fmt.Errorf("backup %s is not a %s executable", b.Path, runtime.GOOS)
+	}
+	if b.Legacy {
+		return nil
+	}
+	size, sum, err := fileSHA256(b.Path)
+	if err != nil {
+		return err
+	}
+	if size != b.Size || !strings.EqualFold(sum, b.SHA256) {
+		return fmt.Errorf("backup %s is corrupted (checksum mismatch)", b.Path)
+	}
+	return nil
+}
+
+// Rollback swaps a kept version back in as the launcher executable and restarts it: the given version,
+// or the newest backup that differs from the running version when versionName is "". The replaced
+// executable is kept as a backup too, so a rollback can be undone, and automatic updates skip its version
+// until a newer release appears or an update is applied by hand. On success the process exits.
+func Rollback(versionName string, logFn func(string)) error {
+	if runtime.GOOS != "windows" && runtime.GOOS != "linux" {
+		return fmt.Errorf("rollback is only supported on Windows and Linux")
+	}
+	exePath, err := executablePath()
+	if err != nil {
+		return err
+	}
+	list, _ := Backups()
+	_, current, _ := fileSHA256(exePath)
+	versionName = strings.TrimPrefix(strings.TrimSpace(versionName), "v")
+	var target *Backup
+	for i := range list {
+		b := &list[i]
+		if versionName == "" && b.SHA256 != current {
+			target = b
+			break
+		}
+		if versionName != "" && strings.TrimPrefix(b.Version, "v") == versionName {
+			target = b
+			break
+		}
+	}
+	if target == nil {
+		if versionName == "" {
+			return fmt.Errorf("no launcher backup to roll back to")
+		}
+		return fmt.Errorf("no launcher backup of version %s", versionName)
+	}
+	if err := ValidateBackup(*target); err != nil {
+		return err
+	}
+	if target.SHA256 == current {
+		return fmt.Errorf("version %s is already running", target.Version)
+	}
+
+	// Stage a copy: keeping the running version as a backup may prune the one being restored
+	tempDir := filepath.Join(os.TempDir(), "qmlauncher-rollback")
+	_ = os.MkdirAll(tempDir, 0755)
+	tempBin := filepath.Join(tempDir, filepath.Base(exePath))
+	if err := copyFile(target.Path, tempBin); err != nil {
+		return err
+	}
+	_ = os.Chmod(tempBin, 0755)
+
+	// Keep automatic updates from reinstalling the release being rolled back from
+	if err := skipUpdate(version.Current, exePath); err != nil && logFn != nil {
+		logFn(fmt.Sprintf("[AutoUpdate] Could not record skipped version %s: %v", version.Current, err))
+	}
+
+	if logFn != nil {
+		name := target.Version
+		if name == "" {
+			name = filepath.Base(target.Path)
+		}
+		logFn(fmt.Sprintf("[AutoUpdate] Rolling back %s to %s", version.Current, name))
+	}
+	switch runtime.GOOS {
+	case "windows":
+		if err := windowsReplaceExecutableAndRelaunch(exePath, tempBin, logFn); err != nil {
+			return err
+		}
+		os.Exit(0)
+	case "linux":
+		return linuxReplaceExecutableAndRelaunch(exePath, tempBin)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package updater
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ReleasePublicKey is the minisign public key release assets are signed with. Release builds set it via:
+//
+//	-X QMLauncher/pkg/updater.ReleasePublicKey=$(MINISIGN_PUBLIC_KEY)
+//
+// With no key (plain `go build`), GitHub updates are installed without signature verification.
+var ReleasePublicKey = ""
+
+// SignatureSuffix is appended to an asset name for its minisign signature asset.
+const SignatureSuffix = ".minisig"
+
+var (
+	// ErrUpdateUnsigned is returned when a release asset has no signature but a release key is configured.
+	ErrUpdateUnsigned = errors.New("update is not signed")
+	// ErrUpdateSignature is returned when an update does not match its signature or the release key.
+	ErrUpdateSignature = errors.New("update signature verification failed")
+)
+
+type minisignKey struct {
+	id  [8]byte
+	key ed25519.PublicKey
+}
+
+// parseMinisignKey decodes a minisign public key: the base64 line, optionally with the "untrusted
+// comment:" line of a .pub file.
+func parseMinisignKey(s string) (*minisignKey, error) {
+	var line string
+	for _, l := range strings.Split(strings.TrimSpace(s), "\n") {
+		if l = strings.TrimSpace(l); l != "" && !strings.HasPrefix(l, "untrusted comment:") {
+			line = l
+			break
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+	k := &minisignKey{key: ed25519.PublicKey(raw[10:])}
+	copy(k.id[:], raw[2:10])
+	return k, nil
+}
+
+// VerifyFileSignature checks a file against a minisign signature (the contents of its .minisig file)
+// and public key. Both legacy ("Ed") and prehashed ("ED", BLAKE2b-512) signatures are accepted; the
+// trusted comment must be signed by the same key.
+func VerifyFileSignature(path string, signature []byte, publicKey string) error {
+	key, err := parseMinisignKey(publicKey)
+	if err != nil {
+		return err
+	}
+	lines := []string{}
+	for _, l := range strings.Split(strings.TrimSpace(string(signature)), "\n") {
+		lines = append(lines, strings.TrimRight(l, "\r"))
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("%w: malformed signature", ErrUpdateSignature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signature", ErrUpdateSignature)
+	}
+	if !bytes.Equal(sig[2:10], key.id[:]) {
+		return fmt.Errorf("%w: signed with another key", ErrUpdateSignature)
+	}
+
+	var message []byte
+	switch string(sig[:2]) {
+	case "Ed":
+		if message, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	case "ED":
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h, _ := blake2b.New512(nil)
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		message = h.Sum(nil)
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrUpdateSignature, sig[:2])
+	}
+	if !ed25519.Verify(key.key, message, sig[10:]) {
+		return ErrUpdateSignature
+	}
+
+	trusted := strings.TrimPrefix(lines[2], "trusted comment: ")
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || !ed25519.Verify(key.key, append(append([]byte{}, sig[10:]...), trusted...), global) {
+		return fmt.Errorf("%w: trusted comment", ErrUpdateSignature)
+	}
+	return nil
+}
+
+// verifyUpdate checks a downloaded release asset against its signature asset. It is a no-op without a
+// ReleasePublicKey; with one, a missing signature is an error.
+func verifyUpdate(path, signatureURL string) error {
+	key := strings.TrimSpace(ReleasePublicKey)
+	if key == "" {
+		return nil
+	}
+	if signatureURL == "" {
+		return ErrUpdateUnsigned
+	}
+	sig, err := fetchTextURL(signatureURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUpdateUnsigned, err)
+	}
+	return VerifyFileSignature(path, []byte(sig), key)
+}
@@ -0,0 +1,65 @@
+package updater
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// skippedUpdate is the release a rollback left: automatic updates don't reinstall it, or anything older,
+// until a newer release appears or an update is applied by hand.
+type skippedUpdate struct {
+	Version string `json:"version"`
+	MD5     string `json:"md5,omitempty"` // of the executable, for the unversioned QMWeb update
+}
+
+func skippedUpdatePath() string {
+	return filepath.Join(backupsDir(), "skipped.json")
+}
+
+func readSkippedUpdate() skippedUpdate {
+	var skip skippedUpdate
+	if data, err := os.ReadFile(skippedUpdatePath()); err == nil {
+		_ = json.Unmarshal(data, &skip)
+	}
+	return skip
+}
+
+// skipUpdate records the version (and executable) rolled back from.
+func skipUpdate(version, exePath string) error {
+	skip := skippedUpdate{Version: strings.TrimSpace(version)}
+	if sum, err := ComputeFileMD5(exePath); err == nil {
+		skip.MD5 = sum
+	}
+	data, err := json.Marshal(skip)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(backupsDir(), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(skippedUpdatePath(), data, 0644)
+}
+
+// clearSkippedUpdate forgets the skipped release once an update is applied by hand.
+func clearSkippedUpdate() {
+	_ = os.Remove(skippedUpdatePath())
+}
+
+// SkippedVersion returns the launcher version automatic updates skip after a rollback, or "".
+func SkippedVersion() string {
+	return readSkippedUpdate().Version
+}
+
+// updateSkipped reports whether an automatic update to version (or to an executable with the given MD5,
+// when the source has no versions) would reinstall the release rolled back from.
+func updateSkipped(version, md5 string) bool {
+	skip := readSkippedUpdate()
+	if version != "" && skip.Version != "" && semver.Compare(canonicalSemverStr(version), canonicalSemverStr(skip.Version)) <= 0 {
+		return true
+	}
+	return md5 != "" && skip.MD5 != "" && strings.EqualFold(md5, skip.MD5)
+}
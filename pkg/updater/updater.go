@@ -2,6 +2,7 @@ package updater
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"QMLauncher/internal/network"
+
+	"golang.org/x/mod/semver"
 )
 
 // GitHubRelease represents a GitHub release
@@ -22,6 +25,8 @@ type GitHubRelease struct {
 	PublishedAt time.Time `json:"published_at"`
 	Assets      []Asset   `json:"assets"`
 	Prerelease  bool      `json:"prerelease"`
+	Draft       bool      `json:"draft"`
+	HTMLURL     string    `json:"html_url"`
 }
 
 // Asset represents a release asset
@@ -38,6 +43,10 @@ type Updater struct {
 	CurrentVer  string
 	CacheDir    string
 	APIEndpoint string
+	Channel     string // ChannelStable, ChannelBeta or ChannelNightly
+
+	AllowDowngrade bool   // offer the channel's newest release even when it is older than CurrentVer
+	ManifestURL    string // self-hosted update manifest used instead of the GitHub API; see manifestReleases
 }
 
 // UpdateInfo contains information about available updates
@@ -45,9 +54,20 @@ type UpdateInfo struct {
 	Available   bool
 	LatestVer   string
 	ReleaseURL  string
-	Changelog   string
+	Changelog   string // notes of all releases since the current version, newest first
 	DownloadURL string
 	Size        int64
+	Channel     string
+	Prerelease  bool
+	Downgrade   bool
+
+	SignatureURL string // minisign signature of the download; "" when the release has none
+	ChecksumURL  string // sha256sum file of the download (or of all assets); "" when the release has none
+	// ChecksumPerAsset is set when ChecksumURL is the download's own "<asset>.sha256", which may hold a bare hash
+	ChecksumPerAsset bool
+	AssetName        string
+	PatchURL         string // binary patch from the current version; "" when the release has none
+	PatchSize        int64
 }
 
 // New creates a new updater instance
@@ -58,34 +78,134 @@ func New(owner, repo, currentVer, cacheDir string) *Updater {
 		CurrentVer:  currentVer,
 		CacheDir:    cacheDir,
 		APIEndpoint: "https://api.github.com",
+		Channel:     Channel(),
+		ManifestURL: ManifestURL(),
 	}
 }
 
-// CheckForUpdates checks if there's a newer version available
-func (u *Updater) CheckForUpdates() (*UpdateInfo, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", u.APIEndpoint, u.Owner, u.Repo)
+// releasesPerPage is how many of the most recent releases a check looks at.
+const releasesPerPage = 30
 
-	cache := network.Cache[GitHubRelease]{
-		Path:        filepath.Join(u.CacheDir, "updater", "latest_release.json"),
-		URL:         url,
-		AlwaysFetch: false,
+// releases fetches the most recent releases from the update manifest when one is configured, otherwise
+// from the GitHub API.
+func (u *Updater) releases() ([]GitHubRelease, error) {
+	if u.ManifestURL != "" {
+		return u.manifestReleases()
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d", u.APIEndpoint, u.Owner, u.Repo, releasesPerPage)
+	body, err := fetchCached(url, filepath.Join(u.CacheDir, "updater", "releases.json"), true)
+	if err != nil {
+		return nil, err
+	}
+	var releases []GitHubRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
 	}
+	return releases, nil
+}
+
+// fetchCached GETs a release list and keeps it at cachePath, revalidating the cached copy with its ETag:
+// unchanged lists (304) don't count against the GitHub API rate limit. For the GitHub API, the GitHub
+// token is sent when configured.
+func fetchCached(url, cachePath string, github bool) ([]byte, error) {
+	etagPath := cachePath + ".etag"
 
-	var release GitHubRelease
-	if err := cache.Get(&release); err != nil {
-		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", network.QMServerUserAgent)
+	if github {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if token := GitHubToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	cached, cacheErr := os.ReadFile(cachePath)
+	if etag, err := os.ReadFile(etagPath); err == nil && cacheErr == nil {
+		req.Header.Set("If-None-Match", strings.TrimSpace(string(etag)))
 	}
 
-	// Skip prereleases unless current version is also a prerelease
-	if release.Prerelease && !strings.Contains(u.CurrentVer, "-") {
-		return &UpdateInfo{Available: false}, nil
+	resp, err := qmWebHTTPClient(60 * time.Second).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch releases: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil && os.WriteFile(cachePath, body, 0644) == nil {
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				_ = os.WriteFile(etagPath, []byte(etag), 0644)
+			} else {
+				_ = os.Remove(etagPath)
+			}
+		}
+		return body, nil
+	case github && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		if GitHubToken() == "" {
+			return nil, fmt.Errorf("GitHub API rate limit exceeded, set a GitHub token for update checks")
+		}
+		return nil, fmt.Errorf("GitHub API rate limit exceeded")
+	}
+	return nil, fmt.Errorf("failed to fetch releases: %w", network.CheckResponse(resp))
+}
+
+// latestRelease returns the release with the highest version offered on the updater's channel (see
+// newerRelease).
+func (u *Updater) latestRelease(releases []GitHubRelease) (*GitHubRelease, error) {
+	name, err := ParseChannel(u.Channel)
+	if err != nil {
+		return nil, err
+	}
+	var latest *GitHubRelease
+	for i := range releases {
+		if channelIncludes(name, releases[i]) && (latest == nil || newerRelease(releases[i], *latest)) {
+			latest = &releases[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no releases on the %s channel", name)
+	}
+	return latest, nil
+}
+
+// newerRelease reports whether a has a higher semver precedence than b, so a patch release of an older
+// line published later does not replace the newest version. Releases with equal or non-semver tags are
+// ordered by publish date.
+func newerRelease(a, b GitHubRelease) bool {
+	if cmp, ok := compareVersions(a.TagName, b.TagName); ok && cmp != 0 {
+		return cmp > 0
+	}
+	return a.PublishedAt.After(b.PublishedAt)
+}
+
+// CheckForUpdates checks if there's a newer version available on the updater's channel
+func (u *Updater) CheckForUpdates() (*UpdateInfo, error) {
+	releases, err := u.releases()
+	if err != nil {
+		return nil, err
+	}
+	release, err := u.latestRelease(releases)
+	if err != nil {
+		return nil, err
 	}
 
 	latestVer := strings.TrimPrefix(release.TagName, "v")
 	currentVer := strings.TrimPrefix(u.CurrentVer, "v")
 
-	if latestVer == currentVer {
-		return &UpdateInfo{Available: false}, nil
+	cmp, ok := compareVersions(latestVer, currentVer)
+	if !ok && !u.AllowDowngrade {
+		return nil, fmt.Errorf("cannot compare release %s with current version %s", release.TagName, u.CurrentVer)
+	}
+	if ok && (cmp == 0 || (cmp < 0 && !u.AllowDowngrade)) {
+		return &UpdateInfo{Available: false, LatestVer: latestVer, Channel: u.Channel}, nil
 	}
 
 	// Find appropriate asset for current platform
@@ -94,74 +214,141 @@ func (u *Updater) CheckForUpdates() (*UpdateInfo, error) {
 		return nil, fmt.Errorf("no suitable download found for platform %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
-	return &UpdateInfo{
+	info := &UpdateInfo{
 		Available:   true,
 		LatestVer:   latestVer,
-		ReleaseURL:  fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", u.Owner, u.Repo, release.TagName),
-		Changelog:   release.Body,
+		ReleaseURL:  release.HTMLURL,
+		Changelog:   u.changelog(releases, release),
 		DownloadURL: asset.BrowserDownloadURL,
 		Size:        asset.Size,
-	}, nil
+		Channel:     u.Channel,
+		Prerelease:  release.Prerelease,
+		Downgrade:   ok && cmp < 0,
+
+		SignatureURL: assetURL(release.Assets, asset.Name+SignatureSuffix),
+		AssetName:    asset.Name,
+	}
+	info.ChecksumURL, info.ChecksumPerAsset = checksumURL(release.Assets, asset.Name)
+	if info.ReleaseURL == "" && u.ManifestURL == "" {
+		info.ReleaseURL = fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", u.Owner, u.Repo, release.TagName)
+	}
+	if patch := findAsset(release.Assets, patchAssetName(asset.Name, u.CurrentVer)); patch != nil {
+		info.PatchURL = patch.BrowserDownloadURL
+		info.PatchSize = patch.Size
+	}
+	return info, nil
 }
 
-// findAssetForPlatform finds the appropriate asset for current platform
-func (u *Updater) findAssetForPlatform(assets []Asset) *Asset {
-	os := runtime.GOOS
-	arch := runtime.GOARCH
-
-	// Normalize OS names for matching
-	osNormalized := os
-	switch os {
-	case "windows":
-		osNormalized = "windows"
-	case "darwin":
-		osNormalized = "darwin"
-	case "linux":
-		osNormalized = "linux"
-	}
-
-	// Normalize architecture names
-	archNormalized := arch
-	switch arch {
-	case "amd64":
-		archNormalized = "amd64"
-	case "386":
-		archNormalized = "386"
-	}
-
-	for _, asset := range assets {
-		name := strings.ToLower(asset.Name)
-
-		// For our release naming convention:
-		// - Linux: QMLauncher-cli-linux-amd64 (no extension)
-		// - macOS: QMLauncher-cli-macos-amd64 (no extension)
-		// - Windows: QMLauncher-cli-windows-amd64.exe
-
-		// Check platform by release naming convention
-		switch os {
-		case "windows":
-			if strings.Contains(name, "windows") && strings.Contains(name, "amd64") && strings.HasSuffix(name, ".exe") {
-				return &asset
-			}
-		case "darwin":
-			if strings.Contains(name, "macos") && strings.Contains(name, "amd64") && !strings.Contains(name, ".exe") {
-				return &asset
-			}
-		case "linux":
-			if strings.Contains(name, "linux") && strings.Contains(name, "amd64") && !strings.Contains(name, ".exe") {
-				return &asset
-			}
+// compareVersions compares two versions by semver precedence ("1.4.0-beta.2" < "1.4.0-rc.1" < "1.4.0").
+// ok is false when either is not a semantic version, e.g. a dev build.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	va, vb := "v"+strings.TrimPrefix(strings.TrimSpace(a), "v"), "v"+strings.TrimPrefix(strings.TrimSpace(b), "v")
+	if !semver.IsValid(va) || !semver.IsValid(vb) {
+		return 0, false
+	}
+	return semver.Compare(va, vb), true
+}
+
+// findAsset returns the named asset, or nil.
+func findAsset(assets []Asset, name string) *Asset {
+	for i := range assets {
+		if strings.EqualFold(assets[i].Name, name) {
+			return &assets[i]
 		}
+	}
+	return nil
+}
+
+// assetURL returns the download URL of the named asset, or "".
+func assetURL(assets []Asset, name string) string {
+	if a := findAsset(assets, name); a != nil {
+		return a.BrowserDownloadURL
+	}
+	return ""
+}
 
-		// Fallback: check for platform and arch in filename
-		if strings.Contains(name, osNormalized) && strings.Contains(name, archNormalized) {
-			return &asset
+// assetOSNames and assetArchNames are the words release asset names use for each GOOS and GOARCH.
+var (
+	assetOSNames = map[string][]string{
+		"windows": {"windows", "win", "win64", "win32"},
+		"darwin":  {"macos", "darwin", "mac", "osx"},
+		"linux":   {"linux"},
+	}
+	assetArchNames = map[string][]string{
+		"amd64": {"amd64", "x64", "x86_64"},
+		"arm64": {"arm64", "aarch64"},
+		"386":   {"386", "i386", "i686", "x86", "win32"},
+	}
+)
+
+// assetNameTokens splits a lowercase asset name into words ("qmlauncher-linux-x86_64.tar.gz" ->
+// qmlauncher, linux, x86_64, tar, gz).
+func assetNameTokens(name string) map[string]bool {
+	tokens := map[string]bool{}
+	for _, t := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' || r == ' ' }) {
+		tokens[t] = true
+	}
+	if tokens["x86"] && tokens["64"] {
+		delete(tokens, "x86")
+		tokens["x86_64"] = true
+	}
+	return tokens
+}
+
+// matchesPlatform reports whether an asset name is a build for goos/goarch that the updater can install:
+// the executable itself or a zip archive.
+func matchesPlatform(name, goos, goarch string) bool {
+	name = strings.ToLower(name)
+	if strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") {
+		return false
+	}
+	tokens := assetNameTokens(name)
+	hasAny := func(words []string) bool {
+		for _, w := range words {
+			if tokens[w] {
+				return true
+			}
 		}
+		return false
+	}
+	if !hasAny(assetOSNames[goos]) || !hasAny(assetArchNames[goarch]) {
+		return false
+	}
+	if goos == "windows" {
+		return strings.HasSuffix(name, ".exe") || strings.HasSuffix(name, ".zip")
 	}
+	return !strings.HasSuffix(name, ".exe")
+}
+
+// findAssetForPlatform finds the appropriate asset for current platform, e.g. QMLauncher-linux-amd64,
+// QMLauncher-linux-aarch64 or QMLauncher-windows-arm64.exe.
+func (u *Updater) findAssetForPlatform(assets []Asset) *Asset {
+	if a := findAsset(assets, platformBinaryName()); a != nil {
+		return a
+	}
+	for i := range assets {
+		name := strings.ToLower(assets[i].Name)
 
+		// Signatures, checksums and patches of the binaries are published alongside them
+		if strings.HasSuffix(name, SignatureSuffix) || isChecksumAsset(name) || strings.HasSuffix(name, PatchSuffix) {
+			continue
+		}
+		if matchesPlatform(name, runtime.GOOS, runtime.GOARCH) {
+			return &assets[i]
+		}
+	}
 	return nil
 }
 
+// platformBinaryName is the release asset name of the launcher binary for this platform, which the
+// self-updating executable is expected to keep.
+func platformBinaryName() string {
+	if runtime.GOOS == "windows" {
+		return "QMLauncher-windows-" + runtime.GOARCH + ".exe"
+	}
+	return "QMLauncher-" + runtime.GOOS + "-" + runtime.GOARCH
+}
+
 // DownloadUpdate downloads and installs the update
 func (u *Updater) DownloadUpdate(updateInfo *UpdateInfo, progressCallback func(float64)) error {
 	if updateInfo == nil || !updateInfo.Available {
@@ -181,15 +368,31 @@ func (u *Updater) DownloadUpdate(updateInfo *UpdateInfo, progressCallback func(f
 		return fmt.Errorf("failed to download update: %w", err)
 	}
 
-	// Extract the update
-	if err := u.extractUpdate(tempFile, tempDir); err != nil {
-		return fmt.Errorf("failed to extract update: %w", err)
+	if err := verifyChecksum(tempFile, updateInfo); err != nil {
+		os.RemoveAll(tempDir)
+		return err
 	}
 
-	// Find the new binary
-	newBinary, err := u.findNewBinary(tempDir)
-	if err != nil {
-		return fmt.Errorf("failed to find new binary: %w", err)
+	// Verify the signature before anything from the download is executed or installed
+	if err := verifyUpdate(tempFile, updateInfo.SignatureURL); err != nil {
+		os.RemoveAll(tempDir)
+		return err
+	}
+
+	// Release assets are zip archives or the executable itself (e.g. QMLauncher-windows-amd64.exe)
+	newBinary := tempFile
+	if isZipFile(tempFile) {
+		// Extract the update
+		if err := u.extractUpdate(tempFile, tempDir); err != nil {
+			return fmt.Errorf("failed to extract update: %w", err)
+		}
+
+		// Find the new binary
+		found, err := u.findNewBinary(tempDir)
+		if err != nil {
+			return fmt.Errorf("failed to find new binary: %w", err)
+		}
+		newBinary = found
 	}
 
 	// Replace current binary
@@ -209,7 +412,7 @@ func (u *Updater) downloadFile(url, destPath string, progressCallback func(float
 	if err != nil {
 		return err
 	}
-	resp, err := network.HTTPClientLongDownload.Do(req)
+	resp, err := qmWebHTTPClient(0).Do(req)
 	if err != nil {
 		return err
 	}
@@ -226,7 +429,7 @@ func (u *Updater) downloadFile(url, destPath string, progressCallback func(float
 	defer out.Close()
 
 	counter := &ProgressReader{
-		Reader:   resp.Body,
+		Reader:   network.LimitBandwidth(resp.Body),
 		Total:    resp.ContentLength,
 		Callback: progressCallback,
 	}
@@ -255,6 +458,18 @@ func (pr *ProgressReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// isZipFile reports whether a file starts with the ZIP local file header.
+func isZipFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, 4)
+	_, err = io.ReadFull(f, head)
+	return err == nil && string(head) == "PK\x03\x04"
+}
+
 // extractUpdate extracts the ZIP archive
 func (u *Updater) extractUpdate(zipPath, destDir string) error {
 	reader, err := zip.OpenReader(zipPath)
@@ -369,12 +584,18 @@ func (u *Updater) replaceBinary(newBinary string) error {
 		return fmt.Errorf("failed to get current executable path: %w", err)
 	}
 
-	// Create backup of current binary
+	// Create backup of current binary, and keep it among the versions available for rollback
+	_ = BackupExecutable(currentBinary)
 	backupPath := currentBinary + ".backup"
 	if err := copyFile(currentBinary, backupPath); err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
+	// Windows can't overwrite the running exe, but can rename it
+	if runtime.GOOS == "windows" {
+		return replaceRunningBinary(currentBinary, newBinary)
+	}
+
 	// Replace the binary
 	if err := copyFile(newBinary, currentBinary); err != nil {
 		// Restore backup on failure
@@ -391,6 +612,37 @@ func (u *Updater) replaceBinary(newBinary string) error {
 	return nil
 }
 
+// oldBinarySuffix marks the executable an update moved aside; CleanupOldBinary removes it on the next start.
+const oldBinarySuffix = ".old"
+
+// replaceRunningBinary moves the running executable aside and writes the new one in its place, moving
+// the old one back if that fails.
+func replaceRunningBinary(currentBinary, newBinary string) error {
+	oldBinary := currentBinary + oldBinarySuffix
+	_ = os.Remove(oldBinary) // Left by an earlier update if the launcher didn't start since
+	if err := os.Rename(currentBinary, oldBinary); err != nil {
+		return fmt.Errorf("failed to move the running executable aside: %w", err)
+	}
+	if err := copyFile(newBinary, currentBinary); err != nil {
+		_ = os.Remove(currentBinary)
+		if rerr := os.Rename(oldBinary, currentBinary); rerr != nil {
+			return fmt.Errorf("failed to replace binary: %w (restore failed: %v; the previous version is %s)", err, rerr, oldBinary)
+		}
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+	return nil
+}
+
+// CleanupOldBinary removes the executable a previous update moved aside. Call it at startup: the file is
+// in use until the process that was updated exits.
+func CleanupOldBinary() {
+	exePath, err := executablePath()
+	if err != nil {
+		return
+	}
+	_ = os.Remove(exePath + oldBinarySuffix)
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
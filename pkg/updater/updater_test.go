@@ -0,0 +1,167 @@
+package updater
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatestRelease(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 12, 0, 0, 0, time.UTC) }
+	releases := []GitHubRelease{
+		{TagName: "v1.4.0", PublishedAt: day(1)},
+		{TagName: "v1.3.5", PublishedAt: day(3)}, // backport published after 1.4.0
+		{TagName: "v1.5.0-beta.2", Prerelease: true, PublishedAt: day(4)},
+		{TagName: "v1.5.0-beta.10", Prerelease: true, PublishedAt: day(2)},
+		{TagName: "v1.5.0-nightly.20260505", Prerelease: true, PublishedAt: day(5)},
+		{TagName: "v1.6.0", Draft: true, PublishedAt: day(6)},
+	}
+	tests := []struct {
+		channel, want string
+	}{
+		{"", "v1.4.0"},
+		{ChannelStable, "v1.4.0"},
+		{ChannelBeta, "v1.5.0-beta.10"},
+		{ChannelNightly, "v1.5.0-nightly.20260505"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			got, err := (&Updater{Channel: tt.channel}).latestRelease(releases)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.TagName != tt.want {
+				t.Fatalf("latestRelease = %s, want %s", got.TagName, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestReleaseErrors(t *testing.T) {
+	prereleases := []GitHubRelease{{TagName: "v2.0.0-beta.1", Prerelease: true}}
+	if _, err := (&Updater{Channel: ChannelStable}).latestRelease(prereleases); err == nil {
+		t.Fatal("stable channel offered a prerelease")
+	}
+	if _, err := (&Updater{Channel: "canary"}).latestRelease(prereleases); err == nil {
+		t.Fatal("unknown channel accepted")
+	}
+}
+
+func TestNewerRelease(t *testing.T) {
+	early, late := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		a, b GitHubRelease
+		want bool
+	}{
+		{"higher version", GitHubRelease{TagName: "v1.10.0", PublishedAt: early}, GitHubRelease{TagName: "v1.9.0", PublishedAt: late}, true},
+		{"lower version published later", GitHubRelease{TagName: "1.3.5", PublishedAt: late}, GitHubRelease{TagName: "1.4.0", PublishedAt: early}, false},
+		{"release over its prerelease", GitHubRelease{TagName: "v1.5.0", PublishedAt: early}, GitHubRelease{TagName: "v1.5.0-rc.1", PublishedAt: late}, true},
+		{"same version, later date", GitHubRelease{TagName: "v1.5.0", PublishedAt: late}, GitHubRelease{TagName: "1.5.0", PublishedAt: early}, true},
+		{"non-semver tag by date", GitHubRelease{TagName: "nightly", PublishedAt: late}, GitHubRelease{TagName: "v1.5.0", PublishedAt: early}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newerRelease(tt.a, tt.b); got != tt.want {
+				t.Fatalf("newerRelease = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelIncludes(t *testing.T) {
+	tests := []struct {
+		tag        string
+		prerelease bool
+		stable     bool
+		beta       bool
+		nightly    bool
+	}{
+		{"v1.4.0", false, true, true, true},
+		{"v1.5.0-beta.1", true, false, true, true},
+		{"v1.5.0-rc.1", true, false, true, true},
+		{"v1.5.0-nightly.1", true, false, false, true},
+	}
+	for _, tt := range tests {
+		r := GitHubRelease{TagName: tt.tag, Prerelease: tt.prerelease}
+		for channel, want := range map[string]bool{ChannelStable: tt.stable, ChannelBeta: tt.beta, ChannelNightly: tt.nightly} {
+			if got := channelIncludes(channel, r); got != want {
+				t.Errorf("channelIncludes(%s, %s) = %v, want %v", channel, tt.tag, got, want)
+			}
+		}
+	}
+}
+
+func TestFetchSHA256(t *testing.T) {
+	sumA := strings.Repeat("a", 64)
+	sumB := strings.Repeat("b", 64)
+	files := map[string]string{
+		"/checksums.txt":     sumA + "  QMLauncher-linux-amd64\n" + sumB + " *dist/QMLauncher-windows-amd64.exe\n",
+		"/single.txt":        sumB + "  QMLauncher-windows-amd64.exe\n",
+		"/bare.sha256":       sumA + "\n",
+		"/bare-multi.sha256": sumA + "\n" + sumB + "\n",
+		"/invalid.txt":       "not-a-hash  QMLauncher-linux-amd64\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		file     string
+		name     string
+		perAsset bool
+		want     string
+		missing  bool
+	}{
+		{"/checksums.txt", "QMLauncher-linux-amd64", false, sumA, false},
+		{"/checksums.txt", "QMLauncher-windows-amd64.exe", false, sumB, false},
+		{"/checksums.txt", "QMLauncher-darwin-arm64", false, "", true},
+		// a single entry for another asset is not a checksum of this one
+		{"/single.txt", "QMLauncher-linux-amd64", false, "", true},
+		{"/single.txt", "QMLauncher-linux-amd64", true, "", true},
+		{"/bare.sha256", "QMLauncher-linux-amd64", true, sumA, false},
+		{"/bare.sha256", "QMLauncher-linux-amd64", false, "", true},
+		{"/bare-multi.sha256", "QMLauncher-linux-amd64", true, "", true},
+	}
+	for _, tt := range tests {
+		got, err := fetchSHA256(srv.URL+tt.file, tt.name, tt.perAsset)
+		if tt.missing {
+			if !errors.Is(err, ErrChecksumMissing) {
+				t.Errorf("fetchSHA256(%s, %s, %v) = %q, %v; want ErrChecksumMissing", tt.file, tt.name, tt.perAsset, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("fetchSHA256(%s, %s, %v) = %q, %v; want %q", tt.file, tt.name, tt.perAsset, got, err, tt.want)
+		}
+	}
+	if _, err := fetchSHA256(srv.URL+"/invalid.txt", "QMLauncher-linux-amd64", false); err == nil || errors.Is(err, ErrChecksumMissing) {
+		t.Errorf("fetchSHA256 of an invalid file: %v", err)
+	}
+}
+
+func TestChecksumURL(t *testing.T) {
+	assets := []Asset{
+		{Name: "QMLauncher-linux-amd64", BrowserDownloadURL: "https://example.com/linux"},
+		{Name: "QMLauncher-linux-amd64.sha256", BrowserDownloadURL: "https://example.com/linux.sha256"},
+		{Name: "checksums.txt", BrowserDownloadURL: "https://example.com/checksums.txt"},
+	}
+	if u, per := checksumURL(assets, "QMLauncher-linux-amd64"); u != "https://example.com/linux.sha256" || !per {
+		t.Errorf("checksumURL(linux) = %q, %v", u, per)
+	}
+	if u, per := checksumURL(assets, "QMLauncher-windows-amd64.exe"); u != "https://example.com/checksums.txt" || per {
+		t.Errorf("checksumURL(windows) = %q, %v", u, per)
+	}
+	if u, _ := checksumURL(assets[:1], "QMLauncher-linux-amd64"); u != "" {
+		t.Errorf("checksumURL without checksum assets = %q", u)
+	}
+}